@@ -22,6 +22,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-spring/spring-base/util"
 )
@@ -29,6 +32,28 @@ import (
 var (
 	ErrNotExist      = util.FormatError(nil, "not exist")
 	ErrInvalidSyntax = util.FormatError(nil, "invalid syntax")
+
+	// The errors returned when resolving property references exceeds one
+	// of the limits below.
+	ErrResolveDepth   = util.FormatError(nil, "max resolve depth exceeded")
+	ErrResolveSize    = util.FormatError(nil, "max resolve size exceeded")
+	ErrResolveTimeout = util.FormatError(nil, "resolve timeout")
+)
+
+// Limits of property reference resolution, so that a malformed or
+// malicious value, e.g. received from a remote config, can't hang or
+// crash the application.
+var (
+	// MaxResolveDepth is the maximum nesting depth of property references,
+	// which also stops circular references such as "a=${b}" and "b=${a}".
+	MaxResolveDepth = 64
+
+	// MaxResolveSize is the maximum size in bytes of a resolved string.
+	MaxResolveSize = 1 << 20
+
+	// ResolveTimeout is the maximum time spent resolving a string. It's
+	// checked every few references, so the time may be slightly exceeded.
+	ResolveTimeout = time.Second
 )
 
 // ParsedTag represents a parsed configuration tag that encodes
@@ -77,13 +102,15 @@ func (tag ParsedTag) String() string {
 // Supported syntax: `${key:=default}>>splitter`
 //
 // - The `${...}` block is mandatory.
-// - ":=" introduces an optional default value.
+// - ":=" introduces an optional default value, as does a single ":".
 // - ">>splitter" is optional and specifies a custom splitter.
 //
 // Example parses:
 //
 //	"${foo}"               -> Key="foo"
 //	"${foo:=bar}"          -> Key="foo", HasDef=true, Def="bar"
+//	"${foo:bar}"           -> Key="foo", HasDef=true, Def="bar"
+//	"${foo:${bar}}"        -> Key="foo", HasDef=true, Def="${bar}"
 //	"${foo:=bar}>>csv"     -> Key="foo", HasDef=true, Def="bar", Splitter="csv"
 //	"${:=fallback}"        -> Key="", HasDef=true, Def="fallback"
 //
@@ -103,15 +130,41 @@ func ParseTag(tag string) (ret ParsedTag, err error) {
 	if i := strings.LastIndex(tag, ">>"); i > j {
 		ret.Splitter = strings.TrimSpace(tag[i+2:])
 	}
-	ss := strings.SplitN(tag[k+2:j], ":=", 2)
-	ret.Key = strings.TrimSpace(ss[0])
-	if len(ss) > 1 {
+	body := tag[k+2 : j]
+	if i := defaultIndex(body); i >= 0 {
+		ret.Key = strings.TrimSpace(body[:i])
 		ret.HasDef = true
-		ret.Def = strings.TrimSpace(ss[1])
+		def := strings.TrimPrefix(body[i+1:], "=")
+		ret.Def = strings.TrimSpace(def)
+	} else {
+		ret.Key = strings.TrimSpace(body)
 	}
 	return
 }
 
+// defaultIndex returns the index of the ':' that starts the default value
+// in the body of a tag, skipping those in nested references and
+// expressions, or -1.
+func defaultIndex(body string) int {
+	level := 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '$', '#':
+			if i+1 < len(body) && body[i+1] == '{' {
+				level++
+				i++
+			}
+		case '}':
+			level--
+		case ':':
+			if level == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 // BindParam holds metadata needed to bind a single configuration value
 // to a Go struct field, slice element, or map entry.
 type BindParam struct {
@@ -178,40 +231,48 @@ type Filter interface {
 // - Returns wrapped errors with context (path, type).
 func BindValue(p Properties, v reflect.Value, t reflect.Type, param BindParam, filter Filter) (RetErr error) {
 
-	if !util.IsPropBindingTarget(t) {
+	// a type with a converter, or a non-primitive type that unmarshals
+	// itself from text, is bound from a single value
+	fn := converters[t]
+	text := fn == nil && isTextUnmarshaler(t)
+
+	if !util.IsPropBindingTarget(t) && !isStructPtrCollection(t) && fn == nil && !text {
 		err := util.FormatError(nil, "target should be value type")
 		return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
 	}
 
-	// run validation if "expr" tag is defined and no prior error
+	// run validation if "expr" or "validate" tag is defined and no prior error
 	defer func() {
 		if RetErr == nil {
 			tag, ok := param.Validate.Lookup("expr")
 			if ok && len(tag) > 0 {
 				if RetErr = validateField(tag, v.Interface()); RetErr != nil {
 					RetErr = util.FormatError(RetErr, "validate path=%s type=%s error", param.Path, v.Type().String())
+					return
 				}
 			}
+			if tag, ok = param.Validate.Lookup("validate"); ok && len(tag) > 0 {
+				RetErr = validateRules(tag, v, param)
+			}
 		}
 	}()
 
-	switch v.Kind() {
-	case reflect.Map:
-		return bindMap(p, v, t, param, filter)
-	case reflect.Slice:
-		return bindSlice(p, v, t, param, filter)
-	case reflect.Array:
-		err := util.FormatError(nil, "use slice instead of array")
-		return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
-	default: // for linter
-	}
-
-	fn := converters[t]
-	if fn == nil && v.Kind() == reflect.Struct {
-		if err := bindStruct(p, v, t, param, filter); err != nil {
-			return err // no wrap
+	if fn == nil && !text {
+		switch v.Kind() {
+		case reflect.Map:
+			return bindMap(p, v, t, param, filter)
+		case reflect.Slice:
+			return bindSlice(p, v, t, param, filter)
+		case reflect.Array:
+			err := util.FormatError(nil, "use slice instead of array")
+			return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		case reflect.Struct:
+			if err := bindStruct(p, v, t, param, filter); err != nil {
+				return err // no wrap
+			}
+			return nil
+		default: // for linter
 		}
-		return nil
 	}
 
 	// resolve property value (with default and references)
@@ -220,6 +281,16 @@ func BindValue(p Properties, v reflect.Value, t reflect.Type, param BindParam, f
 		return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
 	}
 
+	// the format tag of the field takes precedence over the converters
+	if format, ok := param.Validate.Lookup("format"); ok && format != "" {
+		out, err := convertFormat(val, t, format)
+		if err != nil {
+			return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		}
+		v.Set(out)
+		return nil
+	}
+
 	// try converter function first
 	if fn != nil {
 		fnValue := reflect.ValueOf(fn)
@@ -232,6 +303,15 @@ func BindValue(p Properties, v reflect.Value, t reflect.Type, param BindParam, f
 		return nil
 	}
 
+	if text {
+		out, err := unmarshalText(t, val)
+		if err != nil {
+			return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		}
+		v.Set(out)
+		return nil
+	}
+
 	// fallback: parse string into basic types
 	switch v.Kind() {
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -282,7 +362,7 @@ func BindValue(p Properties, v reflect.Value, t reflect.Type, param BindParam, f
 func bindSlice(p Properties, v reflect.Value, t reflect.Type, param BindParam, filter Filter) error {
 
 	elemType := t.Elem()
-	p, err := getSlice(p, elemType, param)
+	p, err := getSlice(p, elemType, &param)
 	if err != nil {
 		return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
 	}
@@ -294,22 +374,28 @@ func bindSlice(p Properties, v reflect.Value, t reflect.Type, param BindParam, f
 		return nil
 	}
 
-	for i := 0; ; i++ {
-		subValue := reflect.New(elemType).Elem()
+	i := 0
+	for ; ; i++ {
 		subParam := BindParam{
 			Key:  fmt.Sprintf("%s[%d]", param.Key, i),
 			Path: fmt.Sprintf("%s[%d]", param.Path, i),
 		}
-		err = BindValue(p, subValue, elemType, subParam, filter)
-		if errors.Is(err, ErrNotExist) {
+		if !p.Has(subParam.Key) {
 			// stop when no more indexed elements
 			break
 		}
+		subValue, err := bindElem(p, elemType, subParam, filter)
 		if err != nil {
 			return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
 		}
 		slice = reflect.Append(slice, subValue)
 	}
+
+	// indexes after a missing one would be silently dropped
+	if keys, err := p.SubKeys(param.Key); err == nil && len(keys) > i {
+		err = util.FormatError(nil, "missing element %s[%d]", param.Key, i)
+		return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+	}
 	return nil
 }
 
@@ -323,16 +409,23 @@ func bindSlice(p Properties, v reflect.Value, t reflect.Type, param BindParam, f
 // - ErrNotExist if property is missing and no default is provided.
 // - Unknown splitter name if specified splitter is not registered.
 // - Converter missing for non-primitive element types.
-func getSlice(p Properties, et reflect.Type, param BindParam) (Properties, error) {
+func getSlice(p Properties, et reflect.Type, param *BindParam) (Properties, error) {
 
 	// case 1: properties already defined as list (e.g. key[0], key[1]...)
 	if p.Has(param.Key + "[0]") {
 		return p, nil
 	}
 
-	// case 2: property is a single string -> split into slice
+	// case 2: property is a single string or an expression -> split into slice
 	var strVal string
-	{
+	if _, ok := exprInput(param.Key); ok {
+		s, err := resolve(p, *param)
+		if err != nil {
+			return nil, err
+		}
+		strVal = s
+		param.Key = "expr" // the elements are keyed by a valid key
+	} else {
 		if p.Has(param.Key) {
 			strVal = p.Get(param.Key)
 		} else {
@@ -342,7 +435,7 @@ func getSlice(p Properties, et reflect.Type, param BindParam) (Properties, error
 			if param.Tag.Def == "" {
 				return nil, nil
 			}
-			if !util.IsPrimitiveValueType(et) && converters[et] == nil {
+			if !util.IsPrimitiveValueType(et) && converters[et] == nil && !isTextUnmarshaler(et) {
 				return nil, util.FormatError(nil, "can't find converter for %s", et.String())
 			}
 			strVal = param.Tag.Def
@@ -427,16 +520,16 @@ func bindMap(p Properties, v reflect.Value, t reflect.Type, param BindParam, fil
 	}
 
 	for _, key := range keys {
-		subValue := reflect.New(elemType).Elem()
 		subKey := key
 		if param.Key != "" {
 			subKey = param.Key + "." + key
 		}
 		subParam := BindParam{
 			Key:  subKey,
-			Path: param.Path,
+			Path: param.Path + "[" + key + "]",
 		}
-		if err = BindValue(p, subValue, elemType, subParam, filter); err != nil {
+		subValue, err := bindElem(p, elemType, subParam, filter)
+		if err != nil {
 			return err // no wrap
 		}
 		ret.SetMapIndex(reflect.ValueOf(key), subValue)
@@ -444,6 +537,30 @@ func bindMap(p Properties, v reflect.Value, t reflect.Type, param BindParam, fil
 	return nil
 }
 
+// bindElem binds an element of a slice or a map. An element can be a
+// pointer to a struct, e.g. of a map[string]*ServerConfig, for which a
+// new struct is allocated.
+func bindElem(p Properties, elemType reflect.Type, param BindParam, filter Filter) (reflect.Value, error) {
+	if isStructPtr(elemType) {
+		v := reflect.New(elemType.Elem())
+		return v, BindValue(p, v.Elem(), elemType.Elem(), param, filter)
+	}
+	v := reflect.New(elemType).Elem()
+	return v, BindValue(p, v, elemType, param, filter)
+}
+
+// isStructPtrCollection returns whether t is a slice or a map of pointers
+// to structs.
+func isStructPtrCollection(t reflect.Type) bool {
+	k := t.Kind()
+	return (k == reflect.Slice || k == reflect.Map) && isStructPtr(t.Elem())
+}
+
+// isStructPtr returns whether t is a pointer to a struct.
+func isStructPtr(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
 // bindStruct binds configuration properties into a struct.
 //
 // Example:
@@ -469,6 +586,18 @@ func bindStruct(p Properties, v reflect.Value, t reflect.Type, param BindParam,
 		return util.FormatError(err, "bind path=%s type=%s error", param.Path, v.Type().String())
 	}
 
+	// validation errors of the fields are collected, so that all of them
+	// are reported at once.
+	var errs ValidationErrors
+	collect := func(err error) bool {
+		var verr ValidationErrors
+		if errors.As(err, &verr) {
+			errs = append(errs, verr...)
+			return true
+		}
+		return false
+	}
+
 	for i := range t.NumField() {
 		ft := t.Field(i)
 		fv := v.Field(i)
@@ -496,7 +625,7 @@ func bindStruct(p Properties, v reflect.Value, t reflect.Type, param BindParam,
 					continue
 				}
 			}
-			if err := BindValue(p, fv, ft.Type, subParam, filter); err != nil {
+			if err := BindValue(p, fv, ft.Type, subParam, filter); err != nil && !collect(err) {
 				return err // no wrap
 			}
 			continue
@@ -507,11 +636,14 @@ func bindStruct(p Properties, v reflect.Value, t reflect.Type, param BindParam,
 			if ft.Type.Kind() != reflect.Struct {
 				continue
 			}
-			if err := bindStruct(p, fv, ft.Type, subParam, filter); err != nil {
+			if err := bindStruct(p, fv, ft.Type, subParam, filter); err != nil && !collect(err) {
 				return err // no wrap
 			}
 		}
 	}
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
@@ -526,26 +658,96 @@ func bindStruct(p Properties, v reflect.Value, t reflect.Type, param BindParam,
 //
 //	resolve(url) -> "http://localhost:8080"
 func resolve(p Properties, param BindParam) (string, error) {
+	var st resolveState
+	return st.resolve(p, param)
+}
+
+// resolveState tracks the resources spent resolving a string, in order
+// to enforce the resolve limits.
+type resolveState struct {
+	depth int       // current nesting depth of references
+	steps int       // number of references resolved so far
+	start time.Time // set at the first time check
+	owner string    // key of the property whose value is being resolved
+}
+
+// resolveTimeCheckSteps is the number of references resolved between two
+// time checks, so that short resolutions never read the clock.
+const resolveTimeCheckSteps = 64
+
+// enter checks the limits before resolving the reference to key.
+func (st *resolveState) enter(key string) error {
+	st.depth++
+	if st.depth > MaxResolveDepth {
+		return util.FormatError(ErrResolveDepth, "resolve property %q at depth %d error", key, st.depth)
+	}
+	st.steps++
+	if st.steps%resolveTimeCheckSteps == 0 {
+		if st.start.IsZero() {
+			st.start = time.Now()
+		} else if time.Since(st.start) > ResolveTimeout {
+			return util.FormatError(ErrResolveTimeout, "resolve property %q after %s error", key, ResolveTimeout)
+		}
+	}
+	return nil
+}
+
+// isResolveLimit returns whether err comes from a resolve limit. Such
+// errors aren't wrapped again on each nesting level.
+func isResolveLimit(err error) bool {
+	return errors.Is(err, ErrResolveDepth) ||
+		errors.Is(err, ErrResolveSize) ||
+		errors.Is(err, ErrResolveTimeout)
+}
+
+// resolve fetches the value of the referenced property, see [resolve].
+func (st *resolveState) resolve(p Properties, param BindParam) (string, error) {
+	if err := st.enter(param.Key); err != nil {
+		return "", err
+	}
+	defer func() { st.depth-- }()
+
+	if input, ok := exprInput(param.Key); ok {
+		return st.eval(p, input)
+	}
+	if strings.HasPrefix(param.Key, RandomPrefix) {
+		return st.random(p, param.Key)
+	}
+
 	const defVal = "@@def@@"
 	val := p.Get(param.Key, defVal)
 	if val != defVal {
-		return resolveString(p, val)
+		owner := st.owner
+		st.owner = param.Key
+		defer func() { st.owner = owner }()
+		return st.resolveString(p, val)
 	}
 	if p.Has(param.Key) {
 		return "", util.FormatError(nil, "property %q isn't simple value", param.Key)
 	}
 	if param.Tag.HasDef {
-		return resolveString(p, param.Tag.Def)
+		return st.resolveString(p, param.Tag.Def)
 	}
 	return "", util.FormatError(nil, "property %q %w", param.Key, ErrNotExist)
 }
 
+// resolveString expands the property references in s, see [resolveString].
+func (st *resolveState) resolveString(p Properties, s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	return compileString(s).resolve(p, st)
+}
+
 // resolveString expands property references of the form ${key}
 // inside a string, recursively resolving nested expressions.
 //
 // Supported features:
 // - Nested references: e.g. "${outer${inner}}"
-// - Default values:    "${key:=fallback}"
+// - Default values:    "${key:=fallback}" or "${key:fallback}"
+// - Escaping:          "\${literal}" is resolved to "${literal}"
+// - Expressions:       "${#{server.port + 1}}", see [resolveState.eval]
+// - Random values:     "${random.int(1024,65536)}", see [RandomPrefix]
 // - Arbitrary string concatenation around references.
 //
 // Example:
@@ -564,51 +766,139 @@ func resolve(p Properties, param BindParam) (string, error) {
 func resolveString(p Properties, s string) (string, error) {
 
 	// If there is no property reference, return the original string.
-	start := strings.Index(s, "${")
-	if start < 0 {
+	if !strings.Contains(s, "${") {
 		return s, nil
 	}
+	var st resolveState
+	return compileString(s).resolve(p, &st)
+}
 
-	var (
-		level = 1
-		end   = -1
-	)
+// maxCompiledStrings limits the number of cached compiled strings, so that
+// strings resolved once, e.g. built per request, can't grow the cache forever.
+const maxCompiledStrings = 4096
 
-	// scan for matching closing brace, handling nested references
-	for i := start + 2; i < len(s); i++ {
-		if s[i] == '$' {
-			if i+1 < len(s) && s[i+1] == '{' {
-				level++
-			}
-		} else if s[i] == '}' {
-			level--
-			if level == 0 {
-				end = i
-				break
-			}
+var (
+	compiledStrings     sync.Map // string -> *compiledString
+	compiledStringCount atomic.Int32
+)
+
+// compiledString is a string with property references split into its
+// literal text and references, so that it's only scanned once no matter
+// how many times it's resolved.
+type compiledString struct {
+	refs []stringRef
+	tail string // text after the last reference
+	err  error  // syntax error after the last reference, if any
+}
+
+// stringRef is a property reference with the text before it.
+type stringRef struct {
+	src    string // the string from the text on, for error messages
+	prefix string // text before the reference
+	param  BindParam
+}
+
+// compileString returns the compiled form of s, from the cache if any.
+func compileString(s string) *compiledString {
+	if v, ok := compiledStrings.Load(s); ok {
+		return v.(*compiledString)
+	}
+	c := newCompiledString(s)
+	if compiledStringCount.Load() < maxCompiledStrings {
+		if _, loaded := compiledStrings.LoadOrStore(s, c); !loaded {
+			compiledStringCount.Add(1)
 		}
 	}
+	return c
+}
 
-	if end < 0 {
-		err := ErrInvalidSyntax
-		return "", util.FormatError(err, "resolve string %q error", s)
-	}
+// newCompiledString scans s for property references, handling nested ones.
+// An escaped "\${" is kept as the literal text "${".
+func newCompiledString(s string) *compiledString {
+	c := &compiledString{}
+	var literal string // escaped text before the next reference
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			c.tail = literal + s
+			return c
+		}
+		if start > 0 && s[start-1] == '\\' {
+			literal += s[:start-1] + "${"
+			s = s[start+2:]
+			continue
+		}
 
-	var param BindParam
-	_ = param.BindTag(s[start:end+1], "")
+		var (
+			level = 1
+			end   = -1
+		)
+
+		// scan for matching closing brace, handling nested references
+		// and expressions
+		for i := start + 2; i < len(s); i++ {
+			if s[i] == '$' || s[i] == '#' {
+				if i+1 < len(s) && s[i+1] == '{' {
+					level++
+				}
+			} else if s[i] == '}' {
+				level--
+				if level == 0 {
+					end = i
+					break
+				}
+			}
+		}
 
-	// resolve the referenced property
-	resolved, err := resolve(p, param)
-	if err != nil {
-		return "", util.FormatError(err, "resolve string %q error", s)
+		if end < 0 {
+			c.err = util.FormatError(ErrInvalidSyntax, "resolve string %q error", s)
+			return c
+		}
+
+		ref := stringRef{src: s, prefix: literal + s[:start]}
+		literal = ""
+		_ = ref.param.BindTag(s[start:end+1], "")
+		c.refs = append(c.refs, ref)
+		s = s[end+1:]
 	}
+}
 
-	// resolve the remaining part of the string
-	suffix, err := resolveString(p, s[end+1:])
-	if err != nil {
-		return "", util.FormatError(err, "resolve string %q error", s)
+// resolve replaces the property references with their values.
+func (c *compiledString) resolve(p Properties, st *resolveState) (string, error) {
+	var sb strings.Builder
+	for i, ref := range c.refs {
+
+		// resolve the referenced property
+		resolved, err := st.resolve(p, ref.param)
+		if err != nil {
+			if isResolveLimit(err) {
+				return "", err
+			}
+			return "", c.wrapError(util.FormatError(err, "resolve string %q error", ref.src), i)
+		}
+
+		// a single reference needs no copy
+		if len(c.refs) == 1 && ref.prefix == "" && c.tail == "" && c.err == nil {
+			return resolved, nil
+		}
+		if n := sb.Len() + len(ref.prefix) + len(resolved) + len(c.tail); n > MaxResolveSize {
+			return "", util.FormatError(ErrResolveSize, "resolve string %q to %d bytes error", ref.src, n)
+		}
+		sb.WriteString(ref.prefix)
+		sb.WriteString(resolved)
 	}
+	if c.err != nil {
+		return "", c.wrapError(c.err, len(c.refs))
+	}
+	sb.WriteString(c.tail)
+	return sb.String(), nil
+}
 
-	// combine: prefix + resolved + suffix
-	return s[:start] + resolved + suffix, nil
+// wrapError wraps the error of the i-th part of the string with the text
+// of every enclosing part, as a recursive scan of the string would do.
+func (c *compiledString) wrapError(err error, i int) error {
+	for j := i - 1; j >= 0; j-- {
+		err = util.FormatError(err, "resolve string %q error", c.refs[j].src)
+	}
+	return err
 }
@@ -18,8 +18,12 @@ package conf_test
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"io"
+	"net"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
@@ -85,6 +89,85 @@ func TestConverter(t *testing.T) {
 	})
 }
 
+// Level is an enum type bound by a converter.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+)
+
+func init() {
+	conf.RegisterConverter(func(s string) (Level, error) {
+		switch s {
+		case "debug":
+			return LevelDebug, nil
+		case "info":
+			return LevelInfo, nil
+		default:
+			return 0, fmt.Errorf("unknown level %q", s)
+		}
+	})
+	conf.RegisterConverter(func(s string) (net.IP, error) {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip %q", s)
+		}
+		return ip, nil
+	})
+	conf.RegisterConverter(url.Parse)
+}
+
+func TestCustomConverter(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"level":  "info",
+			"levels": "debug,info",
+			"ip":     "10.0.0.1",
+			"url":    "https://example.com/api",
+			"addr":   "192.168.0.1",
+			"addrs":  []string{"::1", "127.0.0.1"},
+		})
+		var s struct {
+			Level  Level        `value:"${level}"`
+			Levels []Level      `value:"${levels}"`
+			IP     net.IP       `value:"${ip}"`
+			URL    *url.URL     `value:"${url}"`
+			Addr   netip.Addr   `value:"${addr}"`
+			Addrs  []netip.Addr `value:"${addrs}"`
+			Def    netip.Addr   `value:"${def:=0.0.0.0}"`
+		}
+		err := p.Bind(&s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Level).Equal(LevelInfo)
+		assert.That(t, s.Levels).Equal([]Level{LevelDebug, LevelInfo})
+		assert.That(t, s.IP.String()).Equal("10.0.0.1")
+		assert.That(t, s.URL.Host).Equal("example.com")
+		assert.That(t, s.Addr).Equal(netip.MustParseAddr("192.168.0.1"))
+		assert.That(t, s.Addrs).Equal([]netip.Addr{netip.MustParseAddr("::1"), netip.MustParseAddr("127.0.0.1")})
+		assert.That(t, s.Def).Equal(netip.MustParseAddr("0.0.0.0"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"level": "warn",
+			"addr":  "x.y",
+		})
+		var s1 struct {
+			Level Level `value:"${level}"`
+		}
+		err := p.Bind(&s1)
+		assert.Error(t, err).Matches(`unknown level "warn"`)
+
+		var s2 struct {
+			Addr netip.Addr `value:"${addr}"`
+		}
+		err = p.Bind(&s2)
+		assert.Error(t, err).Matches(`bind path=.*Addr type=netip.Addr error: ParseAddr\("x.y"\)`)
+	})
+}
+
 func TestSplitter(t *testing.T) {
 
 	t.Run("split points success", func(t *testing.T) {
@@ -164,6 +247,39 @@ func TestParseTag(t *testing.T) {
 		})
 	})
 
+	t.Run("colon default", func(t *testing.T) {
+		tag, err := conf.ParseTag("${a:http://localhost:8080}")
+		assert.That(t, err).Nil()
+		assert.That(t, tag).Equal(conf.ParsedTag{
+			Key:    "a",
+			Def:    "http://localhost:8080",
+			HasDef: true,
+		})
+		tag, err = conf.ParseTag("${a:}")
+		assert.That(t, err).Nil()
+		assert.That(t, tag).Equal(conf.ParsedTag{
+			Key:    "a",
+			HasDef: true,
+		})
+	})
+
+	t.Run("nested default", func(t *testing.T) {
+		tag, err := conf.ParseTag("${a:${b:=c}}")
+		assert.That(t, err).Nil()
+		assert.That(t, tag).Equal(conf.ParsedTag{
+			Key:    "a",
+			Def:    "${b:=c}",
+			HasDef: true,
+		})
+		tag, err = conf.ParseTag("${${a:b}:c}")
+		assert.That(t, err).Nil()
+		assert.That(t, tag).Equal(conf.ParsedTag{
+			Key:    "${a:b}",
+			Def:    "c",
+			HasDef: true,
+		})
+	})
+
 	t.Run("key with special chars", func(t *testing.T) {
 		tag, err := conf.ParseTag("${key-with.dots_and_underscores:=value}")
 		assert.That(t, err).Nil()
@@ -795,6 +911,54 @@ func TestResolveString(t *testing.T) {
 		assert.That(t, err).Nil()
 		assert.That(t, s.Value).Equal("default")
 	})
+
+	t.Run("colon default", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"b":    "fromB",
+			"port": "8080",
+		})
+
+		var s struct {
+			A string `value:"${a:default}"`
+			B string `value:"${a:${b}}"`
+			C string `value:"${a:${c:${port}}}"`
+			D string `value:"${a:}"`
+		}
+
+		err := p.Bind(&s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.A).Equal("default")
+		assert.That(t, s.B).Equal("fromB")
+		assert.That(t, s.C).Equal("8080")
+		assert.That(t, s.D).Equal("")
+
+		str, err := p.Resolve("http://${host:localhost}:${port:80}")
+		assert.That(t, err).Nil()
+		assert.That(t, str).Equal("http://localhost:8080")
+	})
+
+	t.Run("escape", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"a":        "1",
+			"template": "\\${name}-${a}",
+		})
+
+		str, err := p.Resolve("\\${a}")
+		assert.That(t, err).Nil()
+		assert.That(t, str).Equal("${a}")
+
+		str, err = p.Resolve("x\\${a}y${a}z\\${b:${a}}")
+		assert.That(t, err).Nil()
+		assert.That(t, str).Equal("x${a}y1z${b:1}")
+
+		str, err = p.Resolve("${template}")
+		assert.That(t, err).Nil()
+		assert.That(t, str).Equal("${name}-1")
+
+		str, err = p.Resolve("${missing:\\${a}}")
+		assert.That(t, err).Nil()
+		assert.That(t, str).Equal("${a}")
+	})
 }
 
 func TestMapBinding(t *testing.T) {
@@ -918,3 +1082,80 @@ func TestStructBinding(t *testing.T) {
 		})
 	})
 }
+
+func TestStructCollectionBinding(t *testing.T) {
+
+	type Server struct {
+		Addr string   `value:"${addr}"`
+		Port int      `value:"${port:=80}"`
+		Tags []string `value:"${tags:=}"`
+	}
+
+	type Endpoint struct {
+		URL     string    `value:"${url}"`
+		Servers []*Server `value:"${servers:=}"`
+	}
+
+	src := `
+host=h1
+servers.api.addr=${host}:1
+servers.api.tags=a,b
+servers.web.addr=w
+servers.web.port=${servers.api.port:=90}
+endpoints[0].url=u0
+endpoints[1].url=${host}/x
+endpoints[1].servers[0].addr=s
+`
+
+	t.Run("success", func(t *testing.T) {
+		p, err := conf.Parse([]byte(src), ".properties", "test")
+		assert.That(t, err).Nil()
+
+		var s struct {
+			Servers    map[string]Server  `value:"${servers}"`
+			ServerPtrs map[string]*Server `value:"${servers}"`
+			Endpoints  []Endpoint         `value:"${endpoints}"`
+		}
+		err = p.Bind(&s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Servers).Equal(map[string]Server{
+			"api": {Addr: "h1:1", Port: 80, Tags: []string{"a", "b"}},
+			"web": {Addr: "w", Port: 90, Tags: []string{}},
+		})
+		assert.That(t, s.ServerPtrs["api"]).Equal(&Server{Addr: "h1:1", Port: 80, Tags: []string{"a", "b"}})
+		assert.That(t, s.Endpoints).Equal([]Endpoint{
+			{URL: "u0", Servers: []*Server{}},
+			{URL: "h1/x", Servers: []*Server{{Addr: "s", Port: 80, Tags: []string{}}}},
+		})
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		p, err := conf.Parse([]byte("endpoints[0].url=u0\nendpoints[1].servers=a"), ".properties", "test")
+		assert.That(t, err).Nil()
+		var s struct {
+			Endpoints []Endpoint `value:"${endpoints}"`
+		}
+		err = p.Bind(&s)
+		assert.Error(t, err).Matches(`property "endpoints\[1\].url" not exist`)
+	})
+
+	t.Run("missing element", func(t *testing.T) {
+		p, err := conf.Parse([]byte("endpoints[0].url=u0\nendpoints[2].url=u2"), ".properties", "test")
+		assert.That(t, err).Nil()
+		var s struct {
+			Endpoints []Endpoint `value:"${endpoints}"`
+		}
+		err = p.Bind(&s)
+		assert.Error(t, err).Matches(`missing element endpoints\[1\]`)
+	})
+
+	t.Run("map element path", func(t *testing.T) {
+		p, err := conf.Parse([]byte("servers.api.addr=a\nservers.api.port=abc"), ".properties", "test")
+		assert.That(t, err).Nil()
+		var s struct {
+			Servers map[string]*Server `value:"${servers}"`
+		}
+		err = p.Bind(&s)
+		assert.Error(t, err).Matches(`bind path=.*Servers\[api\].Port type=int error`)
+	})
+}
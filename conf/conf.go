@@ -50,6 +50,9 @@ Supports binding to various types with automatic conversion:
 
 3. Custom Types: Register converters using RegisterConverter
 
+4. Formats: A format tag binds data sizes (format:"size"), plain numbers
+as durations in a unit (format:"s") and time layouts (format:"2006-01-02")
+
 # Validation System:
 
  1. Expression validation using expr tag:
@@ -69,8 +72,14 @@ Built-in readers handle:
 - Properties (.properties)
 - YAML (.yaml/.yml)
 - TOML (.toml/.tml)
+- INI (.ini)
+- HOCON (.conf), with include directives
+
+Register custom readers with RegisterReader, or RegisterFileReader when
+the reader needs the name of the file, e.g. to resolve relative includes.
 
-Register custom readers with RegisterReader.
+Properties are written back by Marshal or Save as JSON, Properties or
+YAML, and by the writers registered with RegisterWriter.
 
 # Property Resolution:
 
@@ -83,7 +92,8 @@ Register custom readers with RegisterReader.
 1. RegisterSplitter: Add custom string splitters
 2. RegisterConverter: Add type converters
 3. RegisterReader: Support new file formats
-4. RegisterValidateFunc: Add custom validators
+4. RegisterWriter: Write new file formats
+5. RegisterValidateFunc: Add custom validators
 
 # Examples:
 
@@ -119,15 +129,22 @@ Validation:
 package conf
 
 import (
+	"encoding"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-spring/spring-base/barky"
 	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf/reader/hocon"
+	"github.com/go-spring/spring-core/conf/reader/ini"
 	"github.com/go-spring/spring-core/conf/reader/json"
 	"github.com/go-spring/spring-core/conf/reader/prop"
 	"github.com/go-spring/spring-core/conf/reader/toml"
@@ -136,7 +153,9 @@ import (
 )
 
 var (
-	readers    = map[string]Reader{}
+	readers    = map[string]FileReader{}
+	docReaders = map[string]DocumentReader{}
+	writers    = map[string]Writer{}
 	splitters  = map[string]Splitter{}
 	converters = map[reflect.Type]any{}
 )
@@ -147,7 +166,17 @@ func init() {
 	RegisterReader(json.Read, ".json")
 	RegisterReader(prop.Read, ".properties")
 	RegisterReader(yaml.Read, ".yaml", ".yml")
+	RegisterDocumentReader(func(b []byte, _ string) ([]map[string]any, error) {
+		return yaml.ReadDocuments(b)
+	}, ".yaml", ".yml")
 	RegisterReader(toml.Read, ".toml", ".tml")
+	RegisterReader(ini.Read, ".ini")
+	RegisterFileReader(hocon.ReadFile, ".conf")
+
+	// built-in writers
+	RegisterWriter(json.Write, ".json")
+	RegisterWriter(prop.Write, ".properties")
+	RegisterWriter(yaml.Write, ".yaml", ".yml")
 
 	// time.Time
 	RegisterConverter(func(s string) (time.Time, error) {
@@ -173,8 +202,43 @@ type Reader func(b []byte) (map[string]any, error)
 
 // RegisterReader registers its Reader for some kind of file extension.
 func RegisterReader(r Reader, ext ...string) {
+	RegisterFileReader(func(b []byte, _ string) (map[string]any, error) {
+		return r(b)
+	}, ext...)
+}
+
+// FileReader parses raw bytes read from the file into a nested map[string]any.
+type FileReader func(b []byte, file string) (map[string]any, error)
+
+// RegisterFileReader registers its FileReader for some kind of file extension.
+// It replaces the DocumentReader registered for the same extension.
+func RegisterFileReader(r FileReader, ext ...string) {
 	for _, s := range ext {
 		readers[s] = r
+		delete(docReaders, s)
+	}
+}
+
+// DocumentReader parses raw bytes read from the file into its documents,
+// for the formats that hold several documents in one file, like yaml.
+type DocumentReader func(b []byte, file string) ([]map[string]any, error)
+
+// RegisterDocumentReader registers its DocumentReader for some kind of
+// file extension. It takes precedence over the FileReader registered for
+// the same extension.
+func RegisterDocumentReader(r DocumentReader, ext ...string) {
+	for _, s := range ext {
+		docReaders[s] = r
+	}
+}
+
+// Writer formats a nested map[string]any into raw bytes.
+type Writer func(m map[string]any) ([]byte, error)
+
+// RegisterWriter registers its Writer for some kind of file extension.
+func RegisterWriter(w Writer, ext ...string) {
+	for _, s := range ext {
+		writers[s] = w
 	}
 }
 
@@ -189,21 +253,59 @@ func RegisterSplitter(name string, fn Splitter) {
 // Converter converts a string to a target type T.
 type Converter[T any] func(string) (T, error)
 
-// RegisterConverter registers a Converter for a non-primitive type such as
-// time.Time, time.Duration, or other user-defined value types.
+// RegisterConverter registers a Converter for a type such as time.Time,
+// time.Duration, or other user-defined types, e.g. net.IP, *url.URL or
+// an enum type. A value of the type is then bound from a single string,
+// even if it's a slice, a map, a struct or a pointer.
+//
+// Without a converter, a non-primitive type that implements
+// [encoding.TextUnmarshaler], e.g. netip.Addr, is bound by UnmarshalText.
 func RegisterConverter[T any](fn Converter[T]) {
 	t := reflect.TypeFor[T]()
 	converters[t] = fn
 }
 
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// isTextUnmarshaler returns whether t is a non-primitive type bound by
+// its UnmarshalText method.
+func isTextUnmarshaler(t reflect.Type) bool {
+	if util.IsPrimitiveValueType(t) {
+		return false
+	}
+	if t.Kind() == reflect.Ptr {
+		return t.Implements(textUnmarshalerType)
+	}
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// unmarshalText creates a value of the type t by its UnmarshalText method.
+func unmarshalText(t reflect.Type, s string) (reflect.Value, error) {
+	var v, ptr reflect.Value
+	if t.Kind() == reflect.Ptr {
+		ptr = reflect.New(t.Elem())
+		v = ptr
+	} else {
+		ptr = reflect.New(t)
+		v = ptr.Elem()
+	}
+	err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	return v, err
+}
+
 // Properties defines the read-only interface for accessing configuration data.
 type Properties interface {
 	// Data returns all key-value pairs as a flat map.
 	Data() map[string]string
-	// Keys returns all keys.
-	Keys() []string
+	// Keys returns all keys, sorted, or only those under a prefix if given.
+	Keys(prefix ...string) []string
 	// SubKeys returns the sorted sub-keys of a given key.
 	SubKeys(key string) ([]string, error)
+	// Subset returns the properties under a prefix, with the prefix removed.
+	Subset(prefix string) (Properties, error)
+	// Range calls fn for the keys under a prefix and their values, in key
+	// order, until fn returns false.
+	Range(prefix string, fn func(key, value string) bool)
 	// Has checks whether a key exists.
 	Has(key string) bool
 	// Get returns the value for a given key, with an optional default.
@@ -232,39 +334,192 @@ var _ Properties = (*MutableProperties)(nil)
 // Java properties isn't strictly verified. Although configuration can store as a tree,
 // but it costs more CPU time when getting properties because it reads property node
 // by node. So `conf` uses a tree to strictly verify and a flat map to store.
+//
+// MutableProperties is safe for concurrent use, so that keys streamed in by
+// watchers can be set while the properties are being read. The readers
+// never lock: they read an immutable snapshot of the properties, which is
+// copied on the first write after it has been read, see load and edit.
 type MutableProperties struct {
-	*barky.Storage
+	mutex   sync.Mutex               // serializes the writers
+	storage *barky.Storage           // storage being written, nil if published
+	names   map[string]string        // names of keys in their sources, see SetNamed
+	current atomic.Pointer[snapshot] // the snapshot to read, nil if stale
+
+	randoms map[string]string // random values generated, see RandomPrefix
+}
+
+// snapshot is an immutable version of the properties.
+type snapshot struct {
+	storage *barky.Storage
+	names   map[string]string
+
+	keys    atomic.Pointer[[]string]                   // sorted keys, nil until used
+	relaxed atomic.Pointer[map[string]string]          // see relaxedIndex, nil until used
+	raw     atomic.Pointer[map[string]barky.ValueInfo] // see rawData, nil until used
+	files   atomic.Pointer[[]string]                   // file names by index, nil until used
 }
 
 // New creates a new empty MutableProperties instance.
 func New() *MutableProperties {
-	return &MutableProperties{
-		Storage: barky.NewStorage(),
+	p := &MutableProperties{}
+	p.current.Store(&snapshot{storage: barky.NewStorage()})
+	return p
+}
+
+// load returns the snapshot to read, publishing the storage written since
+// the last read. The lock is only taken on the first read after a write,
+// so that a reader of properties that don't change never blocks.
+func (p *MutableProperties) load() *snapshot {
+	if s := p.current.Load(); s != nil {
+		return s
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if s := p.current.Load(); s != nil {
+		return s
+	}
+	if p.storage == nil {
+		p.storage = barky.NewStorage()
+	}
+	s := &snapshot{storage: p.storage, names: p.names}
+	p.storage, p.names = nil, nil
+	p.current.Store(s)
+	return s
+}
+
+// edit returns the storage to write, a copy of the snapshot if it has
+// been published. It must be called with p.mutex held.
+func (p *MutableProperties) edit() *barky.Storage {
+	if p.storage != nil {
+		return p.storage
+	}
+	if s := p.current.Load(); s != nil {
+		p.storage, p.names = cloneStorage(s.storage, nil), maps.Clone(s.names)
+	} else {
+		p.storage = barky.NewStorage()
+	}
+	p.current.Store(nil)
+	return p.storage
+}
+
+// cloneStorage returns a copy of the storage with the same file indexes,
+// without the keys that drop reports, if it isn't nil.
+func cloneStorage(s *barky.Storage, drop func(key string) bool) *barky.Storage {
+	c := barky.NewStorage()
+	for _, file := range fileNames(s) {
+		c.AddFile(file)
+	}
+	for key, v := range s.RawData() {
+		if drop == nil || !drop(key) {
+			_ = c.Set(key, v.Value, v.File) // the keys are known to be valid
+		}
+	}
+	return c
+}
+
+// fileNames returns the names of the files of the storage by index.
+func fileNames(s *barky.Storage) []string {
+	files := make([]string, len(s.RawFile()))
+	for file, i := range s.RawFile() {
+		files[i] = file
 	}
+	return files
+}
+
+// sortedKeys returns the sorted keys of the snapshot, which must not be
+// modified.
+func (s *snapshot) sortedKeys() []string {
+	if keys := s.keys.Load(); keys != nil {
+		return *keys
+	}
+	keys := s.storage.Keys()
+	s.keys.Store(&keys)
+	return keys
 }
 
 // Load creates a MutableProperties instance from a configuration file.
-// Returns an error if the file type is not supported or parsing fails.
-func Load(file string) (*MutableProperties, error) {
+// The profiles are the active profiles, see Parse. Returns an error if the
+// file type is not supported or parsing fails.
+func Load(file string, profiles ...string) (*MutableProperties, error) {
 	b, err := os.ReadFile(file)
 	if err != nil {
 		return nil, util.FormatError(err, "read file %s error", file)
 	}
-	ext := filepath.Ext(file)
+	p, err := Parse(b, filepath.Ext(file), file, profiles...)
+	if err != nil {
+		return nil, util.FormatError(err, "read file %s error", file)
+	}
+	return p, nil
+}
+
+// Parse creates a MutableProperties instance from raw configuration data
+// in the format registered for the file extension ext (e.g. ".yaml").
+// The name is recorded as the source of the parsed properties.
+//
+// In a format with several documents in one file, the documents are
+// merged in order, later ones overriding earlier ones. A document with
+// OnProfileKey is only merged if it matches the active profiles, e.g.
+// "dev", "dev,test" for either of them, or "!prod" unless prod is active.
+func Parse(b []byte, ext string, name string, profiles ...string) (*MutableProperties, error) {
+	if r, ok := docReaders[ext]; ok {
+		docs, err := r(b, name)
+		if err != nil {
+			return nil, err
+		}
+		p := New()
+		for _, doc := range docs {
+			m := barky.FlattenMap(doc)
+			if onProfile(m, profiles) {
+				if err = p.merge(m, name); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return p, nil
+	}
 	r, ok := readers[ext]
 	if !ok {
-		err = util.FormatError(nil, "unsupported file type %s", ext)
-		return nil, util.FormatError(err, "read file %s error", file)
+		return nil, util.FormatError(nil, "unsupported file type %s", ext)
 	}
-	m, err := r(b)
+	m, err := r(b, name)
 	if err != nil {
-		return nil, util.FormatError(err, "read file %s error", file)
+		return nil, err
 	}
 	p := New()
-	_ = p.merge(barky.FlattenMap(m), file)
+	_ = p.merge(barky.FlattenMap(m), name)
 	return p, nil
 }
 
+// OnProfileKey is the key by which a document of a multi-document file
+// is activated for some profiles only.
+const OnProfileKey = "spring.config.activate.on-profile"
+
+// onProfile reports whether the flattened document is active for the
+// profiles, and removes OnProfileKey, which may be a list, from it.
+func onProfile(m map[string]string, profiles []string) bool {
+	var exprs []string
+	for k, v := range m {
+		if k == OnProfileKey || strings.HasPrefix(k, OnProfileKey+"[") {
+			exprs = append(exprs, strings.Split(v, ",")...)
+			delete(m, k)
+		}
+	}
+	if exprs == nil {
+		return true
+	}
+	for _, s := range exprs {
+		s = strings.TrimSpace(s)
+		if name, ok := strings.CutPrefix(s, "!"); ok {
+			if !slices.Contains(profiles, strings.TrimSpace(name)) {
+				return true
+			}
+		} else if s != "" && slices.Contains(profiles, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // Map creates a MutableProperties instance directly from a map.
 func Map(data map[string]any) *MutableProperties {
 	p := New()
@@ -273,17 +528,274 @@ func Map(data map[string]any) *MutableProperties {
 	return p
 }
 
-// merge flattens the map and sets all keys and values.
+// RawData returns a copy of the flattened keys with their values and
+// the indexes of the files they come from.
+func (p *MutableProperties) RawData() map[string]barky.ValueInfo {
+	return maps.Clone(p.load().storage.RawData())
+}
+
+// Data returns all key-value pairs as a flat map.
+func (p *MutableProperties) Data() map[string]string {
+	return p.load().storage.Data()
+}
+
+// AddFile registers a file name and returns its index.
+func (p *MutableProperties) AddFile(file string) int8 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if s := p.current.Load(); s != nil {
+		if i, ok := s.storage.RawFile()[file]; ok {
+			return i
+		}
+	}
+	return p.edit().AddFile(file)
+}
+
+// RawFile returns a copy of the file names with their indexes.
+func (p *MutableProperties) RawFile() map[string]int8 {
+	return maps.Clone(p.load().storage.RawFile())
+}
+
+// Keys returns all keys, sorted. With a prefix, e.g. "http.server", only
+// the keys at or under it are returned, e.g. "http.server.addr" but not
+// "http.servers". An empty prefix stands for all keys. The prefix is
+// matched like the keys in Get.
+func (p *MutableProperties) Keys(prefix ...string) []string {
+	s := p.load()
+	if len(prefix) == 0 || prefix[0] == "" {
+		return slices.Clone(s.sortedKeys())
+	}
+	return s.keysUnder(s.resolvePrefix(prefix[0]))
+}
+
+// resolvePrefix returns the stored path matching the prefix, see lookup.
+func (s *snapshot) resolvePrefix(prefix string) string {
+	if !s.storage.Has(prefix) {
+		if k, ok := s.lookup(prefix); ok {
+			return k
+		}
+	}
+	return prefix
+}
+
+// keysUnder returns the sorted keys at or under the prefix.
+func (s *snapshot) keysUnder(prefix string) []string {
+	return keysUnder(s.sortedKeys(), prefix)
+}
+
+// keysUnder returns the keys at or under the prefix from the sorted keys.
+func keysUnder(keys []string, prefix string) []string {
+	i, _ := slices.BinarySearch(keys, prefix)
+	ret := []string{}
+	for _, key := range keys[i:] {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			break
+		}
+		if rest == "" || rest[0] == '.' || rest[0] == '[' {
+			ret = append(ret, key)
+		}
+	}
+	return ret
+}
+
+// Subset returns a copy of the properties under the prefix, with the
+// prefix removed from their keys, e.g. "addr" for "http.server.addr"
+// under "http.server", or "[0].addr" for "servers[0].addr" under
+// "servers". The sources of the values are kept. An empty prefix copies
+// all properties, and a prefix that is itself a value is an error.
+func (p *MutableProperties) Subset(prefix string) (Properties, error) {
+	s := p.load()
+	out := New()
+	files := make([]string, len(s.storage.RawFile()))
+	for name, i := range s.storage.RawFile() {
+		files[i] = name
+	}
+	data := s.storage.RawData()
+	var keys []string
+	if prefix == "" {
+		keys = s.sortedKeys()
+	} else {
+		prefix = s.resolvePrefix(prefix)
+		if _, ok := data[prefix]; ok {
+			return nil, util.FormatError(nil, "property conflict at path %s", prefix)
+		}
+		keys = s.keysUnder(prefix)
+	}
+	for _, key := range keys {
+		v := data[key]
+		var file string
+		if int(v.File) < len(files) {
+			file = files[v.File]
+		}
+		sub := strings.TrimPrefix(strings.TrimPrefix(key, prefix), ".")
+		if err := out.SetNamed(sub, v.Value, out.AddFile(file), s.names[key]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Range calls fn for the keys at or under the prefix, all keys if it's
+// empty, and their values, in key order, until fn returns false. The
+// values are those at the time of the call, even if fn sets some.
+func (p *MutableProperties) Range(prefix string, fn func(key, value string) bool) {
+	s := p.load()
+	var keys []string
+	if prefix == "" {
+		keys = s.sortedKeys()
+	} else {
+		keys = s.keysUnder(s.resolvePrefix(prefix))
+	}
+	for _, key := range keys {
+		if !fn(key, s.storage.Get(key)) {
+			return
+		}
+	}
+}
+
+// SubKeys returns the sorted sub-keys of a given key. The key is matched
+// like in Get.
+func (p *MutableProperties) SubKeys(key string) ([]string, error) {
+	s := p.load()
+	if !s.storage.Has(key) {
+		if k, ok := s.lookup(key); ok {
+			key = k
+		}
+	}
+	return s.storage.SubKeys(key)
+}
+
+// Has checks whether a key exists. The key is matched like in Get.
+func (p *MutableProperties) Has(key string) bool {
+	s := p.load()
+	if s.storage.Has(key) {
+		return true
+	}
+	_, ok := s.lookup(key)
+	return ok
+}
+
+// Get returns the value for a given key, with an optional default.
+// If the key isn't set, the value of its deprecated key registered by
+// RegisterDeprecatedKey is returned, and keys are matched relaxedly,
+// ignoring case and the '-' and '_' separators, e.g. "server.max-conns"
+// matches "server.maxConns" and "SERVER.MAX_CONNS".
+func (p *MutableProperties) Get(key string, def ...string) string {
+	s := p.load()
+	const missing = "\x00missing"
+	if v := s.storage.Get(key, missing); v != missing {
+		return v
+	}
+	if k, ok := s.lookup(key); ok {
+		key = k
+	}
+	return s.storage.Get(key, def...)
+}
+
+// Set sets the value of a key, recording the index of the file it comes
+// from. It returns an error if the key conflicts with existing ones.
+func (p *MutableProperties) Set(key string, val string, file int8) error {
+	return p.SetNamed(key, val, file, "")
+}
+
+// SetNamed is like Set, but also records the name of the value in the
+// file it comes from when the name differs from the key, e.g. the name of
+// the environment variable "GS_DB_HOST" for the key "db.host".
+func (p *MutableProperties) SetNamed(key string, val string, file int8, name string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if err := p.edit().Set(key, val, file); err != nil {
+		return err
+	}
+	if name != "" && name != key {
+		if p.names == nil {
+			p.names = make(map[string]string)
+		}
+		p.names[key] = name
+	} else {
+		delete(p.names, key)
+	}
+	return nil
+}
+
+// Origin describes where the value of a key comes from.
+type Origin struct {
+	File string // The file or other source, e.g. "Environment".
+	Name string // The name of the value in the file if it differs from the key.
+}
+
+// String returns the origin in the form "file" or "file(name)".
+func (o Origin) String() string {
+	if o.Name == "" {
+		return o.File
+	}
+	return o.File + "(" + o.Name + ")"
+}
+
+// Origin returns where the value of the key comes from, and false if
+// the key doesn't exist. It doesn't copy the properties, so that it may
+// be called for many keys.
+func (p *MutableProperties) Origin(key string) (Origin, bool) {
+	s := p.load()
+	v, ok := s.rawData()[key]
+	if !ok {
+		return Origin{}, false
+	}
+	o := Origin{Name: s.names[key]}
+	if files := s.fileNames(); int(v.File) < len(files) {
+		o.File = files[v.File]
+	}
+	return o, true
+}
+
+// rawData returns the values of the snapshot with their file indexes,
+// including the empty containers, which must not be modified.
+func (s *snapshot) rawData() map[string]barky.ValueInfo {
+	if raw := s.raw.Load(); raw != nil {
+		return *raw
+	}
+	raw := s.storage.RawData()
+	s.raw.Store(&raw)
+	return raw
+}
+
+// fileNames returns the names of the files of the snapshot by index,
+// which must not be modified.
+func (s *snapshot) fileNames() []string {
+	if files := s.files.Load(); files != nil {
+		return *files
+	}
+	files := fileNames(s.storage)
+	s.files.Store(&files)
+	return files
+}
+
+// merge flattens the map and sets all keys and values. Equal values are
+// interned, since large configs repeat a small set of values many times.
 func (p *MutableProperties) merge(m map[string]string, file string) error {
 	fileID := p.AddFile(file)
+	values := make(interner)
 	for key, val := range m {
-		if err := p.Set(key, val, fileID); err != nil {
+		if err := p.Set(key, values.intern(val), fileID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// interner deduplicates equal strings so that they share the same memory.
+type interner map[string]string
+
+// intern returns the first string equal to s that has been interned.
+func (in interner) intern(s string) string {
+	if v, ok := in[s]; ok {
+		return v
+	}
+	in[s] = s
+	return s
+}
+
 // Resolve resolves placeholders in a string, replacing references like
 // ${key:=default} with their actual values from the properties.
 func (p *MutableProperties) Resolve(s string) (string, error) {
@@ -338,16 +850,17 @@ func (p *MutableProperties) Bind(i any, tag ...string) error {
 // CopyTo copies all properties into another MutableProperties instance,
 // overriding values if keys already exist.
 func (p *MutableProperties) CopyTo(out *MutableProperties) error {
-	rawFile := p.RawFile()
+	s := p.load()
+	rawFile := s.storage.RawFile()
 	newfile := make(map[string]int8)
 	oldFile := make([]string, len(rawFile))
 	for k, v := range rawFile {
 		oldFile[v] = k
 		newfile[k] = out.AddFile(k)
 	}
-	for key, v := range p.RawData() {
+	for key, v := range s.storage.RawData() {
 		fileID := newfile[oldFile[v.File]]
-		if err := out.Set(key, v.Value, fileID); err != nil {
+		if err := out.SetNamed(key, v.Value, fileID, s.names[key]); err != nil {
 			return err
 		}
 	}
@@ -17,9 +17,15 @@
 package conf_test
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-spring/spring-base/testing/assert"
 	"github.com/go-spring/spring-core/conf"
@@ -56,6 +62,85 @@ func TestProperties_Load(t *testing.T) {
 	})
 }
 
+func TestProperties_Parse(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		p, err := conf.Parse([]byte("a:\n  b: 1\n  c: [x, z]"), ".yaml", "inline")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a.b":    "1",
+			"a.c[0]": "x",
+			"a.c[1]": "z",
+		})
+	})
+
+	t.Run("unsupported ext", func(t *testing.T) {
+		_, err := conf.Parse([]byte("a=1"), ".xml", "inline")
+		assert.Error(t, err).Matches("unsupported file type .xml")
+	})
+
+	t.Run("ini", func(t *testing.T) {
+		p, err := conf.Parse([]byte("a=1\n[b]\nc=2"), ".ini", "inline")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a":   "1",
+			"b.c": "2",
+		})
+	})
+
+	t.Run("hocon", func(t *testing.T) {
+		p, err := conf.Parse([]byte("a { b = 1, c = [x, z] }"), ".conf", "inline")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a.b":    "1",
+			"a.c[0]": "x",
+			"a.c[1]": "z",
+		})
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := conf.Parse([]byte("{"), ".json", "inline")
+		assert.Error(t, err).Matches("read json error")
+	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		src := strings.Join([]string{
+			"a: base\nb: base",
+			"spring.config.activate.on-profile: dev\na: dev",
+			"spring:\n  config:\n    activate:\n      on-profile: [test, prod]\nb: test-or-prod",
+			"spring.config.activate.on-profile: '!prod'\nc: not-prod",
+		}, "\n---\n")
+
+		p, err := conf.Parse([]byte(src), ".yaml", "inline")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a": "base",
+			"b": "base",
+			"c": "not-prod",
+		})
+
+		p, err = conf.Parse([]byte(src), ".yml", "inline", "dev", "test")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a": "dev",
+			"b": "test-or-prod",
+			"c": "not-prod",
+		})
+
+		p, err = conf.Parse([]byte(src), ".yaml", "inline", "prod")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a": "base",
+			"b": "test-or-prod",
+		})
+	})
+
+	t.Run("multiple documents conflict", func(t *testing.T) {
+		_, err := conf.Parse([]byte("a: 1\n---\na:\n  b: 2"), ".yaml", "inline")
+		assert.Error(t, err).Matches("property conflict at path a.b")
+	})
+}
+
 func TestProperties_Resolve(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
@@ -113,6 +198,80 @@ func TestProperties_Resolve(t *testing.T) {
 		_, err := p.Resolve("${a.b.c[0]}==${a.b.c}")
 		assert.Error(t, err).Matches("property \"a.b.c\" isn't simple value")
 	})
+
+	t.Run("multiple references", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"host": "localhost",
+			"port": "8080",
+		})
+		for range 2 { // the second time from the compiled cache
+			s, err := p.Resolve("http://${host}:${port}/${path:=api}")
+			assert.That(t, err).Nil()
+			assert.That(t, s).Equal("http://localhost:8080/api")
+		}
+
+		// the compiled string doesn't keep the values
+		p = conf.Map(map[string]any{
+			"host": "127.0.0.1",
+			"port": "9090",
+		})
+		s, err := p.Resolve("http://${host}:${port}/${path:=api}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("http://127.0.0.1:9090/api")
+	})
+
+	t.Run("circular reference", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"a": "${b}",
+			"b": "x-${a}",
+		})
+		_, err := p.Resolve("${a}")
+		assert.That(t, errors.Is(err, conf.ErrResolveDepth)).True()
+		assert.Error(t, err).Matches(`resolve property "[ab]" at depth 65 error: max resolve depth exceeded`)
+
+		var s struct {
+			A string `value:"${a}"`
+		}
+		err = p.Bind(&s)
+		assert.That(t, errors.Is(err, conf.ErrResolveDepth)).True()
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		m := map[string]any{"v0": strings.Repeat("x", 64)}
+		for i := 1; i <= 16; i++ {
+			m["v"+strconv.Itoa(i)] = fmt.Sprintf("${v%d}${v%d}", i-1, i-1)
+		}
+		p := conf.Map(m)
+		s, err := p.Resolve("${v8}")
+		assert.That(t, err).Nil()
+		assert.That(t, len(s)).Equal(64 << 8)
+		_, err = p.Resolve("${v16}")
+		assert.That(t, errors.Is(err, conf.ErrResolveSize)).True()
+		assert.Error(t, err).Matches(`resolve string .* to \d+ bytes error: max resolve size exceeded`)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		timeout := conf.ResolveTimeout
+		conf.ResolveTimeout = 0
+		defer func() { conf.ResolveTimeout = timeout }()
+
+		p := conf.Map(map[string]any{"k": "x"})
+		_, err := p.Resolve(strings.Repeat("${k}", 64))
+		assert.That(t, err).Nil()
+		_, err = p.Resolve(strings.Repeat("${k}", 200))
+		assert.That(t, errors.Is(err, conf.ErrResolveTimeout)).True()
+		assert.Error(t, err).Matches(`resolve property "k" after 0s error: resolve timeout`)
+	})
+
+	t.Run("nested errors", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"host": "localhost",
+		})
+		_, err := p.Resolve("${host}:${port}")
+		assert.Error(t, err).String(`resolve string "${host}:${port}" error: resolve string ":${port}" error: property "port" not exist`)
+		_, err = p.Resolve("${host}:${port")
+		assert.Error(t, err).String(`resolve string "${host}:${port" error: resolve string ":${port" error: invalid syntax`)
+	})
 }
 
 func TestProperties_CopyTo(t *testing.T) {
@@ -173,6 +332,300 @@ func TestProperties_CopyTo(t *testing.T) {
 	})
 }
 
+func TestProperties_Origin(t *testing.T) {
+	p := conf.New()
+	file := p.AddFile("app.yaml")
+	env := p.AddFile("Environment")
+	assert.That(t, p.Set("a", "1", file)).Nil()
+	assert.That(t, p.SetNamed("b", "2", env, "GS_B")).Nil()
+	assert.That(t, p.SetNamed("c", "3", env, "c")).Nil()
+
+	o, ok := p.Origin("a")
+	assert.That(t, ok).True()
+	assert.That(t, o).Equal(conf.Origin{File: "app.yaml"})
+	assert.That(t, o.String()).Equal("app.yaml")
+
+	o, ok = p.Origin("b")
+	assert.That(t, ok).True()
+	assert.That(t, o.String()).Equal("Environment(GS_B)")
+
+	o, _ = p.Origin("c")
+	assert.That(t, o.String()).Equal("Environment")
+
+	_, ok = p.Origin("d")
+	assert.That(t, ok).False()
+
+	t.Run("copy", func(t *testing.T) {
+		out := conf.New()
+		assert.That(t, p.CopyTo(out)).Nil()
+		o, _ := out.Origin("b")
+		assert.That(t, o.String()).Equal("Environment(GS_B)")
+	})
+
+	t.Run("override", func(t *testing.T) {
+		assert.That(t, p.Set("b", "4", file)).Nil()
+		o, _ := p.Origin("b")
+		assert.That(t, o.String()).Equal("app.yaml")
+	})
+
+	t.Run("no copy", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = p.Origin("a")
+		})
+		assert.That(t, allocs).Equal(0.0)
+	})
+}
+
+func TestProperties_Subtree(t *testing.T) {
+	p := conf.New()
+	file := p.AddFile("app.yaml")
+	env := p.AddFile("Environment")
+	for k, v := range map[string]string{
+		"http.server.addr":        ":8080",
+		"http.server.tls.cert":    "x.pem",
+		"http.servers":            "ignored",
+		"http.serverless.enabled": "true",
+		"db.hosts[0].addr":        "h1",
+		"db.hosts[1].addr":        "h2",
+		"a":                       "1",
+	} {
+		assert.That(t, p.Set(k, v, file)).Nil()
+	}
+	assert.That(t, p.SetNamed("http.server.port", "80", env, "GS_HTTP_SERVER_PORT")).Nil()
+
+	t.Run("keys", func(t *testing.T) {
+		assert.That(t, len(p.Keys())).Equal(8)
+		assert.That(t, len(p.Keys(""))).Equal(8)
+		assert.That(t, p.Keys("http.server")).Equal([]string{
+			"http.server.addr",
+			"http.server.port",
+			"http.server.tls.cert",
+		})
+		assert.That(t, p.Keys("HTTP.Server.TLS")).Equal([]string{"http.server.tls.cert"})
+		assert.That(t, p.Keys("db.hosts")).Equal([]string{"db.hosts[0].addr", "db.hosts[1].addr"})
+		assert.That(t, p.Keys("a")).Equal([]string{"a"})
+		assert.That(t, p.Keys("none")).Equal([]string{})
+	})
+
+	t.Run("subset", func(t *testing.T) {
+		sub, err := p.Subset("http.server")
+		assert.That(t, err).Nil()
+		assert.That(t, sub.Data()).Equal(map[string]string{
+			"addr":     ":8080",
+			"port":     "80",
+			"tls.cert": "x.pem",
+		})
+		o, _ := sub.(*conf.MutableProperties).Origin("port")
+		assert.That(t, o.String()).Equal("Environment(GS_HTTP_SERVER_PORT)")
+
+		var v struct {
+			Addr string `value:"${addr}"`
+			Port int    `value:"${port}"`
+		}
+		assert.That(t, sub.Bind(&v)).Nil()
+		assert.That(t, v.Port).Equal(80)
+
+		sub, err = p.Subset("db.hosts")
+		assert.That(t, err).Nil()
+		assert.That(t, sub.Keys()).Equal([]string{"[0].addr", "[1].addr"})
+
+		sub, err = p.Subset("")
+		assert.That(t, err).Nil()
+		assert.That(t, sub.Data()).Equal(p.Data())
+
+		sub, err = p.Subset("none")
+		assert.That(t, err).Nil()
+		assert.That(t, sub.Keys()).Equal([]string{})
+
+		_, err = p.Subset("a")
+		assert.Error(t, err).Matches("property conflict at path a")
+	})
+
+	t.Run("range", func(t *testing.T) {
+		var keys, values []string
+		p.Range("http.server", func(key, value string) bool {
+			keys = append(keys, key)
+			values = append(values, value)
+			return true
+		})
+		assert.That(t, keys).Equal([]string{"http.server.addr", "http.server.port", "http.server.tls.cert"})
+		assert.That(t, values).Equal([]string{":8080", "80", "x.pem"})
+
+		// stops early, and may use the properties
+		keys = nil
+		p.Range("", func(key, value string) bool {
+			keys = append(keys, key)
+			return p.Has(key) && len(keys) < 2
+		})
+		assert.That(t, keys).Equal([]string{"a", "db.hosts[0].addr"})
+	})
+}
+
+func TestProperties_Concurrent(t *testing.T) {
+	const (
+		writers = 4
+		keys    = 50
+	)
+
+	p := conf.Map(map[string]any{
+		"watch": map[string]any{"w0": map[string]any{"k0": "0"}},
+	})
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fileID := p.AddFile("watcher-" + strconv.Itoa(w))
+			for k := range keys {
+				key := fmt.Sprintf("watch.w%d.k%d", w, k)
+				assert.That(t, p.Set(key, strconv.Itoa(k), fileID)).Nil()
+			}
+		}()
+	}
+
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for range 4 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_ = p.Get("watch.w0.k0")
+				_ = p.Has("watch.w1")
+				_ = p.Keys()
+				_ = p.Data()
+				_ = p.RawData()
+				_ = p.RawFile()
+				_, _ = p.SubKeys("watch")
+				_, _ = p.Resolve("${watch.w0.k0}")
+				var m map[string]map[string]string
+				_ = p.Bind(&m, "${watch}")
+				_ = p.CopyTo(conf.New())
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	assert.That(t, len(p.Keys())).Equal(writers * keys)
+	for w := range writers {
+		assert.That(t, p.Get(fmt.Sprintf("watch.w%d.k%d", w, keys-1))).Equal(strconv.Itoa(keys - 1))
+	}
+}
+
+func TestProperties_Snapshot(t *testing.T) {
+	p := conf.Map(map[string]any{"a": "1", "b": "2"})
+
+	keys := p.Keys()
+	var values []string
+	p.Range("", func(key, value string) bool {
+		values = append(values, value)
+		return p.Set("b", "3", 0) == nil
+	})
+	assert.That(t, values).Equal([]string{"1", "2"})
+	assert.That(t, p.Get("b")).Equal("3")
+
+	assert.That(t, p.Set("0", "0", 0)).Nil()
+	assert.That(t, keys).Equal([]string{"a", "b"})
+	assert.That(t, p.Keys()).Equal([]string{"0", "a", "b"})
+}
+
+func TestProperties_GetAllocs(t *testing.T) {
+	var p conf.Properties = conf.Map(map[string]any{
+		"a": map[string]any{
+			"b": "1",
+			"c": []string{"x", "y"},
+		},
+	})
+	for _, f := range []func(){
+		func() { _ = p.Get("a.b") },
+		func() { _ = p.Get("a.c[1]") },
+		func() { _ = p.Get("a.d", "def") },
+		func() { _ = p.Has("a.b") },
+		func() { _ = p.Has("a.c[0]") },
+	} {
+		assert.That(t, testing.AllocsPerRun(100, f)).Equal(0.0)
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	p := conf.Map(map[string]any{
+		"feature": map[string]any{
+			"flags": map[string]any{
+				"checkout": "true",
+			},
+		},
+	})
+	b.Run("get", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = p.Get("feature.flags.checkout")
+		}
+	})
+	b.Run("get with default", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = p.Get("feature.flags.search", "false")
+		}
+	})
+	b.Run("has", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = p.Has("feature.flags.checkout")
+		}
+	})
+}
+
+func BenchmarkConcurrentGet(b *testing.B) {
+	p := conf.Map(map[string]any{
+		"feature": map[string]any{
+			"flags": map[string]any{
+				"checkout": "true",
+			},
+		},
+	})
+	b.Run("readers", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = p.Get("feature.flags.checkout")
+			}
+		})
+	})
+	b.Run("readers with writer", func(b *testing.B) {
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_ = p.Set("feature.flags.search", strconv.Itoa(i), 0)
+				time.Sleep(time.Millisecond)
+			}
+		}()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = p.Get("feature.flags.checkout")
+			}
+		})
+		close(stop)
+		<-done
+	})
+}
+
 func BenchmarkResolve(b *testing.B) {
 	const src = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
 
@@ -194,4 +647,49 @@ func BenchmarkResolve(b *testing.B) {
 			_, _ = p.Resolve(s)
 		}
 	})
+
+	p = conf.Map(map[string]any{
+		"host": "localhost",
+		"port": "8080",
+		"url":  "http://${host}:${port}",
+	})
+	b.Run("placeholders", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = p.Resolve("${url}/${path:=api}/v1?timeout=${timeout:=5s}")
+		}
+	})
+}
+
+func BenchmarkLargeProperties(b *testing.B) {
+	const services = 2000
+
+	var sb strings.Builder
+	sb.WriteString("services:\n")
+	for i := range services {
+		sb.WriteString("  svc" + strconv.Itoa(i) + ":\n")
+		sb.WriteString("    protocol: http\n")
+		sb.WriteString("    enabled: \"true\"\n")
+		sb.WriteString("    timeout: 30s\n")
+		sb.WriteString("    retry: \"3\"\n")
+		sb.WriteString("    region: us-east-1\n")
+		sb.WriteString("    zone: us-east-1a\n")
+		sb.WriteString("    loadBalancer: round_robin\n")
+		sb.WriteString("    owner: platform-team\n")
+		sb.WriteString("    host: svc" + strconv.Itoa(i) + ".internal\n")
+		sb.WriteString("    port: \"8080\"\n")
+	}
+	data := []byte(sb.String())
+
+	b.ReportAllocs()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for b.Loop() {
+		if _, err := conf.Parse(data, ".yaml", "bench"); err != nil {
+			b.Fatal(err)
+		}
+	}
+	runtime.ReadMemStats(&after)
+	alloc := after.TotalAlloc - before.TotalAlloc
+	b.ReportMetric(float64(alloc)/float64(b.N)/(services*10), "B/key")
 }
@@ -17,9 +17,14 @@
 package conf
 
 import (
+	"fmt"
 	"maps"
+	"strconv"
+	"strings"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 	"github.com/go-spring/spring-base/util"
 )
 
@@ -55,3 +60,114 @@ func validateField(tag string, i any) error {
 	}
 	return nil
 }
+
+// exprInput returns the expression of a key of the form "#{expression}".
+func exprInput(key string) (string, bool) {
+	if s, ok := strings.CutPrefix(key, "#{"); ok {
+		if s, ok = strings.CutSuffix(s, "}"); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// eval evaluates the expression of a "${#{expression}}" reference, e.g.
+// "${#{server.port + 1}}" or "${#{len(hosts)}}". The expression is in the
+// syntax of github.com/expr-lang/expr, without braces, and the properties
+// are its variables: numbers and booleans as such, lists as arrays and
+// the other keys as maps. A list result is joined by commas, so that it
+// binds to a slice.
+func (st *resolveState) eval(p Properties, input string) (string, error) {
+	tree, err := parser.Parse(input)
+	if err != nil {
+		return "", util.FormatError(err, "eval %q returns error", input)
+	}
+	env := map[string]any{}
+	var v exprVisitor
+	ast.Walk(&tree.Node, &v)
+	for _, name := range v.names {
+		if _, ok := env[name]; ok || !p.Has(name) {
+			continue
+		}
+		if env[name], err = st.exprValue(p, name); err != nil {
+			return "", err
+		}
+	}
+	r, err := expr.Eval(input, env)
+	if err != nil {
+		return "", util.FormatError(err, "eval %q returns error", input)
+	}
+	if arr, ok := r.([]any); ok {
+		ss := make([]string, len(arr))
+		for i, e := range arr {
+			ss[i] = fmt.Sprint(e)
+		}
+		return strings.Join(ss, ","), nil
+	}
+	return fmt.Sprint(r), nil
+}
+
+// exprVisitor collects the names of the variables of an expression.
+type exprVisitor struct {
+	names []string
+}
+
+func (v *exprVisitor) Visit(node *ast.Node) {
+	if n, ok := (*node).(*ast.IdentifierNode); ok {
+		v.names = append(v.names, n.Value)
+	}
+}
+
+// exprValue returns the value of the property key as a variable.
+func (st *resolveState) exprValue(p Properties, key string) (any, error) {
+	if p.Has(key + "[0]") {
+		var arr []any
+		for i := 0; ; i++ {
+			k := fmt.Sprintf("%s[%d]", key, i)
+			if !p.Has(k) {
+				return arr, nil
+			}
+			e, err := st.exprValue(p, k)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, e)
+		}
+	}
+
+	const defVal = "@@def@@"
+	if p.Get(key, defVal) != defVal {
+		s, err := st.resolve(p, BindParam{Key: key})
+		if err != nil {
+			return nil, err
+		}
+		return ExprValue(s), nil
+	}
+
+	subKeys, err := p.SubKeys(key)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]any, len(subKeys))
+	for _, subKey := range subKeys {
+		if m[subKey], err = st.exprValue(p, key+"."+subKey); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ExprValue converts the value of a property to an expression variable:
+// an int, a float64 or a bool if it reads as one, otherwise the string.
+func ExprValue(s string) any {
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !strings.ContainsAny(s, "nN") {
+		return f // not NaN or Inf
+	}
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	return s
+}
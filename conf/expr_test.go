@@ -120,3 +120,65 @@ func TestExpr(t *testing.T) {
 		assert.That(t, 5).Equal(v.A)
 	})
 }
+
+func TestEvalExpr(t *testing.T) {
+	p := conf.Map(map[string]any{
+		"server": map[string]any{
+			"port": 8080,
+			"host": "localhost",
+			"tls":  true,
+			"rate": 0.5,
+		},
+		"hosts": []any{"a", "b", "c"},
+		"ports": []any{80, 443},
+		"base":  "${server.port}",
+		"name":  "nan",
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			want string
+		}{
+			{"${#{server.port + 1}}", "8081"},
+			{"${#{len(hosts)}}", "3"},
+			{"${#{base * 2}}", "16160"},
+			{"${#{server.tls ? 'https' : 'http'}}://${server.host}", "https://localhost"},
+			{"${#{server.rate * 4}}", "2"},
+			{"${#{hosts[1] + name}}", "bnan"},
+			{"${#{map(ports, # + 1)}}", "81,444"},
+			{"${#{'x' + 'y'}:default}", "xy"},
+		}
+		for _, tt := range tests {
+			s, err := p.Resolve(tt.s)
+			assert.That(t, err).Nil()
+			assert.That(t, s).Equal(tt.want)
+		}
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		var v struct {
+			Port  int      `value:"${#{server.port + 1}}"`
+			Hosts []string `value:"${#{filter(hosts, # != 'b')}}"`
+		}
+		err := p.Bind(&v)
+		assert.That(t, err).Nil()
+		assert.That(t, v.Port).Equal(8081)
+		assert.That(t, v.Hosts).Equal([]string{"a", "c"})
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := p.Resolve("${#{server.port +}}")
+		assert.Error(t, err).Matches(`eval "server.port \+" returns error`)
+		_, err = p.Resolve("${#{unknown + 1}}")
+		assert.Error(t, err).Matches(`eval "unknown \+ 1" returns error`)
+	})
+}
+
+func TestExprValue(t *testing.T) {
+	assert.That(t, conf.ExprValue("8080")).Equal(any(8080))
+	assert.That(t, conf.ExprValue("0.5")).Equal(any(0.5))
+	assert.That(t, conf.ExprValue("true")).Equal(any(true))
+	assert.That(t, conf.ExprValue("NaN")).Equal(any("NaN"))
+	assert.That(t, conf.ExprValue("prod")).Equal(any("prod"))
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// sizeUnits are the units of data sizes, decimal ones and binary ones.
+var sizeUnits = map[string]float64{
+	"":    1,
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"PB":  1e15,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+	"PiB": 1 << 50,
+}
+
+// ParseSize parses a data size, such as "512", "10MB" or "1.5GiB", into
+// bytes. The units KB, MB, GB, TB and PB are powers of 1000, and KiB, MiB,
+// GiB, TiB and PiB are powers of 1024.
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, unicode.IsLetter)
+	if i < 0 {
+		i = len(s)
+	}
+	unit, ok := sizeUnits[s[i:]]
+	if !ok {
+		return 0, util.FormatError(nil, "invalid size unit: %s", s)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s[:i]), 64)
+	if err != nil || n < 0 {
+		return 0, util.FormatError(err, "invalid size format: %s", s)
+	}
+	return int64(n * unit), nil
+}
+
+// durationUnits are the units of plain numbers bound to time.Duration.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+// convertFormat converts val to the type t as specified by the "format"
+// tag of the field:
+//
+//   - `format:"size"` binds a data size, e.g. "10MB", to an integer, see
+//     [ParseSize].
+//   - `format:"s"` binds a plain number to a time.Duration in the unit,
+//     one of ns, us, ms, s, m, h and d; durations with units, e.g. "2h",
+//     are still accepted.
+//   - any other format is the layout of a time.Time, e.g. "2006-01-02",
+//     or one of the names "RFC3339", "RFC3339Nano" and "DateTime".
+func convertFormat(val string, t reflect.Type, format string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch {
+	case format == "size" && (v.CanInt() || v.CanUint()):
+		n, err := ParseSize(val)
+		if err != nil {
+			return v, err
+		}
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(n) {
+				return v, util.FormatError(nil, "size %s overflows %s", val, t.String())
+			}
+			v.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v.OverflowUint(uint64(n)) {
+				return v, util.FormatError(nil, "size %s overflows %s", val, t.String())
+			}
+			v.SetUint(uint64(n))
+		default: // for linter
+		}
+		return v, nil
+
+	case t == reflect.TypeFor[time.Duration]():
+		unit, ok := durationUnits[format]
+		if !ok {
+			return v, util.FormatError(nil, "invalid duration unit: %s", format)
+		}
+		s := strings.TrimSpace(val)
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			v.SetInt(int64(n * float64(unit)))
+			return v, nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return v, util.FormatError(err, "invalid duration format: %s", val)
+		}
+		v.SetInt(int64(d))
+		return v, nil
+
+	case t == reflect.TypeFor[time.Time]():
+		switch format {
+		case "RFC3339":
+			format = time.RFC3339
+		case "RFC3339Nano":
+			format = time.RFC3339Nano
+		case "DateTime":
+			format = time.DateTime
+		default: // for linter
+		}
+		tm, err := time.Parse(format, strings.TrimSpace(val))
+		if err != nil {
+			return v, util.FormatError(err, "invalid time format: %s", val)
+		}
+		v.Set(reflect.ValueOf(tm))
+		return v, nil
+
+	default:
+		return v, util.FormatError(nil, "format %q doesn't apply to %s", format, t.String())
+	}
+}
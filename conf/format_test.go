@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int64
+	}{
+		{"512", 512},
+		{"512B", 512},
+		{"10KB", 10_000},
+		{"10MB", 10_000_000},
+		{"1GiB", 1 << 30},
+		{"1.5 KiB", 1536},
+		{" 2TiB ", 2 << 40},
+	}
+	for _, tt := range tests {
+		n, err := conf.ParseSize(tt.s)
+		assert.That(t, err).Nil()
+		assert.That(t, n).Equal(tt.want)
+	}
+
+	_, err := conf.ParseSize("10XB")
+	assert.Error(t, err).Matches("invalid size unit: 10XB")
+	_, err = conf.ParseSize("MB")
+	assert.Error(t, err).Matches("invalid size format: MB")
+	_, err = conf.ParseSize("-1KB")
+	assert.Error(t, err).Matches("invalid size format: -1KB")
+}
+
+func TestFormat(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"size":    "10MB",
+			"usize":   "1GiB",
+			"timeout": "30",
+			"delay":   "1.5",
+			"period":  "2h",
+			"day":     "2025-03-01",
+			"at":      "2025-03-01T08:00:00+08:00",
+		})
+		var v struct {
+			Size    int64         `value:"${size}" format:"size"`
+			USize   uint32        `value:"${usize}" format:"size"`
+			Timeout time.Duration `value:"${timeout}" format:"s"`
+			Delay   time.Duration `value:"${delay}" format:"ms"`
+			Period  time.Duration `value:"${period}" format:"d"`
+			Day     time.Time     `value:"${day}" format:"2006-01-02"`
+			At      time.Time     `value:"${at}" format:"RFC3339"`
+		}
+		err := p.Bind(&v)
+		assert.That(t, err).Nil()
+		assert.That(t, v.Size).Equal(int64(10_000_000))
+		assert.That(t, v.USize).Equal(uint32(1 << 30))
+		assert.That(t, v.Timeout).Equal(30 * time.Second)
+		assert.That(t, v.Delay).Equal(1500 * time.Microsecond)
+		assert.That(t, v.Period).Equal(2 * time.Hour)
+		assert.That(t, v.Day).Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+		assert.That(t, v.At.Unix()).Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"size": "1GiB",
+			"day":  "2025/03/01",
+			"s":    "abc",
+		})
+
+		var v1 struct {
+			Size int8 `value:"${size}" format:"size"`
+		}
+		err := p.Bind(&v1)
+		assert.Error(t, err).Matches("size 1GiB overflows int8")
+
+		var v2 struct {
+			Day time.Time `value:"${day}" format:"2006-01-02"`
+		}
+		err = p.Bind(&v2)
+		assert.Error(t, err).Matches("invalid time format: 2025/03/01")
+
+		var v3 struct {
+			S time.Duration `value:"${s}" format:"w"`
+		}
+		err = p.Bind(&v3)
+		assert.Error(t, err).Matches("invalid duration unit: w")
+
+		var v4 struct {
+			S string `value:"${s}" format:"size"`
+		}
+		err = p.Bind(&v4)
+		assert.Error(t, err).Matches(`format "size" doesn't apply to string`)
+	})
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-spring/spring-base/barky"
+	"github.com/go-spring/spring-base/util"
+)
+
+// ConflictStrategy tells how Merge resolves a property that conflicts
+// with the structure of the properties it is merged into, e.g. "a.b=x"
+// merged into properties with "a.b.c=y", a.b being a value in one and a
+// map in the other.
+type ConflictStrategy string
+
+const (
+	// ConflictError fails the merge, which is the default.
+	ConflictError ConflictStrategy = "error"
+
+	// ConflictLastWins replaces the existing properties at the path of
+	// the conflict with the merged ones.
+	ConflictLastWins ConflictStrategy = "last-wins"
+
+	// ConflictFirstWins keeps the existing properties at the path of the
+	// conflict and drops the merged ones.
+	ConflictFirstWins ConflictStrategy = "first-wins"
+
+	// ConflictDeepMerge keeps a map, whichever side it comes from, so
+	// that the keys of the maps of all sources are merged, and resolves
+	// the other conflicts as ConflictLastWins.
+	ConflictDeepMerge ConflictStrategy = "deep-merge"
+)
+
+// ParseConflictStrategy parses the name of a ConflictStrategy, an empty
+// name standing for ConflictError.
+func ParseConflictStrategy(s string) (ConflictStrategy, error) {
+	switch r := ConflictStrategy(s); r {
+	case "":
+		return ConflictError, nil
+	case ConflictError, ConflictLastWins, ConflictFirstWins, ConflictDeepMerge:
+		return r, nil
+	default:
+		return "", util.FormatError(nil, "unknown conflict strategy %q", s)
+	}
+}
+
+// Conflict describes a conflict resolved by Merge.
+type Conflict struct {
+	Path     string           // The path defined differently, e.g. "a.b".
+	Source   string           // The source merged, set by the caller of Merge.
+	Strategy ConflictStrategy // The strategy that resolved the conflict.
+	Replaced bool             // Whether the existing properties were replaced.
+	Dropped  []string         // The keys dropped, existing or merged ones.
+}
+
+// Merge sets the properties of src into p like src.CopyTo(p), resolving
+// the conflicts between them by the strategy, and returns the conflicts
+// resolved, in the order of their paths.
+func (p *MutableProperties) Merge(src *MutableProperties, strategy ConflictStrategy) ([]Conflict, error) {
+	strategy, err := ParseConflictStrategy(string(strategy))
+	if err != nil {
+		return nil, err
+	}
+	if strategy == ConflictError {
+		return nil, src.CopyTo(p)
+	}
+	s := src.load()
+	raw := s.storage.RawData()
+	keys := util.OrderedMapKeys(raw)
+	files := fileNames(s.storage)
+	var (
+		ret     []Conflict
+		dropped string // path of the merged keys being dropped
+	)
+	for _, key := range keys {
+		if dropped != "" && isUnder(key, dropped) {
+			continue
+		}
+		v := raw[key]
+		fileID := p.AddFile(files[v.File])
+		err = p.SetNamed(key, v.Value, fileID, s.names[key])
+		if err == nil {
+			continue
+		}
+		d := p.load()
+		path, ok := d.conflictPath(key)
+		if !ok {
+			return nil, err
+		}
+		c := Conflict{Path: path, Strategy: strategy}
+		srcKind := kindOf(raw, keysUnder(keys, path), path)
+		dstKind := d.kind(path)
+		// An empty map or list on one side merges silently with the same
+		// kind on the other side, the empty one being dropped.
+		silent := srcKind == dstKind
+		if silent {
+			_, c.Replaced = d.storage.RawData()[path]
+		} else {
+			c.Replaced = strategy == ConflictLastWins ||
+				strategy == ConflictDeepMerge && dstKind != "map"
+		}
+		if c.Replaced {
+			c.Dropped = p.remove(path)
+			if err = p.SetNamed(key, v.Value, fileID, s.names[key]); err != nil {
+				return nil, err
+			}
+		} else {
+			c.Dropped = keysUnder(keys, path)
+			dropped = path
+		}
+		if !silent {
+			ret = append(ret, c)
+		}
+	}
+	return ret, nil
+}
+
+// isUnder returns whether the key is at or under the path.
+func isUnder(key, path string) bool {
+	rest, ok := strings.CutPrefix(key, path)
+	return ok && (rest == "" || rest[0] == '.' || rest[0] == '[')
+}
+
+// kindOf returns the kind, "value", "map" or "list", of the path in the
+// data, given the sorted keys at or under it.
+func kindOf(raw map[string]barky.ValueInfo, keys []string, path string) string {
+	if v, ok := raw[path]; ok {
+		switch v.Value {
+		case "{}":
+			return "map"
+		case "[]":
+			return "list"
+		default:
+			return "value"
+		}
+	}
+	if len(keys) > 0 && keys[0][len(path)] == '[' {
+		return "list"
+	}
+	return "map"
+}
+
+// kind returns the kind of the path in the snapshot, see kindOf.
+func (s *snapshot) kind(path string) string {
+	raw := s.storage.RawData()
+	return kindOf(raw, keysUnder(util.OrderedMapKeys(raw), path), path)
+}
+
+// conflictPath returns the path of the key that the snapshot defines as
+// another kind than the key does.
+func (s *snapshot) conflictPath(key string) (string, bool) {
+	path, err := barky.SplitPath(key)
+	if err != nil {
+		return "", false
+	}
+	raw := s.storage.RawData()
+	keys := util.OrderedMapKeys(raw)
+	last := len(path) - 1
+	for i := range path {
+		q := barky.JoinPath(path[:i+1])
+		if _, ok := raw[q]; ok {
+			if i < last {
+				return q, true
+			}
+			return "", false
+		}
+		under := keysUnder(keys, q)
+		if len(under) == 0 {
+			return "", false
+		}
+		if i == last {
+			return q, true
+		}
+		isList := under[0][len(q)] == '['
+		if isList != (path[i+1].Type == barky.PathTypeIndex) {
+			return q, true
+		}
+	}
+	return "", false
+}
+
+// remove removes the keys at or under the path, and returns them sorted.
+func (p *MutableProperties) remove(path string) []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	st := p.edit()
+	keys := keysUnder(util.OrderedMapKeys(st.RawData()), path)
+	if len(keys) == 0 {
+		return nil
+	}
+	p.storage = cloneStorage(st, func(key string) bool {
+		_, found := slices.BinarySearch(keys, key)
+		return found
+	})
+	for _, key := range keys {
+		delete(p.names, key)
+	}
+	return keys
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestMerge(t *testing.T) {
+
+	// merge merges src into dst by the strategy.
+	merge := func(t *testing.T, dst, src map[string]any, strategy conf.ConflictStrategy) (*conf.MutableProperties, []conf.Conflict) {
+		p := conf.Map(dst)
+		s := conf.New()
+		for k, v := range conf.Map(src).RawData() {
+			assert.That(t, s.SetNamed(k, v.Value, s.AddFile("src"), "SRC_"+k)).Nil()
+		}
+		cs, err := p.Merge(s, strategy)
+		assert.That(t, err).Nil()
+		return p, cs
+	}
+
+	t.Run("error", func(t *testing.T) {
+		p := conf.Map(map[string]any{"a": map[string]any{"b": "x"}})
+		_, err := p.Merge(conf.Map(map[string]any{"a": map[string]any{"b": map[string]any{"c": "y"}}}), "")
+		assert.Error(t, err).Matches("property conflict at path a.b.c")
+		_, err = p.Merge(conf.New(), "unknown")
+		assert.Error(t, err).Matches(`unknown conflict strategy "unknown"`)
+	})
+
+	t.Run("last wins", func(t *testing.T) {
+		p, cs := merge(t, map[string]any{
+			"a": map[string]any{"b": "x"},
+			"c": map[string]any{"d": "1", "e": "2"},
+		}, map[string]any{
+			"a": map[string]any{"b": map[string]any{"c": "y", "d": "z"}},
+			"c": "3",
+		}, conf.ConflictLastWins)
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a.b.c": "y",
+			"a.b.d": "z",
+			"c":     "3",
+		})
+		assert.That(t, cs).Equal([]conf.Conflict{
+			{Path: "a.b", Strategy: conf.ConflictLastWins, Replaced: true, Dropped: []string{"a.b"}},
+			{Path: "c", Strategy: conf.ConflictLastWins, Replaced: true, Dropped: []string{"c.d", "c.e"}},
+		})
+		o, ok := p.Origin("a.b.c")
+		assert.That(t, ok).True()
+		assert.That(t, o.String()).Equal("src(SRC_a.b.c)")
+	})
+
+	t.Run("first wins", func(t *testing.T) {
+		p, cs := merge(t, map[string]any{
+			"a": map[string]any{"b": "x"},
+			"c": []any{"1"},
+		}, map[string]any{
+			"a": map[string]any{"b": map[string]any{"c": "y", "d": "z"}},
+			"c": map[string]any{"d": "2"},
+			"e": "3",
+		}, conf.ConflictFirstWins)
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a.b":  "x",
+			"c[0]": "1",
+			"e":    "3",
+		})
+		assert.That(t, cs).Equal([]conf.Conflict{
+			{Path: "a.b", Strategy: conf.ConflictFirstWins, Dropped: []string{"a.b.c", "a.b.d"}},
+			{Path: "c", Strategy: conf.ConflictFirstWins, Dropped: []string{"c.d"}},
+		})
+	})
+
+	t.Run("deep merge", func(t *testing.T) {
+		p, cs := merge(t, map[string]any{
+			"a": map[string]any{"b": "x"},
+			"c": map[string]any{"d": "1"},
+			"e": []any{"2"},
+			"f": map[string]any{"g": "3"},
+		}, map[string]any{
+			"a": map[string]any{"b": map[string]any{"c": "y"}},
+			"c": "4",
+			"e": map[string]any{"h": "5"},
+			"f": []any{"6"},
+		}, conf.ConflictDeepMerge)
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a.b.c": "y",
+			"c.d":   "1",
+			"e.h":   "5",
+			"f.g":   "3",
+		})
+		assert.That(t, cs).Equal([]conf.Conflict{
+			{Path: "a.b", Strategy: conf.ConflictDeepMerge, Replaced: true, Dropped: []string{"a.b"}},
+			{Path: "c", Strategy: conf.ConflictDeepMerge, Dropped: []string{"c"}},
+			{Path: "e", Strategy: conf.ConflictDeepMerge, Replaced: true, Dropped: []string{"e[0]"}},
+			{Path: "f", Strategy: conf.ConflictDeepMerge, Dropped: []string{"f[0]"}},
+		})
+	})
+
+	t.Run("empty containers", func(t *testing.T) {
+		p, cs := merge(t, map[string]any{
+			"a": map[string]any{},
+			"b": []any{"1"},
+		}, map[string]any{
+			"a": map[string]any{"c": "2"},
+			"b": []any{},
+		}, conf.ConflictFirstWins)
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"a.c":  "2",
+			"b[0]": "1",
+		})
+		assert.That(t, len(cs)).Equal(0)
+		raw := p.RawData()
+		assert.That(t, len(raw)).Equal(2)
+	})
+}
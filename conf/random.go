@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// RandomPrefix is the namespace of the random values, which are generated
+// when a reference to them is resolved, e.g. "${random.int(1024,65536)}".
+// The supported keys are:
+//
+//   - random.int: a non-negative int32, or one in [0,max) with
+//     "random.int(max)", or in [min,max) with "random.int(min,max)".
+//   - random.long: like random.int, but an int64.
+//   - random.uuid: a version 4 UUID.
+//   - random.value: 32 random hex characters.
+//
+// A random value is generated once per property referencing it and per
+// properties snapshot, so that all the users of a property such as
+// "server.port=${random.int(1024,65536)}" see the same value. A random
+// key referenced directly, e.g. by a `value:"${random.uuid}"` tag, isn't
+// owned by a property, so each reference gets a new value.
+const RandomPrefix = "random."
+
+// randomCache caches the random values by the property referencing them.
+type randomCache interface {
+	cachedRandom(key string, gen func() (string, error)) (string, error)
+}
+
+// cachedRandom returns the random value cached for the key, or generates
+// and caches it.
+func (p *MutableProperties) cachedRandom(key string, gen func() (string, error)) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if v, ok := p.randoms[key]; ok {
+		return v, nil
+	}
+	v, err := gen()
+	if err != nil {
+		return "", err
+	}
+	if p.randoms == nil {
+		p.randoms = make(map[string]string)
+	}
+	p.randoms[key] = v
+	return v, nil
+}
+
+// random returns the value of a random key referenced by the property
+// being resolved, or a new one if it's referenced directly.
+func (st *resolveState) random(p Properties, key string) (string, error) {
+	gen := func() (string, error) { return randomValue(key) }
+	if c, ok := p.(randomCache); ok && st.owner != "" {
+		return c.cachedRandom(st.owner+"="+key, gen)
+	}
+	return gen()
+}
+
+// randomValue generates the value of a random key, see RandomPrefix.
+func randomValue(key string) (string, error) {
+	name := strings.TrimPrefix(key, RandomPrefix)
+	var args string
+	if i := strings.Index(name, "("); i >= 0 {
+		if !strings.HasSuffix(name, ")") {
+			return "", util.FormatError(nil, "invalid random property %q", key)
+		}
+		name, args = name[:i], name[i+1:len(name)-1]
+	}
+	switch name {
+	case "int", "long":
+		bits := 32
+		if name == "long" {
+			bits = 64
+		}
+		if args == "" {
+			if bits == 32 {
+				return strconv.FormatInt(int64(rand.Int32()), 10), nil
+			}
+			return strconv.FormatInt(rand.Int64(), 10), nil
+		}
+		minVal, maxVal, err := randomRange(args, bits)
+		if err != nil {
+			return "", util.FormatError(err, "invalid random property %q", key)
+		}
+		return strconv.FormatInt(minVal+rand.Int64N(maxVal-minVal), 10), nil
+	case "uuid", "value":
+		if args != "" {
+			break
+		}
+		b := make([]byte, 16)
+		_, _ = cryptorand.Read(b)
+		if name == "value" {
+			return hex.EncodeToString(b), nil
+		}
+		b[6] = b[6]&0x0f | 0x40 // version 4
+		b[8] = b[8]&0x3f | 0x80 // variant 10
+		s := hex.EncodeToString(b)
+		return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], nil
+	}
+	return "", util.FormatError(nil, "unknown random property %q", key)
+}
+
+// randomRange parses the "max" or "min,max" arguments of a random number
+// of the given bit size.
+func randomRange(args string, bits int) (int64, int64, error) {
+	var minVal int64
+	s := args
+	if a, b, ok := strings.Cut(args, ","); ok {
+		v, err := strconv.ParseInt(strings.TrimSpace(a), 10, bits)
+		if err != nil {
+			return 0, 0, err
+		}
+		minVal, s = v, b
+	}
+	maxVal, err := strconv.ParseInt(strings.TrimSpace(s), 10, bits)
+	if err != nil {
+		return 0, 0, err
+	}
+	if maxVal <= minVal {
+		return 0, 0, util.FormatError(nil, "empty range [%d,%d)", minVal, maxVal)
+	}
+	if maxVal-minVal < 0 {
+		return 0, 0, util.FormatError(nil, "range [%d,%d) too large", minVal, maxVal)
+	}
+	return minVal, maxVal, nil
+}
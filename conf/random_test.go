@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestRandom(t *testing.T) {
+
+	t.Run("values", func(t *testing.T) {
+		p := conf.New()
+		for range 100 {
+			s, err := p.Resolve("${random.int(1024,1030)}")
+			assert.That(t, err).Nil()
+			n, err := strconv.Atoi(s)
+			assert.That(t, err).Nil()
+			assert.That(t, n >= 1024 && n < 1030).True()
+		}
+
+		s, err := p.Resolve("${random.int(10)}")
+		assert.That(t, err).Nil()
+		assert.String(t, s).Matches(`^\d$`)
+
+		s, err = p.Resolve("${random.int}")
+		assert.That(t, err).Nil()
+		assert.String(t, s).Matches(`^\d+$`)
+
+		s, err = p.Resolve("${random.long(-5,-4)}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("-5")
+
+		s, err = p.Resolve("${random.long}")
+		assert.That(t, err).Nil()
+		assert.String(t, s).Matches(`^\d+$`)
+
+		s, err = p.Resolve("${random.uuid}")
+		assert.That(t, err).Nil()
+		assert.String(t, s).Matches(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+		s, err = p.Resolve("${random.value}")
+		assert.That(t, err).Nil()
+		assert.String(t, s).Matches(`^[0-9a-f]{32}$`)
+	})
+
+	t.Run("direct references", func(t *testing.T) {
+		p := conf.New()
+		var v struct {
+			A string `value:"${random.uuid}"`
+			B string `value:"${random.uuid}"`
+			C int    `value:"${random.int(0,1000000000)}"`
+			D int    `value:"${random.int(0,1000000000)}"`
+		}
+		assert.That(t, p.Bind(&v)).Nil()
+		assert.That(t, v.A != v.B).True()
+		assert.That(t, v.C != v.D).True()
+	})
+
+	t.Run("once per property", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"server.port": "${random.int(1024,65536)}",
+			"admin.port":  "${random.int(1024,65536)}",
+			"instance.id": "app-${random.uuid}",
+		})
+		var v struct {
+			Port     int    `value:"${server.port}"`
+			PortCopy int    `value:"${server.port}"`
+			ID       string `value:"${instance.id}"`
+		}
+		assert.That(t, p.Bind(&v)).Nil()
+		assert.That(t, v.Port).Equal(v.PortCopy)
+		assert.String(t, v.ID).Matches(`^app-[0-9a-f-]{36}$`)
+
+		id, err := p.Resolve("${instance.id}")
+		assert.That(t, err).Nil()
+		assert.That(t, id).Equal(v.ID)
+
+		// another property has its own value
+		ids := map[string]bool{}
+		for range 10 {
+			q := conf.Map(map[string]any{"a": "${random.uuid}", "b": "${random.uuid}"})
+			a, _ := q.Resolve("${a}")
+			b, _ := q.Resolve("${b}")
+			ids[a], ids[b] = true, true
+		}
+		assert.That(t, len(ids)).Equal(20)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		p := conf.New()
+		_, err := p.Resolve("${random.float}")
+		assert.Error(t, err).Matches(`unknown random property "random.float"`)
+		_, err = p.Resolve("${random.uuid(1)}")
+		assert.Error(t, err).Matches(`unknown random property "random.uuid\(1\)"`)
+		_, err = p.Resolve("${random.int(5,5)}")
+		assert.Error(t, err).Matches(`invalid random property "random.int\(5,5\)": empty range \[5,5\)`)
+		_, err = p.Resolve("${random.int(x)}")
+		assert.Error(t, err).Matches(`invalid random property "random.int\(x\)"`)
+		_, err = p.Resolve("${random.int(1,2}")
+		assert.Error(t, err).Matches(`invalid random property "random.int\(1,2"`)
+	})
+}
@@ -0,0 +1,447 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/*
+Package hocon reads configuration in the HOCON format (.conf).
+
+The supported subset covers what configuration files commonly use:
+
+  - objects with or without the root braces, dotted and quoted keys,
+    ':' or '=' separators, and no separator before '{';
+  - arrays, quoted and triple-quoted strings, unquoted values and the
+    concatenation of values on the same line;
+  - '#' and '//' comments, commas or newlines between fields;
+  - duplicate keys, where objects are merged and other values override;
+  - include directives: include "f", include file("f") and
+    include required("f"). A relative path is resolved against the
+    directory of the including file, and a missing file is ignored
+    unless it is required.
+
+Substitutions such as ${a.b} are kept as they are, so they are resolved
+by the conf package in the same way as placeholders in other formats.
+*/
+package hocon
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// maxIncludeDepth limits nested includes, which also stops include cycles.
+const maxIncludeDepth = 10
+
+// Read parses []byte in the HOCON format into map, relative includes
+// are resolved against the working directory.
+func Read(b []byte) (map[string]any, error) {
+	return ReadFile(b, "")
+}
+
+// ReadFile parses []byte in the HOCON format read from the file into map,
+// relative includes are resolved against the directory of the file. The
+// working directory is used when the file is empty or a URL.
+func ReadFile(b []byte, file string) (map[string]any, error) {
+	dir := ""
+	if file != "" && !strings.Contains(file, "://") {
+		dir = filepath.Dir(file)
+	}
+	m, err := parse(b, dir, 0)
+	if err != nil {
+		return nil, util.FormatError(err, "read hocon error")
+	}
+	return m, nil
+}
+
+// parse parses a whole document into map.
+func parse(b []byte, dir string, depth int) (map[string]any, error) {
+	p := &parser{s: string(b), line: 1, dir: dir, depth: depth}
+	p.skipSpace(true)
+	braced := p.peek() == '{'
+	if braced {
+		p.pos++
+	}
+	m, err := p.parseFields(braced)
+	if err != nil {
+		return nil, err
+	}
+	if braced {
+		p.pos++ // '}'
+	}
+	p.skipSpace(true)
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+	return m, nil
+}
+
+type parser struct {
+	s     string
+	pos   int
+	line  int
+	dir   string
+	depth int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return util.FormatError(nil, "line %d: "+format, append([]any{p.line}, args...)...)
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.s[p.pos:], prefix)
+}
+
+// skipSpace skips spaces and comments, and also newlines and commas when
+// separators is true.
+func (p *parser) skipSpace(separators bool) {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '\n' || c == ',':
+			if !separators {
+				return
+			}
+			if c == '\n' {
+				p.line++
+			}
+			p.pos++
+		case c == '#' || p.hasPrefix("//"):
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// parseFields parses the fields of an object until '}' or the end.
+func (p *parser) parseFields(braced bool) (map[string]any, error) {
+	m := make(map[string]any)
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			if braced {
+				return nil, p.errorf("missing '}'")
+			}
+			return m, nil
+		}
+		if p.peek() == '}' {
+			if !braced {
+				return nil, p.errorf("unexpected '}'")
+			}
+			return m, nil
+		}
+		if p.isInclude() {
+			r, err := p.parseInclude()
+			if err != nil {
+				return nil, err
+			}
+			merge(m, r)
+			continue
+		}
+		keys, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		if c := p.peek(); c == ':' || c == '=' {
+			p.pos++
+		} else if c != '{' {
+			return nil, p.errorf("missing ':' or '=' after key %q", strings.Join(keys, "."))
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		set(m, keys, v)
+	}
+}
+
+// parseKey parses a path expression like a.b."c.d" into its keys.
+func (p *parser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace(false)
+		var key string
+		if p.peek() == '"' {
+			s, err := p.parseQuoted()
+			if err != nil {
+				return nil, err
+			}
+			key = s
+		} else {
+			start := p.pos
+			for !p.eof() && !strings.ContainsRune(".:={}[],\"#\n\r\t +", rune(p.peek())) && !p.hasPrefix("//") {
+				p.pos++
+			}
+			key = p.s[start:p.pos]
+			if key == "" {
+				return nil, p.errorf("invalid key at %q", p.peek())
+			}
+		}
+		keys = append(keys, key)
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+// parseValue parses an object, an array or a (concatenated) string.
+func (p *parser) parseValue() (any, error) {
+	p.skipSpace(false)
+	switch p.peek() {
+	case '{':
+		p.pos++
+		m, err := p.parseFields(true)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++ // '}'
+		return m, nil
+	case '[':
+		p.pos++
+		return p.parseArray()
+	}
+	return p.parseString()
+}
+
+// parseArray parses the elements of an array until ']'.
+func (p *parser) parseArray() ([]any, error) {
+	arr := make([]any, 0)
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, p.errorf("missing ']'")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return arr, nil
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+}
+
+// parseString parses the quoted and unquoted pieces of a value up to the
+// end of the line and concatenates them. An unquoted null is nil.
+func (p *parser) parseString() (any, error) {
+	var (
+		sb     strings.Builder
+		quoted bool
+		spaces string
+	)
+	for !p.eof() {
+		c := p.peek()
+		if c == '\n' || c == ',' || c == '}' || c == ']' || c == '#' || p.hasPrefix("//") {
+			break
+		}
+		if c == ' ' || c == '\t' || c == '\r' {
+			start := p.pos
+			for !p.eof() && strings.ContainsRune(" \t\r", rune(p.peek())) {
+				p.pos++
+			}
+			if sb.Len() > 0 || quoted {
+				spaces = p.s[start:p.pos]
+			}
+			continue
+		}
+		sb.WriteString(spaces)
+		spaces = ""
+		if c == '"' {
+			s, err := p.parseQuoted()
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(s)
+			quoted = true
+			continue
+		}
+		if p.hasPrefix("${") {
+			end := strings.IndexByte(p.s[p.pos:], '}')
+			if end < 0 {
+				return nil, p.errorf("missing '}' in substitution")
+			}
+			sb.WriteString(p.s[p.pos : p.pos+end+1])
+			p.pos += end + 1
+			continue
+		}
+		if c == '{' || c == '[' {
+			return nil, p.errorf("unexpected %q in value", c)
+		}
+		sb.WriteByte(c)
+		p.pos++
+	}
+	s := sb.String()
+	if !quoted {
+		if s == "" {
+			return nil, p.errorf("missing value")
+		}
+		if s == "null" {
+			return nil, nil
+		}
+	}
+	return s, nil
+}
+
+// parseQuoted parses a "quoted" or a """triple-quoted""" string.
+func (p *parser) parseQuoted() (string, error) {
+	if p.hasPrefix(`"""`) {
+		end := strings.Index(p.s[p.pos+3:], `"""`)
+		if end < 0 {
+			return "", p.errorf("missing closing \"\"\"")
+		}
+		s := p.s[p.pos+3 : p.pos+3+end]
+		p.line += strings.Count(s, "\n")
+		p.pos += end + 6
+		return s, nil
+	}
+	for i := p.pos + 1; i < len(p.s); i++ {
+		switch p.s[i] {
+		case '\\':
+			i++
+		case '\n':
+			return "", p.errorf("missing closing '\"'")
+		case '"':
+			s, err := strconv.Unquote(p.s[p.pos : i+1])
+			if err != nil {
+				return "", p.errorf("invalid string %s", p.s[p.pos:i+1])
+			}
+			p.pos = i + 1
+			return s, nil
+		}
+	}
+	return "", p.errorf("missing closing '\"'")
+}
+
+// isInclude reports whether the next field is an include directive.
+func (p *parser) isInclude() bool {
+	if !p.hasPrefix("include") {
+		return false
+	}
+	rest := strings.TrimLeft(p.s[p.pos+len("include"):], " \t")
+	if len(rest) == len(p.s)-p.pos-len("include") {
+		return false // no space after include
+	}
+	return rest != "" && (rest[0] == '"' || strings.HasPrefix(rest, "file(") ||
+		strings.HasPrefix(rest, "required(") || strings.HasPrefix(rest, "url(") ||
+		strings.HasPrefix(rest, "classpath("))
+}
+
+// parseInclude parses an include directive and reads the included file.
+func (p *parser) parseInclude() (map[string]any, error) {
+	p.pos += len("include")
+	p.skipSpace(false)
+
+	required := false
+	if p.hasPrefix("required(") {
+		required = true
+		p.pos += len("required(")
+		p.skipSpace(false)
+	}
+	wrapped := false
+	if p.hasPrefix("file(") {
+		wrapped = true
+		p.pos += len("file(")
+		p.skipSpace(false)
+	} else if p.hasPrefix("url(") || p.hasPrefix("classpath(") {
+		return nil, p.errorf("unsupported include %s", p.s[p.pos:p.pos+strings.IndexByte(p.s[p.pos:], '(')])
+	}
+	if p.peek() != '"' {
+		return nil, p.errorf("include expects a quoted file name")
+	}
+	file, err := p.parseQuoted()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range []bool{wrapped, required} {
+		if !b {
+			continue
+		}
+		p.skipSpace(false)
+		if p.peek() != ')' {
+			return nil, p.errorf("missing ')' in include")
+		}
+		p.pos++
+	}
+
+	if p.depth >= maxIncludeDepth {
+		return nil, p.errorf("include %s exceeds max depth %d", file, maxIncludeDepth)
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(p.dir, file)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if !required && errors.Is(err, fs.ErrNotExist) {
+			return map[string]any{}, nil
+		}
+		return nil, util.FormatError(err, "line %d: include %s error", p.line, file)
+	}
+	m, err := parse(b, filepath.Dir(file), p.depth+1)
+	if err != nil {
+		return nil, util.FormatError(err, "line %d: include %s error", p.line, file)
+	}
+	return m, nil
+}
+
+// set sets the value at the path of keys, merging it into an existing
+// object when both of them are objects.
+func set(m map[string]any, keys []string, v any) {
+	for _, k := range keys[:len(keys)-1] {
+		sub, ok := m[k].(map[string]any)
+		if !ok {
+			sub = make(map[string]any)
+			m[k] = sub
+		}
+		m = sub
+	}
+	k := keys[len(keys)-1]
+	if src, ok := v.(map[string]any); ok {
+		if dst, ok := m[k].(map[string]any); ok {
+			merge(dst, src)
+			return
+		}
+	}
+	m[k] = v
+}
+
+// merge merges the fields of src into dst.
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		set(dst, []string{k}, v)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hocon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestRead(t *testing.T) {
+
+	t.Run("basic type", func(t *testing.T) {
+		r, err := Read([]byte(`
+			empty = ""
+			bool = false
+			int: 3
+			float = 3.0
+			string = hello world  # comment
+			quoted = "a \"b\" c"
+			null = null
+			time = "2018-02-17T15:02:31+08:00"
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"empty":  "",
+			"bool":   "false",
+			"int":    "3",
+			"float":  "3.0",
+			"string": "hello world",
+			"quoted": `a "b" c`,
+			"null":   nil,
+			"time":   "2018-02-17T15:02:31+08:00",
+		})
+	})
+
+	t.Run("object and array", func(t *testing.T) {
+		r, err := Read([]byte(`{
+			// comment
+			server {
+				addr = ":8080"
+				hosts = [ a, b
+					c ]
+			}
+			server.timeout = 3s
+			"a.b".c = 1, d = 2
+			list = [ { name = x }, { name = y } ]
+			text = """line1
+line2"""
+			url = ${server.addr}/path
+		}`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"server": map[string]any{
+				"addr":    ":8080",
+				"hosts":   []any{"a", "b", "c"},
+				"timeout": "3s",
+			},
+			"a.b": map[string]any{"c": "1"},
+			"d":   "2",
+			"list": []any{
+				map[string]any{"name": "x"},
+				map[string]any{"name": "y"},
+			},
+			"text": "line1\nline2",
+			"url":  "${server.addr}/path",
+		})
+	})
+
+	t.Run("duplicate keys", func(t *testing.T) {
+		r, err := Read([]byte(`
+			a { x = 1, y = 2 }
+			a { y = 3 }
+			b = 1
+			b = 2
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"a": map[string]any{"x": "1", "y": "3"},
+			"b": "2",
+		})
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := Read([]byte("a {\n b = 1"))
+		assert.Error(t, err).Matches(`read hocon error: line 2: missing '}'`)
+		_, err = Read([]byte("a b"))
+		assert.Error(t, err).Matches(`line 1: missing ':' or '=' after key "a"`)
+		_, err = Read([]byte("a = [1, 2"))
+		assert.Error(t, err).Matches(`line 1: missing ']'`)
+		_, err = Read([]byte("a = \"b"))
+		assert.Error(t, err).Matches(`line 1: missing closing '"'`)
+		_, err = Read([]byte("a ="))
+		assert.Error(t, err).Matches(`line 1: missing value`)
+	})
+}
+
+func TestInclude(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(file), os.ModePerm)
+		assert.That(t, err).Nil()
+		err = os.WriteFile(file, []byte(content), 0644)
+		assert.That(t, err).Nil()
+		return file
+	}
+
+	t.Run("success", func(t *testing.T) {
+		write("sub/db.conf", `db { host = localhost, port = 3306 }`)
+		write("sub/log.conf", `include "level.conf"`)
+		write("sub/level.conf", `log.level = info`)
+		file := write("app.conf", `
+			include "sub/db.conf"
+			include file("sub/log.conf")
+			include "missing.conf"
+			db.port = 3307
+		`)
+		b, err := os.ReadFile(file)
+		assert.That(t, err).Nil()
+		r, err := ReadFile(b, file)
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"db":  map[string]any{"host": "localhost", "port": "3307"},
+			"log": map[string]any{"level": "info"},
+		})
+	})
+
+	t.Run("required", func(t *testing.T) {
+		file := write("required.conf", `include required("missing.conf")`)
+		b, err := os.ReadFile(file)
+		assert.That(t, err).Nil()
+		_, err = ReadFile(b, file)
+		assert.Error(t, err).Matches(`line 1: include .*missing.conf error`)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		file := write("cycle.conf", `include "cycle.conf"`)
+		b, err := os.ReadFile(file)
+		assert.That(t, err).Nil()
+		_, err = ReadFile(b, file)
+		assert.Error(t, err).Matches(`exceeds max depth 10`)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Read([]byte(`include url("http://example.com/a.conf")`))
+		assert.Error(t, err).Matches(`line 1: unsupported include url`)
+	})
+}
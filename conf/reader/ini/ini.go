@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ini
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// Read parses []byte in the ini format into map. The keys in a section,
+// e.g. "[db.primary]", are prefixed by the section name, e.g. "host" in
+// it becomes "db.primary.host". A key and its value are separated by '='
+// or ':', lines starting with ';' or '#' are comments, and values may be
+// quoted to keep their leading and trailing spaces.
+func Read(b []byte) (map[string]any, error) {
+	ret := make(map[string]any)
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return nil, util.FormatError(nil, "read ini error: line %d: invalid section %q", n, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return nil, util.FormatError(nil, "read ini error: line %d: invalid key-value %q", n, line)
+		}
+		key := strings.TrimSpace(line[:i])
+		val := strings.TrimSpace(line[i+1:])
+		if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			if val[0] == '"' {
+				s, err := strconv.Unquote(val)
+				if err != nil {
+					return nil, util.FormatError(err, "read ini error: line %d: invalid value %q", n, val)
+				}
+				val = s
+			} else {
+				val = val[1 : len(val)-1]
+			}
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		ret[key] = val
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, util.FormatError(err, "read ini error")
+	}
+	return ret, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ini
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestRead(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		r, err := Read([]byte(`
+			; comment
+			name = app
+			empty =
+
+			[db]
+			# comment
+			host = localhost
+			port: 3306
+			user = "  root  "
+			pass = 'a=b'
+			url = mysql://localhost:3306
+
+			[db.replica]
+			hosts[0] = r1
+			hosts[1] = r2
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"name":                "app",
+			"empty":               "",
+			"db.host":             "localhost",
+			"db.port":             "3306",
+			"db.user":             "  root  ",
+			"db.pass":             "a=b",
+			"db.url":              "mysql://localhost:3306",
+			"db.replica.hosts[0]": "r1",
+			"db.replica.hosts[1]": "r2",
+		})
+	})
+
+	t.Run("invalid section", func(t *testing.T) {
+		_, err := Read([]byte("[db"))
+		assert.Error(t, err).Matches(`read ini error: line 1: invalid section "\[db"`)
+	})
+
+	t.Run("invalid key-value", func(t *testing.T) {
+		_, err := Read([]byte("[db]\nhost"))
+		assert.Error(t, err).Matches(`read ini error: line 2: invalid key-value "host"`)
+	})
+
+	t.Run("invalid quoted value", func(t *testing.T) {
+		_, err := Read([]byte(`a = "\x"`))
+		assert.Error(t, err).Matches(`read ini error: line 1: invalid value`)
+	})
+}
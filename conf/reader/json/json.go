@@ -30,3 +30,12 @@ func Read(b []byte) (map[string]any, error) {
 	}
 	return ret, nil
 }
+
+// Write formats the map in the json format, indented by two spaces.
+func Write(m map[string]any) ([]byte, error) {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, util.FormatError(err, "write json error")
+	}
+	return append(b, '\n'), nil
+}
@@ -99,3 +99,21 @@ func TestRead(t *testing.T) {
 		assert.That(t, r).Equal(map[string]any{})
 	})
 }
+
+func TestWrite(t *testing.T) {
+	b, err := Write(map[string]any{
+		"b": []any{"1", map[string]any{"c": "2"}},
+		"a": map[string]any{},
+	})
+	assert.That(t, err).Nil()
+	assert.That(t, string(b)).Equal(`{
+  "a": {},
+  "b": [
+    "1",
+    {
+      "c": "2"
+    }
+  ]
+}
+`)
+}
@@ -17,6 +17,9 @@
 package prop
 
 import (
+	"bytes"
+
+	"github.com/go-spring/spring-base/barky"
 	"github.com/go-spring/spring-base/util"
 	"github.com/magiconair/properties"
 )
@@ -36,3 +39,21 @@ func Read(b []byte) (map[string]any, error) {
 	}
 	return ret, nil
 }
+
+// Write formats the map in the properties format, one flattened key per
+// line in key order, e.g. "a.b[0] = x".
+func Write(m map[string]any) ([]byte, error) {
+	flat := barky.FlattenMap(m)
+	p := properties.NewProperties()
+	p.DisableExpansion = true
+	for _, k := range util.OrderedMapKeys(flat) {
+		if _, _, err := p.Set(k, flat[k]); err != nil {
+			return nil, util.FormatError(err, "write properties error")
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := p.Write(&buf, properties.UTF8); err != nil {
+		return nil, util.FormatError(err, "write properties error")
+	}
+	return buf.Bytes(), nil
+}
@@ -143,3 +143,16 @@ func TestRead(t *testing.T) {
 		})
 	})
 }
+
+func TestWrite(t *testing.T) {
+	b, err := Write(map[string]any{
+		"b": []any{"1", map[string]any{"c": "x y"}},
+		"a": map[string]any{},
+		"d": " \n",
+	})
+	assert.That(t, err).Nil()
+	assert.That(t, string(b)).Equal("a = {}\nb[0] = 1\nb[1].c = x y\nd = \\ \\n\n")
+	r, err := Read(b)
+	assert.That(t, err).Nil()
+	assert.That(t, r["d"]).Equal(" \n")
+}
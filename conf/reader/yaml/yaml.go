@@ -17,6 +17,10 @@
 package yaml
 
 import (
+	"bytes"
+	"errors"
+	"io"
+
 	"github.com/go-spring/spring-base/util"
 	"gopkg.in/yaml.v2"
 )
@@ -29,3 +33,32 @@ func Read(b []byte) (map[string]any, error) {
 	}
 	return ret, nil
 }
+
+// Write formats the map in the yaml format, with the keys sorted.
+func Write(m map[string]any) ([]byte, error) {
+	b, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, util.FormatError(err, "write yaml error")
+	}
+	return b, nil
+}
+
+// ReadDocuments parses []byte holding one or more yaml documents separated
+// by "---" into maps, in the order of the documents. Empty documents are
+// skipped.
+func ReadDocuments(b []byte) ([]map[string]any, error) {
+	var ret []map[string]any
+	d := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		m := make(map[string]any)
+		if err := d.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ret, nil
+			}
+			return nil, util.FormatError(err, "read yaml error")
+		}
+		if len(m) > 0 {
+			ret = append(ret, m)
+		}
+	}
+}
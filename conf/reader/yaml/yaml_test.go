@@ -161,3 +161,30 @@ func TestRead(t *testing.T) {
 		})
 	})
 }
+
+func TestReadDocuments(t *testing.T) {
+
+	t.Run("invalid yaml format", func(t *testing.T) {
+		_, err := ReadDocuments([]byte("a: 1\n---\n{"))
+		assert.Error(t, err).Matches("did not find expected node content")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		str := "---\na: 1\n---\n---\nb: 2\n"
+		r, err := ReadDocuments([]byte(str))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal([]map[string]any{
+			{"a": 1},
+			{"b": 2},
+		})
+	})
+}
+
+func TestWrite(t *testing.T) {
+	b, err := Write(map[string]any{
+		"b": []any{"1", map[string]any{"c": "2"}},
+		"a": map[string]any{},
+	})
+	assert.That(t, err).Nil()
+	assert.That(t, string(b)).Equal("a: {}\nb:\n- \"1\"\n- c: \"2\"\n")
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// canonicalKey returns the relaxed form of a key, in which the keys that
+// only differ by case and by the '-' and '_' word separators are equal,
+// e.g. "max-conns", "maxConns", "MAX_CONNS" and "max_conns".
+func canonicalKey(key string) string {
+	if !strings.ContainsFunc(key, func(r rune) bool {
+		return r == '-' || r == '_' || ('A' <= r && r <= 'Z')
+	}) {
+		return key
+	}
+	var sb strings.Builder
+	sb.Grow(len(key))
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c == '-' || c == '_':
+			continue
+		case 'A' <= c && c <= 'Z':
+			c += 'a' - 'A'
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// relaxedIndex maps the relaxed forms of the sorted stored keys, and of
+// all the paths leading to them, to the keys themselves. When two keys
+// have the same relaxed form, the first one in sorted order wins.
+func relaxedIndex(keys []string) map[string]string {
+	m := make(map[string]string)
+	for _, key := range keys {
+		for i := 1; i <= len(key); i++ {
+			if i < len(key) && key[i] != '.' && key[i] != '[' {
+				continue
+			}
+			path := key[:i]
+			if c := canonicalKey(path); m[c] == "" {
+				m[c] = path
+			}
+		}
+	}
+	return m
+}
+
+// aliases maps the current keys of the renamed properties to their
+// deprecated keys, see RegisterDeprecatedKey.
+var aliases = struct {
+	sync.RWMutex
+	m map[string]string
+}{m: map[string]string{}}
+
+// RegisterDeprecatedKey registers that the property at the deprecated key
+// is renamed to the current key, so that the deprecated key, and the keys
+// under it, keep working for the current ones while they aren't set. Use
+// Deprecations to warn about the deprecated keys in use.
+func RegisterDeprecatedKey(deprecated, current string) {
+	aliases.Lock()
+	defer aliases.Unlock()
+	aliases.m[current] = deprecated
+}
+
+// deprecatedKey returns the deprecated key of the key, or of the closest
+// path leading to it that has one, e.g. "old.addr" for "new.addr" if
+// "new" was renamed from "old".
+func deprecatedKey(key string) (string, bool) {
+	aliases.RLock()
+	defer aliases.RUnlock()
+	if len(aliases.m) == 0 {
+		return "", false
+	}
+	for i := len(key); i > 0; i-- {
+		if i < len(key) && key[i] != '.' && key[i] != '[' {
+			continue
+		}
+		if old, ok := aliases.m[key[:i]]; ok {
+			return old + key[i:], true
+		}
+	}
+	return "", false
+}
+
+// currentKey returns the current key of a deprecated key, or of a key
+// under it, or else the key itself.
+func currentKey(key string) string {
+	aliases.RLock()
+	defer aliases.RUnlock()
+	for current, deprecated := range aliases.m {
+		if rest, ok := strings.CutPrefix(key, deprecated); ok && (rest == "" || rest[0] == '.' || rest[0] == '[') {
+			return current + rest
+		}
+	}
+	return key
+}
+
+// Deprecation is a deprecated key in use and the current key it's
+// renamed to.
+type Deprecation struct {
+	Deprecated string `json:"deprecated"`
+	Current    string `json:"current"`
+}
+
+// Deprecations returns the registered deprecated keys that are set in the
+// properties, sorted by the deprecated keys.
+func Deprecations(p Properties) []Deprecation {
+	aliases.RLock()
+	m := maps.Clone(aliases.m)
+	aliases.RUnlock()
+
+	var ret []Deprecation
+	for current, deprecated := range m {
+		if hasExact(p, deprecated) {
+			ret = append(ret, Deprecation{Deprecated: deprecated, Current: current})
+		}
+	}
+	slices.SortFunc(ret, func(a, b Deprecation) int {
+		return strings.Compare(a.Deprecated, b.Deprecated)
+	})
+	return ret
+}
+
+// hasExact returns whether the key is set in the properties, without
+// relaxed matching.
+func hasExact(p Properties, key string) bool {
+	if m, ok := p.(*MutableProperties); ok {
+		return m.load().storage.Has(key)
+	}
+	return p.Has(key)
+}
+
+// lookup returns the stored key for a key that isn't stored: its
+// deprecated key, or a stored key with the same relaxed form as either of
+// them.
+func (s *snapshot) lookup(key string) (string, bool) {
+	old, deprecated := deprecatedKey(key)
+	if deprecated && s.storage.Has(old) {
+		return old, true
+	}
+	index := s.relaxed.Load()
+	if index == nil {
+		m := relaxedIndex(s.sortedKeys())
+		index = &m
+		s.relaxed.Store(index)
+	}
+	if k, ok := (*index)[canonicalKey(key)]; ok {
+		return k, true
+	}
+	if deprecated {
+		if k, ok := (*index)[canonicalKey(old)]; ok {
+			return k, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestRelaxedBinding(t *testing.T) {
+
+	t.Run("get", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"server.maxConns":   "10",
+			"SERVER.READ_TIME":  "1s",
+			"db.pool-size":      "8",
+			"list[0].user_name": "tom",
+		})
+		for _, key := range []string{"server.max-conns", "server.maxconns", "SERVER.MAX_CONNS", "server.max_conns"} {
+			assert.That(t, p.Get(key)).Equal("10")
+			assert.That(t, p.Has(key)).True()
+		}
+		assert.That(t, p.Get("server.read-time")).Equal("1s")
+		assert.That(t, p.Get("db.poolSize")).Equal("8")
+		assert.That(t, p.Get("List[0].userName")).Equal("tom")
+		assert.That(t, p.Has("Server")).True()
+		assert.That(t, p.Has("server.write-time")).False()
+		assert.That(t, p.Get("server.write-time", "2s")).Equal("2s")
+
+		// an exact key wins
+		assert.That(t, p.Set("server.max-conns", "20", 0)).Nil()
+		assert.That(t, p.Get("server.max-conns")).Equal("20")
+		assert.That(t, p.Get("server.maxConns")).Equal("10")
+		assert.That(t, p.Get("SERVER.MAX_CONNS")).Equal("20")
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"http": map[string]any{
+				"readTimeout":   "3s",
+				"MAX_BODY_SIZE": "1024",
+				"headers": map[string]any{
+					"X-Request-Id": "on",
+				},
+			},
+		})
+		var v struct {
+			ReadTimeout time.Duration     `value:"${read-timeout}"`
+			MaxBodySize int               `value:"${max-body-size}"`
+			Headers     map[string]string `value:"${headers}"`
+		}
+		assert.That(t, p.Bind(&v, "${HTTP}")).Nil()
+		assert.That(t, v.ReadTimeout).Equal(3 * time.Second)
+		assert.That(t, v.MaxBodySize).Equal(1024)
+		assert.That(t, v.Headers).Equal(map[string]string{"X-Request-Id": "on"})
+	})
+}
+
+func TestDeprecatedKey(t *testing.T) {
+	conf.RegisterDeprecatedKey("server.addr", "http.server.addr")
+	conf.RegisterDeprecatedKey("old-cache", "cache")
+
+	p := conf.Map(map[string]any{
+		"server.addr":       ":8080",
+		"old-cache.ttl":     "1m",
+		"old-cache.maxSize": "100",
+	})
+	assert.That(t, p.Get("http.server.addr")).Equal(":8080")
+	assert.That(t, p.Get("cache.ttl")).Equal("1m")
+	assert.That(t, p.Get("cache.max-size")).Equal("100")
+	assert.That(t, p.Has("cache")).True()
+
+	var v struct {
+		TTL     time.Duration `value:"${ttl}"`
+		MaxSize int           `value:"${max-size}"`
+	}
+	assert.That(t, p.Bind(&v, "${cache}")).Nil()
+	assert.That(t, v.TTL).Equal(time.Minute)
+	assert.That(t, v.MaxSize).Equal(100)
+
+	assert.That(t, conf.Deprecations(p)).Equal([]conf.Deprecation{
+		{Deprecated: "old-cache", Current: "cache"},
+		{Deprecated: "server.addr", Current: "http.server.addr"},
+	})
+
+	// the current key wins
+	assert.That(t, p.Set("http.server.addr", ":9090", 0)).Nil()
+	assert.That(t, p.Get("http.server.addr")).Equal(":9090")
+
+	assert.That(t, conf.Deprecations(conf.New())).Equal([]conf.Deprecation(nil))
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// SchemaEntry is a property declared by a Schema. In its key, the key of
+// a map entry is written "*" and the index of a list element "[*]", e.g.
+// "db.hosts[*].addr" or "breaker.*.window".
+type SchemaEntry struct {
+	Key         string            // property key
+	Type        reflect.Type      // type of the bound value
+	Default     string            // default value
+	HasDef      bool              // whether the property has a default value
+	Description string            // description, from the "desc" tag
+	Tag         reflect.StructTag // tag of the bound field, if any
+}
+
+// Schema declares the properties consumed under a prefix: their keys,
+// types and defaults, derived from the value tags of the type bound to
+// the prefix. A schema claims prefixes, by default the prefix it's bound
+// to, under which the properties it doesn't declare, e.g. misspelt keys,
+// are reported by [CheckSchemas]. A schema without claims only declares
+// properties under the prefixes claimed by others.
+type Schema struct {
+	Prefix  string
+	Claims  []string
+	Entries []SchemaEntry
+	t       reflect.Type
+}
+
+// NewSchema creates the schema of the properties bound to a value of type
+// t under the prefix, which is empty for a type declaring its full keys.
+func NewSchema(prefix string, t reflect.Type) (*Schema, error) {
+	s := &Schema{Prefix: prefix, t: t}
+	if prefix != "" {
+		s.Claims = []string{prefix}
+	}
+	if err := s.walk(prefix, t, "", ParsedTag{}); err != nil {
+		return nil, util.FormatError(err, "schema of %s error", t)
+	}
+	return s, nil
+}
+
+// Claim replaces the prefixes claimed by the schema.
+func (s *Schema) Claim(prefixes ...string) *Schema {
+	s.Claims = prefixes
+	return s
+}
+
+// walk adds the entries of the properties bound to a value of type t.
+func (s *Schema) walk(key string, t reflect.Type, tag reflect.StructTag, pt ParsedTag) error {
+	if isStructPtr(t) {
+		t = t.Elem()
+	}
+	leaf := converters[t] != nil || isTextUnmarshaler(t)
+	if !leaf {
+		switch t.Kind() {
+		case reflect.Struct:
+			for i := range t.NumField() {
+				ft := t.Field(i)
+				if !ft.IsExported() {
+					continue
+				}
+				if v, ok := ft.Tag.Lookup("value"); ok {
+					param := BindParam{Key: key}
+					if err := param.BindTag(v, ft.Tag); err != nil {
+						return err
+					}
+					if err := s.walk(param.Key, ft.Type, ft.Tag, param.Tag); err != nil {
+						return err
+					}
+					continue
+				}
+				if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+					if err := s.walk(key, ft.Type, "", ParsedTag{}); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		case reflect.Map:
+			return s.walk(joinKey(key, "*"), t.Elem(), "", ParsedTag{})
+		case reflect.Slice:
+			et := t.Elem()
+			if isStructPtr(et) {
+				et = et.Elem()
+			}
+			// A list of structs or maps is declared by the keys of its elements
+			if et.Kind() == reflect.Struct && converters[et] == nil && !isTextUnmarshaler(et) || et.Kind() == reflect.Map {
+				return s.walk(key+"[*]", t.Elem(), "", ParsedTag{})
+			}
+		default: // for linter
+		}
+	}
+	s.Entries = append(s.Entries, SchemaEntry{
+		Key:         key,
+		Type:        t,
+		Default:     pt.Def,
+		HasDef:      pt.HasDef,
+		Description: tag.Get("desc"),
+		Tag:         tag,
+	})
+	return nil
+}
+
+// joinKey joins the parts of a property key, either may be empty.
+func joinKey(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + "." + b
+}
+
+// indexRegexp matches the indexes of list elements in property keys.
+var indexRegexp = regexp.MustCompile(`\[\d+]`)
+
+// declares returns whether the property key is declared by the schema.
+func (s *Schema) declares(key string) bool {
+	ks := strings.Split(indexRegexp.ReplaceAllString(key, "[*]"), ".")
+	for _, e := range s.Entries {
+		if matchKey(strings.Split(e.Key, "."), ks) {
+			return true
+		}
+		// The elements of a list of values may be set one by one
+		if e.Type.Kind() == reflect.Slice && len(ks) > 0 {
+			if last, ok := strings.CutSuffix(ks[len(ks)-1], "[*]"); ok {
+				trimmed := append(slices.Clone(ks[:len(ks)-1]), last)
+				if matchKey(strings.Split(e.Key, "."), trimmed) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// matchKey returns whether the segments of a key match the segments of a
+// declared key, in which "*" matches any map key. Other segments match
+// relaxedly, see canonicalKey.
+func matchKey(declared, key []string) bool {
+	if len(declared) != len(key) {
+		return false
+	}
+	for i, d := range declared {
+		if canonicalKey(d) == canonicalKey(key[i]) {
+			continue
+		}
+		suffix, ok := strings.CutPrefix(d, "*")
+		if !ok {
+			return false
+		}
+		name, ok := strings.CutSuffix(key[i], suffix)
+		if !ok || name == "" || strings.Contains(name, "[") {
+			return false
+		}
+	}
+	return true
+}
+
+// under returns whether the key is the prefix or a key under it.
+func under(key, prefix string) bool {
+	if prefix == "" || key == prefix {
+		return true
+	}
+	rest, ok := strings.CutPrefix(key, prefix)
+	return ok && (rest[0] == '.' || rest[0] == '[')
+}
+
+// CheckSchemas checks the properties against the schemas. It reports the
+// properties under the prefixes claimed by the schemas that none of them
+// declares, suggesting the closest declared key, and the values that fail
+// to bind to the types of the schemas whose claimed prefixes are present,
+// all at once.
+func CheckSchemas(p Properties, schemas []*Schema) error {
+	var errs []error
+	keys := p.Keys()
+	slices.Sort(keys)
+	for _, key := range keys {
+		claimed := false
+		for _, s := range schemas {
+			if slices.ContainsFunc(s.Claims, func(c string) bool { return under(key, c) }) {
+				claimed = true
+				break
+			}
+		}
+		current := currentKey(key)
+		if !claimed || slices.ContainsFunc(schemas, func(s *Schema) bool { return s.declares(current) }) {
+			continue
+		}
+		err := util.FormatError(nil, "unknown property %q", key)
+		if k := closestKey(key, schemas); k != "" {
+			err = util.FormatError(nil, "unknown property %q, did you mean %q?", key, k)
+		}
+		errs = append(errs, err)
+	}
+	for _, s := range schemas {
+		present := slices.ContainsFunc(keys, func(key string) bool {
+			return slices.ContainsFunc(s.Claims, func(c string) bool { return under(key, c) })
+		})
+		if !present {
+			continue
+		}
+		var tag []string
+		if s.Prefix != "" {
+			tag = append(tag, "${"+s.Prefix+"}")
+		}
+		if err := p.Bind(reflect.New(s.t).Interface(), tag...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closestKey returns the declared key closest to the key, if it's within
+// a few edits, or an empty string.
+func closestKey(key string, schemas []*Schema) string {
+	key = indexRegexp.ReplaceAllString(key, "[*]")
+	best, dist := "", 3
+	for _, s := range schemas {
+		for _, e := range s.Entries {
+			if d := editDistance(key, e.Key); d < dist {
+				best, dist = e.Key, d
+			}
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
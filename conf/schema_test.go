@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+type SchemaServer struct {
+	Addr    string        `value:"${addr:=:8080}"`
+	Timeout time.Duration `value:"${timeout:=5s}"`
+	Tags    []string      `value:"${tags:=}"`
+	TLS     struct {
+		Cert string `value:"${cert:=}"`
+	} `value:"${tls}"`
+}
+
+type SchemaDB struct {
+	Hosts []struct {
+		Addr string `value:"${addr}"`
+	} `value:"${hosts}"`
+}
+
+func TestSchema(t *testing.T) {
+
+	t.Run("entries", func(t *testing.T) {
+		s, err := conf.NewSchema("http.server", reflect.TypeFor[SchemaServer]())
+		assert.That(t, err).Nil()
+		var keys []string
+		for _, e := range s.Entries {
+			keys = append(keys, e.Key)
+		}
+		assert.That(t, keys).Equal([]string{
+			"http.server.addr",
+			"http.server.timeout",
+			"http.server.tags",
+			"http.server.tls.cert",
+		})
+		assert.That(t, s.Entries[0].Default).Equal(":8080")
+		assert.That(t, s.Entries[1].Type).Equal(reflect.TypeFor[time.Duration]())
+		assert.That(t, s.Claims).Equal([]string{"http.server"})
+
+		s, err = conf.NewSchema("db", reflect.TypeFor[map[string]SchemaDB]())
+		assert.That(t, err).Nil()
+		assert.That(t, s.Entries[0].Key).Equal("db.*.hosts[*].addr")
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		_, err := conf.NewSchema("x", reflect.TypeFor[struct {
+			A int `value:"a"`
+		}]())
+		assert.Error(t, err).Matches("parse tag 'a' error")
+	})
+
+	t.Run("check", func(t *testing.T) {
+		server, _ := conf.NewSchema("http.server", reflect.TypeFor[SchemaServer]())
+		db, _ := conf.NewSchema("db", reflect.TypeFor[map[string]SchemaDB]())
+		extra, _ := conf.NewSchema("http.server.rate-limit", reflect.TypeFor[string]())
+		schemas := []*conf.Schema{server, db, extra.Claim()}
+
+		p := conf.Map(map[string]any{
+			"http": map[string]any{
+				"server": map[string]any{
+					"addr":       ":9090",
+					"tags":       []string{"a", "b"},
+					"tls":        map[string]any{"cert": "x.pem"},
+					"rate-limit": "api",
+				},
+			},
+			"db": map[string]any{
+				"main": map[string]any{
+					"hosts": []map[string]any{{"addr": "h1"}, {"addr": "h2"}},
+				},
+			},
+			"other": map[string]any{"key": 1},
+		})
+		err := conf.CheckSchemas(p, schemas)
+		assert.That(t, err).Nil()
+
+		p = conf.Map(map[string]any{
+			"http": map[string]any{
+				"server": map[string]any{
+					"adr":     ":9090",
+					"timeout": "abc",
+				},
+			},
+			"db": map[string]any{
+				"main": map[string]any{
+					"hosts": []map[string]any{{"address": "h1"}},
+				},
+			},
+		})
+		err = conf.CheckSchemas(p, schemas)
+		assert.Error(t, err).Matches(`unknown property "http.server.adr", did you mean "http.server.addr"\?`)
+		assert.Error(t, err).Matches(`unknown property "db.main.hosts\[0\].address"`)
+		assert.Error(t, err).Matches(`bind path=SchemaServer.Timeout type=time.Duration error`)
+		assert.Error(t, err).Matches(`property "db.main.hosts\[0\].addr" not exist`)
+	})
+
+	t.Run("relaxed and deprecated keys", func(t *testing.T) {
+		conf.RegisterDeprecatedKey("http.server.rate", "http.server.rate-limit")
+		server, _ := conf.NewSchema("http.server", reflect.TypeFor[SchemaServer]())
+		extra, _ := conf.NewSchema("http.server.rate-limit", reflect.TypeFor[string]())
+		schemas := []*conf.Schema{server, extra.Claim()}
+
+		p := conf.Map(map[string]any{
+			"http.server.ADDR":     ":9090",
+			"http.server.TLS.Cert": "x.pem",
+			"http.server.rate":     "api",
+		})
+		err := conf.CheckSchemas(p, schemas)
+		assert.That(t, err).Nil()
+	})
+}
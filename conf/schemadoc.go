@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schemaEntries returns the entries of the schemas sorted by key, the
+// first declaration of a key winning.
+func schemaEntries(schemas []*Schema) []SchemaEntry {
+	var entries []SchemaEntry
+	seen := make(map[string]bool)
+	for _, s := range schemas {
+		for _, e := range s.Entries {
+			if !seen[e.Key] {
+				seen[e.Key] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b SchemaEntry) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return entries
+}
+
+// SchemaMarkdown returns the documentation of the properties declared by
+// the schemas as a markdown table, sorted by key.
+func SchemaMarkdown(schemas []*Schema) string {
+	var sb strings.Builder
+	sb.WriteString("| Property | Type | Default | Description |\n")
+	sb.WriteString("|----------|------|---------|-------------|\n")
+	for _, e := range schemaEntries(schemas) {
+		def := "*required*"
+		if e.HasDef {
+			def = "`" + e.Default + "`"
+			if e.Default == "" {
+				def = ""
+			}
+		}
+		desc := strings.ReplaceAll(e.Description, "|", "\\|")
+		fmt.Fprintf(&sb, "| `%s` | `%s` | %s | %s |\n", e.Key, e.Type, def, desc)
+	}
+	return sb.String()
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) of the properties
+// declared by the schemas, as nested objects, so that editors complete and
+// check the keys and values of YAML or JSON configuration files.
+func JSONSchema(schemas []*Schema) ([]byte, error) {
+	root := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+	}
+	for _, e := range schemaEntries(schemas) {
+		node := root
+		segments := strings.Split(e.Key, ".")
+		for i, seg := range segments {
+			name, list := strings.CutSuffix(seg, "[*]")
+			if name == "*" {
+				node = child(node, "additionalProperties", "")
+			} else {
+				node = child(node, "properties", name)
+			}
+			if list {
+				node["type"] = "array"
+				node = child(node, "items", "")
+			}
+			if i < len(segments)-1 {
+				node["type"] = "object"
+			}
+		}
+		for k, v := range jsonType(e.Type) {
+			node[k] = v
+		}
+		if e.HasDef && e.Default != "" {
+			node["default"] = jsonDefault(e.Type, e.Default)
+		}
+		if e.Description != "" {
+			node["description"] = e.Description
+		}
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// child returns the schema under the key of a schema, or under the name of
+// the map at the key if name isn't empty, creating it if missing.
+func child(node map[string]any, key, name string) map[string]any {
+	m, ok := node[key].(map[string]any)
+	if !ok {
+		m = make(map[string]any)
+		node[key] = m
+	}
+	if name == "" {
+		return m
+	}
+	c, ok := m[name].(map[string]any)
+	if !ok {
+		c = make(map[string]any)
+		m[name] = c
+	}
+	return c
+}
+
+// jsonType returns the JSON Schema keywords of the values of type t.
+func jsonType(t reflect.Type) map[string]any {
+	if t == reflect.TypeFor[time.Duration]() {
+		return map[string]any{"type": "string", "format": "duration"}
+	}
+	if converters[t] != nil || isTextUnmarshaler(t) {
+		return map[string]any{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		// A list may also be set as a string split by a splitter
+		return map[string]any{"type": []string{"array", "string"}, "items": jsonType(t.Elem())}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+// jsonDefault returns the default value as a JSON value of type t, or as
+// a string if it isn't a literal of the type, e.g. a reference.
+func jsonDefault(t reflect.Type, def string) any {
+	if t == reflect.TypeFor[time.Duration]() {
+		return def
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(def); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(def, 0, 64); err == nil {
+			return i
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(def, 0, 64); err == nil {
+			return u
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(def, 64); err == nil {
+			return f
+		}
+	default: // for linter
+	}
+	return def
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+type DocServer struct {
+	Addr    string        `value:"${addr:=:8080}" desc:"address to listen on"`
+	Timeout time.Duration `value:"${timeout:=5s}" desc:"read timeout"`
+	Debug   bool          `value:"${debug:=false}"`
+	Tags    []string      `value:"${tags:=}"`
+	Routes  []struct {
+		Path string `value:"${path}" desc:"route | path"`
+	} `value:"${routes:=}"`
+}
+
+func docSchemas(t *testing.T) []*conf.Schema {
+	server, err := conf.NewSchema("server", reflect.TypeFor[DocServer]())
+	assert.That(t, err).Nil()
+	pools, err := conf.NewSchema("pools", reflect.TypeFor[map[string]struct {
+		Size int `value:"${size:=10}"`
+	}]())
+	assert.That(t, err).Nil()
+	return []*conf.Schema{server, pools}
+}
+
+func TestSchemaMarkdown(t *testing.T) {
+	s := conf.SchemaMarkdown(docSchemas(t))
+	assert.That(t, s).Equal("" +
+		"| Property | Type | Default | Description |\n" +
+		"|----------|------|---------|-------------|\n" +
+		"| `pools.*.size` | `int` | `10` |  |\n" +
+		"| `server.addr` | `string` | `:8080` | address to listen on |\n" +
+		"| `server.debug` | `bool` | `false` |  |\n" +
+		"| `server.routes[*].path` | `string` | *required* | route \\| path |\n" +
+		"| `server.tags` | `[]string` |  |  |\n" +
+		"| `server.timeout` | `time.Duration` | `5s` | read timeout |\n")
+}
+
+func TestJSONSchema(t *testing.T) {
+	b, err := conf.JSONSchema(docSchemas(t))
+	assert.That(t, err).Nil()
+
+	var m map[string]any
+	err = json.Unmarshal(b, &m)
+	assert.That(t, err).Nil()
+	assert.That(t, m["$schema"]).Equal("https://json-schema.org/draft/2020-12/schema")
+
+	props := m["properties"].(map[string]any)
+	server := props["server"].(map[string]any)
+	assert.That(t, server["type"]).Equal("object")
+	sp := server["properties"].(map[string]any)
+	assert.That(t, sp["addr"]).Equal(map[string]any{
+		"type":        "string",
+		"default":     ":8080",
+		"description": "address to listen on",
+	})
+	assert.That(t, sp["timeout"]).Equal(map[string]any{
+		"type":        "string",
+		"format":      "duration",
+		"default":     "5s",
+		"description": "read timeout",
+	})
+	assert.That(t, sp["debug"]).Equal(map[string]any{
+		"type":    "boolean",
+		"default": false,
+	})
+	assert.That(t, sp["tags"]).Equal(map[string]any{
+		"type":  []any{"array", "string"},
+		"items": map[string]any{"type": "string"},
+	})
+	routes := sp["routes"].(map[string]any)
+	assert.That(t, routes["type"]).Equal("array")
+	items := routes["items"].(map[string]any)
+	assert.That(t, items["type"]).Equal("object")
+
+	pools := props["pools"].(map[string]any)
+	size := pools["additionalProperties"].(map[string]any)["properties"].(map[string]any)["size"]
+	assert.That(t, size).Equal(map[string]any{"type": "integer", "default": float64(10)})
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// FieldError describes a bound value that fails a rule of its "validate"
+// tag, e.g. `validate:"min=1,max=65535"`.
+type FieldError struct {
+	Key   string // property key
+	Path  string // path of the bound field
+	Rule  string // failed rule, e.g. "min=1"
+	Value any    // bound value
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("key %q path=%s value=%v fails %q", e.Key, e.Path, e.Value, e.Rule)
+}
+
+// ValidationErrors lists every bound value that fails its rules. Binding
+// a struct goes on after a field fails, so that all the failures are
+// reported at once.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	ss := make([]string, len(e))
+	for i, fe := range e {
+		ss[i] = fe.Error()
+	}
+	return "validate error: " + strings.Join(ss, "; ")
+}
+
+// validateRules checks the value v against the comma-separated rules of a
+// "validate" tag. The rules are:
+//
+//   - required:   the value isn't the zero value.
+//   - min=n:      a number is at least n, or a string, slice or map has at
+//     least n elements; n of a time.Duration is a duration, e.g. "1s".
+//   - max=n:      likewise, at most n.
+//   - oneof=a b:  the value is one of the space-separated values.
+//
+// It returns ValidationErrors for the failed rules, or another error if
+// a rule is invalid.
+func validateRules(tag string, v reflect.Value, param BindParam) error {
+	var errs ValidationErrors
+	for rule := range strings.SplitSeq(tag, ",") {
+		if rule = strings.TrimSpace(rule); rule == "" {
+			continue
+		}
+		ok, err := checkRule(rule, v)
+		if err != nil {
+			return util.FormatError(err, "validate path=%s type=%s error", param.Path, v.Type().String())
+		}
+		if !ok {
+			errs = append(errs, &FieldError{
+				Key:   param.Key,
+				Path:  param.Path,
+				Rule:  rule,
+				Value: v.Interface(),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkRule returns whether the value v passes the rule.
+func checkRule(rule string, v reflect.Value) (bool, error) {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		return !v.IsZero(), nil
+	case "oneof":
+		s := fmt.Sprint(v.Interface())
+		for opt := range strings.FieldsSeq(arg) {
+			if s == opt {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "min", "max":
+		n, limit, err := ruleNumbers(v, arg)
+		if err != nil {
+			return false, util.FormatError(err, "invalid validate rule %q", rule)
+		}
+		if name == "min" {
+			return n >= limit, nil
+		}
+		return n <= limit, nil
+	default:
+		return false, util.FormatError(nil, "unknown validate rule %q", rule)
+	}
+}
+
+// ruleNumbers returns the number to compare of the value v, i.e. itself
+// or its length, and the limit arg parsed for it.
+func ruleNumbers(v reflect.Value, arg string) (n, limit float64, err error) {
+	if v.Type() == reflect.TypeFor[time.Duration]() {
+		d, err := time.ParseDuration(arg)
+		return float64(v.Int()), float64(d), err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = v.Float()
+	case reflect.String:
+		n = float64(utf8.RuneCountInString(v.String()))
+	case reflect.Slice, reflect.Map:
+		n = float64(v.Len())
+	default:
+		return 0, 0, util.FormatError(nil, "can't compare %s", v.Type().String())
+	}
+	limit, err = strconv.ParseFloat(arg, 64)
+	return n, limit, err
+}
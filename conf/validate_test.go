@@ -0,0 +1,128 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestValidate(t *testing.T) {
+
+	type DB struct {
+		Host  string   `value:"${host:=}" validate:"required"`
+		Hosts []string `value:"${hosts:=}" validate:"max=2"`
+	}
+
+	type Config struct {
+		Port    int           `value:"${port}" validate:"min=1,max=65535"`
+		Env     string        `value:"${env:=dev}" validate:"oneof=dev test prod"`
+		Rate    float64       `value:"${rate:=0.5}" validate:"min=0,max=1"`
+		Name    string        `value:"${name:=app}" validate:"min=2"`
+		Timeout time.Duration `value:"${timeout:=1s}" validate:"min=100ms,max=1m"`
+		DB      DB            `value:"${db}"`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"port": 8080,
+			"env":  "prod",
+			"db": map[string]any{
+				"host":  "localhost",
+				"hosts": []string{"a", "b"},
+			},
+		})
+		var c Config
+		err := p.Bind(&c)
+		assert.That(t, err).Nil()
+		assert.That(t, c.Port).Equal(8080)
+		assert.That(t, c.Env).Equal("prod")
+	})
+
+	t.Run("aggregated errors", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"port":    70000,
+			"env":     "staging",
+			"rate":    1.5,
+			"name":    "x",
+			"timeout": "2m",
+			"db": map[string]any{
+				"hosts": []string{"a", "b", "c"},
+			},
+		})
+		var c Config
+		err := p.Bind(&c)
+		var verr conf.ValidationErrors
+		assert.That(t, errors.As(err, &verr)).True()
+
+		var keys []string
+		for _, e := range verr {
+			keys = append(keys, e.Key+" "+e.Rule)
+		}
+		assert.That(t, keys).Equal([]string{
+			"port max=65535",
+			"env oneof=dev test prod",
+			"rate max=1",
+			"name min=2",
+			"timeout max=1m",
+			"db.host required",
+			"db.hosts max=2",
+		})
+		assert.Error(t, err).Matches(`validate error: key "port" path=Config.Port value=70000 fails "max=65535"; key "env" .*`)
+
+		// other values are still bound
+		assert.That(t, c.Port).Equal(70000)
+		assert.That(t, c.DB.Hosts).Equal([]string{"a", "b", "c"})
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"port": "abc",
+			"env":  "staging",
+		})
+		var c Config
+		err := p.Bind(&c)
+		assert.Error(t, err).Matches(`bind path=Config.Port type=int error`)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"a": 1,
+		})
+		var v struct {
+			A int `value:"${a}" validate:"min=x"`
+		}
+		err := p.Bind(&v)
+		assert.Error(t, err).Matches(`invalid validate rule "min=x"`)
+
+		var v2 struct {
+			A int `value:"${a}" validate:"email"`
+		}
+		err = p.Bind(&v2)
+		assert.Error(t, err).Matches(`unknown validate rule "email"`)
+
+		var v3 struct {
+			A bool `value:"${a}" validate:"min=1"`
+		}
+		err = p.Bind(&v3)
+		assert.Error(t, err).Matches(`can't compare bool`)
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/go-spring/spring-base/barky"
+	"github.com/go-spring/spring-base/util"
+)
+
+// Tree converts the flattened properties back into a nested map, in which
+// "a.b" is a map entry and "a[0]" a list element, e.g. {"a": {"b": ...}}
+// and {"a": [...]}. The values are the strings of the properties. Empty
+// lists and maps are kept as such if the properties are MutableProperties.
+// A key starting with an index, e.g. of a Subset of a list, is an error.
+func Tree(p Properties) (map[string]any, error) {
+	var data map[string]string
+	if m, ok := p.(*MutableProperties); ok {
+		raw := m.RawData()
+		data = make(map[string]string, len(raw))
+		for k, v := range raw {
+			data[k] = v.Value
+		}
+	} else {
+		data = p.Data()
+	}
+	root := make(map[string]any)
+	for _, key := range util.OrderedMapKeys(data) {
+		path, err := barky.SplitPath(key)
+		if err != nil {
+			return nil, err
+		}
+		if path[0].Type != barky.PathTypeKey {
+			return nil, util.FormatError(nil, "property conflict at path %s", key)
+		}
+		v, err := treeSet(root[path[0].Elem], path[1:], treeValue(data[key]))
+		if err != nil {
+			return nil, util.FormatError(err, "property conflict at path %s", key)
+		}
+		root[path[0].Elem] = v
+	}
+	return root, nil
+}
+
+// treeValue returns the value in the tree of a flattened value, see
+// barky.FlattenValue.
+func treeValue(s string) any {
+	switch s {
+	case "[]":
+		return []any{}
+	case "{}":
+		return map[string]any{}
+	case "<nil>":
+		return nil
+	default:
+		return s
+	}
+}
+
+// treeSet sets the value at the path under the node, which is nil if it
+// doesn't exist yet, and returns the node.
+func treeSet(node any, path []barky.Path, val any) (any, error) {
+	if len(path) == 0 {
+		if node != nil {
+			return nil, util.FormatError(nil, "value set twice")
+		}
+		return val, nil
+	}
+	switch path[0].Type {
+	case barky.PathTypeIndex:
+		index, err := strconv.Atoi(path[0].Elem)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := node.([]any)
+		if node != nil && !ok {
+			return nil, util.FormatError(nil, "not a list")
+		}
+		for len(list) <= index {
+			list = append(list, nil)
+		}
+		if list[index], err = treeSet(list[index], path[1:], val); err != nil {
+			return nil, err
+		}
+		return list, nil
+	default:
+		m, ok := node.(map[string]any)
+		if node != nil && !ok {
+			return nil, util.FormatError(nil, "not a map")
+		}
+		if m == nil {
+			m = make(map[string]any)
+		}
+		v, err := treeSet(m[path[0].Elem], path[1:], val)
+		if err != nil {
+			return nil, err
+		}
+		m[path[0].Elem] = v
+		return m, nil
+	}
+}
+
+// Marshal formats the properties in the format registered for the file
+// extension ext (e.g. ".yaml"), nested as by Tree, so that Parse reads
+// back the same properties.
+func Marshal(p Properties, ext string) ([]byte, error) {
+	w, ok := writers[ext]
+	if !ok {
+		return nil, util.FormatError(nil, "unsupported file type %s", ext)
+	}
+	m, err := Tree(p)
+	if err != nil {
+		return nil, err
+	}
+	return w(m)
+}
+
+// Save writes the properties to the file, in the format of its extension,
+// see Marshal.
+func Save(p Properties, file string) error {
+	b, err := Marshal(p, filepath.Ext(file))
+	if err != nil {
+		return util.FormatError(err, "write file %s error", file)
+	}
+	if err = os.WriteFile(file, b, 0644); err != nil {
+		return util.FormatError(err, "write file %s error", file)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestTree(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"http": map[string]any{
+				"port":  8080,
+				"hosts": []any{"a", "b"},
+			},
+			"servers": []any{
+				map[string]any{"addr": ":1"},
+				map[string]any{"addr": ":2"},
+			},
+			"list": []any{},
+			"map":  map[string]any{},
+		})
+		m, err := conf.Tree(p)
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]any{
+			"http": map[string]any{
+				"port":  "8080",
+				"hosts": []any{"a", "b"},
+			},
+			"servers": []any{
+				map[string]any{"addr": ":1"},
+				map[string]any{"addr": ":2"},
+			},
+			"list": []any{},
+			"map":  map[string]any{},
+		})
+	})
+
+	t.Run("subset", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"servers": []any{"a", "b"},
+		})
+		s, err := p.Subset("servers")
+		assert.That(t, err).Nil()
+		_, err = conf.Tree(s)
+		assert.Error(t, err).Matches("property conflict at path \\[0\\]")
+	})
+}
+
+func TestMarshal(t *testing.T) {
+
+	p := conf.Map(map[string]any{
+		"http": map[string]any{
+			"port":  8080,
+			"hosts": []any{"a", "b"},
+		},
+		"servers": []any{
+			map[string]any{"addr": ":1"},
+		},
+		"list": []any{},
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := conf.Marshal(p, ".xml")
+		assert.Error(t, err).Matches("unsupported file type .xml")
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		b, err := conf.Marshal(p, ".properties")
+		assert.That(t, err).Nil()
+		assert.That(t, string(b)).Equal(`http.hosts[0] = a
+http.hosts[1] = b
+http.port = 8080
+list = []
+servers[0].addr = :1
+`)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		b, err := conf.Marshal(p, ".yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, string(b)).Equal(`http:
+  hosts:
+  - a
+  - b
+  port: "8080"
+list: []
+servers:
+- addr: :1
+`)
+	})
+
+	for _, ext := range []string{".json", ".properties", ".yaml"} {
+		t.Run("round trip "+ext, func(t *testing.T) {
+			b, err := conf.Marshal(p, ext)
+			assert.That(t, err).Nil()
+			r, err := conf.Parse(b, ext, "test")
+			assert.That(t, err).Nil()
+			assert.That(t, r.Data()).Equal(p.Data())
+			m, err := conf.Tree(r)
+			assert.That(t, err).Nil()
+			assert.That(t, m["list"]).Equal([]any{})
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	p := conf.Map(map[string]any{"a": map[string]any{"b": "c"}})
+
+	file := filepath.Join(t.TempDir(), "app.json")
+	err := conf.Save(p, file)
+	assert.That(t, err).Nil()
+	b, err := os.ReadFile(file)
+	assert.That(t, err).Nil()
+	assert.That(t, string(b)).Equal("{\n  \"a\": {\n    \"b\": \"c\"\n  }\n}\n")
+
+	err = conf.Save(p, filepath.Join(t.TempDir(), "no", "app.yaml"))
+	assert.Error(t, err).Matches("write file .*app.yaml error")
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func init() {
+	DeclareConfig[AdminServerConfig]("").Claim("admin.server")
+
+	Module([]ConditionOnProperty{
+		OnEnableServers(),
+		OnProperty(EnableAdminServerProp).HavingValue("true"),
+	}, func(p conf.Properties) error {
+
+		// Provide the admin server with all registered AdminHandlers.
+		Provide(
+			NewAdminServer,
+			IndexArg(1, TagArg("?")),
+		).AsServer()
+
+		return nil
+	})
+}
+
+// AdminHandler is an HTTP handler mounted on the admin server.
+// Register a bean exported as AdminHandler to add a management endpoint.
+type AdminHandler interface {
+	http.Handler
+
+	// Pattern returns the [http.ServeMux] pattern the handler is mounted
+	// on, e.g. "GET /loggers" or "/debug/pprof/".
+	Pattern() string
+}
+
+// AdminServerConfig holds configuration for the AdminServer.
+type AdminServerConfig struct {
+	// Address specifies the TCP address the server listens on.
+	Address string `value:"${admin.server.addr:=:9091}" desc:"address the admin server listens on"`
+
+	// Username and Password enable HTTP basic authentication on all
+	// endpoints of the admin server when either of them is set.
+	Username string `value:"${admin.server.username:=}" desc:"username of the basic authentication"`
+	Password string `value:"${admin.server.password:=}" desc:"password of the basic authentication"`
+
+	// ReadTimeout is the maximum duration for reading the entire
+	// request, including the body.
+	ReadTimeout time.Duration `value:"${admin.server.readTimeout:=5s}" desc:"timeout of reading a request"`
+
+	// WriteTimeout is the maximum duration before timing out a response
+	// write. It defaults to zero, i.e. no timeout, since some endpoints
+	// such as CPU profiling stream their response for a long time.
+	WriteTimeout time.Duration `value:"${admin.server.writeTimeout:=0s}" desc:"timeout of writing a response, none if zero"`
+}
+
+// HasAuth returns whether authentication is enabled on the admin server.
+func (c AdminServerConfig) HasAuth() bool {
+	return c.Username != "" || c.Password != ""
+}
+
+// AdminServer is an HTTP server for management endpoints, kept apart from
+// the application traffic so that it can listen on a private address.
+type AdminServer struct {
+	*SimpleHttpServer
+}
+
+// NewAdminServer constructs a new AdminServer serving the given handlers.
+func NewAdminServer(cfg AdminServerConfig, handlers []AdminHandler) *AdminServer {
+	mux := http.NewServeMux()
+	for _, h := range handlers {
+		mux.Handle(h.Pattern(), h)
+	}
+
+	var h http.Handler = mux
+	if cfg.HasAuth() {
+		h = basicAuth(mux, cfg.Username, cfg.Password)
+	}
+
+	return &AdminServer{
+		SimpleHttpServer: NewSimpleHttpServer(h, SimpleHttpServerConfig{
+			Address:      cfg.Address,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+		}),
+	}
+}
+
+// Handler returns the HTTP handler of the admin server.
+func (s *AdminServer) Handler() http.Handler {
+	return s.svr.Handler
+}
+
+// basicAuth wraps h so that requests must carry the given credentials.
+func basicAuth(h http.Handler, username, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// requireAuth returns an error if authentication is disabled on the admin
+// server, for endpoints that must never be exposed without credentials.
+func requireAuth(cfg AdminServerConfig, endpoint string) error {
+	if !cfg.HasAuth() {
+		return util.FormatError(nil, "%s on the admin server requires admin.server.username and admin.server.password", endpoint)
+	}
+	return nil
+}
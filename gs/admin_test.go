@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+)
+
+type pingHandler struct{}
+
+func (pingHandler) Pattern() string {
+	return "GET /ping"
+}
+
+func (pingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte("pong"))
+}
+
+func TestAdminServer(t *testing.T) {
+
+	serve := func(s *gs.AdminServer, path string, auth ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if len(auth) > 0 {
+			r.SetBasicAuth(auth[0], auth[1])
+		}
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("no auth", func(t *testing.T) {
+		s := gs.NewAdminServer(gs.AdminServerConfig{}, []gs.AdminHandler{pingHandler{}})
+		w := serve(s, "/ping")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		assert.String(t, w.Body.String()).Equal("pong")
+		assert.That(t, serve(s, "/debug/pprof/").Code).Equal(http.StatusNotFound)
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		cfg := gs.AdminServerConfig{Username: "admin", Password: "secret"}
+		s := gs.NewAdminServer(cfg, []gs.AdminHandler{pingHandler{}})
+		w := serve(s, "/ping")
+		assert.That(t, w.Code).Equal(http.StatusUnauthorized)
+		assert.String(t, w.Header().Get("WWW-Authenticate")).Equal(`Basic realm="admin"`)
+		assert.That(t, serve(s, "/ping", "admin", "wrong").Code).Equal(http.StatusUnauthorized)
+		assert.That(t, serve(s, "/ping", "admin", "secret").Code).Equal(http.StatusOK)
+	})
+
+	t.Run("pprof", func(t *testing.T) {
+		_, err := gs.NewPProfAdminHandler(gs.AdminServerConfig{})
+		assert.Error(t, err).Matches("pprof on the admin server requires admin.server.username and admin.server.password")
+
+		cfg := gs.AdminServerConfig{Username: "admin", Password: "secret"}
+		h, err := gs.NewPProfAdminHandler(cfg)
+		assert.That(t, err).Nil()
+		s := gs.NewAdminServer(cfg, []gs.AdminHandler{h})
+		assert.That(t, serve(s, "/debug/pprof/goroutine").Code).Equal(http.StatusUnauthorized)
+		w := serve(s, "/debug/pprof/goroutine?debug=1", "admin", "secret")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		assert.String(t, w.Body.String()).Contains("goroutine profile:")
+		assert.That(t, serve(s, "/debug/pprof/cmdline", "admin", "secret").Code).Equal(http.StatusOK)
+	})
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package aop intercepts the method calls of interface-typed beans. A
+// proxy, generated by the aopgen command for an interface, routes every
+// call through an ordered chain of interceptors, so that logging, metrics
+// or retry policies are applied to service beans declared in code:
+//
+//	//go:generate go run github.com/go-spring/spring-core/gs/aop/aopgen -i UserService
+//
+//	aop.Intercept("user*", NewUserServiceProxy, Logging(), Retry(3))
+//
+// Only beans whose type is the interface, e.g. created by a constructor
+// returning it, can be replaced by a proxy.
+package aop
+
+import (
+	"path"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/gs"
+)
+
+// Invocation is a call of a method of a proxied bean.
+type Invocation struct {
+	Bean   string // Name of the bean
+	Method string // Name of the method
+	Args   []any  // Arguments, which interceptors may change before Proceed
+
+	chain  []Interceptor
+	index  int
+	target func(args []any) []any
+}
+
+// Proceed calls the next interceptor, or the method of the bean at the
+// end of the chain, and returns the results of the method.
+func (inv *Invocation) Proceed() []any {
+	if inv.index < len(inv.chain) {
+		i := inv.chain[inv.index]
+		inv.index++
+		defer func() { inv.index-- }()
+		return i.Intercept(inv)
+	}
+	return inv.target(inv.Args)
+}
+
+// Interceptor intercepts the method calls of proxied beans. It calls
+// Proceed to continue the call, and may change the arguments before and
+// the results after.
+type Interceptor interface {
+	Intercept(inv *Invocation) []any
+}
+
+// InterceptorFunc is an around advice implementing [Interceptor].
+type InterceptorFunc func(inv *Invocation) []any
+
+// Intercept calls f.
+func (f InterceptorFunc) Intercept(inv *Invocation) []any {
+	return f(inv)
+}
+
+// Before returns an advice called before the method.
+func Before(fn func(inv *Invocation)) Interceptor {
+	return InterceptorFunc(func(inv *Invocation) []any {
+		fn(inv)
+		return inv.Proceed()
+	})
+}
+
+// After returns an advice called after the method with its results.
+func After(fn func(inv *Invocation, results []any)) Interceptor {
+	return InterceptorFunc(func(inv *Invocation) []any {
+		results := inv.Proceed()
+		fn(inv, results)
+		return results
+	})
+}
+
+// Chain is the ordered interceptors of a proxied bean; the first one is
+// the outermost.
+type Chain struct {
+	bean         string
+	interceptors []Interceptor
+}
+
+// NewChain returns the chain of the interceptors for the named bean.
+func NewChain(bean string, interceptors ...Interceptor) *Chain {
+	return &Chain{bean: bean, interceptors: interceptors}
+}
+
+// Invoke calls the method through the interceptors. The target calls
+// the method of the bean with the arguments and returns its results.
+func (c *Chain) Invoke(method string, args []any, target func(args []any) []any) []any {
+	inv := &Invocation{
+		Bean:   c.bean,
+		Method: method,
+		Args:   args,
+		chain:  c.interceptors,
+		target: target,
+	}
+	return inv.Proceed()
+}
+
+// Value returns values[i] as a T, or the zero value of T if it is nil.
+// Proxies use it to convert arguments and results.
+func Value[T any](values []any, i int) T {
+	if v, ok := values[i].(T); ok {
+		return v
+	}
+	var zero T
+	return zero
+}
+
+// Err returns the last result if it is a non-nil error.
+func Err(results []any) error {
+	if n := len(results); n > 0 {
+		if err, ok := results[n-1].(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// processor is a bean post-processor replacing the beans, whose names
+// match the pattern, with proxies of type T.
+type processor[T any] struct {
+	pattern      string
+	proxy        func(target T, c *Chain) T
+	interceptors []Interceptor
+}
+
+// NewProcessor returns a bean post-processor replacing the beans, whose
+// names match the pattern of [path.Match], with the proxies created by
+// the function, which calls the interceptors in order.
+func NewProcessor[T any](pattern string, proxy func(target T, c *Chain) T, interceptors ...Interceptor) gs.BeanPostProcessor {
+	return &processor[T]{
+		pattern:      pattern,
+		proxy:        proxy,
+		interceptors: interceptors,
+	}
+}
+
+// BeforeInit returns the bean as is.
+func (p *processor[T]) BeforeInit(bean any, name string) (any, error) {
+	return bean, nil
+}
+
+// AfterInit returns the proxy of the bean if its name matches.
+func (p *processor[T]) AfterInit(bean any, name string) (any, error) {
+	ok, err := path.Match(p.pattern, name)
+	if err != nil {
+		return nil, util.FormatError(err, "invalid pattern %q", p.pattern)
+	}
+	if !ok {
+		return bean, nil
+	}
+	t, ok := bean.(T)
+	if !ok {
+		return nil, util.FormatError(nil, "bean %s doesn't implement %T", name, (*T)(nil))
+	}
+	return p.proxy(t, NewChain(name, p.interceptors...)), nil
+}
+
+// Intercept registers a bean post-processor replacing the beans of the
+// application, whose names match the pattern, with the proxies created
+// by the function, which calls the interceptors in order.
+func Intercept[T any](pattern string, proxy func(target T, c *Chain) T, interceptors ...Interceptor) {
+	p := NewProcessor(pattern, proxy, interceptors...)
+	gs.Object(p).
+		Name("Intercept(" + pattern + ")").
+		Export(gs.As[gs.BeanPostProcessor]()).
+		Caller(2)
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aop_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/aop"
+	"github.com/go-spring/spring-core/gs/aop/internal/example"
+	"github.com/go-spring/spring-core/gs/gstest"
+)
+
+func TestChain(t *testing.T) {
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+		around := aop.InterceptorFunc(func(inv *aop.Invocation) []any {
+			calls = append(calls, "around before")
+			r := inv.Proceed()
+			calls = append(calls, "around after")
+			return r
+		})
+		before := aop.Before(func(inv *aop.Invocation) {
+			calls = append(calls, "before "+inv.Bean+"."+inv.Method)
+		})
+		after := aop.After(func(inv *aop.Invocation, results []any) {
+			calls = append(calls, "after "+results[0].(string))
+		})
+		c := aop.NewChain("greeter", around, before, after)
+		p := example.NewGreeterProxy(example.NewGreeter(), c)
+		s, err := p.Greet(context.Background(), "jim")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("hello jim")
+		assert.That(t, calls).Equal([]string{
+			"around before",
+			"before greeter.Greet",
+			"after hello jim",
+			"around after",
+		})
+	})
+
+	t.Run("change arguments and results", func(t *testing.T) {
+		double := aop.InterceptorFunc(func(inv *aop.Invocation) []any {
+			if inv.Method != "Add" {
+				return inv.Proceed()
+			}
+			inv.Args[0] = inv.Args[0].(int) * 2
+			r := inv.Proceed()
+			r[0] = r[0].(int) + 1
+			return r
+		})
+		p := example.NewGreeterProxy(example.NewGreeter(), aop.NewChain("greeter", double))
+		assert.That(t, p.Add(1, 2)).Equal(5)
+		assert.That(t, p.GreetAll("hi", "a", "b")).Equal("hi a,b")
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		n := 0
+		retry := aop.InterceptorFunc(func(inv *aop.Invocation) []any {
+			var r []any
+			for range 3 {
+				n++
+				if r = inv.Proceed(); aop.Err(r) == nil {
+					break
+				}
+				inv.Args[1] = "jim"
+			}
+			return r
+		})
+		p := example.NewGreeterProxy(example.NewGreeter(), aop.NewChain("greeter", retry))
+		s, err := p.Greet(context.Background(), "")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("hello jim")
+		assert.That(t, n).Equal(2)
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		deny := aop.InterceptorFunc(func(inv *aop.Invocation) []any {
+			return []any{"", errors.New("denied")}
+		})
+		g := &example.SimpleGreeter{}
+		p := example.NewGreeterProxy(g, aop.NewChain("greeter", deny))
+		_, err := p.Greet(context.Background(), "jim")
+		assert.Error(t, err).Matches("denied")
+		p.Wait(time.Second)
+		assert.That(t, g.Waited).Equal(time.Duration(0))
+	})
+
+	t.Run("no interceptor", func(t *testing.T) {
+		g := &example.SimpleGreeter{}
+		p := example.NewGreeterProxy(g, aop.NewChain("greeter"))
+		_, err := p.Greet(context.Background(), "")
+		assert.Error(t, err).Matches("empty name")
+		p.Wait(time.Second)
+		assert.That(t, g.Waited).Equal(time.Second)
+	})
+}
+
+func TestValue(t *testing.T) {
+	values := []any{nil, 3, errors.New("error")}
+	assert.That(t, aop.Value[error](values, 0)).Nil()
+	assert.That(t, aop.Value[int](values, 1)).Equal(3)
+	assert.Error(t, aop.Value[error](values, 2)).Matches("error")
+	assert.Error(t, aop.Err(values)).Matches("error")
+	assert.That(t, aop.Err(values[:2])).Nil()
+	assert.That(t, aop.Err(nil)).Nil()
+}
+
+func TestProcessor(t *testing.T) {
+
+	logging := func(calls *[]string) aop.Interceptor {
+		return aop.Before(func(inv *aop.Invocation) {
+			*calls = append(*calls, inv.Bean+"."+inv.Method)
+		})
+	}
+
+	t.Run("match", func(t *testing.T) {
+		var calls []string
+		p := aop.NewProcessor("greet*", example.NewGreeterProxy, logging(&calls))
+		bean, err := p.AfterInit(example.NewGreeter(), "greeter")
+		assert.That(t, err).Nil()
+		_, ok := bean.(*example.GreeterProxy)
+		assert.That(t, ok).True()
+		bean.(example.Greeter).Wait(time.Second)
+		assert.That(t, calls).Equal([]string{"greeter.Wait"})
+	})
+
+	t.Run("not match", func(t *testing.T) {
+		p := aop.NewProcessor("user*", example.NewGreeterProxy)
+		g := example.NewGreeter()
+		bean, err := p.AfterInit(g, "greeter")
+		assert.That(t, err).Nil()
+		assert.That(t, bean).Equal(g)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		p := aop.NewProcessor("[", example.NewGreeterProxy)
+		_, err := p.AfterInit(example.NewGreeter(), "greeter")
+		assert.Error(t, err).Matches(`invalid pattern "\["`)
+	})
+
+	t.Run("not implemented", func(t *testing.T) {
+		p := aop.NewProcessor("*", example.NewGreeterProxy)
+		_, err := p.AfterInit(strings.NewReader(""), "reader")
+		assert.Error(t, err).Matches(`bean reader doesn't implement \*example.Greeter`)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		var calls []string
+		h := gstest.New(t)
+		h.Object(aop.NewProcessor("greeter", example.NewGreeterProxy, logging(&calls))).
+			Export(gs.As[gs.BeanPostProcessor]())
+		h.Provide(example.NewGreeter).Name("greeter")
+		err := h.Refresh()
+		assert.That(t, err).Nil()
+		defer h.Close()
+
+		var s struct {
+			Greeter example.Greeter `autowire:""`
+		}
+		err = h.Wire(&s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Greeter.Add(1, 2)).Equal(3)
+		assert.That(t, calls).Equal([]string{"greeter.Add"})
+	})
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command aopgen generates the proxy of an interface declared in the
+// current directory, for use with go:generate:
+//
+//	//go:generate go run github.com/go-spring/spring-core/gs/aop/aopgen -i UserService
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-spring/spring-core/gs/aop"
+)
+
+func main() {
+	iface := flag.String("i", "", "name of the interface")
+	output := flag.String("o", "", "output file, <interface>_proxy.go by default")
+	flag.Parse()
+
+	if *iface == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = strings.ToLower(*iface) + "_proxy.go"
+	}
+
+	b, err := aop.Generate(".", *iface)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(*output, b, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
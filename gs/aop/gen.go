@@ -0,0 +1,243 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aop
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// importPath is the import path of this package in the generated code.
+const importPath = "github.com/go-spring/spring-core/gs/aop"
+
+// Generate returns the source of the proxy of the interface declared in
+// the Go files of the directory, excluding tests. The proxy is named
+// after the interface, e.g. UserServiceProxy with the constructor
+// NewUserServiceProxy, to be passed to [Intercept]. Embedded interfaces
+// and type parameters are not supported.
+func Generate(dir string, iface string) ([]byte, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if t := findInterface(f, iface); t != nil {
+			return generate(fset, f, iface, t)
+		}
+	}
+	return nil, util.FormatError(nil, "interface %s not found in %s", iface, dir)
+}
+
+// findInterface returns the interface type declared with the name.
+func findInterface(f *ast.File, name string) *ast.TypeSpec {
+	for _, d := range f.Decls {
+		g, ok := d.(*ast.GenDecl)
+		if !ok || g.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range g.Specs {
+			if t := s.(*ast.TypeSpec); t.Name.Name == name {
+				if _, ok = t.Type.(*ast.InterfaceType); ok {
+					return t
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// generator writes the source of a proxy.
+type generator struct {
+	fset *token.FileSet
+	buf  bytes.Buffer
+	pkgs map[string]struct{} // Package names used by the methods
+}
+
+// expr returns the source of the expression.
+func (g *generator) expr(e ast.Expr) string {
+	var sb strings.Builder
+	_ = printer.Fprint(&sb, g.fset, e)
+	return sb.String()
+}
+
+// generate returns the formatted source of the proxy of the interface.
+func generate(fset *token.FileSet, f *ast.File, iface string, t *ast.TypeSpec) ([]byte, error) {
+	if t.TypeParams != nil {
+		return nil, util.FormatError(nil, "generic interface %s is not supported", iface)
+	}
+	g := &generator{fset: fset, pkgs: make(map[string]struct{})}
+
+	var methods []*ast.Field
+	for _, m := range t.Type.(*ast.InterfaceType).Methods.List {
+		if _, ok := m.Type.(*ast.FuncType); !ok || len(m.Names) == 0 {
+			return nil, util.FormatError(nil, "embedded interface %s in %s is not supported", g.expr(m.Type), iface)
+		}
+		ast.Inspect(m.Type, func(n ast.Node) bool {
+			if s, ok := n.(*ast.SelectorExpr); ok {
+				if x, ok := s.X.(*ast.Ident); ok {
+					g.pkgs[x.Name] = struct{}{}
+				}
+			}
+			return true
+		})
+		methods = append(methods, m)
+	}
+
+	proxy := iface + "Proxy"
+	fmt.Fprintf(&g.buf, "// Code generated by aopgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&g.buf, "package %s\n\n", f.Name.Name)
+	g.imports(f)
+	fmt.Fprintf(&g.buf, "// %s is the proxy of %s calling the interceptors of a chain.\n", proxy, iface)
+	fmt.Fprintf(&g.buf, "type %s struct {\n\ttarget %s\n\tchain *aop.Chain\n}\n\n", proxy, iface)
+	fmt.Fprintf(&g.buf, "// New%s returns the proxy of the target.\n", proxy)
+	fmt.Fprintf(&g.buf, "func New%s(target %s, chain *aop.Chain) %s {\n", proxy, iface, iface)
+	fmt.Fprintf(&g.buf, "\treturn &%s{target: target, chain: chain}\n}\n", proxy)
+	for _, m := range methods {
+		g.method(proxy, m.Names[0].Name, m.Type.(*ast.FuncType))
+	}
+
+	b, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, util.FormatError(err, "format proxy of %s error", iface)
+	}
+	return b, nil
+}
+
+// imports writes the imports of the file used by the methods, and aop,
+// with the standard packages in a separate group.
+func (g *generator) imports(f *ast.File) {
+	var std, lines []string
+	lines = append(lines, strconv.Quote(importPath))
+	for _, s := range f.Imports {
+		p, _ := strconv.Unquote(s.Path.Value)
+		name := importName(p)
+		if s.Name != nil {
+			name = s.Name.Name
+		}
+		if _, ok := g.pkgs[name]; !ok {
+			continue
+		}
+		line := s.Path.Value
+		if s.Name != nil {
+			line = s.Name.Name + " " + line
+		}
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			lines = append(lines, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(lines)
+	if len(std) > 0 {
+		lines = append(append(std, ""), lines...)
+	}
+	fmt.Fprintf(&g.buf, "import (\n\t%s\n)\n\n", strings.Join(lines, "\n\t"))
+}
+
+// importName returns the default name of the package imported by the
+// path, i.e. its last element without a major version suffix.
+func importName(p string) string {
+	elems := strings.Split(p, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// method writes the method of the proxy calling the chain.
+func (g *generator) method(proxy, name string, ft *ast.FuncType) {
+	var params, args, calls []string
+	i := 0
+	for _, p := range ft.Params.List {
+		n := max(len(p.Names), 1)
+		for range n {
+			arg := fmt.Sprintf("a%d", i)
+			if e, ok := p.Type.(*ast.Ellipsis); ok {
+				elem := g.expr(e.Elt)
+				params = append(params, arg+" ..."+elem)
+				calls = append(calls, fmt.Sprintf("aop.Value[[]%s](args, %d)...", elem, i))
+			} else {
+				typ := g.expr(p.Type)
+				params = append(params, arg+" "+typ)
+				calls = append(calls, fmt.Sprintf("aop.Value[%s](args, %d)", typ, i))
+			}
+			args = append(args, arg)
+			i++
+		}
+	}
+
+	var results, vars, returns []string
+	if ft.Results != nil {
+		i = 0
+		for _, r := range ft.Results.List {
+			n := max(len(r.Names), 1)
+			for range n {
+				typ := g.expr(r.Type)
+				results = append(results, typ)
+				vars = append(vars, fmt.Sprintf("r%d", i))
+				returns = append(returns, fmt.Sprintf("aop.Value[%s](r, %d)", typ, i))
+				i++
+			}
+		}
+	}
+
+	fmt.Fprintf(&g.buf, "\nfunc (p *%s) %s(%s) ", proxy, name, strings.Join(params, ", "))
+	if len(results) > 0 {
+		fmt.Fprintf(&g.buf, "(%s) ", strings.Join(results, ", "))
+	}
+	g.buf.WriteString("{\n")
+
+	call := fmt.Sprintf("p.target.%s(%s)", name, strings.Join(calls, ", "))
+	if len(results) == 0 {
+		fmt.Fprintf(&g.buf, "\tp.chain.Invoke(%q, []any{%s}, func(args []any) []any {\n", name, strings.Join(args, ", "))
+		fmt.Fprintf(&g.buf, "\t\t%s\n\t\treturn nil\n\t})\n}\n", call)
+		return
+	}
+	fmt.Fprintf(&g.buf, "\tr := p.chain.Invoke(%q, []any{%s}, func(args []any) []any {\n", name, strings.Join(args, ", "))
+	fmt.Fprintf(&g.buf, "\t\t%s := %s\n", strings.Join(vars, ", "), call)
+	fmt.Fprintf(&g.buf, "\t\treturn []any{%s}\n\t})\n", strings.Join(vars, ", "))
+	fmt.Fprintf(&g.buf, "\treturn %s\n}\n", strings.Join(returns, ", "))
+}
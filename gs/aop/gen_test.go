@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aop
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestGenerate(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		b, err := Generate("internal/example", "Greeter")
+		assert.That(t, err).Nil()
+		want, err := os.ReadFile("internal/example/greeter_proxy.go")
+		assert.That(t, err).Nil()
+		assert.That(t, string(b)).Equal(string(want))
+	})
+
+	write := func(t *testing.T, src string) string {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(src), 0644)
+		assert.That(t, err).Nil()
+		return dir
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		dir := write(t, "package a\n\ntype Service struct{}\n")
+		_, err := Generate(dir, "Service")
+		assert.Error(t, err).Matches("interface Service not found in ")
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		dir := write(t, "package a\n\ntype Service[T any] interface{ Get() T }\n")
+		_, err := Generate(dir, "Service")
+		assert.Error(t, err).Matches("generic interface Service is not supported")
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		dir := write(t, "package a\n\nimport \"io\"\n\ntype Service interface{ io.Reader }\n")
+		_, err := Generate(dir, "Service")
+		assert.Error(t, err).Matches("embedded interface io.Reader in Service is not supported")
+	})
+
+	t.Run("named import", func(t *testing.T) {
+		src := "package a\n\nimport (\n\tstdctx \"context\"\n\t\"io\"\n)\n\n" +
+			"var _ io.Reader\n\n" +
+			"type Service interface{ Do(ctx stdctx.Context) }\n"
+		b, err := Generate(write(t, src), "Service")
+		assert.That(t, err).Nil()
+		assert.String(t, string(b)).Contains("import (\n\tstdctx \"context\"\n\n\t\"github.com/go-spring/spring-core/gs/aop\"\n)")
+		assert.String(t, string(b)).Contains("func (p *ServiceProxy) Do(a0 stdctx.Context) {")
+	})
+}
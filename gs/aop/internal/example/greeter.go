@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package example declares an interface and its generated proxy, used
+// by the tests of the aop package.
+package example
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/go-spring/spring-core/gs/aop/aopgen -i Greeter
+
+// Greeter greets people.
+type Greeter interface {
+	Greet(ctx context.Context, name string) (string, error)
+	GreetAll(prefix string, names ...string) string
+	Add(a, b int) int
+	Wait(d time.Duration)
+}
+
+// SimpleGreeter is a plain implementation of Greeter.
+type SimpleGreeter struct {
+	Waited time.Duration
+}
+
+// NewGreeter returns a Greeter, so that the bean can be proxied.
+func NewGreeter() Greeter {
+	return &SimpleGreeter{}
+}
+
+func (g *SimpleGreeter) Greet(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("empty name")
+	}
+	return "hello " + name, nil
+}
+
+func (g *SimpleGreeter) GreetAll(prefix string, names ...string) string {
+	return prefix + " " + strings.Join(names, ",")
+}
+
+func (g *SimpleGreeter) Add(a, b int) int {
+	return a + b
+}
+
+func (g *SimpleGreeter) Wait(d time.Duration) {
+	g.Waited += d
+}
@@ -19,8 +19,6 @@ package gs
 import (
 	"context"
 	"os"
-	"os/signal"
-	"syscall"
 
 	"github.com/go-spring/log"
 	"github.com/go-spring/spring-base/util"
@@ -36,7 +34,9 @@ type AppStarter struct{}
 func (s *AppStarter) startApp() error {
 
 	// Print application banner at startup
-	printBanner()
+	if err := printBanner(); err != nil {
+		return err
+	}
 
 	// Initialize logger
 	if err := initLog(); err != nil {
@@ -65,6 +65,8 @@ func (s *AppStarter) stopApp() {
 
 // Run starts the application, optionally runs a user-defined callback,
 // and waits for termination signals (e.g., SIGTERM, Ctrl+C) to trigger graceful shutdown.
+// If the application has a command, Run exits the process with the exit
+// code of the command after shutting down, unless it is zero.
 func (s *AppStarter) Run(fn ...func() error) {
 
 	// Start application
@@ -74,6 +76,15 @@ func (s *AppStarter) Run(fn ...func() error) {
 		return
 	}
 
+	// Shut down and exit with the exit code of the command (if any)
+	if code, ok := app.ExitCode(); ok {
+		s.stopApp()
+		if code != 0 {
+			os.Exit(code)
+		}
+		return
+	}
+
 	// Execute user-provided callback after app starts
 	if len(fn) > 0 && fn[0] != nil {
 		if err := fn[0](); err != nil {
@@ -83,16 +94,8 @@ func (s *AppStarter) Run(fn ...func() error) {
 		}
 	}
 
-	// Start a goroutine to listen for OS interrupt or termination signals
-	go func() {
-		ch := make(chan os.Signal, 1)
-		signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
-		sig := <-ch
-		signal.Stop(ch)
-		close(ch)
-		log.Infof(context.Background(), log.TagAppDef, "Received signal: %v", sig)
-		app.ShutDown()
-	}()
+	// Shut down on OS interrupt or termination signals
+	app.HandleSignals()
 
 	// Wait until shutdown completes
 	s.stopApp()
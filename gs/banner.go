@@ -17,8 +17,15 @@
 package gs
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
 )
 
 var appBanner = `
@@ -29,24 +36,72 @@ var appBanner = `
   \____|  \___/          |____/  |_|     |_| \_\ |___| |_| \_|  \____| 
 `
 
-// Banner sets a custom app banner.
+// customBanner indicates whether the banner was set by Banner.
+var customBanner bool
+
+// Banner sets a custom app banner. It takes precedence over banner.txt.
 func Banner(banner string) {
 	appBanner = banner
+	customBanner = true
+}
+
+// loadBanner returns the banner to print. Unless Banner was called, it's
+// read from "spring.app.banner.location", or from banner.txt in the local
+// configuration directory if it exists. It returns an empty string if
+// "spring.app.banner.enabled" is false.
+func loadBanner() (string, error) {
+	p, err := new(gs_conf.SysConfig).Refresh()
+	if err != nil {
+		return "", util.FormatError(err, "refresh error in source sys")
+	}
+
+	var c struct {
+		Enabled  bool   `value:"${spring.app.banner.enabled:=true}"`
+		Location string `value:"${spring.app.banner.location:=}"`
+		LocalDir string `value:"${spring.app.config-local.dir:=./conf}"`
+	}
+	if err = p.Bind(&c); err != nil {
+		return "", util.FormatError(err, "bind error in source sys")
+	}
+
+	if !c.Enabled {
+		return "", nil
+	}
+	if customBanner {
+		return appBanner, nil
+	}
+
+	location := c.Location
+	if location == "" {
+		location = filepath.Join(c.LocalDir, "banner.txt")
+	}
+	b, err := os.ReadFile(location)
+	if err != nil {
+		if c.Location == "" && errors.Is(err, fs.ErrNotExist) {
+			return appBanner, nil
+		}
+		return "", util.FormatError(err, "read banner %s error", location)
+	}
+	return string(b), nil
 }
 
 // printBanner prints the app banner.
-func printBanner() {
-	if len(appBanner) == 0 {
-		return
+func printBanner() error {
+	banner, err := loadBanner()
+	if err != nil {
+		return err
+	}
+	if len(banner) == 0 {
+		return nil
 	}
 
 	var sb strings.Builder
-	if appBanner[0] != '\n' {
+	if banner[0] != '\n' {
 		sb.WriteString("\n")
 	}
 
 	maxLength := 0
-	for s := range strings.SplitSeq(appBanner, "\n") {
+	for s := range strings.SplitSeq(banner, "\n") {
 		sb.WriteString("\x1b[36m") // ANSI code for cyan color
 		sb.WriteString(s)
 		sb.WriteString("\x1b[0m\n") // ANSI code to reset color
@@ -55,7 +110,7 @@ func printBanner() {
 		}
 	}
 
-	if appBanner[len(appBanner)-1] != '\n' {
+	if banner[len(banner)-1] != '\n' {
 		sb.WriteString("\n")
 	}
 
@@ -73,4 +128,5 @@ func printBanner() {
 	sb.WriteString(info)
 	sb.WriteString("\n")
 	fmt.Println(sb.String())
+	return nil
 }
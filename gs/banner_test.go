@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+)
+
+func TestLoadBanner(t *testing.T) {
+
+	reset := func(t *testing.T) {
+		banner, custom := appBanner, customBanner
+		t.Cleanup(func() {
+			appBanner, customBanner = banner, custom
+			gs_conf.SysConf = conf.New()
+		})
+		gs_conf.SysConf = conf.New()
+	}
+
+	t.Run("default", func(t *testing.T) {
+		reset(t)
+		Property("spring.app.config-local.dir", t.TempDir())
+		s, err := loadBanner()
+		assert.That(t, err).Nil()
+		assert.String(t, s).Equal(appBanner)
+	})
+
+	t.Run("banner.txt", func(t *testing.T) {
+		reset(t)
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "banner.txt"), []byte("my banner"), 0644)
+		assert.That(t, err).Nil()
+		Property("spring.app.config-local.dir", dir)
+		s, err := loadBanner()
+		assert.That(t, err).Nil()
+		assert.String(t, s).Equal("my banner")
+	})
+
+	t.Run("location", func(t *testing.T) {
+		reset(t)
+		file := filepath.Join(t.TempDir(), "logo.txt")
+		err := os.WriteFile(file, []byte("logo"), 0644)
+		assert.That(t, err).Nil()
+		Property("spring.app.banner.location", file)
+		s, err := loadBanner()
+		assert.That(t, err).Nil()
+		assert.String(t, s).Equal("logo")
+	})
+
+	t.Run("location not found", func(t *testing.T) {
+		reset(t)
+		Property("spring.app.banner.location", filepath.Join(t.TempDir(), "none.txt"))
+		_, err := loadBanner()
+		assert.Error(t, err).Matches("read banner .*none.txt error")
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		reset(t)
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "banner.txt"), []byte("my banner"), 0644)
+		assert.That(t, err).Nil()
+		Property("spring.app.config-local.dir", dir)
+		Banner("custom banner")
+		s, err := loadBanner()
+		assert.That(t, err).Nil()
+		assert.String(t, s).Equal("custom banner")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		reset(t)
+		Property("spring.app.banner.enabled", "false")
+		s, err := loadBanner()
+		assert.That(t, err).Nil()
+		assert.String(t, s).Equal("")
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"context"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/breaker"
+)
+
+// BreakerPrefix is the prefix of the properties declaring the circuit
+// breakers, e.g. "spring.breaker.payment.min-requests=10".
+const BreakerPrefix = "spring.breaker"
+
+func init() {
+	DeclareConfig[map[string]breaker.Config](BreakerPrefix)
+
+	// Registers a circuit breaker bean for each "spring.breaker.<name>"
+	// entry, named after it, publishing its changes of state on the event
+	// bus.
+	Module([]ConditionOnProperty{
+		OnProperty(BreakerPrefix),
+	}, func(p conf.Properties) error {
+		var m map[string]breaker.Config
+		if err := p.Bind(&m, "${"+BreakerPrefix+"}"); err != nil {
+			return err
+		}
+		for name, c := range m {
+			Provide(newBreaker, ValueArg(name), ValueArg(c)).Name(name)
+		}
+		return nil
+	})
+}
+
+// newBreaker creates a circuit breaker publishing its changes of state as
+// breaker.StateChange events.
+func newBreaker(name string, c breaker.Config, pub EventPublisher) *breaker.Breaker {
+	b := breaker.New(name, c)
+	b.OnStateChange = func(e breaker.StateChange) {
+		pub.Publish(context.Background(), e)
+	}
+	return b
+}
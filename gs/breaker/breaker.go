@@ -0,0 +1,270 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package breaker implements circuit breakers, which stop calling a failing
+// dependency for a while so that it gets a chance to recover and callers
+// fail fast instead of piling up.
+//
+// A breaker starts closed, letting all calls through while counting their
+// outcomes over a rolling window. It opens when the ratio of failures in the
+// window reaches FailureThreshold, rejecting all calls with ErrOpen. After
+// OpenTimeout it turns half-open, letting a few trial calls through: their
+// success closes it again, while a failure opens it again.
+package breaker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// ErrOpen is returned for calls rejected by an open breaker.
+var ErrOpen = util.FormatError(nil, "circuit breaker is open")
+
+// State is the state of a breaker.
+type State int
+
+const (
+	StateClosed   State = iota // calls go through
+	StateOpen                  // calls are rejected
+	StateHalfOpen              // a few trial calls go through
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// StateChange is the event of a breaker changing its state.
+type StateChange struct {
+	Name string
+	From State
+	To   State
+}
+
+// Config configures a breaker.
+type Config struct {
+	FailureThreshold float64       `value:"${failure-threshold:=0.5}" desc:"ratio of failures opening the breaker"`
+	MinRequests      int           `value:"${min-requests:=10}" desc:"calls in the window before the breaker may open"`
+	Window           time.Duration `value:"${window:=10s}" desc:"length of the rolling window"`
+	Buckets          int           `value:"${buckets:=10}" desc:"number of buckets of the window"`
+	OpenTimeout      time.Duration `value:"${open-timeout:=30s}" desc:"time spent open before trial calls"`
+	HalfOpenRequests int           `value:"${half-open-requests:=1}" desc:"successful trial calls closing the breaker"`
+}
+
+// bucket counts the outcomes of the calls of a slice of the window.
+type bucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	name string
+	cfg  Config
+
+	// OnStateChange, if set, is called after each change of state, outside
+	// of the lock of the breaker.
+	OnStateChange func(e StateChange)
+
+	mu         sync.Mutex
+	state      State
+	generation uint64 // incremented on each change of state
+	buckets    []bucket
+	openedAt   time.Time
+	trials     int // trial calls in progress while half-open
+	passed     int // successful trial calls while half-open
+}
+
+// New creates a closed breaker.
+func New(name string, cfg Config) *Breaker {
+	cfg.Buckets = max(cfg.Buckets, 1)
+	cfg.HalfOpenRequests = max(cfg.HalfOpenRequests, 1)
+	return &Breaker{
+		name:    name,
+		cfg:     cfg,
+		buckets: make([]bucket, cfg.Buckets),
+	}
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	change := b.expire(time.Now())
+	s := b.state
+	b.mu.Unlock()
+	b.notify(change)
+	return s
+}
+
+// Execute calls f if the breaker lets it through, and records its outcome,
+// a non-nil error being a failure. It returns ErrOpen without calling f if
+// the breaker rejects the call.
+func (b *Breaker) Execute(ctx context.Context, f func(ctx context.Context) error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			done(false)
+			panic(r)
+		}
+	}()
+	err = f(ctx)
+	done(err == nil)
+	return err
+}
+
+// Allow checks whether a call may go through, for callers that cannot wrap
+// it in Execute. On success, done must be called once with the outcome of
+// the call. Otherwise it returns ErrOpen.
+func (b *Breaker) Allow() (done func(success bool), err error) {
+	b.mu.Lock()
+	now := time.Now()
+	change := b.expire(now)
+	switch b.state {
+	case StateOpen:
+		b.mu.Unlock()
+		b.notify(change)
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.trials >= b.cfg.HalfOpenRequests-b.passed {
+			b.mu.Unlock()
+			b.notify(change)
+			return nil, ErrOpen
+		}
+		b.trials++
+	}
+	generation := b.generation
+	b.mu.Unlock()
+	b.notify(change)
+
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() { b.record(generation, success) })
+	}, nil
+}
+
+// record records the outcome of a call allowed in the given generation.
+// Outcomes of calls allowed before the last change of state are ignored.
+func (b *Breaker) record(generation uint64, success bool) {
+	b.mu.Lock()
+	now := time.Now()
+	var change *StateChange
+	if generation == b.generation {
+		switch b.state {
+		case StateClosed:
+			bk := b.bucket(now)
+			if success {
+				bk.successes++
+			} else {
+				bk.failures++
+				if b.tripped(now) {
+					change = b.setState(StateOpen, now)
+				}
+			}
+		case StateHalfOpen:
+			b.trials--
+			if !success {
+				change = b.setState(StateOpen, now)
+			} else if b.passed++; b.passed >= b.cfg.HalfOpenRequests {
+				change = b.setState(StateClosed, now)
+			}
+		}
+	}
+	b.mu.Unlock()
+	b.notify(change)
+}
+
+// expire turns an open breaker half-open once OpenTimeout has elapsed.
+func (b *Breaker) expire(now time.Time) *StateChange {
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return b.setState(StateHalfOpen, now)
+	}
+	return nil
+}
+
+// setState changes the state and resets the counters of the new one.
+func (b *Breaker) setState(s State, now time.Time) *StateChange {
+	change := &StateChange{Name: b.name, From: b.state, To: s}
+	b.state = s
+	b.generation++
+	b.trials, b.passed = 0, 0
+	switch s {
+	case StateOpen:
+		b.openedAt = now
+	case StateClosed:
+		clear(b.buckets)
+	}
+	return change
+}
+
+// notify reports a change of state, if any, to OnStateChange.
+func (b *Breaker) notify(change *StateChange) {
+	if change != nil && b.OnStateChange != nil {
+		b.OnStateChange(*change)
+	}
+}
+
+// bucket returns the bucket of the current slice of the window, resetting
+// it if it holds counts of a previous round.
+func (b *Breaker) bucket(now time.Time) *bucket {
+	size := b.bucketSize()
+	start := now.Truncate(size)
+	bk := &b.buckets[int(start.UnixNano()/int64(size))%len(b.buckets)]
+	if !bk.start.Equal(start) {
+		*bk = bucket{start: start}
+	}
+	return bk
+}
+
+// bucketSize returns the duration covered by each bucket.
+func (b *Breaker) bucketSize() time.Duration {
+	return max(b.cfg.Window/time.Duration(len(b.buckets)), time.Millisecond)
+}
+
+// tripped tells whether the failures in the window open the breaker.
+func (b *Breaker) tripped(now time.Time) bool {
+	since := now.Add(-b.bucketSize() * time.Duration(len(b.buckets)))
+	var total, failures int
+	for _, bk := range b.buckets {
+		if bk.start.After(since) {
+			total += bk.successes + bk.failures
+			failures += bk.failures
+		}
+	}
+	if total == 0 || total < b.cfg.MinRequests {
+		return false
+	}
+	return float64(failures)/float64(total) >= b.cfg.FailureThreshold
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/breaker"
+)
+
+var errBoom = errors.New("boom")
+
+func fail(ctx context.Context) error { return errBoom }
+
+func succeed(ctx context.Context) error { return nil }
+
+func TestConfig(t *testing.T) {
+	var c breaker.Config
+	err := conf.New().Bind(&c)
+	assert.That(t, err).Nil()
+	assert.That(t, c).Equal(breaker.Config{
+		FailureThreshold: 0.5,
+		MinRequests:      10,
+		Window:           10 * time.Second,
+		Buckets:          10,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenRequests: 1,
+	})
+}
+
+func TestBreaker(t *testing.T) {
+
+	t.Run("open and recover", func(t *testing.T) {
+		var changes []breaker.StateChange
+		b := breaker.New("payment", breaker.Config{
+			FailureThreshold: 0.5,
+			MinRequests:      4,
+			Window:           time.Second,
+			Buckets:          10,
+			OpenTimeout:      20 * time.Millisecond,
+			HalfOpenRequests: 2,
+		})
+		b.OnStateChange = func(e breaker.StateChange) {
+			changes = append(changes, e)
+		}
+
+		assert.That(t, b.Execute(t.Context(), succeed)).Nil()
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.Execute(t.Context(), succeed)).Nil()
+		assert.That(t, b.State()).Equal(breaker.StateClosed)
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+
+		called := false
+		err := b.Execute(t.Context(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		assert.That(t, err).Equal(breaker.ErrOpen)
+		assert.That(t, called).False()
+
+		time.Sleep(25 * time.Millisecond)
+		done1, err := b.Allow()
+		assert.That(t, err).Nil()
+		done2, err := b.Allow()
+		assert.That(t, err).Nil()
+		_, err = b.Allow()
+		assert.That(t, err).Equal(breaker.ErrOpen)
+		done1(true)
+		assert.That(t, b.State()).Equal(breaker.StateHalfOpen)
+		done2(true)
+		assert.That(t, b.State()).Equal(breaker.StateClosed)
+
+		assert.That(t, changes).Equal([]breaker.StateChange{
+			{Name: "payment", From: breaker.StateClosed, To: breaker.StateOpen},
+			{Name: "payment", From: breaker.StateOpen, To: breaker.StateHalfOpen},
+			{Name: "payment", From: breaker.StateHalfOpen, To: breaker.StateClosed},
+		})
+	})
+
+	t.Run("trial failure", func(t *testing.T) {
+		b := breaker.New("b", breaker.Config{
+			FailureThreshold: 1,
+			MinRequests:      1,
+			Window:           time.Second,
+			OpenTimeout:      10 * time.Millisecond,
+		})
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+		time.Sleep(15 * time.Millisecond)
+		assert.That(t, b.State()).Equal(breaker.StateHalfOpen)
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+	})
+
+	t.Run("rolling window", func(t *testing.T) {
+		b := breaker.New("b", breaker.Config{
+			FailureThreshold: 0.5,
+			MinRequests:      2,
+			Window:           40 * time.Millisecond,
+			Buckets:          4,
+			OpenTimeout:      time.Second,
+		})
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		time.Sleep(60 * time.Millisecond)
+		// the first failure has left the window
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.State()).Equal(breaker.StateClosed)
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+	})
+
+	t.Run("stale outcome", func(t *testing.T) {
+		b := breaker.New("b", breaker.Config{
+			FailureThreshold: 1,
+			MinRequests:      1,
+			Window:           time.Second,
+			OpenTimeout:      time.Second,
+		})
+		done, err := b.Allow()
+		assert.That(t, err).Nil()
+		assert.That(t, b.Execute(t.Context(), fail)).Equal(errBoom)
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+		done(true) // allowed while closed, ignored once open
+		done(false)
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		b := breaker.New("b", breaker.Config{
+			FailureThreshold: 1,
+			MinRequests:      1,
+			Window:           time.Second,
+			OpenTimeout:      time.Second,
+		})
+		func() {
+			defer func() { _ = recover() }()
+			_ = b.Execute(t.Context(), func(ctx context.Context) error {
+				panic("boom")
+			})
+		}()
+		assert.That(t, b.State()).Equal(breaker.StateOpen)
+	})
+}
+
+func TestState(t *testing.T) {
+	assert.String(t, breaker.StateClosed.String()).Equal("closed")
+	assert.String(t, breaker.StateOpen.String()).Equal("open")
+	assert.String(t, breaker.StateHalfOpen.String()).Equal("half-open")
+	assert.String(t, breaker.State(9).String()).Equal("unknown")
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/breaker"
+)
+
+var breakerEvents struct {
+	sync.Mutex
+	changes []breaker.StateChange
+}
+
+func init() {
+	gs.Property("spring.breaker.payment.min-requests", "1")
+	gs.Property("breaker.enabled", "true") // not a breaker, left to the application
+	gs.Object(gs.FuncEventListener(func(ctx context.Context, event any) {
+		if e, ok := event.(breaker.StateChange); ok {
+			breakerEvents.Lock()
+			defer breakerEvents.Unlock()
+			breakerEvents.changes = append(breakerEvents.changes, e)
+		}
+	})).Export(gs.As[gs.EventListener]())
+	gs.AddTester(&BreakerTester{})
+}
+
+type BreakerTester struct {
+	Payment *breaker.Breaker `autowire:"payment"`
+}
+
+func (b *BreakerTester) TestBreaker(t *testing.T) {
+	assert.String(t, b.Payment.Name()).Equal("payment")
+	err := b.Payment.Execute(t.Context(), func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	assert.Error(t, err).Matches("boom")
+	assert.That(t, b.Payment.State()).Equal(breaker.StateOpen)
+
+	breakerEvents.Lock()
+	defer breakerEvents.Unlock()
+	assert.That(t, breakerEvents.changes).Equal([]breaker.StateChange{
+		{Name: "payment", From: breaker.StateClosed, To: breaker.StateOpen},
+	})
+}
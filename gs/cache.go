@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/cache"
+)
+
+// CachePrefix is the prefix of the properties declaring the caches, e.g.
+// "spring.cache.users.ttl=1m".
+const CachePrefix = "spring.cache"
+
+func init() {
+	// Registers a cache bean for each "spring.cache.<name>" entry, named
+	// after it and exported as cache.Cache, with the store of its type.
+	Module([]ConditionOnProperty{
+		OnProperty(CachePrefix),
+	}, func(p conf.Properties) error {
+		var m map[string]cache.Config
+		if err := p.Bind(&m, "${"+CachePrefix+"}"); err != nil {
+			return err
+		}
+		for name, cfg := range m {
+			bind := func(v any) error {
+				return p.Bind(v, "${"+CachePrefix+"."+name+"}")
+			}
+			c, err := cache.Open(name, cfg, bind)
+			if err != nil {
+				return err
+			}
+			Object(c).Name(name).Export(
+				As[cache.Cache](),
+			).Destroy((*cache.NamedCache).Close)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package cache provides named caches backed by pluggable stores.
+//
+// A Cache adds to its Store a default TTL and GetOrLoad, which loads a
+// missing value once however many callers ask for it at the same time.
+// The "memory" store, an LRU with expiration, is built in; other modules
+// plug in external stores, e.g. Redis, by registering a Driver:
+//
+//	func init() {
+//		cache.Register("redis", func(name string, bind func(v any) error) (cache.Store, error) {
+//			var c struct {
+//				Addr string `value:"${addr:=localhost:6379}"`
+//			}
+//			if err := bind(&c); err != nil {
+//				return nil, err
+//			}
+//			return newRedisStore(c.Addr), nil
+//		})
+//	}
+package cache
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// Store is the storage of a cache, implemented by the drivers. Its methods
+// must be safe for concurrent use. A store may implement io.Closer to
+// release its resources when the cache is closed.
+type Store interface {
+
+	// Get returns the value of the key, and whether it is present.
+	Get(ctx context.Context, key string) (any, bool, error)
+
+	// Set stores the value of the key, expiring after ttl if positive.
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+
+	// Delete removes the key, if present.
+	Delete(ctx context.Context, key string) error
+}
+
+// Driver creates the store of the cache of the given name. bind binds the
+// properties of the cache, e.g. the "spring.cache.<name>.*" ones, into v.
+type Driver func(name string, bind func(v any) error) (Store, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]Driver{}
+)
+
+// Register registers the driver of a type of store, replacing any driver
+// previously registered for the type.
+func Register(typ string, d Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[typ] = d
+}
+
+// Config configures a cache.
+type Config struct {
+	Type string        `value:"${type:=memory}"` // type of the store, see Register
+	TTL  time.Duration `value:"${ttl:=0s}"`      // default TTL of the values, none if not positive
+}
+
+// Cache is a cache of values by key.
+type Cache interface {
+	Store
+
+	// GetOrLoad returns the value of the key, loading and storing it if
+	// missing. Concurrent calls for the same key share a single load.
+	GetOrLoad(ctx context.Context, key string, load func(ctx context.Context) (any, error)) (any, error)
+}
+
+// Open creates the cache of the given name, with a store created by the
+// driver registered for the type of cfg.
+func Open(name string, cfg Config, bind func(v any) error) (*NamedCache, error) {
+	driversMu.RLock()
+	d, ok := drivers[cfg.Type]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, util.FormatError(nil, "unknown cache type %q of cache %s", cfg.Type, name)
+	}
+	s, err := d(name, bind)
+	if err != nil {
+		return nil, util.WrapError(err, "create cache %s error", name)
+	}
+	return New(name, s, cfg), nil
+}
+
+// NamedCache is a Cache backed by a Store.
+type NamedCache struct {
+	name  string
+	store Store
+	ttl   time.Duration
+	group group
+}
+
+// New creates a cache backed by the store, with the TTL of cfg.
+func New(name string, store Store, cfg Config) *NamedCache {
+	return &NamedCache{name: name, store: store, ttl: cfg.TTL}
+}
+
+// Name returns the name of the cache.
+func (c *NamedCache) Name() string {
+	return c.name
+}
+
+// Get returns the value of the key, and whether it is present.
+func (c *NamedCache) Get(ctx context.Context, key string) (any, bool, error) {
+	return c.store.Get(ctx, key)
+}
+
+// Set stores the value of the key, expiring after ttl, or after the
+// default TTL of the cache if ttl is zero.
+func (c *NamedCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	return c.store.Set(ctx, key, value, ttl)
+}
+
+// Delete removes the key, if present.
+func (c *NamedCache) Delete(ctx context.Context, key string) error {
+	return c.store.Delete(ctx, key)
+}
+
+// GetOrLoad returns the value of the key, loading and storing it with the
+// default TTL if missing. Concurrent calls for the same key share a single
+// load, run with the context of the first caller, whose error is returned
+// to all of them and not cached.
+func (c *NamedCache) GetOrLoad(ctx context.Context, key string, load func(ctx context.Context) (any, error)) (any, error) {
+	if v, ok, err := c.store.Get(ctx, key); err != nil || ok {
+		return v, err
+	}
+	return c.group.do(key, func() (any, error) {
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.Set(ctx, key, v, 0); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// Close closes the store if it implements io.Closer.
+func (c *NamedCache) Close() error {
+	if cl, ok := c.store.(io.Closer); ok {
+		return cl.Close()
+	}
+	return nil
+}
+
+// call is a load in progress.
+type call struct {
+	done chan struct{}
+	val  any
+	err  error
+}
+
+// group deduplicates the concurrent loads of the same key.
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// do calls fn for the key, unless a call is in progress for it, in which
+// case it waits for that call and returns its result.
+func (g *group) do(key string, fn func() (any, error)) (any, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.val, c.err
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	c := &call{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.val, c.err = fn()
+	return c.val, c.err
+}
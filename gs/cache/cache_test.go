@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/cache"
+)
+
+func TestMemoryStore(t *testing.T) {
+
+	t.Run("lru", func(t *testing.T) {
+		s := cache.NewMemoryStore(2)
+		ctx := t.Context()
+		assert.That(t, s.Set(ctx, "a", 1, 0)).Nil()
+		assert.That(t, s.Set(ctx, "b", 2, 0)).Nil()
+		_, ok, _ := s.Get(ctx, "a") // "b" becomes the least recently used
+		assert.That(t, ok).True()
+		assert.That(t, s.Set(ctx, "c", 3, 0)).Nil()
+		_, ok, _ = s.Get(ctx, "b")
+		assert.That(t, ok).False()
+		v, ok, _ := s.Get(ctx, "a")
+		assert.That(t, ok).True()
+		assert.That(t, v).Equal(1)
+		assert.That(t, s.Len()).Equal(2)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		s := cache.NewMemoryStore(0)
+		ctx := t.Context()
+		assert.That(t, s.Set(ctx, "a", 1, 10*time.Millisecond)).Nil()
+		_, ok, _ := s.Get(ctx, "a")
+		assert.That(t, ok).True()
+		time.Sleep(15 * time.Millisecond)
+		_, ok, _ = s.Get(ctx, "a")
+		assert.That(t, ok).False()
+		assert.That(t, s.Len()).Equal(0)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		s := cache.NewMemoryStore(0)
+		ctx := t.Context()
+		assert.That(t, s.Set(ctx, "a", 1, 0)).Nil()
+		assert.That(t, s.Delete(ctx, "a")).Nil()
+		assert.That(t, s.Delete(ctx, "a")).Nil()
+		_, ok, _ := s.Get(ctx, "a")
+		assert.That(t, ok).False()
+	})
+}
+
+func TestCache(t *testing.T) {
+
+	t.Run("default ttl", func(t *testing.T) {
+		c := cache.New("users", cache.NewMemoryStore(0), cache.Config{TTL: 10 * time.Millisecond})
+		assert.That(t, c.Set(t.Context(), "a", 1, 0)).Nil()
+		assert.That(t, c.Set(t.Context(), "b", 2, time.Hour)).Nil()
+		time.Sleep(15 * time.Millisecond)
+		_, ok, _ := c.Get(t.Context(), "a")
+		assert.That(t, ok).False()
+		_, ok, _ = c.Get(t.Context(), "b")
+		assert.That(t, ok).True()
+	})
+
+	t.Run("get or load", func(t *testing.T) {
+		c := cache.New("users", cache.NewMemoryStore(0), cache.Config{})
+		var loads atomic.Int32
+		release := make(chan struct{})
+		load := func(ctx context.Context) (any, error) {
+			loads.Add(1)
+			<-release
+			return "alice", nil
+		}
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := c.GetOrLoad(t.Context(), "1", load)
+				assert.That(t, err).Nil()
+				assert.That(t, v).Equal("alice")
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.That(t, loads.Load()).Equal(int32(1))
+
+		v, err := c.GetOrLoad(t.Context(), "1", load)
+		assert.That(t, err).Nil()
+		assert.That(t, v).Equal("alice")
+		assert.That(t, loads.Load()).Equal(int32(1))
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		c := cache.New("users", cache.NewMemoryStore(0), cache.Config{})
+		errLoad := errors.New("load error")
+		_, err := c.GetOrLoad(t.Context(), "1", func(ctx context.Context) (any, error) {
+			return nil, errLoad
+		})
+		assert.That(t, err).Equal(errLoad)
+		_, ok, _ := c.Get(t.Context(), "1")
+		assert.That(t, ok).False()
+	})
+}
+
+// closingStore is a Store recording whether it has been closed.
+type closingStore struct {
+	*cache.MemoryStore
+	closed bool
+}
+
+func (s *closingStore) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestOpen(t *testing.T) {
+	p := conf.Map(map[string]any{
+		"cache.users.max-entries": 1,
+		"cache.users.ttl":         "1m",
+	})
+	bind := func(v any) error {
+		return p.Bind(v, "${cache.users}")
+	}
+
+	t.Run("memory", func(t *testing.T) {
+		var cfg cache.Config
+		assert.That(t, p.Bind(&cfg, "${cache.users}")).Nil()
+		assert.That(t, cfg).Equal(cache.Config{Type: "memory", TTL: time.Minute})
+		c, err := cache.Open("users", cfg, bind)
+		assert.That(t, err).Nil()
+		assert.String(t, c.Name()).Equal("users")
+		assert.That(t, c.Set(t.Context(), "a", 1, 0)).Nil()
+		assert.That(t, c.Set(t.Context(), "b", 2, 0)).Nil()
+		_, ok, _ := c.Get(t.Context(), "a")
+		assert.That(t, ok).False()
+		assert.That(t, c.Close()).Nil()
+	})
+
+	t.Run("driver", func(t *testing.T) {
+		s := &closingStore{MemoryStore: cache.NewMemoryStore(0)}
+		cache.Register("closing", func(name string, bind func(v any) error) (cache.Store, error) {
+			return s, nil
+		})
+		c, err := cache.Open("users", cache.Config{Type: "closing"}, bind)
+		assert.That(t, err).Nil()
+		assert.That(t, c.Close()).Nil()
+		assert.That(t, s.closed).True()
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := cache.Open("users", cache.Config{Type: "redis"}, bind)
+		assert.Error(t, err).Matches(`unknown cache type "redis" of cache users`)
+
+		cache.Register("broken", func(name string, bind func(v any) error) (cache.Store, error) {
+			return nil, errors.New("no connection")
+		})
+		_, err = cache.Open("users", cache.Config{Type: "broken"}, bind)
+		assert.Error(t, err).Matches("create cache users error << no connection")
+	})
+}
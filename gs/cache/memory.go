@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("memory", func(name string, bind func(v any) error) (Store, error) {
+		var c struct {
+			MaxEntries int `value:"${max-entries:=10000}"`
+		}
+		if err := bind(&c); err != nil {
+			return nil, err
+		}
+		return NewMemoryStore(c.MaxEntries), nil
+	})
+}
+
+// entry is a value of a MemoryStore.
+type entry struct {
+	key     string
+	value   any
+	expires time.Time // zero if the value never expires
+}
+
+// MemoryStore is an in-memory Store holding up to a maximum number of
+// entries, evicting the least recently used one when full. Expired
+// entries are removed when accessed or evicted.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List // most recently used at the front
+	items      map[string]*list.Element
+}
+
+// NewMemoryStore creates a MemoryStore holding up to maxEntries entries,
+// or an unlimited number of them if not positive.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value of the key, and whether it is present.
+func (s *MemoryStore) Get(ctx context.Context, key string) (any, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*entry)
+	if !e.expires.IsZero() && !time.Now().Before(e.expires) {
+		s.remove(el)
+		return nil, false, nil
+	}
+	s.ll.MoveToFront(el)
+	return e.value, true, nil
+}
+
+// Set stores the value of the key, expiring after ttl if positive.
+func (s *MemoryStore) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expires = value, expires
+		s.ll.MoveToFront(el)
+		return nil
+	}
+	s.items[key] = s.ll.PushFront(&entry{key: key, value: value, expires: expires})
+	if s.maxEntries > 0 && s.ll.Len() > s.maxEntries {
+		s.remove(s.ll.Back())
+	}
+	return nil
+}
+
+// Delete removes the key, if present.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		s.remove(el)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including the expired ones not yet
+// removed.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ll.Len()
+}
+
+// remove removes the entry of the element.
+func (s *MemoryStore) remove(el *list.Element) {
+	s.ll.Remove(el)
+	delete(s.items, el.Value.(*entry).key)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/cache"
+)
+
+func init() {
+	gs.Property("spring.cache.users.ttl", "1m")
+	gs.Property("cache.type", "redis") // not a cache, left to the application
+	gs.AddTester(&CacheTester{})
+}
+
+type CacheTester struct {
+	Users cache.Cache `autowire:"users"`
+}
+
+func (c *CacheTester) TestCache(t *testing.T) {
+	v, err := c.Users.GetOrLoad(t.Context(), "1", func(ctx context.Context) (any, error) {
+		return "alice", nil
+	})
+	assert.That(t, err).Nil()
+	assert.That(t, v).Equal("alice")
+	v, ok, err := c.Users.Get(t.Context(), "1")
+	assert.That(t, err).Nil()
+	assert.That(t, ok).True()
+	assert.That(t, v).Equal("alice")
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+func init() {
+	// Mounts the management endpoints on the admin server when enabled.
+	// The env and refresh endpoints are opt-in, and refresh, which
+	// changes the application state, also requires authentication.
+	for _, e := range []struct {
+		name     string
+		prop     string
+		optional bool
+		fn       any
+	}{
+		{"infoAdminHandler", EnableAdminInfoProp, true, NewInfoAdminHandler},
+		{"envAdminHandler", EnableAdminEnvProp, false, NewEnvAdminHandler},
+		{"beansAdminHandler", EnableAdminBeansProp, true, NewBeansAdminHandler},
+		{"metricsAdminHandler", EnableAdminMetricsProp, true, NewMetricsAdminHandler},
+		{"refreshAdminHandler", EnableAdminRefreshProp, false, NewRefreshAdminHandler},
+	} {
+		enabled := OnProperty(e.prop).HavingValue("true")
+		if e.optional {
+			enabled = enabled.MatchIfMissing()
+		}
+		Provide(e.fn).Name(e.name).Condition(
+			OnEnableServers(),
+			OnProperty(EnableAdminServerProp).HavingValue("true"),
+			enabled,
+		).Export(
+			As[AdminHandler](),
+		)
+	}
+}
+
+// AdminEndpoint is an AdminHandler serving a single endpoint.
+type AdminEndpoint struct {
+	pattern string
+	fn      http.HandlerFunc
+}
+
+// NewAdminEndpoint creates a new AdminEndpoint serving fn on the pattern.
+func NewAdminEndpoint(pattern string, fn http.HandlerFunc) *AdminEndpoint {
+	return &AdminEndpoint{pattern: pattern, fn: fn}
+}
+
+// Pattern returns the pattern the endpoint is mounted on.
+func (e *AdminEndpoint) Pattern() string {
+	return e.pattern
+}
+
+// ServeHTTP serves the endpoint.
+func (e *AdminEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.fn(w, r)
+}
+
+// InfoDescriptor describes the application, see [NewInfoAdminHandler].
+type InfoDescriptor struct {
+	Spring string            `json:"spring"`
+	Go     string            `json:"go"`
+	Info   map[string]string `json:"info,omitempty"`
+}
+
+// NewInfoAdminHandler creates the "GET /info" endpoint, which returns the
+// versions of Go-Spring and Go, and the properties under "info", e.g.
+// "info.app.name", with the prefix removed.
+func NewInfoAdminHandler() *AdminEndpoint {
+	return NewAdminEndpoint("GET /info", func(w http.ResponseWriter, r *http.Request) {
+		ret := InfoDescriptor{Spring: Version, Go: runtime.Version()}
+		p := app.C.Properties()
+		for _, key := range p.Keys() {
+			name, ok := strings.CutPrefix(key, "info.")
+			if !ok {
+				continue
+			}
+			if ret.Info == nil {
+				ret.Info = make(map[string]string)
+			}
+			ret.Info[name] = p.Get(key)
+			if gs_conf.IsSecretKey(key) {
+				ret.Info[name] = gs_conf.MaskedValue
+			}
+		}
+		writeJSON(w, ret)
+	})
+}
+
+// PropertyDescriptor describes a property of the effective configuration.
+type PropertyDescriptor struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Origin string `json:"origin,omitempty"`
+}
+
+// EnvDescriptor describes the effective configuration.
+type EnvDescriptor struct {
+	ActiveProfiles []string             `json:"activeProfiles"`
+	Sources        []string             `json:"sources"`
+	Properties     []PropertyDescriptor `json:"properties"`
+}
+
+// NewEnvAdminHandler creates the "GET /env" endpoint, which returns the
+// effective configuration, each property with the origin of its value,
+// secrets masked, and the sources merged to build it.
+func NewEnvAdminHandler() *AdminEndpoint {
+	return NewAdminEndpoint("GET /env", func(w http.ResponseWriter, r *http.Request) {
+		report, err := app.P.Report()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		ret := EnvDescriptor{
+			ActiveProfiles: []string{},
+			Sources:        app.P.Sources(),
+			Properties:     []PropertyDescriptor{},
+		}
+		for _, e := range report {
+			ret.Properties = append(ret.Properties, PropertyDescriptor(e))
+			if e.Key == ActiveProfilesProp {
+				for s := range strings.SplitSeq(e.Value, ",") {
+					if s = strings.TrimSpace(s); s != "" {
+						ret.ActiveProfiles = append(ret.ActiveProfiles, s)
+					}
+				}
+			}
+		}
+		writeJSON(w, ret)
+	})
+}
+
+// BeanDescriptor describes a bean wired by the container.
+type BeanDescriptor struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	FileLine     string   `json:"fileLine,omitempty"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// NewBeansAdminHandler creates the "GET /beans" endpoint, which returns
+// the beans wired by the container, in the order they finished wiring,
+// each with the beans injected into it.
+func NewBeansAdminHandler() *AdminEndpoint {
+	return NewAdminEndpoint("GET /beans", func(w http.ResponseWriter, r *http.Request) {
+		g := app.DependencyGraph()
+		if g == nil {
+			http.Error(w, "application is not started", http.StatusServiceUnavailable)
+			return
+		}
+		ret := make([]BeanDescriptor, len(g.Nodes))
+		for i, n := range g.Nodes {
+			ret[i] = BeanDescriptor{
+				Name:         n.Name,
+				Type:         n.Type,
+				FileLine:     n.FileLine,
+				Dependencies: []string{},
+			}
+		}
+		for _, e := range g.Edges {
+			ret[e.From].Dependencies = append(ret[e.From].Dependencies, g.Nodes[e.To].Name)
+		}
+		writeJSON(w, ret)
+	})
+}
+
+// MetricsDescriptor is a snapshot of the application statistics.
+type MetricsDescriptor struct {
+	Phase      string             `json:"phase"`
+	Beans      int                `json:"beans"`
+	Jobs       MetricsJobs        `json:"jobs"`
+	Goroutines MetricsGoroutines  `json:"goroutines"`
+	Runtime    MetricsRuntimeInfo `json:"runtime"`
+}
+
+// MetricsJobs holds the statistics of the application jobs.
+type MetricsJobs struct {
+	Started int64 `json:"started"`
+	Running int64 `json:"running"`
+	Failed  int64 `json:"failed"`
+}
+
+// MetricsGoroutines holds the statistics of the goroutines launched by
+// the goutil package.
+type MetricsGoroutines struct {
+	Started int64 `json:"started"`
+	Running int64 `json:"running"`
+	Panics  int64 `json:"panics"`
+}
+
+// MetricsRuntimeInfo holds the statistics of the Go runtime.
+type MetricsRuntimeInfo struct {
+	Goroutines int    `json:"goroutines"`
+	HeapAlloc  uint64 `json:"heapAlloc"`
+	HeapSys    uint64 `json:"heapSys"`
+	NumGC      uint32 `json:"numGC"`
+}
+
+// NewMetricsAdminHandler creates the "GET /metrics" endpoint, which
+// returns a snapshot of the container, lifecycle, goroutine and runtime
+// statistics as JSON.
+func NewMetricsAdminHandler() *AdminEndpoint {
+	return NewAdminEndpoint("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		ret := MetricsDescriptor{Phase: app.Phase().String()}
+		if app.C.Injecting != nil {
+			ret.Beans = len(app.C.Timings())
+		}
+		jobs := app.JobStats()
+		ret.Jobs = MetricsJobs{Started: jobs.Started, Running: jobs.Running, Failed: jobs.Failed}
+		stats := goutil.ReadStats()
+		ret.Goroutines = MetricsGoroutines{Started: stats.Started, Running: stats.Running, Panics: stats.Panics}
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		ret.Runtime = MetricsRuntimeInfo{
+			Goroutines: runtime.NumGoroutine(),
+			HeapAlloc:  m.HeapAlloc,
+			HeapSys:    m.HeapSys,
+			NumGC:      m.NumGC,
+		}
+		writeJSON(w, ret)
+	})
+}
+
+// NewRefreshAdminHandler creates the "POST /refresh" endpoint, which
+// reloads the application properties from all sources, like
+// App.RefreshProperties, and responds 204 on success. Since it changes
+// the application state, it can only be enabled together with the admin
+// server authentication.
+func NewRefreshAdminHandler(cfg AdminServerConfig) (*AdminEndpoint, error) {
+	if err := requireAuth(cfg, "refresh"); err != nil {
+		return nil, err
+	}
+	return NewAdminEndpoint("POST /refresh", func(w http.ResponseWriter, r *http.Request) {
+		if err := app.RefreshProperties(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}), nil
+}
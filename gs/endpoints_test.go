@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+)
+
+func init() {
+	gs.Property("info.app.name", "demo")
+	gs.Property("info.app.token", "abc")
+}
+
+func TestAdminEndpoints(t *testing.T) {
+
+	serve := func(h gs.AdminHandler, method, path string) *httptest.ResponseRecorder {
+		s := gs.NewAdminServer(gs.AdminServerConfig{}, []gs.AdminHandler{h})
+		r := httptest.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("info", func(t *testing.T) {
+		w := serve(gs.NewInfoAdminHandler(), http.MethodGet, "/info")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		var ret gs.InfoDescriptor
+		assert.That(t, json.Unmarshal(w.Body.Bytes(), &ret)).Nil()
+		assert.That(t, ret).Equal(gs.InfoDescriptor{
+			Spring: gs.Version,
+			Go:     runtime.Version(),
+			Info:   map[string]string{"app.name": "demo", "app.token": "******"},
+		})
+	})
+
+	t.Run("env", func(t *testing.T) {
+		w := serve(gs.NewEnvAdminHandler(), http.MethodGet, "/env")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		var ret gs.EnvDescriptor
+		assert.That(t, json.Unmarshal(w.Body.Bytes(), &ret)).Nil()
+		assert.That(t, slices.Contains(ret.Sources, gs.LayerSys)).True()
+		i := slices.IndexFunc(ret.Properties, func(p gs.PropertyDescriptor) bool {
+			return p.Key == "info.app.token"
+		})
+		assert.That(t, i >= 0).True()
+		assert.String(t, ret.Properties[i].Value).Equal("******")
+		assert.String(t, ret.Properties[i].Origin).Contains("endpoints_test.go")
+	})
+
+	t.Run("beans", func(t *testing.T) {
+		w := serve(gs.NewBeansAdminHandler(), http.MethodGet, "/beans")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		var ret []gs.BeanDescriptor
+		assert.That(t, json.Unmarshal(w.Body.Bytes(), &ret)).Nil()
+		i := slices.IndexFunc(ret, func(b gs.BeanDescriptor) bool {
+			return b.Type == "*gs_test.GreetingTester"
+		})
+		assert.That(t, i >= 0).True()
+		assert.That(t, ret[i].Dependencies).Equal([]string{"GreetingService"})
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		w := serve(gs.NewMetricsAdminHandler(), http.MethodGet, "/metrics")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		var ret gs.MetricsDescriptor
+		assert.That(t, json.Unmarshal(w.Body.Bytes(), &ret)).Nil()
+		assert.String(t, ret.Phase).Equal("running")
+		assert.That(t, ret.Beans > 0).True()
+		assert.That(t, ret.Runtime.Goroutines > 0).True()
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		_, err := gs.NewRefreshAdminHandler(gs.AdminServerConfig{})
+		assert.Error(t, err).Matches("refresh on the admin server requires admin.server.username and admin.server.password")
+
+		h, err := gs.NewRefreshAdminHandler(gs.AdminServerConfig{Username: "admin", Password: "secret"})
+		assert.That(t, err).Nil()
+		assert.That(t, serve(h, http.MethodGet, "/refresh").Code).Equal(http.StatusMethodNotAllowed)
+		assert.That(t, serve(h, http.MethodPost, "/refresh").Code).Equal(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package grpcserver runs a gRPC server in the Go-Spring application
+// lifecycle. Importing it registers a server that serves all the beans
+// exported as [Service], with the interceptor beans chained in order:
+//
+//	import _ "github.com/go-spring/spring-core/gs/grpcserver"
+//
+//	gs.Object(grpcserver.NewService(&pb.Greeter_ServiceDesc, &GreeterImpl{})).
+//		Export(gs.As[grpcserver.Service]())
+//
+// The server is configured by the "grpc.server.*" properties and can be
+// disabled by setting "spring.enable.grpc-server" to false.
+//
+// Unless a service bean serves it already, the server also serves the
+// grpc.health.v1.Health service, which reports the status of the
+// [health.Indicator] beans to the gRPC load balancers and probes. It can
+// be disabled by setting "grpc.server.health.enabled" to false.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/health"
+	"github.com/go-spring/spring-core/util/goutil"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// EnableGrpcServerProp enables or disables the gRPC server.
+const EnableGrpcServerProp = "spring.enable.grpc-server"
+
+func init() {
+	gs.Module([]gs.ConditionOnProperty{
+		gs.OnEnableServers(),
+		gs.OnProperty(EnableGrpcServerProp).HavingValue("true").MatchIfMissing(),
+	}, func(p conf.Properties) error {
+
+		// Provide the gRPC server with all registered services,
+		// interceptors and health indicators, if there is a service to
+		// serve.
+		gs.Provide(
+			NewServer,
+			gs.IndexArg(1, gs.TagArg("?")),
+			gs.IndexArg(2, gs.TagArg("?")),
+			gs.IndexArg(3, gs.TagArg("?")),
+			gs.IndexArg(4, gs.TagArg("?")),
+		).Condition(
+			gs.OnBean[Service](),
+		).AsServer()
+
+		return nil
+	})
+}
+
+// Service is a gRPC service served by the server. The bean itself is
+// registered as the implementation of the service, so it must implement
+// the service interface generated with the descriptor, unless it has
+// been created by [NewService].
+type Service interface {
+	// ServiceDesc returns the descriptor of the service, generated as
+	// pb.<Name>_ServiceDesc.
+	ServiceDesc() *grpc.ServiceDesc
+}
+
+// serviceImpl is implemented by the services whose implementation is
+// another value than themselves.
+type serviceImpl interface {
+	serviceImpl() any
+}
+
+// service is a Service implemented by another value.
+type service struct {
+	desc *grpc.ServiceDesc
+	impl any
+}
+
+// NewService creates a Service described by desc and implemented by impl.
+func NewService(desc *grpc.ServiceDesc, impl any) Service {
+	return &service{desc: desc, impl: impl}
+}
+
+func (s *service) ServiceDesc() *grpc.ServiceDesc { return s.desc }
+func (s *service) serviceImpl() any               { return s.impl }
+
+// ServerConfig holds configuration for the gRPC Server.
+type ServerConfig struct {
+	// Address specifies the TCP address the server listens on.
+	Address string `value:"${grpc.server.addr:=:9095}"`
+
+	// Reflection enables the server reflection service, which lets tools
+	// like grpcurl discover the services.
+	Reflection bool `value:"${grpc.server.reflection:=false}"`
+
+	// KeepaliveTime is the idle time after which the server pings the
+	// client to check that the connection is still alive.
+	KeepaliveTime time.Duration `value:"${grpc.server.keepalive.time:=2h}"`
+
+	// KeepaliveTimeout is the time the server waits for a ping answer
+	// before closing the connection.
+	KeepaliveTimeout time.Duration `value:"${grpc.server.keepalive.timeout:=20s}"`
+
+	// MaxConnectionIdle is the idle time after which a connection is
+	// closed, 0 for no limit.
+	MaxConnectionIdle time.Duration `value:"${grpc.server.keepalive.maxConnectionIdle:=0s}"`
+
+	// MinPingInterval is the minimum interval between the pings of a
+	// client, which is disconnected if it pings more often.
+	MinPingInterval time.Duration `value:"${grpc.server.keepalive.minPingInterval:=5m}"`
+
+	// PermitWithoutStream allows the clients to ping when there is no
+	// active stream.
+	PermitWithoutStream bool `value:"${grpc.server.keepalive.permitWithoutStream:=false}"`
+
+	// Health enables the grpc.health.v1.Health service reporting the
+	// health indicators.
+	Health bool `value:"${grpc.server.health.enabled:=true}"`
+
+	// HealthInterval is the interval at which the health service checks
+	// the health indicators.
+	HealthInterval time.Duration `value:"${grpc.server.health.interval:=5s}"`
+}
+
+// Server wraps a [grpc.Server] to integrate it into the Go-Spring
+// application lifecycle.
+type Server struct {
+	svr    *grpc.Server           // The gRPC server instance.
+	cfg    ServerConfig           // The server configuration.
+	addr   atomic.Pointer[string] // The address bound by ListenAndServe.
+	health *healthService         // The health service, nil if not served.
+	ctx    context.Context        // The context of the health checks.
+	cancel context.CancelFunc     // Stops the health checks.
+}
+
+// NewServer constructs a new Server serving the services, with the unary
+// and stream interceptors chained in the order of the slices, and the
+// health service reporting the indicators and the readiness state of a.
+func NewServer(
+	cfg ServerConfig,
+	services []Service,
+	unary []grpc.UnaryServerInterceptor,
+	stream []grpc.StreamServerInterceptor,
+	indicators []health.Indicator,
+	a *health.Availability,
+) (*Server, error) {
+	svr := grpc.NewServer(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              cfg.KeepaliveTime,
+			Timeout:           cfg.KeepaliveTimeout,
+			MaxConnectionIdle: cfg.MaxConnectionIdle,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.MinPingInterval,
+			PermitWithoutStream: cfg.PermitWithoutStream,
+		}),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	for _, s := range services {
+		desc := s.ServiceDesc()
+		var impl any = s
+		if si, ok := s.(serviceImpl); ok {
+			impl = si.serviceImpl()
+		}
+		// grpc exits the process if the implementation is invalid
+		if desc.HandlerType != nil {
+			ht := reflect.TypeOf(desc.HandlerType).Elem()
+			if !reflect.TypeOf(impl).Implements(ht) {
+				return nil, util.FormatError(nil, "grpc service %s: %T does not implement %s", desc.ServiceName, impl, ht)
+			}
+		}
+		if _, ok := svr.GetServiceInfo()[desc.ServiceName]; ok {
+			return nil, util.FormatError(nil, "grpc service %s registered twice", desc.ServiceName)
+		}
+		svr.RegisterService(desc, impl)
+	}
+	s := &Server{svr: svr, cfg: cfg}
+	if _, ok := svr.GetServiceInfo()[healthpb.Health_ServiceDesc.ServiceName]; !ok && cfg.Health {
+		var names []string
+		for name := range svr.GetServiceInfo() {
+			names = append(names, name)
+		}
+		s.health = newHealthService(names, indicators, a)
+		healthpb.RegisterHealthServer(svr, s.health)
+	}
+	if cfg.Reflection {
+		reflection.Register(svr)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s, nil
+}
+
+// GrpcServer returns the underlying gRPC server.
+func (s *Server) GrpcServer() *grpc.Server {
+	return s.svr
+}
+
+// ListenAndServe starts the gRPC server and blocks until it is stopped.
+// It waits for the given ReadySignal to be triggered before accepting traffic.
+func (s *Server) ListenAndServe(sig gs.ReadySignal) error {
+	ln, err := net.Listen("tcp", s.cfg.Address)
+	if err != nil {
+		return util.FormatError(err, "failed to listen on %s", s.cfg.Address)
+	}
+	addr := ln.Addr().String()
+	s.addr.Store(&addr)
+	if s.health != nil {
+		s.health.update(s.ctx)
+		goutil.Go(s.ctx, func(ctx context.Context) {
+			s.health.run(ctx, s.cfg.HealthInterval)
+		})
+	}
+	<-sig.TriggerAndWait()
+	err = s.svr.Serve(ln)
+	if err == nil || errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return util.FormatError(err, "failed to serve on %s", s.cfg.Address)
+}
+
+// Addr returns the address the server is bound to, or the configured
+// address if it is not listening yet. It may be called while the server
+// starts listening.
+func (s *Server) Addr() string {
+	if addr := s.addr.Load(); addr != nil {
+		return *addr
+	}
+	return s.cfg.Address
+}
+
+// Shutdown gracefully stops the gRPC server: it stops accepting
+// connections and waits for the pending RPCs to complete. If the context
+// ends first, the server is stopped at once and the context error is
+// returned. The health service reports NOT_SERVING from then on.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.cancel()
+	if s.health != nil {
+		s.health.Shutdown()
+	}
+	done := make(chan struct{})
+	go func() {
+		s.svr.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.svr.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
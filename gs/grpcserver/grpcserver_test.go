@@ -0,0 +1,195 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpcserver_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/grpcserver"
+	gshealth "github.com/go-spring/spring-core/gs/health"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// readySignal is a ReadySignal closing listening once the server is
+// listening, then letting it serve at once.
+type readySignal struct {
+	listening chan struct{}
+}
+
+func (s readySignal) TriggerAndWait() <-chan struct{} {
+	close(s.listening)
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+// healthService is a service bean implementing the generated interface.
+type healthService struct {
+	*health.Server
+}
+
+func (healthService) ServiceDesc() *grpc.ServiceDesc {
+	return &healthpb.Health_ServiceDesc
+}
+
+func TestServer(t *testing.T) {
+
+	t.Run("invalid service", func(t *testing.T) {
+		_, err := grpcserver.NewServer(grpcserver.ServerConfig{}, []grpcserver.Service{
+			grpcserver.NewService(&healthpb.Health_ServiceDesc, struct{}{}),
+		}, nil, nil, nil, nil)
+		assert.Error(t, err).Matches("grpc service grpc.health.v1.Health: struct {} does not implement grpc_health_v1.HealthServer")
+	})
+
+	t.Run("duplicate service", func(t *testing.T) {
+		_, err := grpcserver.NewServer(grpcserver.ServerConfig{}, []grpcserver.Service{
+			healthService{health.NewServer()},
+			grpcserver.NewService(&healthpb.Health_ServiceDesc, health.NewServer()),
+		}, nil, nil, nil, nil)
+		assert.Error(t, err).Matches("grpc service grpc.health.v1.Health registered twice")
+	})
+
+	t.Run("serve", func(t *testing.T) {
+		var calls []string
+		intercept := func(name string) grpc.UnaryServerInterceptor {
+			return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				calls = append(calls, name+" "+info.FullMethod)
+				return handler(ctx, req)
+			}
+		}
+		s, err := grpcserver.NewServer(grpcserver.ServerConfig{
+			Address:    "127.0.0.1:0",
+			Reflection: true,
+		}, []grpcserver.Service{
+			healthService{health.NewServer()},
+		}, []grpc.UnaryServerInterceptor{
+			intercept("first"),
+			intercept("second"),
+		}, nil, nil, nil)
+		assert.That(t, err).Nil()
+		assert.That(t, len(s.GrpcServer().GetServiceInfo())).Equal(3)
+
+		sig := readySignal{listening: make(chan struct{})}
+		errCh := make(chan error, 1)
+		go func() { errCh <- s.ListenAndServe(sig) }()
+		<-sig.listening
+
+		conn, err := grpc.NewClient(s.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.That(t, err).Nil()
+		defer func() { _ = conn.Close() }()
+		resp, err := healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{})
+		assert.That(t, err).Nil()
+		assert.That(t, resp.GetStatus()).Equal(healthpb.HealthCheckResponse_SERVING)
+		assert.That(t, calls).Equal([]string{
+			"first /grpc.health.v1.Health/Check",
+			"second /grpc.health.v1.Health/Check",
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.That(t, s.Shutdown(ctx)).Nil()
+		assert.That(t, <-errCh).Nil()
+	})
+	t.Run("health", func(t *testing.T) {
+		var dbDown, echoDown atomic.Bool
+		indicator := func(name string, down *atomic.Bool) gshealth.Indicator {
+			return &gshealth.FuncIndicator{
+				Component: name,
+				Fn: func(ctx context.Context) gshealth.Health {
+					if down.Load() {
+						return gshealth.Down(nil)
+					}
+					return gshealth.Up()
+				},
+			}
+		}
+		a := &gshealth.Availability{}
+		s, err := grpcserver.NewServer(grpcserver.ServerConfig{
+			Address:        "127.0.0.1:0",
+			Health:         true,
+			HealthInterval: 10 * time.Millisecond,
+		}, []grpcserver.Service{
+			grpcserver.NewService(&grpc.ServiceDesc{
+				ServiceName: "test.Echo",
+				HandlerType: (*any)(nil),
+			}, struct{}{}),
+		}, nil, nil, []gshealth.Indicator{
+			indicator("db", &dbDown),
+			indicator("test.Echo", &echoDown),
+		}, a)
+		assert.That(t, err).Nil()
+
+		sig := readySignal{listening: make(chan struct{})}
+		errCh := make(chan error, 1)
+		go func() { errCh <- s.ListenAndServe(sig) }()
+		<-sig.listening
+
+		conn, err := grpc.NewClient(s.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.That(t, err).Nil()
+		defer func() { _ = conn.Close() }()
+		client := healthpb.NewHealthClient(conn)
+		check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+			resp, err := client.Check(t.Context(), &healthpb.HealthCheckRequest{Service: service})
+			assert.That(t, err).Nil()
+			return resp.GetStatus()
+		}
+		waitFor := func(service string, want healthpb.HealthCheckResponse_ServingStatus) {
+			for range 100 {
+				if check(service) == want {
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			t.Fatalf("service %q is not %v", service, want)
+		}
+
+		assert.That(t, check("")).Equal(healthpb.HealthCheckResponse_SERVING)
+		assert.That(t, check("test.Echo")).Equal(healthpb.HealthCheckResponse_SERVING)
+		_, err = client.Check(t.Context(), &healthpb.HealthCheckRequest{Service: "test.Unknown"})
+		assert.That(t, status.Code(err)).Equal(codes.NotFound)
+
+		// a down indicator affects the server, and its own service only
+		dbDown.Store(true)
+		waitFor("", healthpb.HealthCheckResponse_NOT_SERVING)
+		assert.That(t, check("test.Echo")).Equal(healthpb.HealthCheckResponse_SERVING)
+		dbDown.Store(false)
+		echoDown.Store(true)
+		waitFor("test.Echo", healthpb.HealthCheckResponse_NOT_SERVING)
+		echoDown.Store(false)
+		waitFor("test.Echo", healthpb.HealthCheckResponse_SERVING)
+
+		// refusing traffic affects every service
+		a.SetReadiness(gshealth.ReadinessRefusing)
+		waitFor("test.Echo", healthpb.HealthCheckResponse_NOT_SERVING)
+		a.SetReadiness(gshealth.ReadinessAccepting)
+		waitFor("", healthpb.HealthCheckResponse_SERVING)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.That(t, s.Shutdown(ctx)).Nil()
+		assert.That(t, <-errCh).Nil()
+	})
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-spring/spring-core/gs/health"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthService serves grpc_health_v1 with the status of the health
+// indicators, polled at an interval. The server, i.e. the empty service
+// name, reports the status of all the indicators. A service reports the
+// status of the indicators named after it, if any, and that of the server
+// otherwise. Both are NOT_SERVING while the application refuses traffic.
+type healthService struct {
+	*grpchealth.Server
+	services   []string
+	indicators []health.Indicator
+}
+
+// newHealthService creates the health service of the services, reporting
+// the indicators and the readiness state of a.
+func newHealthService(services []string, indicators []health.Indicator, a *health.Availability) *healthService {
+	ready := &health.FuncIndicator{
+		Component: "readinessState",
+		Fn: func(ctx context.Context) health.Health {
+			if a != nil && a.Readiness() == health.ReadinessRefusing {
+				return health.Health{Status: health.StatusOutOfService}
+			}
+			return health.Up()
+		},
+	}
+	return &healthService{
+		Server:     grpchealth.NewServer(),
+		services:   services,
+		indicators: append([]health.Indicator{ready}, indicators...),
+	}
+}
+
+// update sets the serving status of the server and of its services from
+// the current health of the indicators.
+func (h *healthService) update(ctx context.Context) {
+	ret := (&health.Aggregator{Indicators: h.indicators}).Health(ctx)
+	h.SetServingStatus("", servingStatus(ret.Status))
+	ready := ret.Components["readinessState"].Status
+	for _, name := range h.services {
+		s := ret.Status
+		if c, ok := ret.Components[name]; ok {
+			s = health.Aggregate(health.DefaultOrder, ready, c.Status)
+		}
+		h.SetServingStatus(name, servingStatus(s))
+	}
+}
+
+// run updates the serving statuses every interval until ctx is done.
+func (h *healthService) run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			h.update(ctx)
+		}
+	}
+}
+
+// servingStatus returns the serving status reporting the health status,
+// i.e. NOT_SERVING for DOWN and OUT_OF_SERVICE, and SERVING otherwise.
+func servingStatus(s health.Status) healthpb.HealthCheckResponse_ServingStatus {
+	switch s {
+	case health.StatusDown, health.StatusOutOfService:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+		return healthpb.HealthCheckResponse_SERVING
+	}
+}
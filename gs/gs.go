@@ -18,9 +18,11 @@ package gs
 
 import (
 	"context"
+	"flag"
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/go-spring/log"
 	"github.com/go-spring/spring-core/conf"
@@ -29,6 +31,7 @@ import (
 	"github.com/go-spring/spring-core/gs/internal/gs_arg"
 	"github.com/go-spring/spring-core/gs/internal/gs_cond"
 	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
 	"github.com/go-spring/spring-core/gs/internal/gs_dync"
 )
 
@@ -41,6 +44,74 @@ const (
 // It represents a property that can change at runtime.
 type Dync[T any] = gs_dync.Value[T]
 
+// ConfigBean is a bean holding the properties under a prefix bound to T,
+// see [ConfigProperties].
+type ConfigBean[T any] struct {
+	value  gs_dync.Value[T]
+	prefix string
+}
+
+// Value returns the current properties.
+func (c *ConfigBean[T]) Value() T {
+	return c.value.Value()
+}
+
+// Prefix returns the prefix of the properties.
+func (c *ConfigBean[T]) Prefix() string {
+	return c.prefix
+}
+
+// PropertiesTarget returns the value the properties are bound to.
+func (c *ConfigBean[T]) PropertiesTarget() (any, string) {
+	return &c.value, c.prefix
+}
+
+// Lazy is a field type that defers the lookup, and the construction if
+// needed, of the injected beans until its Get method is first called.
+// A field of type func() (T, error) tagged with ",lazy", e.g.
+// `autowire:"?,lazy"`, is instead injected with a provider resolving
+// the beans on each call.
+type Lazy[T any] = gs.Lazy[T]
+
+// Scope determines how many instances of a bean the container creates.
+type Scope = gs.Scope
+
+const (
+	Singleton = gs.ScopeSingleton // One instance for the container
+	Prototype = gs.ScopePrototype // A new instance per injection or Get
+	Request   = gs.ScopeRequest   // One instance per request scope
+)
+
+// Initializing is implemented by beans to be initialized by their
+// PostConstruct method after injection, unless an init function is set.
+type Initializing = gs.Initializing
+
+// BeanPostProcessor processes every bean before and after its
+// initialization, e.g. to wrap it in a proxy. Register it with
+// Export(gs.As[gs.BeanPostProcessor]()).
+type BeanPostProcessor = gs.BeanPostProcessor
+
+// Ordered is implemented by beans to set their position in the injected
+// collections by their Order method, unless .Order(n) is set.
+type Ordered = gs.Ordered
+
+// Disposable is implemented by beans to be destroyed by their PreDestroy
+// method on shutdown, before the beans they depend on, unless a destroy
+// function is set. The time limit is set by spring.destroy-timeout or
+// per bean.
+type Disposable = gs.Disposable
+
+// Factory is a field type producing the beans of type T according to
+// their scope on each call of its Get method.
+type Factory[T any] = gs.Factory[T]
+
+// WithRequestScope returns a context carrying a new request scope for
+// [Factory] and the function ending it, which destroys the beans created
+// in the scope.
+func WithRequestScope(ctx context.Context) (context.Context, func()) {
+	return gs.WithRequestScope(ctx)
+}
+
 // BeanSelector is an alias for gs.BeanSelector used to locate beans
 // within the ioc context.
 type BeanSelector = gs.BeanSelector
@@ -124,6 +195,29 @@ func OnProperty(name string) ConditionOnProperty {
 	return gs_cond.OnProperty(name)
 }
 
+// OnMissingProperty creates a condition that matches if the property is missing.
+func OnMissingProperty(name string) Condition {
+	return gs_cond.OnMissingProperty(name)
+}
+
+// OnProfile creates a condition that matches if any of the profiles,
+// separated by '|' or ',', is active.
+func OnProfile(profiles string) Condition {
+	return gs_cond.OnProfile(profiles)
+}
+
+// OnNotProfile creates a condition that matches if none of the profiles,
+// separated by '|' or ',', is active.
+func OnNotProfile(profiles string) Condition {
+	return gs_cond.OnNotProfile(profiles)
+}
+
+// OnReachable creates a condition that matches if the TCP endpoint
+// accepts a connection within the timeout.
+func OnReachable(addr string, timeout time.Duration) Condition {
+	return gs_cond.OnReachable(addr, timeout)
+}
+
 // OnBean requires that a bean of the given type (and optional name) exists.
 func OnBean[T any](name ...string) Condition {
 	return gs_cond.OnBean[T](name...)
@@ -145,7 +239,8 @@ func RegisterExpressFunc(name string, fn any) {
 	gs_cond.RegisterExpressFunc(name, fn)
 }
 
-// OnExpression creates a condition from an expression.
+// OnExpression creates a condition from a boolean expression of property
+// placeholders, e.g. "${http.server.enabled} && ${env} == 'prod'".
 func OnExpression(expression string) Condition {
 	return gs_cond.OnExpression(expression)
 }
@@ -186,8 +281,35 @@ type (
 	// Server is an alias for gs.Server.
 	Server = gs.Server
 
+	// ErrorPolicy decides what the application does when a runner fails.
+	ErrorPolicy = gs.ErrorPolicy
+
+	// RunnerPolicy is implemented by runners to choose their error policy.
+	RunnerPolicy = gs.RunnerPolicy
+
+	// ServerAddr is implemented by servers that report their address.
+	ServerAddr = gs.ServerAddr
+
 	// ReadySignal represents a signal sent when the application is ready.
 	ReadySignal = gs.ReadySignal
+
+	// EventListener receives events published on the application event bus.
+	EventListener = gs.EventListener
+
+	// FuncEventListener is a function adapter for EventListener.
+	FuncEventListener = gs.FuncEventListener
+
+	// EventPublisher publishes events to the application event bus.
+	EventPublisher = gs.EventPublisher
+
+	// PropertyChange describes a property key changed by a refresh.
+	PropertyChange = gs.PropertyChange
+
+	// ChangeKind tells whether a property key was added, modified or removed.
+	ChangeKind = gs.ChangeKind
+
+	// PropertiesRefreshed is published after each properties refresh.
+	PropertiesRefreshed = gs.PropertiesRefreshed
 )
 
 var (
@@ -198,6 +320,37 @@ var (
 	app = gs_app.NewApp()
 )
 
+func init() {
+	gs_app.SetDefault(app)
+}
+
+// Kinds of property changes, see [PropertyChange].
+const (
+	ChangeAdded    = gs.ChangeAdded
+	ChangeModified = gs.ChangeModified
+	ChangeRemoved  = gs.ChangeRemoved
+)
+
+// Error policies of runners, see [ErrorPolicy].
+const (
+	FailOnError     = gs.FailOnError
+	ContinueOnError = gs.ContinueOnError
+)
+
+// Names of the built-in configuration layers, used to reorder them by
+// Config().SetLayerOrder or to position a custom layer by Config().AddLayer.
+const (
+	LayerSys            = gs_conf.LayerSys
+	LayerLocal          = gs_conf.LayerLocal
+	LayerRemote         = gs_conf.LayerRemote
+	LayerRemoteProp     = gs_conf.LayerRemoteProp
+	LayerRemoteProvider = gs_conf.LayerRemoteProvider
+	LayerDotEnv         = gs_conf.LayerDotEnv
+	LayerEnv            = gs_conf.LayerEnv
+	LayerCmd            = gs_conf.LayerCmd
+	LayerRuntime        = gs_conf.LayerRuntime
+)
+
 // Config returns the current application configuration.
 func Config() *gs_conf.AppConfig {
 	return app.P
@@ -212,13 +365,52 @@ func Property(key string, val string) {
 	}
 }
 
+// BindFlags merges the flags of the parsed flag set into the system
+// properties, using the flag names as the property keys.
+func BindFlags(fs *flag.FlagSet) error {
+	return gs_conf.BindFlags(fs)
+}
+
+// EnvKeyMapper maps the name of an environment variable, with the prefix
+// removed, to a property key. It returns false to skip the variable.
+type EnvKeyMapper = gs_conf.EnvKeyMapper
+
+// SetEnvPrefix sets the prefix of the environment variables that are
+// mapped to properties, "GS_" by default.
+func SetEnvPrefix(prefix string) {
+	gs_conf.SetEnvPrefix(prefix)
+}
+
+// ExcludeEnv skips the environment variables whose names match any of
+// the patterns, in the syntax of [path.Match], e.g. "AWS_*".
+func ExcludeEnv(patterns ...string) error {
+	return gs_conf.ExcludeEnv(patterns...)
+}
+
+// SetEnvKeyMapper sets the function that maps the names of the prefixed
+// environment variables to property keys, e.g. to map
+// "MY_APP_HTTP__SERVER_ADDR" to "http.server-addr". A nil fn restores
+// the default, which replaces '_' with '.' and converts to lowercase.
+func SetEnvKeyMapper(fn EnvKeyMapper) {
+	gs_conf.SetEnvKeyMapper(fn)
+}
+
 // RefreshProperties reloads application properties from all sources.
+// The outcome is published as a [PropertiesRefreshed] event.
 func RefreshProperties() error {
-	p, err := app.P.Refresh()
-	if err != nil {
-		return err
-	}
-	return app.C.RefreshProperties(p)
+	return app.RefreshProperties()
+}
+
+// Subscription is the handle of a callback subscribed by [Listen].
+type Subscription = gs_dync.Subscription
+
+// Listen subscribes fn to the changes of the value of type T bound from
+// the key, e.g. a struct bound from "db" changes with "db.host". After
+// each refresh that changes the value, fn is called with the old and new
+// values, and a panic in fn is recovered. Cancel the subscription to stop
+// listening.
+func Listen[T any](key string, fn func(old, new T)) (*Subscription, error) {
+	return gs_dync.Listen(app.L, key, fn)
 }
 
 // Root registers a root bean in the application context.
@@ -231,6 +423,20 @@ func Object(i any) *gs.RegisteredBean {
 	return app.C.Object(i).Caller(1)
 }
 
+// ConfigProperties registers a bean of type *ConfigBean[T], named after the
+// prefix, holding the properties under the prefix. They are validated by
+// the "validate" and "expr" tags of T when bound, and refreshed when the
+// properties change, e.g.
+//
+//	gs.ConfigProperties[HTTPConfig]("http.server")
+//
+//	type Server struct {
+//		Config *gs.ConfigBean[HTTPConfig] `autowire:""`
+//	}
+func ConfigProperties[T any](prefix string) *gs.RegisteredBean {
+	return app.C.Object(&ConfigBean[T]{prefix: prefix}).Name(prefix).Caller(1)
+}
+
 // Provide registers a bean definition using the provided constructor function.
 func Provide(ctor any, args ...Arg) *gs.RegisteredBean {
 	return app.C.Provide(ctor, args...).Caller(1)
@@ -242,6 +448,45 @@ func Module(conditions []ConditionOnProperty, fn func(p conf.Properties) error)
 	app.C.Module(conditions, fn)
 }
 
+// DeclareConfig declares the properties consumed under the prefix by the
+// value tags of type T, like binding T to "${prefix}". The unknown keys
+// under the claimed prefixes, the prefix by default, and the values that
+// fail to bind are logged or fail the startup and the refreshes, by the
+// property spring.config.schema-check ("warn" by default, "fail" or
+// "none"). An empty prefix declares the full keys of T and claims nothing,
+// see [conf.Schema.Claim].
+func DeclareConfig[T any](prefix string) *conf.Schema {
+	s, err := conf.NewSchema(prefix, reflect.TypeFor[T]())
+	if err != nil {
+		panic(err)
+	}
+	app.AddSchema(s)
+	return s
+}
+
+// DeprecateProperty registers that the property at the deprecated key is
+// renamed to the current key. The deprecated key, and the keys under it,
+// keep working for the current ones while they aren't set, and the ones in
+// use are logged as a warning at startup and on each refresh.
+func DeprecateProperty(deprecated, current string) {
+	conf.RegisterDeprecatedKey(deprecated, current)
+}
+
+// ConfigMarkdown returns the documentation of the properties declared by
+// DeclareConfig as a markdown table: their keys, types, defaults and the
+// descriptions of their "desc" tags.
+func ConfigMarkdown() string {
+	return conf.SchemaMarkdown(app.Schemas())
+}
+
+// ConfigJSONSchema returns a JSON Schema of the properties declared by
+// DeclareConfig, which editors use to complete and check the keys and
+// values of app.yaml, e.g. by the yaml-language-server comment
+// "# yaml-language-server: $schema=<file>".
+func ConfigJSONSchema() ([]byte, error) {
+	return conf.JSONSchema(app.Schemas())
+}
+
 // Group registers a set of beans based on a configuration property map.
 // Each map entry spawns a bean constructed via fn and optionally destroyed via d.
 func Group[T any, R any](tag string, fn func(c T) (R, error), d func(R) error) {
@@ -268,11 +513,34 @@ func Runner(fn func() error) *gs.RegisteredBean {
 	return Object(gs.FuncRunner(fn)).AsRunner().Caller(1)
 }
 
+// RunnerWithPolicy registers a function as a runner bean with its own
+// error policy, e.g. ContinueOnError to log its error instead of failing.
+func RunnerWithPolicy(policy ErrorPolicy, fn func() error) *gs.RegisteredBean {
+	return Object(&gs.PolicyRunner{Runner: gs.FuncRunner(fn), Policy: policy}).AsRunner().Caller(1)
+}
+
 // Job registers a function as a job bean.
 func Job(fn func(ctx context.Context) error) *gs.RegisteredBean {
 	return Object(gs.FuncJob(fn)).AsJob().Caller(1)
 }
 
+// Command registers a function as a command bean. The application runs
+// the command instead of its jobs and servers, and exits with the code
+// returned by the function.
+func Command(fn func(ctx context.Context) (int, error)) *gs.RegisteredBean {
+	return Object(gs.FuncCommand(fn)).AsCommand().Caller(1)
+}
+
+// Listener registers a function as an event listener bean.
+func Listener(fn func(ctx context.Context, event any)) *gs.RegisteredBean {
+	return Object(gs.FuncEventListener(fn)).Export(gs.As[gs.EventListener]()).Caller(1)
+}
+
+// Publish publishes an event on the application event bus.
+func Publish(ctx context.Context, event any) {
+	app.E.Publish(ctx, event)
+}
+
 // Web enables or disables the built-in HTTP server.
 func Web(enable bool) *AppStarter {
 	EnableSimpleHttpServer(enable)
@@ -290,6 +558,55 @@ func RunAsync() (func(), error) {
 	return new(AppStarter).RunAsync()
 }
 
+// Validate checks the configuration and the wiring of the beans without
+// constructing them or starting the servers, and returns all the wiring
+// errors found at once, e.g. in a CI check or a "--validate" command line
+// mode. The application can't be run after being validated.
+func Validate() error {
+	return app.Validate()
+}
+
+// StartupReport is the time spent starting the application by phase, by
+// step of the container refresh and by bean.
+type StartupReport = gs_app.StartupReport
+
+// StartupTimings returns the timing report of the application startup,
+// or nil if the application is not started yet. Set the property
+// spring.app.startup.report=true to log it when the application is ready.
+func StartupTimings() *StartupReport {
+	return app.StartupReport()
+}
+
+// BeanGraph is the dependency graph of the beans, which can be written
+// in the Graphviz DOT language or as JSON.
+type BeanGraph = injecting.Graph
+
+// DependencyGraph returns the dependency graph of the beans wired by the
+// application, or nil if the application is not started yet.
+func DependencyGraph() *BeanGraph {
+	return app.DependencyGraph()
+}
+
+// Get returns the bean of type T, or the collection of beans if T is a
+// slice or map, constructing it on demand if needed. Request scoped beans
+// are got from the request scope of ctx, see [WithRequestScope]. Outside
+// testing, it needs spring.allow-bean-lookup=true, which keeps the bean
+// definitions after the application starts.
+func Get[T any](ctx context.Context) (T, error) {
+	return GetByName[T](ctx, "")
+}
+
+// GetByName returns the bean of type T with the name, see [Get].
+func GetByName[T any](ctx context.Context, name string) (T, error) {
+	v, err := app.GetBean(ctx, reflect.TypeFor[T](), name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	r, _ := v.Interface().(T)
+	return r, nil
+}
+
 // Exiting returns true if the application is shutting down.
 func Exiting() bool {
 	return app.Exiting()
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_arg"
+	"github.com/go-spring/spring-core/gs/internal/gs_cond"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/resolving"
+)
+
+// BenchConfig describes a synthetic container used to measure the
+// performance of the refresh.
+type BenchConfig struct {
+	Beans      int  // number of beans, each depending on the previous one
+	Properties int  // number of properties, bound round-robin to the beans
+	Conditions bool // whether each bean has a property condition
+}
+
+// String returns a short description usable as a benchmark name.
+func (c BenchConfig) String() string {
+	s := fmt.Sprintf("beans=%d,props=%d", c.Beans, c.Properties)
+	if c.Conditions {
+		s += ",cond"
+	}
+	return s
+}
+
+// RefreshStats holds the time spent in each phase of one refresh.
+type RefreshStats struct {
+	Properties time.Duration // building the properties
+	Resolving  time.Duration // registering and resolving the beans
+	Injecting  time.Duration // creating and wiring the beans
+}
+
+// Total returns the time spent in all phases.
+func (s RefreshStats) Total() time.Duration {
+	return s.Properties + s.Resolving + s.Injecting
+}
+
+// Add accumulates the stats of another refresh.
+func (s *RefreshStats) Add(o RefreshStats) {
+	s.Properties += o.Properties
+	s.Resolving += o.Resolving
+	s.Injecting += o.Injecting
+}
+
+// benchBean is the bean type of the synthetic container.
+type benchBean struct {
+	Value string
+	Prev  *benchBean
+}
+
+// newBenchBean is the constructor of the synthetic beans.
+func newBenchBean(value string, prev *benchBean) *benchBean {
+	return &benchBean{Value: value, Prev: prev}
+}
+
+// benchPropKey returns the key of the i-th synthetic property.
+func benchPropKey(i int) string {
+	return fmt.Sprintf("bench.props.p%d", i)
+}
+
+// RunRefresh builds a synthetic container described by cfg, refreshes
+// it once, and returns the time spent in each phase.
+func RunRefresh(cfg BenchConfig) (RefreshStats, error) {
+	var stats RefreshStats
+
+	start := time.Now()
+	m := make(map[string]any, cfg.Properties)
+	for i := range cfg.Properties {
+		m[benchPropKey(i)] = fmt.Sprintf("value-%d", i)
+	}
+	p := conf.Map(m)
+	stats.Properties = time.Since(start)
+
+	start = time.Now()
+	r := resolving.New()
+	for i := range cfg.Beans {
+		valueTag := "${bench.default:=none}"
+		if cfg.Properties > 0 {
+			valueTag = "${" + benchPropKey(i%cfg.Properties) + "}"
+		}
+		var b *gs.RegisteredBean
+		if i == 0 {
+			b = r.Provide(newBenchBean, gs_arg.Tag(valueTag), gs_arg.Value((*benchBean)(nil)))
+		} else {
+			prevTag := fmt.Sprintf("bean-%d", i-1)
+			b = r.Provide(newBenchBean, gs_arg.Tag(valueTag), gs_arg.Tag(prevTag))
+		}
+		b.Name(fmt.Sprintf("bean-%d", i))
+		if cfg.Conditions && cfg.Properties > 0 {
+			b.Condition(gs_cond.OnProperty(benchPropKey(i % cfg.Properties)))
+		}
+		r.Root(b)
+	}
+	if err := r.Refresh(p); err != nil {
+		return stats, err
+	}
+	stats.Resolving = time.Since(start)
+
+	start = time.Now()
+	c := injecting.New(p)
+	if err := c.Refresh(r.Roots(), r.Beans()); err != nil {
+		return stats, err
+	}
+	stats.Injecting = time.Since(start)
+	c.Close()
+	return stats, nil
+}
+
+// BenchmarkRefresh runs RunRefresh b.N times and reports the average
+// time of each phase as custom metrics, e.g. "resolving-ns/op".
+func BenchmarkRefresh(b *testing.B, cfg BenchConfig) {
+	b.Helper()
+	b.ReportAllocs()
+	var total RefreshStats
+	for b.Loop() {
+		stats, err := RunRefresh(cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		total.Add(stats)
+	}
+	n := float64(b.N)
+	b.ReportMetric(float64(total.Properties.Nanoseconds())/n, "properties-ns/op")
+	b.ReportMetric(float64(total.Resolving.Nanoseconds())/n, "resolving-ns/op")
+	b.ReportMetric(float64(total.Injecting.Nanoseconds())/n, "injecting-ns/op")
+}
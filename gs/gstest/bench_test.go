@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+)
+
+func TestRunRefresh(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		cfg := gstest.BenchConfig{Beans: 50, Properties: 10, Conditions: true}
+		assert.String(t, cfg.String()).Equal("beans=50,props=10,cond")
+		stats, err := gstest.RunRefresh(cfg)
+		assert.That(t, err).Nil()
+		assert.That(t, stats.Total() > 0).True()
+		assert.That(t, stats.Total()).Equal(stats.Properties + stats.Resolving + stats.Injecting)
+	})
+
+	t.Run("no properties", func(t *testing.T) {
+		stats, err := gstest.RunRefresh(gstest.BenchConfig{Beans: 10})
+		assert.That(t, err).Nil()
+		assert.That(t, stats.Injecting > 0).True()
+	})
+}
+
+// Run with: go test -run=^$ -bench=Refresh ./gs/gstest
+func BenchmarkRefresh(b *testing.B) {
+	configs := []gstest.BenchConfig{
+		{Beans: 100, Properties: 100},
+		{Beans: 1000, Properties: 1000},
+		{Beans: 1000, Properties: 1000, Conditions: true},
+		{Beans: 100, Properties: 10000},
+	}
+	for _, cfg := range configs {
+		b.Run(cfg.String(), func(b *testing.B) {
+			gstest.BenchmarkRefresh(b, cfg)
+		})
+	}
+}
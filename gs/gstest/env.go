@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"os"
+	"strings"
+)
+
+// activeProfilesProp is the property that holds the active profiles.
+const activeProfilesProp = "spring.profiles.active"
+
+// WithEnv sets a process environment variable for the duration of the
+// test. The previous value is restored when the test finishes. Like
+// [testing.T.Setenv], it must not be used in parallel tests.
+func (h *Harness) WithEnv(key, val string) *Harness {
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, val); err != nil {
+		h.t.Helper()
+		h.t.Fatal(err)
+		return h
+	}
+	h.t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+	return h
+}
+
+// WithArgs replaces the process command-line arguments (excluding the
+// program name) for the duration of the test. Arguments are parsed in
+// the same way as the application does, so properties are passed with
+// the "-D" prefix unless GS_ARGS_PREFIX is set, e.g. "-Da.b=c".
+// It must not be used in parallel tests.
+func (h *Harness) WithArgs(args ...string) *Harness {
+	prev := os.Args
+	var program []string
+	if len(prev) > 0 {
+		program = prev[:1]
+	}
+	os.Args = append(append([]string(nil), program...), args...)
+	h.t.Cleanup(func() {
+		os.Args = prev
+	})
+	return h
+}
+
+// WithProfiles sets the active profiles used when the container is
+// refreshed.
+func (h *Harness) WithProfiles(profiles ...string) *Harness {
+	return h.Property(activeProfilesProp, strings.Join(profiles, ","))
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+)
+
+type Endpoint struct {
+	Host    string `value:"${server.host:=localhost}"`
+	Port    int    `value:"${server.port:=80}"`
+	Profile string `value:"${spring.profiles.active:=}"`
+}
+
+func TestEnvAndArgs(t *testing.T) {
+
+	t.Run("env", func(t *testing.T) {
+		e := &Endpoint{}
+		t.Run("", func(t *testing.T) {
+			h := gstest.New(t).
+				WithEnv("GS_SERVER_HOST", "example.com").
+				WithEnv("GS_SERVER_PORT", "8080")
+			h.Object(e)
+			assert.That(t, h.Refresh()).Nil()
+			assert.String(t, os.Getenv("GS_SERVER_HOST")).Equal("example.com")
+		})
+		assert.String(t, e.Host).Equal("example.com")
+		assert.That(t, e.Port).Equal(8080)
+		_, ok := os.LookupEnv("GS_SERVER_HOST")
+		assert.That(t, ok).False()
+	})
+
+	t.Run("restore env", func(t *testing.T) {
+		_ = os.Setenv("GS_SERVER_HOST", "origin")
+		defer func() { _ = os.Unsetenv("GS_SERVER_HOST") }()
+		t.Run("", func(t *testing.T) {
+			gstest.New(t).WithEnv("GS_SERVER_HOST", "example.com")
+		})
+		assert.String(t, os.Getenv("GS_SERVER_HOST")).Equal("origin")
+	})
+
+	t.Run("args", func(t *testing.T) {
+		args := slices.Clone(os.Args)
+		e := &Endpoint{}
+		t.Run("", func(t *testing.T) {
+			h := gstest.New(t).
+				WithEnv("GS_SERVER_PORT", "8080").
+				WithArgs("-Dserver.host=example.com", "-D", "server.port=9090")
+			h.Object(e)
+			assert.That(t, h.Refresh()).Nil()
+		})
+		assert.String(t, e.Host).Equal("example.com")
+		assert.That(t, e.Port).Equal(9090)
+		assert.That(t, os.Args).Equal(args)
+	})
+
+	t.Run("args prefix", func(t *testing.T) {
+		h := gstest.New(t).
+			WithEnv("GS_ARGS_PREFIX", "--").
+			WithArgs("--server.host=example.com")
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("example.com")
+	})
+
+	t.Run("bad args", func(t *testing.T) {
+		h := gstest.New(t).WithArgs("-D")
+		assert.Error(t, h.Refresh()).Matches("cmd option -D: needs arg")
+	})
+
+	t.Run("profiles", func(t *testing.T) {
+		h := gstest.New(t).WithProfiles("dev", "test")
+		e := &Endpoint{}
+		h.Object(e)
+		h.Object(&RedisCache{}).OnProfiles("test")
+		h.Object(&MemoryCache{}).OnProfiles("prod")
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Profile).Equal("dev,test")
+		gstest.AssertBeanExists[*RedisCache](h)
+		gstest.AssertBeanAbsent[*MemoryCache](h, "")
+	})
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordedEvent is an event captured by a Recorder.
+type RecordedEvent struct {
+	Time  time.Time // when the event was published
+	Event any       // the published event
+}
+
+// Recorder is an event listener that records every event published
+// on the application event bus, together with its timestamp.
+type Recorder struct {
+	mutex  sync.Mutex
+	events []RecordedEvent
+}
+
+// OnEvent records the event.
+func (r *Recorder) OnEvent(ctx context.Context, event any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, RecordedEvent{
+		Time:  time.Now(),
+		Event: event,
+	})
+}
+
+// Events returns a snapshot of the recorded events in publish order.
+func (r *Recorder) Events() []RecordedEvent {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]RecordedEvent(nil), r.events...)
+}
+
+// Reset discards all recorded events.
+func (r *Recorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = nil
+}
+
+// String returns the recorded events in a human-readable form,
+// one event per line.
+func (r *Recorder) String() string {
+	var sb strings.Builder
+	for _, e := range r.Events() {
+		fmt.Fprintf(&sb, "%s %T %v\n", e.Time.Format(time.RFC3339Nano), e.Event, e.Event)
+	}
+	return sb.String()
+}
+
+// Recorder returns the recorder attached to the harness event bus.
+func (h *Harness) Recorder() *Recorder {
+	return h.rec
+}
+
+// Publish publishes an event on the harness event bus.
+func (h *Harness) Publish(ctx context.Context, event any) {
+	h.e.Publish(ctx, event)
+}
+
+// EventsOf returns all recorded events of type T in publish order.
+func EventsOf[T any](h *Harness) []T {
+	var ret []T
+	for _, e := range h.rec.Events() {
+		if v, ok := e.Event.(T); ok {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// AssertEventPublished asserts that at least one event of type T
+// satisfying matcher was published. A nil matcher matches any event
+// of type T.
+func AssertEventPublished[T any](h *Harness, matcher func(T) bool) {
+	h.t.Helper()
+	for _, e := range EventsOf[T](h) {
+		if matcher == nil || matcher(e) {
+			return
+		}
+	}
+	h.t.Errorf("expected event %s to be published\n%s", reflect.TypeFor[T](), h.rec)
+}
+
+// AssertEventNotPublished asserts that no event of type T satisfying
+// matcher was published. A nil matcher matches any event of type T.
+func AssertEventNotPublished[T any](h *Harness, matcher func(T) bool) {
+	h.t.Helper()
+	for _, e := range EventsOf[T](h) {
+		if matcher == nil || matcher(e) {
+			h.t.Errorf("expected event %s not to be published, but found %v", reflect.TypeFor[T](), e)
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+type OrderCreated struct {
+	ID string
+}
+
+type OrderShipped struct {
+	ID string
+}
+
+type OrderService struct {
+	Events gs.EventPublisher `autowire:""`
+}
+
+func (s *OrderService) Create(id string) {
+	s.Events.Publish(context.Background(), OrderCreated{ID: id})
+}
+
+// ShippingListener ships every created order.
+type ShippingListener struct {
+	Events gs.EventPublisher `autowire:""`
+}
+
+func (l *ShippingListener) OnEvent(ctx context.Context, event any) {
+	if e, ok := event.(OrderCreated); ok {
+		l.Events.Publish(ctx, OrderShipped{ID: e.ID})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		h := gstest.New(t)
+		s := &OrderService{}
+		h.Object(s)
+		h.Object(&ShippingListener{}).Export(gs.As[gs.EventListener]())
+		assert.That(t, h.Refresh()).Nil()
+
+		s.Create("1")
+		gstest.AssertEventPublished(h, func(e OrderCreated) bool { return e.ID == "1" })
+		gstest.AssertEventPublished[OrderShipped](h, nil)
+		gstest.AssertEventNotPublished(h, func(e OrderShipped) bool { return e.ID == "2" })
+
+		events := h.Recorder().Events()
+		assert.That(t, len(events)).Equal(2)
+		assert.That(t, events[1].Time.Before(events[0].Time)).False()
+		assert.That(t, gstest.EventsOf[OrderShipped](h)).Equal([]OrderShipped{{ID: "1"}})
+
+		h.Recorder().Reset()
+		assert.That(t, len(h.Recorder().Events())).Equal(0)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		ft := &fakeT{}
+		h := gstest.New(ft)
+		assert.That(t, h.Refresh()).Nil()
+		h.Publish(context.Background(), OrderShipped{ID: "2"})
+
+		gstest.AssertEventPublished[OrderCreated](h, nil)
+		gstest.AssertEventNotPublished[OrderShipped](h, nil)
+		assert.That(t, len(ft.errors)).Equal(2)
+		assert.String(t, ft.errors[0]).Matches(`expected event gstest_test.OrderCreated to be published\n.* gstest_test.OrderShipped \{2}`)
+		assert.String(t, ft.errors[1]).Equal("expected event gstest_test.OrderShipped not to be published, but found {2}")
+	})
+}
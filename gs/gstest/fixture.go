@@ -0,0 +1,197 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+)
+
+// fixture loads a part of the local config of a harness run.
+type fixture func(resolver conf.Properties) ([]*gs_conf.NamedPropertyCopier, error)
+
+// WithConfigDir uses the configuration files in dir as the local config
+// of the harness. Files are looked up like the application does, that is
+// "app.{properties,yaml,yml,toml,tml,json}" followed by the variants of
+// the active profiles, e.g. "app-dev.yaml".
+//
+// Once a fixture is set, the environment variables and command-line
+// arguments are no longer merged, so the test only depends on its
+// fixtures and the properties set on the harness, which override them.
+func (h *Harness) WithConfigDir(dir string) *Harness {
+	info, err := os.Stat(dir)
+	if err == nil && !info.IsDir() {
+		err = util.FormatError(nil, "should be a directory %s", dir)
+	}
+	if err != nil {
+		h.t.Helper()
+		h.t.Fatal(err)
+		return h
+	}
+	h.fixtures = append(h.fixtures, func(resolver conf.Properties) ([]*gs_conf.NamedPropertyCopier, error) {
+		r := conf.New()
+		if err := resolver.CopyTo(r); err != nil {
+			return nil, err
+		}
+		if err := r.Set("spring.app.config-local.dir", dir, r.AddFile("harness")); err != nil {
+			return nil, err
+		}
+		return gs_conf.NewPropertySources(gs_conf.ConfigTypeLocal, "app").LoadFiles(r)
+	})
+	return h
+}
+
+// WithConfigYAML uses the inline YAML as a part of the local config of
+// the harness. Its documents are activated by the profiles of the harness
+// like those of a file, see [conf.Parse]. See [Harness.WithConfigDir] for
+// details.
+func (h *Harness) WithConfigYAML(yaml string) *Harness {
+	name := fmt.Sprintf("inline-%d.yaml", len(h.fixtures))
+	if _, err := conf.Parse([]byte(yaml), ".yaml", name); err != nil {
+		h.t.Helper()
+		h.t.Fatal(err)
+		return h
+	}
+	h.fixtures = append(h.fixtures, func(resolver conf.Properties) ([]*gs_conf.NamedPropertyCopier, error) {
+		profiles, err := activeProfiles(resolver)
+		if err != nil {
+			return nil, err
+		}
+		p, err := conf.Parse([]byte(yaml), ".yaml", name, profiles...)
+		if err != nil {
+			return nil, err
+		}
+		return []*gs_conf.NamedPropertyCopier{gs_conf.NewNamedPropertyCopier(name, p)}, nil
+	})
+	return h
+}
+
+// activeProfiles returns the profiles listed in "spring.profiles.active".
+func activeProfiles(resolver conf.Properties) ([]string, error) {
+	s, err := resolver.Resolve("${" + activeProfilesProp + ":=}")
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	for s := range strings.SplitSeq(s, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			profiles = append(profiles, s)
+		}
+	}
+	return profiles, nil
+}
+
+// WithProperties uses the properties as a part of the local config of the
+// harness, so that a test gets an isolated config without touching the
+// environment variables or the command-line arguments of the process. See
+// [Harness.WithConfigDir] for details.
+func (h *Harness) WithProperties(props map[string]string) *Harness {
+	name := fmt.Sprintf("inline-%d", len(h.fixtures))
+	p := conf.New()
+	fileID := p.AddFile(name)
+	for k, v := range props {
+		if err := p.Set(k, v, fileID); err != nil {
+			h.t.Helper()
+			h.t.Fatal(err)
+			return h
+		}
+	}
+	h.fixtures = append(h.fixtures, func(resolver conf.Properties) ([]*gs_conf.NamedPropertyCopier, error) {
+		return []*gs_conf.NamedPropertyCopier{gs_conf.NewNamedPropertyCopier(name, p)}, nil
+	})
+	return h
+}
+
+// ConfigDir is a temporary directory of configuration files, used as the
+// local config of a harness and removed when the test finishes.
+type ConfigDir struct {
+	h   *Harness
+	dir string
+}
+
+// WithTempConfigDir creates an empty temporary directory used as the local
+// config of the harness, see [Harness.WithConfigDir]. Its files are added
+// with [ConfigDir.File] before the harness is refreshed.
+func (h *Harness) WithTempConfigDir() *ConfigDir {
+	dir, err := os.MkdirTemp("", "gstest-config-")
+	if err != nil {
+		h.t.Helper()
+		h.t.Fatal(err)
+		return &ConfigDir{h: h}
+	}
+	h.t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	h.WithConfigDir(dir)
+	return &ConfigDir{h: h, dir: dir}
+}
+
+// File writes a file of the given name, e.g. "app.yaml" or "app-dev.yaml",
+// and content into the directory.
+func (d *ConfigDir) File(name, content string) *ConfigDir {
+	if err := os.WriteFile(filepath.Join(d.dir, name), []byte(content), 0o644); err != nil {
+		d.h.t.Helper()
+		d.h.t.Fatal(err)
+	}
+	return d
+}
+
+// Dir returns the path of the directory.
+func (d *ConfigDir) Dir() string {
+	return d.dir
+}
+
+// Harness returns the harness using the directory, to go on configuring
+// it.
+func (d *ConfigDir) Harness() *Harness {
+	return d.h
+}
+
+// loadFixtures merges the property defaults of the modules, all fixtures
+// in the order they were added, the properties set on the harness and the
+// remote source into a single properties, so that the properties set
+// explicitly override the fixtures. The fixtures resolve their own config,
+// e.g. the active profiles, from all the harness properties in p.
+func (h *Harness) loadFixtures(p *conf.MutableProperties) (conf.Properties, error) {
+	sources := []*gs_conf.NamedPropertyCopier{
+		gs_conf.NewNamedPropertyCopier("defaults", conf.Map(h.defaults)),
+	}
+	for _, f := range h.fixtures {
+		s, err := f(p)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, s...)
+	}
+	sources = append(sources, gs_conf.NewNamedPropertyCopier("harness", conf.Map(h.props)))
+	if h.remote != nil {
+		sources = append(sources, gs_conf.NewNamedPropertyCopier("remote", h.remote))
+	}
+	out := conf.New()
+	for _, s := range sources {
+		if err := s.CopyTo(out); err != nil {
+			return nil, util.WrapError(err, "merge error in source %s", s.Name)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+)
+
+func TestConfigFixture(t *testing.T) {
+
+	t.Run("dir", func(t *testing.T) {
+		h := gstest.New(t).
+			WithEnv("GS_SERVER_PORT", "9090").
+			WithConfigDir("testdata/conf")
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("fixture.local")
+		assert.That(t, e.Port).Equal(8000)
+	})
+
+	t.Run("dir with profile", func(t *testing.T) {
+		h := gstest.New(t).
+			WithProfiles("dev").
+			WithConfigDir("testdata/conf")
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("fixture.local")
+		assert.That(t, e.Port).Equal(8001)
+	})
+
+	t.Run("inline yaml", func(t *testing.T) {
+		h := gstest.New(t).
+			WithConfigDir("testdata/conf").
+			WithConfigYAML(`
+server:
+  port: 7000
+`)
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("fixture.local")
+		assert.That(t, e.Port).Equal(7000)
+	})
+
+	t.Run("inline yaml profiles", func(t *testing.T) {
+		h := gstest.New(t).
+			WithConfigYAML(`
+server:
+  host: inline.local
+  port: 7000
+---
+spring.config.activate.on-profile: dev
+server:
+  port: 7001
+---
+spring.config.activate.on-profile: prod
+server:
+  port: 7002
+`).
+			WithProfiles("dev")
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("inline.local")
+		assert.That(t, e.Port).Equal(7001)
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		h := gstest.New(t).
+			WithEnv("GS_SERVER_HOST", "env.local").
+			WithProperties(map[string]string{
+				"server.host": "props.local",
+				"server.port": "6000",
+			})
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("props.local")
+		assert.That(t, e.Port).Equal(6000)
+	})
+
+	t.Run("explicit property", func(t *testing.T) {
+		h := gstest.New(t).
+			Property("server.port", 9000).
+			WithConfigDir("testdata/conf")
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("fixture.local")
+		assert.That(t, e.Port).Equal(9000)
+	})
+
+	t.Run("temp dir", func(t *testing.T) {
+		h := gstest.New(t).WithProfiles("dev")
+		d := h.WithTempConfigDir().
+			File("app.yaml", "server:\n  host: temp.local\n  port: 5000\n").
+			File("app-dev.properties", "server.port=5001\n")
+		e := &Endpoint{}
+		h.Object(e)
+		assert.That(t, h.Refresh()).Nil()
+		assert.String(t, e.Host).Equal("temp.local")
+		assert.That(t, e.Port).Equal(5001)
+		assert.That(t, d.Harness()).Equal(h)
+		assert.String(t, d.Dir()).Contains("gstest-config-")
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		h := gstest.New(t).
+			WithConfigYAML("server: 1").
+			WithConfigYAML("server:\n  port: 7000")
+		assert.Error(t, h.Refresh()).Matches("merge error in source inline-1.yaml << property conflict at path server.port")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ft := &fakeT{}
+		gstest.New(ft).WithConfigDir("testdata/none")
+		assert.That(t, ft.fatal).True()
+
+		ft = &fakeT{}
+		gstest.New(ft).WithConfigDir("testdata/conf/app.yaml")
+		assert.String(t, ft.errors[0]).Equal("should be a directory testdata/conf/app.yaml")
+
+		ft = &fakeT{}
+		gstest.New(ft).WithConfigYAML("a: [")
+		assert.String(t, ft.errors[0]).Matches("read yaml error")
+	})
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"reflect"
+
+	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
+)
+
+// Dependencies returns the edges of the wiring graph resolved during
+// refresh. Each edge means that From was injected with To, as a field,
+// a constructor argument, or through DependsOn.
+func (h *Harness) Dependencies() []injecting.Dependency {
+	if h.hung || h.c.Injecting == nil {
+		return nil
+	}
+	return h.c.Dependencies()
+}
+
+// findDependency returns the first edge from a bean of type A to a bean
+// of type B, if any.
+func findDependency[A, B any](h *Harness) (injecting.Dependency, bool) {
+	ta, tb := reflect.TypeFor[A](), reflect.TypeFor[B]()
+	for _, d := range h.Dependencies() {
+		if isBeanMatched(ta, nil, d.From) && isBeanMatched(tb, nil, d.To) {
+			return d, true
+		}
+	}
+	return injecting.Dependency{}, false
+}
+
+// AssertDependsOn asserts that a bean of type A was directly injected
+// with a bean of type B.
+func AssertDependsOn[A, B any](h *Harness) {
+	h.t.Helper()
+	if !h.checkRefreshed() {
+		return
+	}
+	if _, ok := findDependency[A, B](h); !ok {
+		h.t.Errorf("expected %s to depend on %s", reflect.TypeFor[A](), reflect.TypeFor[B]())
+	}
+}
+
+// AssertNoDependency asserts that no bean of type A was directly injected
+// with a bean of type B, e.g. to enforce that handlers do not depend on
+// repositories directly.
+func AssertNoDependency[A, B any](h *Harness) {
+	h.t.Helper()
+	if !h.checkRefreshed() {
+		return
+	}
+	if d, ok := findDependency[A, B](h); ok {
+		h.t.Errorf("expected %s not to depend on %s, but %s depends on %s",
+			reflect.TypeFor[A](), reflect.TypeFor[B](), d.From, d.To)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+type UserRepository struct{}
+
+type UserService struct {
+	Repo *UserRepository `autowire:""`
+}
+
+type UserHandler struct {
+	Service *UserService `autowire:""`
+}
+
+type Handler interface {
+	Handle()
+}
+
+func (h *UserHandler) Handle() {}
+
+type Router struct {
+	Handlers []Handler `autowire:""`
+}
+
+func TestDependencyGraph(t *testing.T) {
+
+	register := func(h *gstest.Harness) {
+		h.Object(&UserRepository{})
+		h.Object(&UserService{})
+		h.Object(&UserHandler{}).Export(gs.As[Handler]())
+		h.Object(&Router{})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		h := gstest.New(t)
+		register(h)
+		assert.That(t, h.Refresh()).Nil()
+
+		gstest.AssertDependsOn[*UserService, *UserRepository](h)
+		gstest.AssertDependsOn[*UserHandler, *UserService](h)
+		gstest.AssertDependsOn[*Router, Handler](h)
+		gstest.AssertNoDependency[*UserHandler, *UserRepository](h)
+		gstest.AssertNoDependency[Handler, *UserRepository](h)
+		assert.That(t, len(h.Dependencies())).Equal(3)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		ft := &fakeT{}
+		h := gstest.New(ft)
+		register(h)
+		assert.That(t, h.Refresh()).Nil()
+
+		gstest.AssertDependsOn[*UserHandler, *UserRepository](h)
+		gstest.AssertNoDependency[*UserService, *UserRepository](h)
+		assert.That(t, len(ft.errors)).Equal(2)
+		assert.String(t, ft.errors[0]).Equal("expected *gstest_test.UserHandler to depend on *gstest_test.UserRepository")
+		assert.String(t, ft.errors[1]).Matches(`expected \*gstest_test.UserService not to depend on \*gstest_test.UserRepository, but name=UserService .* depends on name=UserRepository .*`)
+	})
+
+	t.Run("not refreshed", func(t *testing.T) {
+		ft := &fakeT{}
+		h := gstest.New(ft)
+		assert.That(t, h.Dependencies()).Nil()
+		gstest.AssertNoDependency[*UserService, *UserRepository](h)
+		assert.That(t, ft.fatal).True()
+	})
+}
@@ -0,0 +1,345 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gstest provides a lightweight harness for testing Go-Spring
+// modules and auto-configurations. Each Harness owns an isolated IoC
+// container, so a test can register or mock beans, feed properties,
+// refresh, and then assert which beans were activated and why.
+package gstest
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+	"github.com/go-spring/spring-core/gs/internal/gs_cond"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_core"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/resolving"
+	"github.com/go-spring/spring-core/gs/internal/gs_event"
+)
+
+// TestingT is the subset of [testing.TB] used by the harness.
+type TestingT interface {
+	Helper()
+	Error(args ...any)
+	Errorf(format string, args ...any)
+	Fatal(args ...any)
+	Cleanup(f func())
+}
+
+// Harness boots an isolated IoC container for a single test.
+type Harness struct {
+	t     TestingT
+	c     *gs_core.Container
+	r     *resolving.Resolving // kept for introspection after refresh
+	e     *gs_event.Bus
+	rec   *Recorder
+	props map[string]any
+	done  bool
+
+	imported map[string]bool // names of the imported modules
+	defaults map[string]any  // property defaults of the imported modules
+
+	fixtures []fixture     // local config fixtures, replacing env and args
+	remote   *RemoteSource // fake remote property source
+
+	timeout time.Duration // time limit of Refresh, zero means no limit
+	hung    bool          // whether Refresh timed out
+}
+
+// DefaultRefreshTimeout is the default time limit of [Harness.Refresh].
+const DefaultRefreshTimeout = 30 * time.Second
+
+// New creates a new Harness bound to the given test. The container is
+// closed automatically when the test finishes.
+func New(t TestingT) *Harness {
+	c := gs_core.New()
+	h := &Harness{
+		t:     t,
+		c:     c,
+		r:     c.Resolving,
+		e:     gs_event.New(),
+		rec:   &Recorder{},
+		props: make(map[string]any),
+
+		defaults: make(map[string]any),
+		timeout:  DefaultRefreshTimeout,
+	}
+	h.e.Subscribe(h.rec)
+	c.Object(h.e).Export(gs.As[gs.EventPublisher]())
+	t.Cleanup(h.Close)
+	return h
+}
+
+// Property sets a property used when the container is refreshed.
+func (h *Harness) Property(key string, val any) *Harness {
+	h.props[key] = val
+	return h
+}
+
+// Object registers a pre-constructed instance as a root bean.
+func (h *Harness) Object(i any) *gs.RegisteredBean {
+	b := h.c.Register(gs_bean.NewBean(reflect.ValueOf(i))).Caller(3)
+	h.c.Root(b)
+	return b
+}
+
+// Provide registers a constructor function as a root bean.
+func (h *Harness) Provide(ctor any, args ...gs.Arg) *gs.RegisteredBean {
+	b := h.c.Register(gs_bean.NewBean(ctor, args...)).Caller(3)
+	h.c.Root(b)
+	return b
+}
+
+// WithTimeout sets the time limit of Refresh. When it expires, Refresh
+// returns an error describing the chain of beans being wired, instead of
+// blocking until the whole test times out. Zero disables the limit.
+func (h *Harness) WithTimeout(d time.Duration) *Harness {
+	h.timeout = d
+	return h
+}
+
+// Module registers a conditional module in the container. Beans
+// registered by fn through the harness are treated like any other.
+func (h *Harness) Module(conditions []gs_cond.ConditionOnProperty, fn func(p conf.Properties) error) {
+	h.c.Module(conditions, fn)
+}
+
+// properties merges the harness properties with the remote source, the
+// environment variables and the command-line arguments, in the same order
+// as the application does, so later sources override earlier ones. If any
+// config fixture is set, it is merged instead of the env and args, see
+// loadFixtures. The property defaults of the modules are merged first.
+func (h *Harness) properties() (conf.Properties, error) {
+	props := maps.Clone(h.defaults)
+	maps.Copy(props, h.props)
+	p := conf.Map(props)
+	if h.fixtures != nil {
+		return h.loadFixtures(p)
+	}
+	if err := h.remote.CopyTo(p); err != nil {
+		return nil, util.WrapError(err, "merge error in source remote")
+	}
+	if err := gs_conf.NewEnvironment().CopyTo(p); err != nil {
+		return nil, err
+	}
+	if err := gs_conf.NewCommandArgs().CopyTo(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Refresh resolves and wires all registered beans, then subscribes
+// all EventListener beans to the harness event bus.
+func (h *Harness) Refresh() error {
+	h.done = true
+	p, err := h.properties()
+	if err != nil {
+		return err
+	}
+	if err = h.refresh(p); err != nil {
+		return err
+	}
+	var s struct {
+		Listeners []gs.EventListener `autowire:"?"`
+	}
+	if err := h.c.Wire(&s); err != nil {
+		return err
+	}
+	for _, l := range s.Listeners {
+		h.e.Subscribe(l)
+	}
+	return nil
+}
+
+// refresh refreshes the container within the configured time limit.
+// A panic raised by the container is propagated to the caller.
+func (h *Harness) refresh(p conf.Properties) error {
+	if h.timeout <= 0 {
+		return h.c.Refresh(p)
+	}
+
+	type result struct {
+		err      error
+		panicked bool
+		panicVal any
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		panicked := true
+		defer func() {
+			if panicked {
+				ch <- result{panicked: true, panicVal: recover()}
+			}
+		}()
+		err := h.c.Refresh(p)
+		panicked = false
+		ch <- result{err: err}
+	}()
+
+	timer := time.NewTimer(h.timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-ch:
+		if r.panicked {
+			panic(r.panicVal)
+		}
+		return r.err
+	case <-timer.C:
+		h.hung = true
+		if path := h.c.WiringPath(); path != "" {
+			return util.FormatError(nil, "refresh timed out after %s, wiring ↩\n%s", h.timeout, path)
+		}
+		return util.FormatError(nil, "refresh timed out after %s, resolving beans", h.timeout)
+	}
+}
+
+// Wire injects beans of the refreshed container into obj.
+func (h *Harness) Wire(obj any) error {
+	if h.hung || h.c.Injecting == nil {
+		return util.FormatError(nil, "container is not refreshed")
+	}
+	return h.c.Wire(obj)
+}
+
+// Close releases the resources held by the container. A container whose
+// refresh timed out is left untouched, as it is still being refreshed.
+func (h *Harness) Close() {
+	if !h.hung && h.c.Injecting != nil {
+		h.c.Close()
+	}
+}
+
+// Report returns the condition outcomes recorded during refresh.
+func (h *Harness) Report() []resolving.ConditionOutcome {
+	return h.r.Outcomes()
+}
+
+// String returns the condition report in a human-readable form,
+// one outcome per line.
+func (h *Harness) String() string {
+	var sb strings.Builder
+	for _, o := range h.Report() {
+		sb.WriteString(o.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// isBeanMatched reports whether a bean matches type T and optional name.
+func isBeanMatched(t reflect.Type, name []string, b *gs_bean.BeanDefinition) bool {
+	if len(name) > 0 && name[0] != b.Name() {
+		return false
+	}
+	return t == b.Type() || slices.Contains(b.Exports(), t)
+}
+
+// describe returns a readable description of the selected bean.
+func describe[T any](name []string) string {
+	return gs.BeanSelectorFor[T](name...).(gs.BeanSelectorImpl).String()
+}
+
+// checkRefreshed fails the test if the harness has not been refreshed.
+func (h *Harness) checkRefreshed() bool {
+	h.t.Helper()
+	if !h.done {
+		h.t.Fatal("harness is not refreshed")
+		return false
+	}
+	return true
+}
+
+// AssertBeanExists asserts that exactly one active bean of type T
+// (optionally with the given name) is present in the container.
+func AssertBeanExists[T any](h *Harness, name ...string) {
+	h.t.Helper()
+	if !h.checkRefreshed() {
+		return
+	}
+	t := reflect.TypeFor[T]()
+	var n int
+	for _, b := range h.r.Beans() {
+		if isBeanMatched(t, name, b) {
+			n++
+		}
+	}
+	switch {
+	case n == 0:
+		h.t.Errorf("expected bean %s to exist, but it is absent\n%s", describe[T](name), h)
+	case n > 1:
+		h.t.Errorf("expected a single bean %s, but found %d", describe[T](name), n)
+	}
+}
+
+// AssertBeanAbsent asserts that no active bean of type T (optionally
+// with the given name) is present in the container. If reason is not
+// empty, a condition whose description contains reason must have
+// rejected a bean of type T.
+func AssertBeanAbsent[T any](h *Harness, reason string, name ...string) {
+	h.t.Helper()
+	if !h.checkRefreshed() {
+		return
+	}
+	t := reflect.TypeFor[T]()
+	for _, b := range h.r.Beans() {
+		if isBeanMatched(t, name, b) {
+			h.t.Errorf("expected bean %s to be absent, but found %s", describe[T](name), b)
+			return
+		}
+	}
+	if reason == "" {
+		return
+	}
+	for _, o := range h.Report() {
+		if o.Matched || o.Bean == nil || !isBeanMatched(t, name, o.Bean) {
+			continue
+		}
+		if strings.Contains(fmt.Sprint(o.Condition), reason) {
+			return
+		}
+	}
+	h.t.Errorf("expected bean %s to be rejected by a condition containing %q\n%s", describe[T](name), reason, h)
+}
+
+// AssertConditionMatched asserts that a condition whose description
+// contains cond was evaluated and satisfied for a bean of type T
+// (optionally with the given name).
+func AssertConditionMatched[T any](h *Harness, cond string, name ...string) {
+	h.t.Helper()
+	if !h.checkRefreshed() {
+		return
+	}
+	t := reflect.TypeFor[T]()
+	for _, o := range h.Report() {
+		if !o.Matched || o.Bean == nil || !isBeanMatched(t, name, o.Bean) {
+			continue
+		}
+		if strings.Contains(fmt.Sprint(o.Condition), cond) {
+			return
+		}
+	}
+	h.t.Errorf("expected condition %q to match for bean %s\n%s", cond, describe[T](name), h)
+}
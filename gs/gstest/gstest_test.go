@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/gstest"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_cond"
+)
+
+// fakeT records failures instead of failing the enclosing test.
+type fakeT struct {
+	errors []string
+	fatal  bool
+}
+
+func (t *fakeT) Helper()          {}
+func (t *fakeT) Cleanup(f func()) {}
+
+func (t *fakeT) Error(args ...any) {
+	t.errors = append(t.errors, fmt.Sprint(args...))
+}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Fatal(args ...any) {
+	t.fatal = true
+	t.errors = append(t.errors, fmt.Sprint(args...))
+}
+
+type Cache interface {
+	Get(key string) string
+}
+
+type RedisCache struct{}
+
+func (c *RedisCache) Get(key string) string { return "redis" }
+
+type MemoryCache struct{}
+
+func (c *MemoryCache) Get(key string) string { return "memory" }
+
+type Service struct {
+	Cache Cache `autowire:""`
+}
+
+// register simulates an auto-configuration with two alternatives.
+func register(h *gstest.Harness) {
+	h.Object(&RedisCache{}).
+		Export(gs.As[Cache]()).
+		Condition(gs_cond.OnProperty("cache.type").HavingValue("redis"))
+	h.Object(&MemoryCache{}).
+		Export(gs.As[Cache]()).
+		Condition(gs_cond.OnMissingBean[Cache]())
+	h.Object(&Service{})
+}
+
+func TestHarness(t *testing.T) {
+
+	t.Run("redis", func(t *testing.T) {
+		h := gstest.New(t)
+		register(h)
+		h.Property("cache.type", "redis")
+		assert.That(t, h.Refresh()).Nil()
+
+		gstest.AssertBeanExists[*RedisCache](h)
+		gstest.AssertBeanExists[Cache](h)
+		gstest.AssertConditionMatched[*RedisCache](h, "cache.type")
+		gstest.AssertBeanAbsent[*MemoryCache](h, "OnMissingBean")
+
+		var s struct {
+			Cache Cache `autowire:""`
+		}
+		assert.That(t, h.Wire(&s)).Nil()
+		assert.String(t, s.Cache.Get("")).Equal("redis")
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		h := gstest.New(t)
+		register(h)
+		assert.That(t, h.Refresh()).Nil()
+
+		gstest.AssertBeanExists[*MemoryCache](h)
+		gstest.AssertBeanAbsent[*RedisCache](h, "cache.type")
+		gstest.AssertConditionMatched[*MemoryCache](h, "OnMissingBean")
+	})
+
+	t.Run("module", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Module([]gs_cond.ConditionOnProperty{
+			gs_cond.OnProperty("cache.enabled"),
+		}, func(p conf.Properties) error {
+			h.Object(&MemoryCache{})
+			return nil
+		})
+		assert.That(t, h.Refresh()).Nil()
+		gstest.AssertBeanAbsent[*MemoryCache](h, "")
+		assert.String(t, h.String()).Equal("module: OnProperty(name=cache.enabled) did not match\n")
+	})
+
+	t.Run("wire error", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Object(&Service{})
+		assert.Error(t, h.Refresh()).Matches("can't find bean")
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		ft := &fakeT{}
+		h := gstest.New(ft)
+		gstest.AssertBeanExists[*RedisCache](h)
+		assert.That(t, ft.fatal).True()
+
+		ft = &fakeT{}
+		h = gstest.New(ft)
+		register(h)
+		assert.That(t, h.Refresh()).Nil()
+
+		gstest.AssertBeanExists[*RedisCache](h)
+		gstest.AssertBeanAbsent[*MemoryCache](h, "")
+		gstest.AssertBeanAbsent[*RedisCache](h, "OnMissingBean")
+		gstest.AssertConditionMatched[*RedisCache](h, "cache.type")
+		assert.That(t, len(ft.errors)).Equal(4)
+		assert.String(t, ft.errors[0]).HasPrefix("expected bean {Type:*gstest_test.RedisCache} to exist, but it is absent")
+		assert.String(t, ft.errors[1]).Matches(`to be absent, but found name=MemoryCache .*/gstest_test.go:\d+$`)
+		assert.String(t, ft.errors[2]).HasPrefix("expected bean {Type:*gstest_test.RedisCache} to be rejected by a condition containing \"OnMissingBean\"")
+		assert.String(t, ft.errors[3]).HasPrefix("expected condition \"cache.type\" to match for bean {Type:*gstest_test.RedisCache}")
+	})
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+// Mock replaces the bean of type T, optionally with the given name, by
+// obj when the harness is refreshed, e.g.
+//
+//	gstest.Mock[Repo](h, &fakeRepo{})
+//
+// The bean keeps its name and conditions, and is injected as obj wherever
+// it is wired, so obj must implement the interfaces the bean exports. A
+// mock matching no bean is ignored, while one matching several beans makes
+// Refresh fail, even if the conditions of all but one of them fail, since
+// mocks are applied before the conditions are evaluated.
+func Mock[T any](h *Harness, obj T, name ...string) *Harness {
+	h.c.AddMock(gs.BeanMock{
+		Object: obj,
+		Target: gs.BeanSelectorFor[T](name...),
+	})
+	return h
+}
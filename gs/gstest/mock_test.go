@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+type FakeCache struct{}
+
+func (c *FakeCache) Get(key string) string { return "fake" }
+
+func TestMock(t *testing.T) {
+
+	t.Run("by type", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Object(&MemoryCache{}).Export(gs.As[Cache]())
+		h.Object(&Service{})
+		gstest.Mock[Cache](h, &FakeCache{})
+		assert.That(t, h.Refresh()).Nil()
+
+		var s struct {
+			Service *Service `autowire:""`
+		}
+		assert.That(t, h.Wire(&s)).Nil()
+		assert.String(t, s.Service.Cache.Get("k")).Equal("fake")
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Object(&RedisCache{}).Name("redis").Export(gs.As[Cache]())
+		h.Object(&MemoryCache{}).Name("memory").Export(gs.As[Cache]())
+		gstest.Mock[Cache](h, &FakeCache{}, "memory")
+		assert.That(t, h.Refresh()).Nil()
+
+		var s struct {
+			Redis  Cache `autowire:"redis"`
+			Memory Cache `autowire:"memory"`
+		}
+		assert.That(t, h.Wire(&s)).Nil()
+		assert.String(t, s.Redis.Get("k")).Equal("redis")
+		assert.String(t, s.Memory.Get("k")).Equal("fake")
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Object(&RedisCache{}).Name("redis").Export(gs.As[Cache]())
+		h.Object(&MemoryCache{}).Name("memory").Export(gs.As[Cache]())
+		gstest.Mock[Cache](h, &FakeCache{})
+		assert.Error(t, h.Refresh()).Matches("found duplicate mocked beans")
+	})
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs"
+)
+
+// Import imports the modules into the container like [gs.Import] does,
+// except that the property defaults are set as harness properties. The
+// beans of the modules are registered as root beans.
+func (h *Harness) Import(modules ...gs.AppModule) *Harness {
+	for _, m := range modules {
+		if !h.addModule(m) {
+			continue
+		}
+		h.Module(m.Conditions, func(p conf.Properties) error {
+			return m.Install(h, p)
+		})
+	}
+	return h
+}
+
+// AutoConfigure registers the module as an auto-configuration like
+// [gs.AutoConfigure] does, see [Harness.Import].
+func (h *Harness) AutoConfigure(m gs.AppModule, conditions ...gs.Condition) *Harness {
+	if !h.addModule(m) {
+		return h
+	}
+	var arr []gs.Condition
+	for _, c := range m.Conditions {
+		arr = append(arr, c)
+	}
+	arr = append(arr, conditions...)
+	h.c.AutoConfig(m.Name, arr, func(p conf.Properties) error {
+		return m.Install(h, p)
+	})
+	return h
+}
+
+// AutoConfigReport returns the auto-configurations evaluated during
+// refresh, and whether they matched.
+func (h *Harness) AutoConfigReport() []gs.AutoConfigOutcome {
+	return h.r.AutoConfigOutcomes()
+}
+
+// addModule records the name of the module and sets its property
+// defaults, and returns false if the module is added already.
+func (h *Harness) addModule(m gs.AppModule) bool {
+	if h.imported == nil {
+		h.imported = make(map[string]bool)
+	}
+	if h.imported[m.Name] {
+		return false
+	}
+	h.imported[m.Name] = true
+	for key, val := range m.Properties {
+		if _, ok := h.defaults[key]; !ok {
+			h.defaults[key] = val
+		}
+	}
+	return true
+}
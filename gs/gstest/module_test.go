@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/gstest"
+)
+
+type RedisClient struct {
+	Addr string `value:"${redis.addr}"`
+}
+
+func redisModule(stopped *bool) gs.AppModule {
+	return gs.AppModule{
+		Name:       "redis",
+		Properties: map[string]string{"redis.addr": "127.0.0.1:6379"},
+		Conditions: []gs.ConditionOnProperty{
+			gs.OnProperty("redis.enabled").HavingValue("true").MatchIfMissing(),
+		},
+		Register: func(r gs.Registrar, p conf.Properties) error {
+			r.Object(&RedisClient{})
+			return nil
+		},
+		OnStop: func() error {
+			*stopped = true
+			return nil
+		},
+	}
+}
+
+func TestImport(t *testing.T) {
+
+	t.Run("defaults", func(t *testing.T) {
+		stopped := false
+		h := gstest.New(t).Import(redisModule(&stopped), redisModule(&stopped))
+		assert.That(t, h.Refresh()).Nil()
+		gstest.AssertBeanExists[*RedisClient](h)
+
+		var s struct {
+			Client *RedisClient `autowire:""`
+		}
+		assert.That(t, h.Wire(&s)).Nil()
+		assert.That(t, s.Client.Addr).Equal("127.0.0.1:6379")
+
+		h.Close()
+		assert.That(t, stopped).True()
+	})
+
+	t.Run("override", func(t *testing.T) {
+		stopped := false
+		h := gstest.New(t).Property("redis.addr", "10.0.0.1:6379")
+		h.Import(redisModule(&stopped))
+		assert.That(t, h.Refresh()).Nil()
+
+		var s struct {
+			Client *RedisClient `autowire:""`
+		}
+		assert.That(t, h.Wire(&s)).Nil()
+		assert.That(t, s.Client.Addr).Equal("10.0.0.1:6379")
+	})
+
+	t.Run("fixture", func(t *testing.T) {
+		stopped := false
+		h := gstest.New(t).WithConfigYAML("redis:\n  addr: 10.0.0.2:6379")
+		h.Import(redisModule(&stopped))
+		assert.That(t, h.Refresh()).Nil()
+
+		var s struct {
+			Client *RedisClient `autowire:""`
+		}
+		assert.That(t, h.Wire(&s)).Nil()
+		assert.That(t, s.Client.Addr).Equal("10.0.0.2:6379")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		stopped := false
+		h := gstest.New(t).Property("redis.enabled", "false")
+		h.Import(redisModule(&stopped))
+		assert.That(t, h.Refresh()).Nil()
+		gstest.AssertBeanAbsent[*RedisClient](h, "")
+		assert.String(t, h.String()).Contains("module: OnProperty(name=redis.enabled, havingValue=true, matchIfMissing) did not match")
+		h.Close()
+		assert.That(t, stopped).False()
+	})
+
+	t.Run("error", func(t *testing.T) {
+		h := gstest.New(t).Import(gs.AppModule{
+			Name: "broken",
+			Register: func(r gs.Registrar, p conf.Properties) error {
+				return errors.New("no driver")
+			},
+		})
+		assert.Error(t, h.Refresh()).Matches("install module broken error: no driver")
+	})
+}
+
+type MemoryStore struct{}
+
+func TestAutoConfigure(t *testing.T) {
+
+	storeModule := gs.AppModule{
+		Name: "store",
+		Register: func(r gs.Registrar, p conf.Properties) error {
+			r.Object(&MemoryStore{})
+			return nil
+		},
+	}
+
+	t.Run("matched", func(t *testing.T) {
+		h := gstest.New(t).AutoConfigure(storeModule, gs.OnMissingBean[*MemoryStore]())
+		assert.That(t, h.Refresh()).Nil()
+		gstest.AssertBeanExists[*MemoryStore](h)
+		assert.String(t, fmt.Sprint(h.AutoConfigReport())).Equal("[auto-config store: matched]")
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Object(&MemoryStore{})
+		h.AutoConfigure(storeModule, gs.OnMissingBean[*MemoryStore]())
+		assert.That(t, h.Refresh()).Nil()
+		report := h.AutoConfigReport()
+		assert.That(t, len(report)).Equal(1)
+		assert.That(t, report[0].Matched).False()
+		assert.String(t, report[0].String()).Equal("auto-config store: OnMissingBean(selector={Type:*gstest_test.MemoryStore}) did not match")
+		assert.String(t, h.String()).Contains("auto-config store: OnMissingBean(selector={Type:*gstest_test.MemoryStore}) did not match")
+	})
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// RemoteSource is a fake remote property source. Like the remote
+// properties of the application, it overrides the local config but is
+// overridden by environment variables and command-line arguments.
+type RemoteSource struct {
+	h     *Harness
+	push  sync.Mutex // serializes the pushes
+	mutex sync.Mutex // guards data
+	data  map[string]string
+}
+
+// Remote returns the fake remote property source of the harness,
+// installing it on first use.
+func (h *Harness) Remote() *RemoteSource {
+	if h.remote == nil {
+		h.remote = &RemoteSource{h: h}
+	}
+	return h.remote
+}
+
+// Push replaces the content of the remote source with data, as a config
+// server pushing a new snapshot would. If the container is already
+// refreshed, the new properties are applied through the dynamic refresh
+// pipeline, updating all dynamic values bound to changed keys.
+func (r *RemoteSource) Push(data map[string]string) error {
+	r.push.Lock()
+	defer r.push.Unlock()
+	r.mutex.Lock()
+	r.data = maps.Clone(data)
+	r.mutex.Unlock()
+	if r.h.c.Injecting == nil || r.h.hung {
+		return nil
+	}
+	p, err := r.h.properties()
+	if err != nil {
+		return err
+	}
+	return r.h.c.RefreshProperties(p)
+}
+
+// CopyTo copies the remote properties into out.
+func (r *RemoteSource) CopyTo(out *conf.MutableProperties) error {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.data) == 0 {
+		return nil
+	}
+	fileID := out.AddFile("remote")
+	for _, k := range util.OrderedMapKeys(r.data) {
+		if err := out.Set(k, r.data[k], fileID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
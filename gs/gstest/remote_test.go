@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/gstest"
+	"github.com/go-spring/spring-core/gs/internal/gs_dync"
+)
+
+type RateLimiter struct {
+	Limit gs_dync.Value[int]    `value:"${limiter.limit:=10}"`
+	Name  gs_dync.Value[string] `value:"${limiter.name:=default}"`
+}
+
+func TestRemoteSource(t *testing.T) {
+
+	t.Run("push", func(t *testing.T) {
+		h := gstest.New(t)
+		r := &RateLimiter{}
+		h.Object(r)
+		assert.That(t, h.Remote().Push(map[string]string{
+			"limiter.limit": "20",
+		})).Nil()
+		assert.That(t, h.Refresh()).Nil()
+		assert.That(t, r.Limit.Value()).Equal(20)
+
+		l := r.Limit.NewListener()
+		assert.That(t, h.Remote().Push(map[string]string{
+			"limiter.limit": "30",
+			"limiter.name":  "remote",
+		})).Nil()
+		assert.That(t, r.Limit.Value()).Equal(30)
+		assert.String(t, r.Name.Value()).Equal("remote")
+
+		select {
+		case <-l.C:
+		default:
+			t.Fatal("listener is not notified")
+		}
+
+		// keys removed from the snapshot fall back to their defaults
+		assert.That(t, h.Remote().Push(nil)).Nil()
+		assert.That(t, r.Limit.Value()).Equal(10)
+		assert.String(t, r.Name.Value()).Equal("default")
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		h := gstest.New(t).
+			Property("limiter.name", "local").
+			WithEnv("GS_LIMITER_LIMIT", "40")
+		r := &RateLimiter{}
+		h.Object(r)
+		assert.That(t, h.Refresh()).Nil()
+		assert.That(t, h.Remote().Push(map[string]string{
+			"limiter.limit": "30",
+			"limiter.name":  "remote",
+		})).Nil()
+		assert.That(t, r.Limit.Value()).Equal(40)
+		assert.String(t, r.Name.Value()).Equal("remote")
+	})
+
+	t.Run("fixture", func(t *testing.T) {
+		h := gstest.New(t).WithConfigYAML("limiter:\n  limit: 5")
+		r := &RateLimiter{}
+		h.Object(r)
+		assert.That(t, h.Refresh()).Nil()
+		assert.That(t, r.Limit.Value()).Equal(5)
+		assert.That(t, h.Remote().Push(map[string]string{
+			"limiter.limit": "6",
+		})).Nil()
+		assert.That(t, r.Limit.Value()).Equal(6)
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		h := gstest.New(t)
+		r := &RateLimiter{}
+		h.Object(r)
+		assert.That(t, h.Refresh()).Nil()
+		err := h.Remote().Push(map[string]string{
+			"limiter.limit": "abc",
+		})
+		assert.Error(t, err).Matches("strconv.ParseInt: parsing \"abc\": invalid syntax")
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		h := gstest.New(t).Property("limiter", "1")
+		_ = h.Remote().Push(map[string]string{"limiter.limit": "6"})
+		assert.Error(t, h.Refresh()).Matches("merge error in source remote << property conflict at path limiter.limit")
+	})
+
+	t.Run("concurrent copy", func(t *testing.T) {
+		r := gstest.New(t).Remote()
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = r.Push(map[string]string{"limiter.limit": strconv.Itoa(i)})
+			}()
+			go func() {
+				defer wg.Done()
+				assert.That(t, r.CopyTo(conf.New())).Nil()
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gstest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/gstest"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_cond"
+)
+
+type Database struct{}
+
+type Repository struct {
+	DB *Database `autowire:""`
+}
+
+func TestRefreshTimeout(t *testing.T) {
+
+	t.Run("wiring", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		h := gstest.New(t).WithTimeout(50 * time.Millisecond)
+		h.Object(&Repository{})
+		h.Provide(func() *Database {
+			<-block
+			return &Database{}
+		}).Name("database")
+		err := h.Refresh()
+		assert.Error(t, err).Matches(`refresh timed out after 50ms, wiring ↩
+=> name=Repository .*/timeout_test.go:\d+ ↩
+=> name=database .*/timeout_test.go:\d+ ↩$`)
+		assert.Error(t, h.Wire(&Repository{})).Matches("container is not refreshed")
+	})
+
+	t.Run("resolving", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		h := gstest.New(t).WithTimeout(50 * time.Millisecond)
+		h.Object(&Database{}).Condition(gs_cond.OnFunc(func(ctx gs.ConditionContext) (bool, error) {
+			<-block
+			return true, nil
+		}))
+		err := h.Refresh()
+		assert.Error(t, err).Matches("refresh timed out after 50ms, resolving beans")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		h := gstest.New(t)
+		h.Provide(func() *Database {
+			panic("constructor panic")
+		})
+		assert.Panic(t, func() {
+			_ = h.Refresh()
+		}, "constructor panic")
+	})
+
+	t.Run("no timeout", func(t *testing.T) {
+		h := gstest.New(t).WithTimeout(0)
+		h.Object(&Database{})
+		h.Object(&Repository{})
+		assert.That(t, h.Refresh()).Nil()
+		gstest.AssertBeanExists[*Database](h)
+	})
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-spring/spring-core/gs/health"
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+)
+
+// availability holds the liveness and readiness states of the application,
+// injectable as a *health.Availability bean.
+var availability = &health.Availability{}
+
+func init() {
+	Object(availability).Name("availability")
+
+	// Mounts the health endpoints on the admin server when enabled.
+	for name, pattern := range map[string]string{
+		"healthAdminHandler":      "GET /health",
+		"healthGroupAdminHandler": "GET /health/{group}",
+	} {
+		Provide(
+			func(indicators []health.Indicator, a *health.Availability) *HealthAdminHandler {
+				return NewHealthAdminHandler(pattern, indicators, a, appRunning)
+			},
+			IndexArg(0, TagArg("?")),
+		).Name(name).Condition(
+			OnEnableServers(),
+			OnProperty(EnableAdminServerProp).HavingValue("true"),
+			OnProperty(EnableAdminHealthProp).HavingValue("true").MatchIfMissing(),
+		).Export(
+			As[AdminHandler](),
+		)
+	}
+}
+
+// appRunning returns whether the application is running.
+func appRunning() bool {
+	return app.Phase() == gs_app.PhaseRunning
+}
+
+// HealthAdminHandler serves the health endpoints on the admin server:
+//
+//	GET /health            the health of the application and its components
+//	GET /health/liveness   UP unless the liveness state is BROKEN
+//	GET /health/readiness  UP if the application is running, accepting
+//	                       traffic, and all the indicators are UP
+//
+// An endpoint responds 503 if its status is DOWN or OUT_OF_SERVICE.
+type HealthAdminHandler struct {
+	*http.ServeMux
+	pattern   string
+	liveness  *health.Aggregator
+	readiness *health.Aggregator
+	all       *health.Aggregator
+}
+
+// NewHealthAdminHandler creates a new HealthAdminHandler mounted on the
+// pattern, reporting the indicators and the availability states. The
+// readiness is refused while running returns false, i.e. while the
+// application starts or stops.
+func NewHealthAdminHandler(pattern string, indicators []health.Indicator, a *health.Availability, running func() bool) *HealthAdminHandler {
+	live := &livenessIndicator{a: a}
+	ready := &readinessIndicator{a: a, running: running}
+	h := &HealthAdminHandler{
+		ServeMux:  http.NewServeMux(),
+		pattern:   pattern,
+		liveness:  &health.Aggregator{Indicators: []health.Indicator{live}},
+		readiness: &health.Aggregator{Indicators: append([]health.Indicator{ready}, indicators...)},
+		all:       &health.Aggregator{Indicators: append([]health.Indicator{live, ready}, indicators...)},
+	}
+	h.HandleFunc("GET /health", h.serve(h.all))
+	h.HandleFunc("GET /health/liveness", h.serve(h.liveness))
+	h.HandleFunc("GET /health/readiness", h.serve(h.readiness))
+	return h
+}
+
+// Pattern returns the pattern the handler is mounted on.
+func (h *HealthAdminHandler) Pattern() string {
+	return h.pattern
+}
+
+// serve returns the handler responding with the health of the aggregator.
+func (h *HealthAdminHandler) serve(a *health.Aggregator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ret := a.Health(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(ret.Status.HTTPStatus())
+		_ = json.NewEncoder(w).Encode(ret)
+	}
+}
+
+// livenessIndicator reports the liveness state as a component.
+type livenessIndicator struct {
+	a *health.Availability
+}
+
+func (i *livenessIndicator) Name() string {
+	return "livenessState"
+}
+
+func (i *livenessIndicator) Health(ctx context.Context) health.Health {
+	s := i.a.Liveness()
+	h := health.Health{Status: health.StatusUp, Details: map[string]any{"state": s}}
+	if s == health.LivenessBroken {
+		h.Status = health.StatusDown
+	}
+	return h
+}
+
+// readinessIndicator reports the readiness state as a component, refusing
+// traffic while the application is not running.
+type readinessIndicator struct {
+	a       *health.Availability
+	running func() bool
+}
+
+func (i *readinessIndicator) Name() string {
+	return "readinessState"
+}
+
+func (i *readinessIndicator) Health(ctx context.Context) health.Health {
+	s := i.a.Readiness()
+	if !i.running() {
+		s = health.ReadinessRefusing
+	}
+	h := health.Health{Status: health.StatusUp, Details: map[string]any{"state": s}}
+	if s == health.ReadinessRefusing {
+		h.Status = health.StatusOutOfService
+	}
+	return h
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package health reports the health of the application, combined from the
+// health of its components. Beans implementing [Indicator] are collected
+// by the application and served on the admin server:
+//
+//	GET /health            the aggregated health and that of every component
+//	GET /health/liveness   whether the application is alive
+//	GET /health/readiness  whether the application can serve requests
+//
+// The liveness and readiness endpoints back the probes of Kubernetes.
+package health
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync/atomic"
+)
+
+// Status is the health status of a component or of the application.
+type Status string
+
+const (
+	StatusUp           Status = "UP"             // working as expected
+	StatusDown         Status = "DOWN"           // not working
+	StatusOutOfService Status = "OUT_OF_SERVICE" // taken out of service on purpose
+	StatusUnknown      Status = "UNKNOWN"        // not known
+)
+
+// HTTPStatus returns the HTTP status code reporting the status, i.e. 503
+// for DOWN and OUT_OF_SERVICE, and 200 otherwise.
+func (s Status) HTTPStatus() int {
+	switch s {
+	case StatusDown, StatusOutOfService:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusOK
+	}
+}
+
+// Health is the health of a component, with optional details such as the
+// error that made it down.
+type Health struct {
+	Status  Status         `json:"status"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Up returns an UP health.
+func Up() Health {
+	return Health{Status: StatusUp}
+}
+
+// Down returns a DOWN health with the error as detail, if not nil.
+func Down(err error) Health {
+	h := Health{Status: StatusDown}
+	if err != nil {
+		h.Details = map[string]any{"error": err.Error()}
+	}
+	return h
+}
+
+// Indicator is implemented by beans reporting the health of a component,
+// e.g. a database connection. Export the bean as Indicator to collect it.
+type Indicator interface {
+	Name() string
+	Health(ctx context.Context) Health
+}
+
+// FuncIndicator is an Indicator computing the health by a function.
+type FuncIndicator struct {
+	Component string
+	Fn        func(ctx context.Context) Health
+}
+
+// Name returns the name of the component.
+func (i *FuncIndicator) Name() string {
+	return i.Component
+}
+
+// Health returns the health of the component.
+func (i *FuncIndicator) Health(ctx context.Context) Health {
+	return i.Fn(ctx)
+}
+
+// CompositeHealth is the health of the application aggregated from the
+// health of its components.
+type CompositeHealth struct {
+	Status     Status            `json:"status"`
+	Components map[string]Health `json:"components,omitempty"`
+}
+
+// DefaultOrder is the order of severity of the statuses, the most severe
+// first, used to aggregate them.
+var DefaultOrder = []Status{StatusDown, StatusOutOfService, StatusUp, StatusUnknown}
+
+// Aggregate returns the most severe of the statuses according to the
+// order, or UNKNOWN if there is none. Statuses not in the order are less
+// severe than all the others.
+func Aggregate(order []Status, statuses ...Status) Status {
+	ret, rank := StatusUnknown, len(order)+1
+	for _, s := range statuses {
+		r := slices.Index(order, s)
+		if r < 0 {
+			r = len(order)
+		}
+		if r < rank {
+			ret, rank = s, r
+		}
+	}
+	return ret
+}
+
+// Aggregator combines the health of the indicators.
+type Aggregator struct {
+	Indicators []Indicator
+	Order      []Status // order of severity, DefaultOrder if empty
+}
+
+// Health returns the health of every indicator, and their aggregated
+// status. A panicking indicator is reported as DOWN.
+func (a *Aggregator) Health(ctx context.Context) CompositeHealth {
+	order := a.Order
+	if len(order) == 0 {
+		order = DefaultOrder
+	}
+	ret := CompositeHealth{Status: StatusUp}
+	if len(a.Indicators) == 0 {
+		return ret
+	}
+	ret.Components = make(map[string]Health)
+	var statuses []Status
+	for _, i := range a.Indicators {
+		h := check(ctx, i)
+		ret.Components[i.Name()] = h
+		statuses = append(statuses, h.Status)
+	}
+	ret.Status = Aggregate(order, statuses...)
+	return ret
+}
+
+// check returns the health of the indicator, DOWN if it panics.
+func check(ctx context.Context, i Indicator) (h Health) {
+	defer func() {
+		if r := recover(); r != nil {
+			h = Health{Status: StatusDown, Details: map[string]any{"panic": r}}
+		}
+	}()
+	return i.Health(ctx)
+}
+
+// LivenessState tells whether the application is alive. A broken
+// application should be restarted.
+type LivenessState string
+
+const (
+	LivenessCorrect LivenessState = "CORRECT"
+	LivenessBroken  LivenessState = "BROKEN"
+)
+
+// ReadinessState tells whether the application can serve requests.
+type ReadinessState string
+
+const (
+	ReadinessAccepting ReadinessState = "ACCEPTING_TRAFFIC"
+	ReadinessRefusing  ReadinessState = "REFUSING_TRAFFIC"
+)
+
+// Availability holds the liveness and readiness states set by the
+// application components, e.g. to refuse traffic during a maintenance.
+// The zero value is correct and accepting traffic.
+type Availability struct {
+	broken   atomic.Bool
+	refusing atomic.Bool
+}
+
+// Liveness returns the liveness state.
+func (a *Availability) Liveness() LivenessState {
+	if a.broken.Load() {
+		return LivenessBroken
+	}
+	return LivenessCorrect
+}
+
+// SetLiveness changes the liveness state.
+func (a *Availability) SetLiveness(s LivenessState) {
+	a.broken.Store(s == LivenessBroken)
+}
+
+// Readiness returns the readiness state.
+func (a *Availability) Readiness() ReadinessState {
+	if a.refusing.Load() {
+		return ReadinessRefusing
+	}
+	return ReadinessAccepting
+}
+
+// SetReadiness changes the readiness state.
+func (a *Availability) SetReadiness(s ReadinessState) {
+	a.refusing.Store(s == ReadinessRefusing)
+}
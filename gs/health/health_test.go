@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/health"
+)
+
+func TestAggregate(t *testing.T) {
+	order := health.DefaultOrder
+	assert.That(t, health.Aggregate(order)).Equal(health.StatusUnknown)
+	assert.That(t, health.Aggregate(order, health.StatusUp, health.StatusUnknown)).Equal(health.StatusUp)
+	assert.That(t, health.Aggregate(order, health.StatusUp, health.StatusOutOfService)).Equal(health.StatusOutOfService)
+	assert.That(t, health.Aggregate(order, health.StatusOutOfService, health.StatusDown, health.StatusUp)).Equal(health.StatusDown)
+	assert.That(t, health.Aggregate(order, "CUSTOM", health.StatusUnknown)).Equal(health.StatusUnknown)
+	assert.That(t, health.Aggregate(order, "CUSTOM")).Equal(health.Status("CUSTOM"))
+
+	assert.That(t, health.StatusUp.HTTPStatus()).Equal(http.StatusOK)
+	assert.That(t, health.StatusDown.HTTPStatus()).Equal(http.StatusServiceUnavailable)
+	assert.That(t, health.StatusOutOfService.HTTPStatus()).Equal(http.StatusServiceUnavailable)
+}
+
+func TestAggregator(t *testing.T) {
+
+	t.Run("no indicators", func(t *testing.T) {
+		a := &health.Aggregator{}
+		h := a.Health(context.Background())
+		assert.That(t, h.Status).Equal(health.StatusUp)
+		assert.That(t, h.Components).Nil()
+	})
+
+	t.Run("components", func(t *testing.T) {
+		a := &health.Aggregator{Indicators: []health.Indicator{
+			&health.FuncIndicator{Component: "db", Fn: func(ctx context.Context) health.Health {
+				return health.Down(errors.New("connection refused"))
+			}},
+			&health.FuncIndicator{Component: "cache", Fn: func(ctx context.Context) health.Health {
+				return health.Up()
+			}},
+			&health.FuncIndicator{Component: "mq", Fn: func(ctx context.Context) health.Health {
+				panic("mq panic")
+			}},
+		}}
+		h := a.Health(context.Background())
+		assert.That(t, h.Status).Equal(health.StatusDown)
+		assert.That(t, h.Components).Equal(map[string]health.Health{
+			"db":    {Status: health.StatusDown, Details: map[string]any{"error": "connection refused"}},
+			"cache": {Status: health.StatusUp},
+			"mq":    {Status: health.StatusDown, Details: map[string]any{"panic": "mq panic"}},
+		})
+	})
+
+	t.Run("custom order", func(t *testing.T) {
+		a := &health.Aggregator{
+			Indicators: []health.Indicator{
+				&health.FuncIndicator{Component: "a", Fn: func(ctx context.Context) health.Health {
+					return health.Health{Status: health.StatusOutOfService}
+				}},
+				&health.FuncIndicator{Component: "b", Fn: func(ctx context.Context) health.Health {
+					return health.Down(nil)
+				}},
+			},
+			Order: []health.Status{health.StatusOutOfService, health.StatusDown, health.StatusUp},
+		}
+		assert.That(t, a.Health(context.Background()).Status).Equal(health.StatusOutOfService)
+	})
+}
+
+func TestAvailability(t *testing.T) {
+	var a health.Availability
+	assert.That(t, a.Liveness()).Equal(health.LivenessCorrect)
+	assert.That(t, a.Readiness()).Equal(health.ReadinessAccepting)
+
+	a.SetLiveness(health.LivenessBroken)
+	a.SetReadiness(health.ReadinessRefusing)
+	assert.That(t, a.Liveness()).Equal(health.LivenessBroken)
+	assert.That(t, a.Readiness()).Equal(health.ReadinessRefusing)
+
+	a.SetLiveness(health.LivenessCorrect)
+	a.SetReadiness(health.ReadinessAccepting)
+	assert.That(t, a.Liveness()).Equal(health.LivenessCorrect)
+	assert.That(t, a.Readiness()).Equal(health.ReadinessAccepting)
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/health"
+)
+
+func TestHealthAdminHandler(t *testing.T) {
+
+	var dbErr error
+	running := true
+	indicators := []health.Indicator{
+		&health.FuncIndicator{Component: "db", Fn: func(ctx context.Context) health.Health {
+			if dbErr != nil {
+				return health.Down(dbErr)
+			}
+			return health.Up()
+		}},
+	}
+	a := &health.Availability{}
+	s := gs.NewAdminServer(gs.AdminServerConfig{}, []gs.AdminHandler{
+		gs.NewHealthAdminHandler("GET /health", indicators, a, func() bool { return running }),
+		gs.NewHealthAdminHandler("GET /health/{group}", indicators, a, func() bool { return running }),
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("up", func(t *testing.T) {
+		w := serve("/health")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		assert.String(t, w.Header().Get("Content-Type")).Equal("application/json")
+		assert.String(t, w.Body.String()).JSONEqual(`{
+			"status": "UP",
+			"components": {
+				"db": {"status": "UP"},
+				"livenessState": {"status": "UP", "details": {"state": "CORRECT"}},
+				"readinessState": {"status": "UP", "details": {"state": "ACCEPTING_TRAFFIC"}}
+			}
+		}`)
+		assert.That(t, serve("/health/liveness").Code).Equal(http.StatusOK)
+		assert.That(t, serve("/health/readiness").Code).Equal(http.StatusOK)
+		assert.That(t, serve("/health/unknown").Code).Equal(http.StatusNotFound)
+	})
+
+	t.Run("component down", func(t *testing.T) {
+		dbErr = errors.New("connection refused")
+		defer func() { dbErr = nil }()
+		w := serve("/health/readiness")
+		assert.That(t, w.Code).Equal(http.StatusServiceUnavailable)
+		assert.String(t, w.Body.String()).JSONEqual(`{
+			"status": "DOWN",
+			"components": {
+				"db": {"status": "DOWN", "details": {"error": "connection refused"}},
+				"readinessState": {"status": "UP", "details": {"state": "ACCEPTING_TRAFFIC"}}
+			}
+		}`)
+		// the liveness doesn't depend on the components
+		assert.That(t, serve("/health/liveness").Code).Equal(http.StatusOK)
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		running = false
+		defer func() { running = true }()
+		w := serve("/health/readiness")
+		assert.That(t, w.Code).Equal(http.StatusServiceUnavailable)
+		assert.String(t, w.Body.String()).Contains(`"status":"OUT_OF_SERVICE"`)
+		assert.That(t, serve("/health/liveness").Code).Equal(http.StatusOK)
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		a.SetLiveness(health.LivenessBroken)
+		defer a.SetLiveness(health.LivenessCorrect)
+		w := serve("/health/liveness")
+		assert.That(t, w.Code).Equal(http.StatusServiceUnavailable)
+		assert.String(t, w.Body.String()).JSONEqual(`{
+			"status": "DOWN",
+			"components": {
+				"livenessState": {"status": "DOWN", "details": {"state": "BROKEN"}}
+			}
+		}`)
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-spring/spring-base/util"
@@ -28,62 +30,147 @@ import (
 )
 
 func init() {
+	DeclareConfig[SimpleHttpServerConfig]("").Claim("http.server")
+
 	Module([]ConditionOnProperty{
 		OnEnableServers(),
 		OnProperty(EnableSimpleHttpServerProp).HavingValue("true").MatchIfMissing(),
 	}, func(p conf.Properties) error {
 
-		// Register the default HTTP multiplexer as a bean
-		// if no other http.Handler bean has been defined.
-		Provide(http.DefaultServeMux).
+		// Register a multiplexer serving the RouteHandler beans, backed
+		// by the default HTTP multiplexer, as a bean if no other
+		// http.Handler bean has been defined.
+		Provide(NewRouteMux, TagArg("?")).
 			Export(As[http.Handler]()).
 			Condition(OnMissingBean[http.Handler]())
 
 		// Provide a new SimpleHttpServer instance with
-		// http.Handler injection and configuration binding.
-		Provide(NewSimpleHttpServer).AsServer()
+		// http.Handler injection and configuration binding, the
+		// handler being wrapped by the HttpMiddleware beans.
+		Provide(func(h http.Handler, middlewares []HttpMiddleware, cfg SimpleHttpServerConfig) *SimpleHttpServer {
+			return NewSimpleHttpServer(WrapHandler(h, middlewares), cfg)
+		}, IndexArg(1, TagArg("?"))).AsServer()
 
 		return nil
 	})
 }
 
+// RouteHandler is an HTTP handler mounted on the built-in HTTP server.
+// Register a bean exported as RouteHandler to add a route.
+type RouteHandler interface {
+	http.Handler
+
+	// Route returns the [http.ServeMux] pattern the handler is mounted
+	// on, e.g. "GET /users/{id}".
+	Route() string
+}
+
+// HttpMiddleware wraps the handler of the built-in HTTP server, e.g. to
+// limit the rate of requests. Register a bean exported as HttpMiddleware
+// to add a middleware.
+type HttpMiddleware interface {
+	Wrap(next http.Handler) http.Handler
+}
+
+// WrapHandler wraps h with the middlewares, the first one being the
+// outermost, i.e. seeing the requests first.
+func WrapHandler(h http.Handler, middlewares []HttpMiddleware) http.Handler {
+	for _, m := range slices.Backward(middlewares) {
+		h = m.Wrap(h)
+	}
+	return h
+}
+
+// HttpRoute is a RouteHandler mounting an http.Handler on a pattern.
+type HttpRoute struct {
+	http.Handler
+	pattern string
+}
+
+// NewHttpRoute creates a new HttpRoute mounting h on the pattern.
+func NewHttpRoute(pattern string, h http.Handler) *HttpRoute {
+	return &HttpRoute{Handler: h, pattern: pattern}
+}
+
+// Route returns the pattern the handler is mounted on.
+func (r *HttpRoute) Route() string {
+	return r.pattern
+}
+
+// NewRouteMux creates a multiplexer serving the routes, and the handlers
+// registered on [http.DefaultServeMux] for the requests matching none of
+// them. It returns an error if two routes have conflicting patterns.
+func NewRouteMux(routes []RouteHandler) (_ *http.ServeMux, err error) {
+	mux := http.NewServeMux()
+	defer func() {
+		if r := recover(); r != nil {
+			err = util.FormatError(nil, "register route error: %v", r)
+		}
+	}()
+	fallback := true
+	for _, h := range routes {
+		if h.Route() == "/" {
+			fallback = false
+		}
+		mux.Handle(h.Route(), h)
+	}
+	if fallback {
+		mux.Handle("/", http.DefaultServeMux)
+	}
+	return mux, nil
+}
+
 // SimpleHttpServerConfig holds configuration for the SimpleHttpServer.
 type SimpleHttpServerConfig struct {
 	// Address specifies the TCP address the server listens on.
 	// Example: ":9090" (listen on all interfaces, port 9090).
-	Address string `value:"${http.server.addr:=:9090}"`
+	Address string `value:"${http.server.addr:=:9090}" desc:"address the HTTP server listens on"`
 
 	// ReadTimeout is the maximum duration for reading the entire
 	// request, including the body.
-	ReadTimeout time.Duration `value:"${http.server.readTimeout:=5s}"`
+	ReadTimeout time.Duration `value:"${http.server.readTimeout:=5s}" desc:"timeout of reading a request"`
 
 	// HeaderTimeout is the maximum duration for reading request headers.
-	HeaderTimeout time.Duration `value:"${http.server.headerTimeout:=1s}"`
+	HeaderTimeout time.Duration `value:"${http.server.headerTimeout:=1s}" desc:"timeout of reading the request headers"`
 
 	// WriteTimeout is the maximum duration before timing out
 	// a response write.
-	WriteTimeout time.Duration `value:"${http.server.writeTimeout:=5s}"`
+	WriteTimeout time.Duration `value:"${http.server.writeTimeout:=5s}" desc:"timeout of writing a response"`
 
 	// IdleTimeout is the maximum amount of time to wait for
 	// the next request when keep-alive connections are enabled.
-	IdleTimeout time.Duration `value:"${http.server.idleTimeout:=60s}"`
+	IdleTimeout time.Duration `value:"${http.server.idleTimeout:=60s}" desc:"time to wait for the next request of a keep-alive connection"`
+
+	// CertFile and KeyFile are the certificate and private key files
+	// serving HTTPS when both are set.
+	CertFile string `value:"${http.server.tls.certFile:=}" desc:"certificate file serving HTTPS"`
+	KeyFile  string `value:"${http.server.tls.keyFile:=}" desc:"private key file serving HTTPS"`
 }
 
 // SimpleHttpServer wraps a standard [http.Server] to integrate
 // it into the Go-Spring application lifecycle.
 type SimpleHttpServer struct {
-	svr *http.Server // The HTTP server instance.
+	svr      *http.Server           // The HTTP server instance.
+	addr     atomic.Pointer[string] // The address bound by ListenAndServe.
+	certFile string                 // The certificate file, empty for plain HTTP.
+	keyFile  string                 // The private key file, empty for plain HTTP.
 }
 
 // NewSimpleHttpServer constructs a new SimpleHttpServer using
 // the provided HTTP handler and configuration.
 func NewSimpleHttpServer(h http.Handler, cfg SimpleHttpServerConfig) *SimpleHttpServer {
-	return &SimpleHttpServer{svr: &http.Server{
-		Addr:         cfg.Address,
-		Handler:      h,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-	}}
+	return &SimpleHttpServer{
+		svr: &http.Server{
+			Addr:              cfg.Address,
+			Handler:           h,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.HeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		},
+		certFile: cfg.CertFile,
+		keyFile:  cfg.KeyFile,
+	}
 }
 
 // ListenAndServe starts the HTTP server and blocks until it is stopped.
@@ -93,16 +180,39 @@ func (s *SimpleHttpServer) ListenAndServe(sig ReadySignal) error {
 	if err != nil {
 		return util.FormatError(err, "failed to listen on %s", s.svr.Addr)
 	}
+	addr := ln.Addr().String()
+	s.addr.Store(&addr)
 	<-sig.TriggerAndWait()
-	err = s.svr.Serve(ln)
+	if s.certFile != "" && s.keyFile != "" {
+		err = s.svr.ServeTLS(ln, s.certFile, s.keyFile)
+	} else {
+		err = s.svr.Serve(ln)
+	}
 	if errors.Is(err, http.ErrServerClosed) {
 		return nil
 	}
 	return util.FormatError(err, "failed to serve on %s", s.svr.Addr)
 }
 
-// Shutdown gracefully stops the HTTP server using the provided context,
-// allowing in-flight requests to complete before closing.
+// Addr returns the address the server is bound to, or the configured
+// address if it is not listening yet. It may be called while the server
+// starts listening.
+func (s *SimpleHttpServer) Addr() string {
+	if addr := s.addr.Load(); addr != nil {
+		return *addr
+	}
+	return s.svr.Addr
+}
+
+// Shutdown gracefully stops the HTTP server using the provided context:
+// it stops accepting connections, closes the idle ones and waits for the
+// in-flight requests to complete. If the context ends first, the
+// remaining connections are closed and the context error is returned.
 func (s *SimpleHttpServer) Shutdown(ctx context.Context) error {
-	return s.svr.Shutdown(ctx)
+	s.svr.SetKeepAlivesEnabled(false)
+	err := s.svr.Shutdown(ctx)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		_ = s.svr.Close()
+	}
+	return err
 }
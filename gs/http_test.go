@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+)
+
+// readySignal is a ReadySignal closing listening once the server is
+// listening, then letting it serve at once.
+type readySignal struct {
+	listening chan struct{}
+}
+
+func (s readySignal) TriggerAndWait() <-chan struct{} {
+	close(s.listening)
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func TestRouteMux(t *testing.T) {
+
+	serve := func(h http.Handler, method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	t.Run("routes", func(t *testing.T) {
+		mux, err := gs.NewRouteMux([]gs.RouteHandler{
+			gs.NewHttpRoute("GET /users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("user " + r.PathValue("id")))
+			})),
+		})
+		assert.That(t, err).Nil()
+		w := serve(mux, http.MethodGet, "/users/7")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		assert.String(t, w.Body.String()).Equal("user 7")
+		assert.That(t, serve(mux, http.MethodPost, "/users/7").Code).Equal(http.StatusNotFound)
+		assert.That(t, serve(mux, http.MethodGet, "/unknown").Code).Equal(http.StatusNotFound)
+	})
+
+	t.Run("root route", func(t *testing.T) {
+		mux, err := gs.NewRouteMux([]gs.RouteHandler{
+			gs.NewHttpRoute("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("root"))
+			})),
+		})
+		assert.That(t, err).Nil()
+		assert.String(t, serve(mux, http.MethodGet, "/unknown").Body.String()).Equal("root")
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := gs.NewRouteMux([]gs.RouteHandler{
+			gs.NewHttpRoute("GET /users", http.NotFoundHandler()),
+			gs.NewHttpRoute("GET /users", http.NotFoundHandler()),
+		})
+		assert.Error(t, err).Matches("register route error: .*conflicts with pattern")
+	})
+}
+
+// headerMiddleware appends its name to the "X-Chain" header.
+type headerMiddleware string
+
+func (m headerMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Chain", string(m))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestWrapHandler(t *testing.T) {
+	h := gs.WrapHandler(http.NotFoundHandler(), []gs.HttpMiddleware{
+		headerMiddleware("outer"),
+		headerMiddleware("inner"),
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.That(t, w.Code).Equal(http.StatusNotFound)
+	assert.That(t, w.Header().Values("X-Chain")).Equal([]string{"outer", "inner"})
+}
+
+func TestSimpleHttpServer(t *testing.T) {
+
+	t.Run("drain", func(t *testing.T) {
+		started := make(chan struct{})
+		s := gs.NewSimpleHttpServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(100 * time.Millisecond)
+			_, _ = w.Write([]byte("done"))
+		}), gs.SimpleHttpServerConfig{Address: "127.0.0.1:0"})
+
+		sig := readySignal{listening: make(chan struct{})}
+		errCh := make(chan error, 1)
+		go func() { errCh <- s.ListenAndServe(sig) }()
+		<-sig.listening
+
+		respCh := make(chan string, 1)
+		go func() {
+			resp, err := http.Get("http://" + s.Addr())
+			if err != nil {
+				respCh <- err.Error()
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+			b, _ := io.ReadAll(resp.Body)
+			respCh <- string(b)
+		}()
+		<-started
+
+		err := s.Shutdown(context.Background())
+		assert.That(t, err).Nil()
+		assert.String(t, <-respCh).Equal("done")
+		assert.That(t, <-errCh).Nil()
+	})
+
+	t.Run("addr", func(t *testing.T) {
+		s := gs.NewSimpleHttpServer(http.NotFoundHandler(), gs.SimpleHttpServerConfig{Address: "127.0.0.1:0"})
+		assert.String(t, s.Addr()).Equal("127.0.0.1:0")
+
+		sig := readySignal{listening: make(chan struct{})}
+		errCh := make(chan error, 1)
+		go func() { errCh <- s.ListenAndServe(sig) }()
+		for s.Addr() == "127.0.0.1:0" {
+			time.Sleep(time.Millisecond)
+		}
+		<-sig.listening
+		assert.String(t, s.Addr()).Matches(`^127\.0\.0\.1:[1-9][0-9]*$`)
+
+		err := s.Shutdown(context.Background())
+		assert.That(t, err).Nil()
+		assert.That(t, <-errCh).Nil()
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		started := make(chan struct{})
+		s := gs.NewSimpleHttpServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		}), gs.SimpleHttpServerConfig{Address: "127.0.0.1:0"})
+
+		sig := readySignal{listening: make(chan struct{})}
+		go func() { _ = s.ListenAndServe(sig) }()
+		<-sig.listening
+		go func() {
+			resp, err := http.Get("http://" + s.Addr())
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := s.Shutdown(ctx)
+		assert.That(t, err).Equal(context.DeadlineExceeded)
+	})
+}
+
+func TestConfigDocs(t *testing.T) {
+	s := gs.ConfigMarkdown()
+	assert.String(t, s).Contains("| `http.server.addr` | `string` | `:9090` | address the HTTP server listens on |")
+	assert.String(t, s).Contains("| `spring.breaker.*.window` | `time.Duration` | `10s` | length of the rolling window |")
+
+	b, err := gs.ConfigJSONSchema()
+	assert.That(t, err).Nil()
+	assert.String(t, string(b)).Contains(`"rate-limit": {`)
+	assert.String(t, string(b)).Contains(`"description": "rate limiter of the built-in HTTP server"`)
+}
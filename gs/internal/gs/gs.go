@@ -21,8 +21,13 @@ package gs
 import (
 	"context"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 	"unsafe"
+
+	"github.com/go-spring/spring-base/util"
 )
 
 // anyType is the [reflect.Type] of the [any] type.
@@ -150,6 +155,32 @@ func (f FuncRunner) Run() error {
 	return f()
 }
 
+// ErrorPolicy decides what the application does when a Runner fails.
+type ErrorPolicy string
+
+const (
+	FailOnError     ErrorPolicy = "fail"     // stop starting the application
+	ContinueOnError ErrorPolicy = "continue" // log the error and run the next runner
+)
+
+// RunnerPolicy is implemented by Runners to choose their own ErrorPolicy.
+// Other runners use the "spring.app.runner.error-policy" property, which
+// defaults to FailOnError.
+type RunnerPolicy interface {
+	ErrorPolicy() ErrorPolicy
+}
+
+// PolicyRunner is a Runner with its own ErrorPolicy.
+type PolicyRunner struct {
+	Runner
+	Policy ErrorPolicy
+}
+
+// ErrorPolicy returns the error policy of the runner.
+func (r PolicyRunner) ErrorPolicy() ErrorPolicy {
+	return r.Policy
+}
+
 // Job is similar to Runner but allows passing a context to the task.
 // It is typically used for background tasks or setup work that may be cancellable.
 type Job interface {
@@ -163,6 +194,78 @@ func (f FuncJob) Run(ctx context.Context) error {
 	return f(ctx)
 }
 
+// Command is a run-to-completion task, e.g. a command-line tool. When an
+// application has a Command, it runs the command after the runners instead
+// of launching jobs and servers, then shuts down and exits with the code
+// returned by the command.
+type Command interface {
+	Run(ctx context.Context) (exitCode int, err error)
+}
+
+// FuncCommand is a function type adapter for the Command interface.
+type FuncCommand func(ctx context.Context) (int, error)
+
+func (f FuncCommand) Run(ctx context.Context) (int, error) {
+	return f(ctx)
+}
+
+// EventListener receives events published on the application event bus.
+type EventListener interface {
+	OnEvent(ctx context.Context, event any)
+}
+
+// FuncEventListener is a function type adapter for the EventListener interface.
+type FuncEventListener func(ctx context.Context, event any)
+
+func (f FuncEventListener) OnEvent(ctx context.Context, event any) {
+	f(ctx, event)
+}
+
+// EventPublisher publishes events to the listeners of the application event bus.
+type EventPublisher interface {
+	Publish(ctx context.Context, event any)
+}
+
+// ChangeKind tells how a property key changed.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeModified ChangeKind = "modified"
+	ChangeRemoved  ChangeKind = "removed"
+)
+
+// PropertyChange describes a property key changed by a refresh.
+type PropertyChange struct {
+	Key      string     // the changed key
+	Kind     ChangeKind // whether the key was added, modified or removed
+	OldValue string     // the old value, empty if added
+	NewValue string     // the new value, empty if removed
+	Source   string     // source of the new value, or of the old value if removed
+}
+
+// PropertiesRefreshed is published on the application event bus each time
+// the application properties are refreshed, whether or not it succeeded.
+// A failed refresh applies none of the new properties.
+type PropertiesRefreshed struct {
+	Duration time.Duration    // time spent loading and applying the properties
+	Changes  []PropertyChange // keys changed by the refresh, sorted by key, nil if it failed
+	Err      error            // the refresh error, or nil on success
+}
+
+// ChangesOf returns the changes of the key and its sub keys, e.g. "db"
+// matches "db", "db.host" and "db[0]", but not "dbx".
+func (e PropertiesRefreshed) ChangesOf(key string) []PropertyChange {
+	var ret []PropertyChange
+	for _, c := range e.Changes {
+		s, ok := strings.CutPrefix(c.Key, key)
+		if ok && (s == "" || s[0] == '.' || s[0] == '[') {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
 // ReadySignal represents a synchronization mechanism that signals
 // when the application is ready to accept requests.
 type ReadySignal interface {
@@ -176,6 +279,12 @@ type Server interface {
 	Shutdown(ctx context.Context) error
 }
 
+// ServerAddr is implemented by servers that can report the address they
+// listen on, which is shown in the startup summary.
+type ServerAddr interface {
+	Addr() string
+}
+
 /*********************************** bean ************************************/
 
 // BeanMock represents a mocked bean instance that can replace a real bean
@@ -199,12 +308,239 @@ type BeanInitFunc = any
 // Example: `func(bean)` or `func(bean) error`.
 type BeanDestroyFunc = any
 
+// Initializing is implemented by beans to be initialized after their
+// fields are injected, unless the bean has an init function.
+type Initializing interface {
+	PostConstruct() error
+}
+
+// BeanPostProcessor is implemented by beans exported as BeanPostProcessor
+// to process every other bean before and after its initialization, e.g.
+// to wrap it in a proxy. The bean returned replaces the processed one and
+// must be assignable to its type. Post-processors, and the beans they
+// depend on, are wired before and without being processed.
+type BeanPostProcessor interface {
+	BeforeInit(bean any, name string) (any, error)
+	AfterInit(bean any, name string) (any, error)
+}
+
+// Disposable is implemented by beans to be destroyed when the container
+// closes, unless the bean has a destroy function. Beans are destroyed
+// before the beans they depend on.
+type Disposable interface {
+	PreDestroy() error
+}
+
+// Ordered is implemented by beans to set their position in the injected
+// collections, unless the bean has an order set at registration. Lower
+// orders come first.
+type Ordered interface {
+	Order() int
+}
+
 // Configuration specifies parameters for configuring beans during registration.
 type Configuration struct {
 	Includes []string // Methods to include
 	Excludes []string // Methods to exclude
 }
 
+// LazyHandle is implemented by [Lazy] so that the container can defer
+// resolving the field until it is first used.
+type LazyHandle interface {
+	LazyType() reflect.Type
+	SetLazyResolver(fn func() (reflect.Value, error))
+}
+
+// Lazy is a field type that defers the lookup, and the construction if
+// needed, of the beans of type T until Get is first called, e.g.
+//
+//	Cache gs.Lazy[*Cache] `autowire:""`
+//
+// The field is injected with the same tag syntax as a field of type T.
+type Lazy[T any] struct {
+	mutex   sync.Mutex
+	resolve func() (reflect.Value, error)
+	value   T
+	done    bool
+}
+
+// LazyType returns the type of the value to be injected.
+func (l *Lazy[T]) LazyType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// SetLazyResolver sets the function resolving the value, used by the container.
+func (l *Lazy[T]) SetLazyResolver(fn func() (reflect.Value, error)) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.resolve, l.done = fn, false
+}
+
+// Get returns the value, resolving it on the first successful call.
+// A failed resolution is retried on the next call.
+func (l *Lazy[T]) Get() (T, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.done {
+		return l.value, nil
+	}
+	var zero T
+	if l.resolve == nil {
+		return zero, util.FormatError(nil, "lazy %s is not injected", l.LazyType())
+	}
+	v, err := l.resolve()
+	if err != nil {
+		return zero, err
+	}
+	if v.IsValid() {
+		l.value = v.Interface().(T)
+	}
+	l.done = true
+	return l.value, nil
+}
+
+// Scope determines how many instances of a bean the container creates.
+type Scope string
+
+const (
+	ScopeSingleton = Scope("singleton") // One instance for the container
+	ScopePrototype = Scope("prototype") // A new instance per injection or Get
+	ScopeRequest   = Scope("request")   // One instance per request scope
+)
+
+// PropertiesHandle is implemented by the beans holding the properties
+// under a prefix. When the bean is wired, the container binds the
+// properties to the target, a dynamic value, and refreshes it when the
+// properties change.
+type PropertiesHandle interface {
+	PropertiesTarget() (target any, prefix string)
+}
+
+// FactoryHandle is implemented by [Factory] so that the container can
+// set the function producing the instances.
+type FactoryHandle interface {
+	FactoryType() reflect.Type
+	SetFactoryResolver(fn func(ctx context.Context) (reflect.Value, error))
+}
+
+// Factory is a field type producing the beans of type T on each call of
+// Get, e.g.
+//
+//	Session gs.Factory[*Session] `autowire:""`
+//
+// A prototype bean is created on every call, a request scoped bean once
+// per request scope of ctx, and a singleton bean is returned as is.
+type Factory[T any] struct {
+	mutex   sync.Mutex
+	resolve func(ctx context.Context) (reflect.Value, error)
+}
+
+// FactoryType returns the type of the values produced.
+func (f *Factory[T]) FactoryType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// SetFactoryResolver sets the function producing the values, used by the container.
+func (f *Factory[T]) SetFactoryResolver(fn func(ctx context.Context) (reflect.Value, error)) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.resolve = fn
+}
+
+// Get returns the bean for ctx, creating it according to its scope.
+func (f *Factory[T]) Get(ctx context.Context) (T, error) {
+	f.mutex.Lock()
+	resolve := f.resolve
+	f.mutex.Unlock()
+	var zero T
+	if resolve == nil {
+		return zero, util.FormatError(nil, "factory %s is not injected", f.FactoryType())
+	}
+	v, err := resolve(ctx)
+	if err != nil || !v.IsValid() {
+		return zero, err
+	}
+	return v.Interface().(T), nil
+}
+
+// requestScopeKey is the context key of the [RequestScope].
+type requestScopeKey struct{}
+
+// RequestScope holds the request scoped beans created for a request,
+// and the destroy callbacks of the beans created in it.
+type RequestScope struct {
+	mutex      sync.Mutex
+	beans      map[any]reflect.Value
+	destroyers []func()
+	ended      bool
+}
+
+// WithRequestScope returns a context carrying a new request scope, and
+// the function ending it, which calls the destroy callbacks of the beans
+// created in the scope in reverse order.
+func WithRequestScope(ctx context.Context) (context.Context, func()) {
+	s := &RequestScope{beans: make(map[any]reflect.Value)}
+	return context.WithValue(ctx, requestScopeKey{}, s), s.end
+}
+
+// RequestScopeFrom returns the request scope of ctx, or nil if none.
+func RequestScopeFrom(ctx context.Context) *RequestScope {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(requestScopeKey{}).(*RequestScope)
+	return s
+}
+
+// Load returns the bean stored for the key.
+func (s *RequestScope) Load(key any) (reflect.Value, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	v, ok := s.beans[key]
+	return v, ok
+}
+
+// Store stores the bean for the key, and its destroy callback if not nil.
+func (s *RequestScope) Store(key any, v reflect.Value, destroy func()) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.ended {
+		return util.FormatError(nil, "request scope is ended")
+	}
+	s.beans[key] = v
+	if destroy != nil {
+		s.destroyers = append(s.destroyers, destroy)
+	}
+	return nil
+}
+
+// AddDestroyer adds a destroy callback to be called when the scope ends.
+func (s *RequestScope) AddDestroyer(destroy func()) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.ended {
+		return util.FormatError(nil, "request scope is ended")
+	}
+	s.destroyers = append(s.destroyers, destroy)
+	return nil
+}
+
+// end ends the scope and calls the destroy callbacks in reverse order.
+func (s *RequestScope) end() {
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	destroyers := s.destroyers
+	s.beans, s.destroyers = nil, nil
+	s.mutex.Unlock()
+	for _, fn := range slices.Backward(destroyers) {
+		fn()
+	}
+}
+
 // BeanRegistration defines the API for configuring and registering a bean’s metadata
 // in the IoC container.
 type BeanRegistration interface {
@@ -216,11 +552,18 @@ type BeanRegistration interface {
 	SetDestroy(fn BeanDestroyFunc)
 	SetInitMethod(method string)
 	SetDestroyMethod(method string)
+	SetDestroyTimeout(timeout time.Duration)
 	SetCondition(conditions ...Condition)
 	SetDependsOn(selectors ...BeanSelector)
 	SetExport(exports ...reflect.Type)
 	SetConfiguration(c ...Configuration)
 	SetCaller(skip int)
+	SetLazy()
+	SetScope(scope Scope)
+	SetPrimary()
+	SetFallback()
+	SetOrder(order int)
+	SetQualifier(qualifiers ...string)
 	OnProfiles(profiles string)
 }
 
@@ -262,6 +605,13 @@ func (d *beanBuilder[T]) Destroy(fn BeanDestroyFunc) *T {
 	return *(**T)(unsafe.Pointer(&d))
 }
 
+// DestroyTimeout sets the time limit of destroying the bean, which
+// overrides the property spring.destroy-timeout.
+func (d *beanBuilder[T]) DestroyTimeout(timeout time.Duration) *T {
+	d.b.SetDestroyTimeout(timeout)
+	return *(**T)(unsafe.Pointer(&d))
+}
+
 // InitMethod sets the initialization function for the bean by method name.
 func (d *beanBuilder[T]) InitMethod(method string) *T {
 	d.b.SetInitMethod(method)
@@ -298,6 +648,12 @@ func (d *beanBuilder[T]) AsJob() *T {
 	return *(**T)(unsafe.Pointer(&d))
 }
 
+// AsCommand marks the bean as a Command.
+func (d *beanBuilder[T]) AsCommand() *T {
+	d.b.SetExport(As[Command]())
+	return *(**T)(unsafe.Pointer(&d))
+}
+
 // AsServer marks the bean as a Server.
 func (d *beanBuilder[T]) AsServer() *T {
 	d.b.SetExport(As[Server]())
@@ -322,6 +678,47 @@ func (d *beanBuilder[T]) Caller(skip int) *T {
 	return *(**T)(unsafe.Pointer(&d))
 }
 
+// Lazy marks the bean to be constructed on first injection or lookup
+// rather than as a root of the container refresh.
+func (d *beanBuilder[T]) Lazy() *T {
+	d.b.SetLazy()
+	return *(**T)(unsafe.Pointer(&d))
+}
+
+// Scope sets the scope of the bean, which is [ScopeSingleton] by default.
+func (d *beanBuilder[T]) Scope(scope Scope) *T {
+	d.b.SetScope(scope)
+	return *(**T)(unsafe.Pointer(&d))
+}
+
+// Primary marks the bean to be injected when several beans match a
+// field or argument without a bean name.
+func (d *beanBuilder[T]) Primary() *T {
+	d.b.SetPrimary()
+	return *(**T)(unsafe.Pointer(&d))
+}
+
+// Fallback marks the bean to be injected only when no other bean matches
+// a field or argument without a bean name.
+func (d *beanBuilder[T]) Fallback() *T {
+	d.b.SetFallback()
+	return *(**T)(unsafe.Pointer(&d))
+}
+
+// Order sets the position of the bean in the injected collections,
+// where lower orders come first.
+func (d *beanBuilder[T]) Order(order int) *T {
+	d.b.SetOrder(order)
+	return *(**T)(unsafe.Pointer(&d))
+}
+
+// Qualifier adds the qualifiers of the bean, which collections select
+// with "@qualifier" in their autowire tags.
+func (d *beanBuilder[T]) Qualifier(qualifiers ...string) *T {
+	d.b.SetQualifier(qualifiers...)
+	return *(**T)(unsafe.Pointer(&d))
+}
+
 // OnProfiles sets the profiles that the bean will be active in.
 func (d *beanBuilder[T]) OnProfiles(profiles string) *T {
 	d.b.OnProfiles(profiles)
@@ -57,3 +57,24 @@ func TestBeanSelector(t *testing.T) {
 		assert.That(t, fmt.Sprint(s)).Equal("{Type:io.Writer,Name:writer}")
 	})
 }
+
+func TestPropertiesRefreshed_ChangesOf(t *testing.T) {
+	e := PropertiesRefreshed{
+		Changes: []PropertyChange{
+			{Key: "db", Kind: ChangeAdded},
+			{Key: "db.host", Kind: ChangeModified},
+			{Key: "db[0]", Kind: ChangeRemoved},
+			{Key: "dbx", Kind: ChangeAdded},
+			{Key: "http.addr", Kind: ChangeAdded},
+		},
+	}
+	assert.That(t, e.ChangesOf("db")).Equal([]PropertyChange{
+		{Key: "db", Kind: ChangeAdded},
+		{Key: "db.host", Kind: ChangeModified},
+		{Key: "db[0]", Kind: ChangeRemoved},
+	})
+	assert.That(t, e.ChangesOf("db.host")).Equal([]PropertyChange{
+		{Key: "db.host", Kind: ChangeModified},
+	})
+	assert.That(t, e.ChangesOf("log")).Nil()
+}
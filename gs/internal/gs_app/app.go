@@ -19,9 +19,13 @@ package gs_app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-spring/log"
 	"github.com/go-spring/spring-base/util"
@@ -29,26 +33,119 @@ import (
 	"github.com/go-spring/spring-core/gs/internal/gs"
 	"github.com/go-spring/spring-core/gs/internal/gs_conf"
 	"github.com/go-spring/spring-core/gs/internal/gs_core"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/resolving"
+	"github.com/go-spring/spring-core/gs/internal/gs_dync"
+	"github.com/go-spring/spring-core/gs/internal/gs_event"
 	"github.com/go-spring/spring-core/util/goutil"
 )
 
+// Phase is a stage of the application lifecycle.
+type Phase int32
+
+const (
+	PhaseCreated  = Phase(iota) // Not started yet
+	PhaseStarting               // Loading config, wiring beans and starting servers
+	PhaseRunning                // Started and serving requests
+	PhaseStopping               // Shutting down servers and jobs
+	PhaseStopped                // Fully shut down
+)
+
+// String returns the name of the phase.
+func (p Phase) String() string {
+	switch p {
+	case PhaseCreated:
+		return "created"
+	case PhaseStarting:
+		return "starting"
+	case PhaseRunning:
+		return "running"
+	case PhaseStopping:
+		return "stopping"
+	case PhaseStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// JobStats holds counters of the jobs launched by the application.
+type JobStats struct {
+	Started int64 // number of jobs started
+	Running int64 // number of jobs not yet finished
+	Failed  int64 // number of jobs that returned an error or panicked
+}
+
 // App represents the core application, managing its lifecycle,
 // configuration, and dependency injection.
 type App struct {
-	C *gs_core.Container // IoC container
-	P *gs_conf.AppConfig // Application configuration
+	C *gs_core.Container       // IoC container
+	P *gs_conf.AppConfig       // Application configuration
+	E *gs_event.Bus            // Application event bus
+	L *gs_dync.ChangeListeners // Callbacks subscribed to property changes
+
+	// Tracer records the startup and refresh spans, none if nil.
+	Tracer Tracer
 
 	exiting atomic.Bool        // Indicates whether the application is shutting down
+	phase   atomic.Int32       // Current lifecycle phase
 	ctx     context.Context    // Root context for managing cancellation
 	cancel  context.CancelFunc // Function to cancel the root context
-	wg      sync.WaitGroup     // WaitGroup to track running jobs and servers
+
+	refreshMutex sync.Mutex // Serializes the refreshes of the properties
+
+	tasksMutex sync.Mutex     // Guards tasks
+	tasks      []shutdownTask // Waits for the running jobs and servers
+
+	jobsStarted atomic.Int64 // Number of jobs started
+	jobsRunning atomic.Int64 // Number of jobs not yet finished
+	jobsFailed  atomic.Int64 // Number of jobs failed
+
+	schemas []*conf.Schema // Schemas of the properties consumed by modules
+
+	summary  atomic.Pointer[StartupSummary] // Summary of the last startup
+	report   atomic.Pointer[StartupReport]  // Timing report of the last startup
+	shutdown atomic.Pointer[ShutdownReport] // Report of the shutdown
+
+	exitCode atomic.Int32 // Exit code returned by the command
+	command  atomic.Bool  // Indicates whether a command has run
 
 	Runners []gs.Runner `autowire:"${spring.app.runners:=?}"`
 	Jobs    []gs.Job    `autowire:"${spring.app.jobs:=?}"`
 	Servers []gs.Server `autowire:"${spring.app.servers:=?}"`
 
+	Commands []gs.Command `autowire:"${spring.app.commands:=?}"`
+
+	RunnerErrorPolicy gs.ErrorPolicy `value:"${spring.app.runner.error-policy:=fail}"`
+
+	Listeners []gs.EventListener `autowire:"${spring.app.listeners:=?}"`
+
 	EnableJobs    bool `value:"${spring.app.enable-jobs:=true}"`
 	EnableServers bool `value:"${spring.app.enable-servers:=true}"`
+
+	WatchLocalConfig bool `value:"${spring.app.config-local.watch:=false}"`
+
+	LogStartupSummary bool `value:"${spring.app.startup-summary.enabled:=true}"`
+	LogStartupReport  bool `value:"${spring.app.startup.report:=false}"`
+
+	ShutdownTimeout time.Duration `value:"${spring.app.shutdown.timeout:=30s}"`
+
+	EnableTracing  bool   `value:"${spring.enable.otel-tracing:=true}"`
+	GoroutineSpans string `value:"${spring.app.goroutine-spans:=none}"`
+}
+
+// defaultApp is the application run by the gs package, see Default.
+var defaultApp atomic.Pointer[App]
+
+// SetDefault sets the application run by the gs package.
+func SetDefault(app *App) {
+	defaultApp.Store(app)
+}
+
+// Default returns the application run by the gs package, so that the
+// packages extending it, such as gs/metrics, can reach its statistics.
+func Default() *App {
+	return defaultApp.Load()
 }
 
 // NewApp creates and initializes a new application instance.
@@ -57,6 +154,8 @@ func NewApp() *App {
 	return &App{
 		C:      gs_core.New(),
 		P:      gs_conf.NewAppConfig(),
+		E:      gs_event.New(),
+		L:      gs_dync.NewChangeListeners(),
 		ctx:    ctx,
 		cancel: cancel,
 	}
@@ -66,13 +165,24 @@ func NewApp() *App {
 // 1. Registers the App itself as a root bean.
 // 2. Loads application configuration.
 // 3. Refreshes the IoC container to initialize and wire beans.
-// 4. Runs all registered Runners.
-// 5. Launches Jobs (if enabled) as background goroutines.
-// 6. Starts all Servers (if enabled) and waits for readiness.
+// 4. Subscribes all EventListeners to the application event bus.
+// 5. Runs all registered Runners, in their order.
+// 6. Runs the Command (if any) and shuts down, skipping the next steps.
+// 7. Launches Jobs (if enabled) as background goroutines.
+// 8. Starts all Servers (if enabled) and waits for readiness.
+// 9. Watches the local configuration files and the remote config provider
+// for changes (if enabled).
+// 10. Logs the startup summary (if enabled).
 func (app *App) Start() error {
+	app.phase.Store(int32(PhaseStarting))
+	timer := newStartupTimer()
+
 	// Register App as a root bean in the container
 	app.C.Root(app.C.Object(app))
 
+	// Register the event bus so that beans can publish events
+	app.C.Object(app.E).Export(gs.As[gs.EventPublisher]())
+
 	// Load layered application properties
 	var p conf.Properties
 	{
@@ -80,38 +190,60 @@ func (app *App) Start() error {
 		if p, err = app.P.Refresh(); err != nil {
 			return err
 		}
+		if err = app.checkSchemas(p); err != nil {
+			return err
+		}
 	}
+	timer.mark("config")
 
 	// Refresh the container to wire all beans
 	if err := app.C.Refresh(p); err != nil {
 		return err
 	}
+	timer.mark("container")
+
+	// Configure the spans of the goroutines launched by goutil
+	if err := app.traceGoroutines(); err != nil {
+		return err
+	}
+
+	// Subscribe all registered EventListeners
+	for _, l := range app.Listeners {
+		app.E.Subscribe(l)
+	}
 
 	// Run all registered Runners
-	for _, r := range app.Runners {
-		if err := r.Run(); err != nil {
-			return err
-		}
+	if err := app.runRunners(); err != nil {
+		return err
+	}
+	timer.mark("runners")
+
+	// Run the Command (if any) instead of jobs and servers
+	if len(app.Commands) > 0 {
+		return app.runCommand()
 	}
 
 	// Launch all Jobs (if enabled) as background tasks
 	if app.EnableJobs {
 		for _, job := range app.Jobs {
-			app.wg.Add(1)
-			goutil.Go(app.ctx, func(ctx context.Context) {
-				defer app.wg.Done()
+			app.jobsStarted.Add(1)
+			app.jobsRunning.Add(1)
+			app.track(fmt.Sprintf("job %T", job), goutil.Go(app.ctx, func(ctx context.Context) {
+				defer app.jobsRunning.Add(-1)
 				defer func() {
 					// Handle unexpected panics by shutting down the app
 					if r := recover(); r != nil {
+						app.jobsFailed.Add(1)
 						app.ShutDown()
 						panic(r)
 					}
 				}()
 				if err := job.Run(ctx); err != nil {
 					log.Errorf(ctx, log.TagAppDef, "job run error: %v", err)
+					app.jobsFailed.Add(1)
 					app.ShutDown()
 				}
-			})
+			}))
 		}
 	}
 
@@ -120,9 +252,7 @@ func (app *App) Start() error {
 		sig := NewReadySignal() // Used to coordinate readiness among servers
 		for _, svr := range app.Servers {
 			sig.Add()
-			app.wg.Add(1)
-			goutil.Go(app.ctx, func(ctx context.Context) {
-				defer app.wg.Done()
+			app.track(fmt.Sprintf("server %T", svr), goutil.Go(app.ctx, func(ctx context.Context) {
 				defer func() {
 					// Handle server panics by intercepting readiness and shutting down
 					if r := recover(); r != nil {
@@ -139,7 +269,7 @@ func (app *App) Start() error {
 				} else {
 					log.Infof(ctx, log.TagAppDef, "server closed")
 				}
-			})
+			}))
 		}
 
 		// Wait for all servers to be ready
@@ -150,29 +280,380 @@ func (app *App) Start() error {
 		}
 		log.Infof(app.ctx, log.TagAppDef, "ready to serve requests")
 		sig.Close()
+		timer.mark("servers")
+	}
+
+	// Watch the local configuration files (if enabled)
+	if app.WatchLocalConfig {
+		if err := app.watchLocalConfig(); err != nil {
+			return err
+		}
 	}
+
+	// Watch the remote config provider (if any)
+	if app.P.RemoteProvider != nil {
+		if err := app.watchRemoteConfig(app.P.RemoteProvider); err != nil {
+			return err
+		}
+	}
+
+	summary := app.buildSummary(p, timer)
+	app.summary.Store(&summary)
+	app.traceStartup(timer)
+	if app.LogStartupSummary {
+		log.Infof(app.ctx, log.TagAppDef, "%s", summary)
+	}
+	report := app.buildReport(timer)
+	app.report.Store(&report)
+	if app.LogStartupReport {
+		log.Infof(app.ctx, log.TagAppDef, "%s", report)
+	}
+
+	// Don't move out of stopping if ShutDown was called while starting
+	app.phase.CompareAndSwap(int32(PhaseStarting), int32(PhaseRunning))
 	return nil
 }
 
+// Validate loads the properties and checks the wiring of the beans like
+// Start, but without constructing the beans, running the runners, jobs
+// and commands, or starting the servers. It returns all the wiring errors
+// found at once. The application can't be started after being validated.
+func (app *App) Validate() error {
+
+	// Register the same root beans as Start
+	app.C.Root(app.C.Object(app))
+	app.C.Object(app.E).Export(gs.As[gs.EventPublisher]())
+
+	p, err := app.P.Refresh()
+	if err != nil {
+		return err
+	}
+	if err = app.checkSchemas(p); err != nil {
+		return err
+	}
+	return app.C.Validate(p)
+}
+
+// AddSchema declares the properties consumed by a module, which are
+// checked against the loaded properties, see checkSchemas.
+func (app *App) AddSchema(s *conf.Schema) {
+	app.schemas = append(app.schemas, s)
+}
+
+// Schemas returns the declared schemas of the properties.
+func (app *App) Schemas() []*conf.Schema {
+	return app.schemas
+}
+
+// checkSchemas checks the properties against the declared schemas, by the
+// "spring.config.schema-check" property: "none" skips the check, "warn"
+// logs the unknown properties and the invalid values, and "fail" returns
+// them as an error. The deprecated keys in use, and the conflicts resolved
+// between the config sources, are logged in any mode.
+func (app *App) checkSchemas(p conf.Properties) error {
+	if cs := app.P.Conflicts(); len(cs) > 0 {
+		log.Warn(app.ctx, log.TagAppDef,
+			log.Msg("property conflicts between config sources resolved"),
+			log.Reflect("conflicts", cs))
+	}
+	if ds := conf.Deprecations(p); len(ds) > 0 {
+		log.Warn(app.ctx, log.TagAppDef,
+			log.Msg("deprecated properties in use, rename them to the current keys"),
+			log.Reflect("deprecations", ds))
+	}
+	mode := p.Get("spring.config.schema-check", "warn")
+	switch mode {
+	case "none":
+		return nil
+	case "warn", "fail":
+	default:
+		return util.FormatError(nil, "invalid schema check mode %q", mode)
+	}
+	err := conf.CheckSchemas(p, app.schemas)
+	if err == nil {
+		return nil
+	}
+	if mode == "warn" {
+		log.Warnf(app.ctx, log.TagAppDef, "config schema check: %v", err)
+		return nil
+	}
+	return util.FormatError(err, "config schema check error")
+}
+
+// runRunners runs the Runners in their order. A failed runner stops the
+// application unless its error policy is ContinueOnError.
+func (app *App) runRunners() error {
+	switch app.RunnerErrorPolicy {
+	case gs.FailOnError, gs.ContinueOnError:
+	default:
+		return util.FormatError(nil, "invalid runner error policy %q", app.RunnerErrorPolicy)
+	}
+	for _, r := range app.Runners {
+		err := r.Run()
+		if err == nil {
+			continue
+		}
+		policy := app.RunnerErrorPolicy
+		if p, ok := r.(gs.RunnerPolicy); ok {
+			policy = p.ErrorPolicy()
+		}
+		if policy != gs.ContinueOnError {
+			return err
+		}
+		log.Errorf(app.ctx, log.TagAppDef, "runner run error: %v", err)
+	}
+	return nil
+}
+
+// runCommand runs the only Command to completion, records its exit code
+// and shuts down the application. A command error is logged, and its exit
+// code is 1 if the command returned 0.
+func (app *App) runCommand() error {
+	if n := len(app.Commands); n > 1 {
+		return util.FormatError(nil, "found %d commands, expected at most one", n)
+	}
+	code, err := app.Commands[0].Run(app.ctx)
+	if err != nil {
+		log.Errorf(app.ctx, log.TagAppDef, "command run error: %v", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	app.exitCode.Store(int32(code))
+	app.command.Store(true)
+	app.ShutDown()
+	return nil
+}
+
+// ExitCode returns the exit code of the Command, and whether a command
+// has run.
+func (app *App) ExitCode() (int, bool) {
+	return int(app.exitCode.Load()), app.command.Load()
+}
+
+// Phase returns the current lifecycle phase of the application.
+func (app *App) Phase() Phase {
+	return Phase(app.phase.Load())
+}
+
+// JobStats returns a snapshot of the job counters.
+func (app *App) JobStats() JobStats {
+	return JobStats{
+		Started: app.jobsStarted.Load(),
+		Running: app.jobsRunning.Load(),
+		Failed:  app.jobsFailed.Load(),
+	}
+}
+
+// StartupSummary returns the summary of the application startup, or nil
+// if the application is not started yet.
+func (app *App) StartupSummary() *StartupSummary {
+	return app.summary.Load()
+}
+
+// StartupReport returns the timing report of the application startup, or
+// nil if the application is not started yet.
+func (app *App) StartupReport() *StartupReport {
+	return app.report.Load()
+}
+
+// DependencyGraph returns the dependency graph of the beans wired by the
+// container, or nil if the container is not refreshed yet.
+func (app *App) DependencyGraph() *injecting.Graph {
+	if app.C.Injecting == nil {
+		return nil
+	}
+	return app.C.Graph()
+}
+
+// AutoConfigReport returns the auto-configurations evaluated by the
+// container, and whether they matched.
+func (app *App) AutoConfigReport() []resolving.AutoConfigOutcome {
+	return app.C.AutoConfigOutcomes()
+}
+
+// GetBean returns the bean of type t matching the name if it is not
+// empty, constructing it on demand if needed.
+func (app *App) GetBean(ctx context.Context, t reflect.Type, name string) (reflect.Value, error) {
+	if app.C.Injecting == nil {
+		return reflect.Value{}, util.FormatError(nil, "application is not started")
+	}
+	return app.C.Get(ctx, t, name)
+}
+
+// RefreshProperties reloads the application properties from all sources
+// and applies them to the container. The outcome is published on the event
+// bus as a [gs.PropertiesRefreshed] event.
+func (app *App) RefreshProperties() (err error) {
+	start := time.Now()
+	endSpan := app.startRefreshSpan(start)
+	defer func() { endSpan(err) }()
+
+	app.refreshMutex.Lock()
+	defer app.refreshMutex.Unlock()
+
+	p, err := app.P.Refresh()
+	if err != nil {
+		app.E.Publish(app.ctx, gs.PropertiesRefreshed{
+			Duration: time.Since(start),
+			Err:      err,
+		})
+		return err
+	}
+	return app.applyProperties(start, p)
+}
+
+// applyProperties applies the refreshed properties to the container,
+// notifies the change listeners and publishes the outcome, with the time
+// spent since start. The properties are applied atomically: if any value
+// bound by the container or the change listeners is invalid, nothing is
+// applied, and the error is published without changes. It must be called
+// with refreshMutex held, from reading the current properties to
+// publishing the event, so that concurrent refreshes don't compute their
+// diffs from the same properties.
+func (app *App) applyProperties(start time.Time, p conf.Properties) error {
+	old := app.C.Properties()
+	diff := gs_dync.Diff(old, p)
+	err := app.checkSchemas(p)
+	var notify func()
+	if err == nil {
+		notify, err = app.L.Prepare(app.ctx, old, p, diff)
+	}
+	if err == nil {
+		err = app.C.RefreshPropertiesDiff(old, p, diff)
+	}
+	if err != nil {
+		app.E.Publish(app.ctx, gs.PropertiesRefreshed{
+			Duration: time.Since(start),
+			Err:      err,
+		})
+		return err
+	}
+	notify()
+
+	var changes []gs.PropertyChange
+	for _, c := range diff {
+		changes = append(changes, gs.PropertyChange{
+			Key:      c.Key,
+			Kind:     c.Kind,
+			OldValue: c.Old,
+			NewValue: c.New,
+			Source:   c.Source,
+		})
+	}
+	app.E.Publish(app.ctx, gs.PropertiesRefreshed{
+		Duration: time.Since(start),
+		Changes:  changes,
+	})
+	return nil
+}
+
+// watchRemoteConfig refreshes the properties each time the properties of
+// the remote config provider change, until the application shuts down.
+func (app *App) watchRemoteConfig(provider gs_conf.RemoteConfigProvider) error {
+	ch, err := provider.Watch(app.ctx)
+	if err != nil {
+		return err
+	}
+	goutil.Go(app.ctx, func(ctx context.Context) {
+		for range ch {
+			if err := app.RefreshProperties(); err != nil {
+				log.Errorf(ctx, log.TagAppDef, "refresh properties error: %v", err)
+			}
+		}
+	})
+	return nil
+}
+
+// watchLocalConfig applies the properties refreshed after each change of
+// the local configuration files, until the application shuts down.
+func (app *App) watchLocalConfig() error {
+	ch, err := app.P.WatchLocal(app.ctx)
+	if err != nil {
+		return err
+	}
+	goutil.Go(app.ctx, func(ctx context.Context) {
+		for p := range ch {
+			app.refreshMutex.Lock()
+			err := app.applyProperties(time.Now(), p)
+			app.refreshMutex.Unlock()
+			if err != nil {
+				log.Errorf(ctx, log.TagAppDef, "refresh properties error: %v", err)
+			}
+		}
+	})
+	return nil
+}
+
+// track records the status of a running job or server, so that the
+// shutdown waits for it to finish.
+func (app *App) track(name string, s *goutil.Status) {
+	app.tasksMutex.Lock()
+	defer app.tasksMutex.Unlock()
+	done := make(chan struct{})
+	go func() {
+		s.Wait()
+		close(done)
+	}()
+	app.tasks = append(app.tasks, shutdownTask{name: name, done: done})
+}
+
 // WaitForShutdown waits for the application to be signaled to shut down
-// and then gracefully stops all servers and jobs.
+// and then gracefully stops it in phases: it shuts down the servers,
+// drains the running jobs and servers, and destroys the beans. All the
+// phases must complete within "spring.app.shutdown.timeout" (30s by
+// default, 0 for no limit); the components still running afterwards are
+// abandoned and reported.
 func (app *App) WaitForShutdown() {
 	// Wait until the application context is cancelled (triggered by ShutDown)
 	<-app.ctx.Done()
 
+	m := newShutdownManager(app.ShutdownTimeout)
+
 	// Gracefully shut down all running servers
+	var servers []shutdownTask
 	for _, svr := range app.Servers {
-		goutil.Go(app.ctx, func(ctx context.Context) {
-			if err := svr.Shutdown(context.Background()); err != nil {
-				log.Errorf(ctx, log.TagAppDef, "shutdown server failed: %v", err)
-			}
+		servers = append(servers, shutdownTask{
+			name: fmt.Sprintf("server %T", svr),
+			fn: func(ctx context.Context) {
+				if err := svr.Shutdown(ctx); err != nil {
+					log.Errorf(ctx, log.TagAppDef, "shutdown server failed: %v", err)
+				}
+			},
 		})
 	}
-	app.wg.Wait()
-	app.C.Close()
+	m.run("servers", servers)
+
+	// Wait for the jobs and servers to finish their in-flight work
+	app.tasksMutex.Lock()
+	tasks := app.tasks
+	app.tasksMutex.Unlock()
+	m.run("drain", tasks)
+
+	// Destroy the beans, dependents first
+	if app.C.Injecting != nil {
+		m.run("beans", []shutdownTask{{
+			name: "beans",
+			fn:   func(context.Context) { app.C.Close() },
+		}})
+	}
+
+	report := m.done()
+	app.shutdown.Store(&report)
+	if exceeded := report.Exceeded(); len(exceeded) > 0 {
+		log.Warnf(app.ctx, log.TagAppDef, "shutdown timeout after %s, still running: %s",
+			report.Timeout, strings.Join(exceeded, ", "))
+	}
+	app.phase.Store(int32(PhaseStopped))
 	log.Infof(app.ctx, log.TagAppDef, "shutdown complete")
 }
 
+// ShutdownReport returns the report of the shutdown, or nil if the
+// application is not shut down yet.
+func (app *App) ShutdownReport() *ShutdownReport {
+	return app.shutdown.Load()
+}
+
 // Exiting returns whether the application is currently in the process of shutting down.
 func (app *App) Exiting() bool {
 	return app.exiting.Load()
@@ -182,6 +663,7 @@ func (app *App) Exiting() bool {
 // setting the exiting flag and cancelling the root context.
 func (app *App) ShutDown() {
 	if app.exiting.CompareAndSwap(false, true) {
+		app.phase.Store(int32(PhaseStopping))
 		log.Infof(app.ctx, log.TagAppDef, "shutting down")
 		app.cancel()
 	}
@@ -22,6 +22,13 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,6 +38,8 @@ import (
 	"github.com/go-spring/spring-core/conf"
 	"github.com/go-spring/spring-core/gs/internal/gs"
 	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
+	"github.com/go-spring/spring-core/gs/internal/gs_dync"
 	"github.com/go-spring/spring-core/util/goutil"
 )
 
@@ -50,6 +59,14 @@ func Reset() {
 	gs_conf.SysConf = conf.New()
 }
 
+// addrServer is a server that reports its address.
+type addrServer struct {
+	gs.Server
+	addr string
+}
+
+func (s *addrServer) Addr() string { return s.addr }
+
 func TestApp(t *testing.T) {
 
 	t.Run("property conflict", func(t *testing.T) {
@@ -128,6 +145,359 @@ func TestApp(t *testing.T) {
 		assert.Error(t, err).Matches("runner error")
 	})
 
+	t.Run("runners in order", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		var ret []string
+		app := NewApp()
+		for _, name := range []string{"r1", "r2", "r3"} {
+			r := gs.FuncRunner(func() error {
+				ret = append(ret, name)
+				return nil
+			})
+			order := map[string]int{"r1": 3, "r2": 1, "r3": 2}[name]
+			app.C.Object(r).AsRunner().Name(name).Order(order)
+		}
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+		assert.That(t, ret).Equal([]string{"r2", "r3", "r1"})
+	})
+
+	t.Run("runner continue on error", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		var ran bool
+		app := NewApp()
+		r1 := &gs.PolicyRunner{
+			Runner: gs.FuncRunner(func() error {
+				return errors.New("runner error")
+			}),
+			Policy: gs.ContinueOnError,
+		}
+		app.C.Object(r1).AsRunner().Name("r1").Order(1)
+		r2 := gs.FuncRunner(func() error {
+			ran = true
+			return nil
+		})
+		app.C.Object(r2).AsRunner().Name("r2").Order(2)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+		assert.That(t, ran).True()
+		assert.String(t, logBuf.String()).Contains("runner run error: runner error")
+	})
+
+	t.Run("runner error policy property", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.runner.error-policy", "continue", fileID)
+		app := NewApp()
+		r1 := gs.FuncRunner(func() error {
+			return errors.New("runner error")
+		})
+		app.C.Object(r1).AsRunner().Name("r1")
+		r2 := &gs.PolicyRunner{
+			Runner: gs.FuncRunner(func() error {
+				return errors.New("fatal runner error")
+			}),
+			Policy: gs.FailOnError,
+		}
+		app.C.Object(r2).AsRunner().Name("r2")
+		err := app.Start()
+		assert.Error(t, err).Matches("fatal runner error")
+		assert.String(t, logBuf.String()).Contains("runner run error: runner error")
+	})
+
+	t.Run("invalid runner error policy", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.runner.error-policy", "ignore", fileID)
+		app := NewApp()
+		err := app.Start()
+		assert.Error(t, err).Matches(`invalid runner error policy "ignore"`)
+	})
+
+	t.Run("command", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		_, ok := app.ExitCode()
+		assert.That(t, ok).False()
+
+		c := gs.FuncCommand(func(ctx context.Context) (int, error) {
+			return 3, nil
+		})
+		app.C.Object(c).AsCommand()
+		m := gsmock.NewManager()
+		svr := gs.NewServerMockImpl(m)
+		svr.MockShutdown().ReturnDefault()
+		app.C.Object(svr).AsServer()
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, app.Exiting()).True()
+		app.WaitForShutdown()
+
+		code, ok := app.ExitCode()
+		assert.That(t, ok).True()
+		assert.That(t, code).Equal(3)
+		assert.That(t, app.StartupSummary()).Nil()
+	})
+
+	t.Run("command return error", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		c := gs.FuncCommand(func(ctx context.Context) (int, error) {
+			return 0, errors.New("command error")
+		})
+		app.C.Object(c).AsCommand()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+
+		code, ok := app.ExitCode()
+		assert.That(t, ok).True()
+		assert.That(t, code).Equal(1)
+		assert.String(t, logBuf.String()).Contains("command run error: command error")
+	})
+
+	t.Run("multiple commands", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		for _, name := range []string{"c1", "c2"} {
+			c := gs.FuncCommand(func(ctx context.Context) (int, error) {
+				return 0, nil
+			})
+			app.C.Object(c).AsCommand().Name(name)
+		}
+		err := app.Start()
+		assert.Error(t, err).Matches("found 2 commands, expected at most one")
+	})
+
+	t.Run("event listeners", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		type Publisher struct {
+			E gs.EventPublisher `autowire:""`
+		}
+
+		var events []any
+		app := NewApp()
+		p := &Publisher{}
+		app.C.Root(app.C.Object(p))
+		app.C.Object(gs.FuncEventListener(func(ctx context.Context, event any) {
+			events = append(events, event)
+		})).Export(gs.As[gs.EventListener]())
+		app.C.Object(gs.FuncRunner(func() error {
+			p.E.Publish(context.Background(), "started")
+			return nil
+		})).AsRunner()
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		err := app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, len(app.Listeners)).Equal(1)
+		assert.That(t, events).Equal([]any{"started"})
+		assert.That(t, app.Phase()).Equal(PhaseRunning)
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("refresh properties", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		var events []gs.PropertiesRefreshed
+		app := NewApp()
+		app.C.Object(gs.FuncEventListener(func(ctx context.Context, event any) {
+			if e, ok := event.(gs.PropertiesRefreshed); ok {
+				events = append(events, e)
+			}
+		})).Export(gs.As[gs.EventListener]())
+
+		var values [][2]int
+		_, err := gs_dync.Listen(app.L, "a", func(old, new int) {
+			values = append(values, [2]int{old, new})
+		})
+		assert.That(t, err).Nil()
+
+		dir := t.TempDir()
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.config-local.dir", dir, fileID)
+		_ = gs_conf.SysConf.Set("a", "1", fileID)
+		err = app.Start()
+		assert.That(t, err).Nil()
+
+		_ = gs_conf.SysConf.Set("a", "2", fileID)
+		_ = gs_conf.SysConf.Set("b", "1", fileID)
+		err = app.RefreshProperties()
+		assert.That(t, err).Nil()
+		assert.That(t, app.C.Properties().Get("a")).Equal("2")
+
+		err = os.WriteFile(dir+"/app.yaml", []byte("a: [1"), os.ModePerm)
+		assert.That(t, err).Nil()
+		err = app.RefreshProperties()
+		assert.Error(t, err).Matches("refresh error in source local")
+
+		assert.That(t, len(events)).Equal(2)
+		assert.That(t, events[0].Err).Nil()
+		assert.That(t, events[0].Changes).Equal([]gs.PropertyChange{
+			{Key: "a", Kind: gs.ChangeModified, OldValue: "1", NewValue: "2", Source: "app_test.go"},
+			{Key: "b", Kind: gs.ChangeAdded, NewValue: "1", Source: "app_test.go"},
+		})
+		assert.That(t, events[1].Err).Equal(err)
+		assert.That(t, events[1].Changes).Nil()
+		assert.That(t, values).Equal([][2]int{{1, 2}})
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("concurrent refreshes", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		var last atomic.Int64
+		_, err := gs_dync.Listen(app.L, "a", func(old, new int) {
+			last.Store(int64(new))
+		})
+		assert.That(t, err).Nil()
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("a", "0", fileID)
+		err = app.Start()
+		assert.That(t, err).Nil()
+
+		var wg sync.WaitGroup
+		for i := 1; i <= 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = gs_conf.SysConf.Set("a", strconv.Itoa(i), fileID)
+				assert.That(t, app.RefreshProperties()).Nil()
+			}()
+		}
+		wg.Wait()
+
+		// the listeners saw the last applied value
+		assert.That(t, strconv.FormatInt(last.Load(), 10)).Equal(app.C.Properties().Get("a"))
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("refresh properties rollback", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		var events []gs.PropertiesRefreshed
+		app := NewApp()
+		app.C.Object(gs.FuncEventListener(func(ctx context.Context, event any) {
+			if e, ok := event.(gs.PropertiesRefreshed); ok {
+				events = append(events, e)
+			}
+		})).Export(gs.As[gs.EventListener]())
+
+		cfg := &struct {
+			A gs_dync.Value[int] `value:"${a}"`
+		}{}
+		app.C.Root(app.C.Object(cfg))
+
+		var values []string
+		_, err := gs_dync.Listen(app.L, "b", func(old, new string) {
+			values = append(values, new)
+		})
+		assert.That(t, err).Nil()
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.config-local.dir", t.TempDir(), fileID)
+		_ = gs_conf.SysConf.Set("a", "1", fileID)
+		err = app.Start()
+		assert.That(t, err).Nil()
+
+		_ = gs_conf.SysConf.Set("a", "x", fileID)
+		_ = gs_conf.SysConf.Set("b", "2", fileID)
+		err = app.RefreshProperties()
+		assert.Error(t, err).Matches("strconv.ParseInt: parsing \"x\": invalid syntax")
+		assert.That(t, cfg.A.Value()).Equal(1)
+		assert.That(t, app.C.Properties().Get("a")).Equal("1")
+		assert.That(t, app.C.Properties().Has("b")).False()
+		assert.That(t, values).Nil()
+		assert.That(t, len(events)).Equal(1)
+		assert.That(t, events[0].Err).Equal(err)
+		assert.That(t, events[0].Changes).Nil()
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("watch local config", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		events := make(chan gs.PropertiesRefreshed, 1)
+		app := NewApp()
+		app.C.Object(gs.FuncEventListener(func(ctx context.Context, event any) {
+			if e, ok := event.(gs.PropertiesRefreshed); ok {
+				events <- e
+			}
+		})).Export(gs.As[gs.EventListener]())
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "app.properties")
+		assert.That(t, os.WriteFile(file, []byte("a=1"), os.ModePerm)).Nil()
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.config-local.dir", dir, fileID)
+		_ = gs_conf.SysConf.Set("spring.app.config-local.watch", "true", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.config-local.watch-interval", "10ms", fileID)
+		err := app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, app.C.Properties().Get("a")).Equal("1")
+
+		assert.That(t, os.WriteFile(file, []byte("a=22"), os.ModePerm)).Nil()
+		select {
+		case e := <-events:
+			assert.That(t, e.Err).Nil()
+			assert.That(t, e.Changes).Equal([]gs.PropertyChange{
+				{Key: "a", Kind: gs.ChangeModified, OldValue: "1", NewValue: "22", Source: file},
+			})
+		case <-time.After(5 * time.Second):
+			t.Fatal("no refresh after the config file changed")
+		}
+		assert.That(t, app.C.Properties().Get("a")).Equal("22")
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
 	t.Run("disable jobs & servers", func(t *testing.T) {
 		Reset()
 		t.Cleanup(Reset)
@@ -152,6 +522,184 @@ func TestApp(t *testing.T) {
 		assert.String(t, logBuf.String()).Contains("shutdown complete")
 	})
 
+	t.Run("dependency graph", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		assert.That(t, app.DependencyGraph()).Nil()
+
+		r := gs.FuncRunner(func() error { return nil })
+		app.C.Object(r).Name("runner").AsRunner()
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+
+		g := app.DependencyGraph()
+		ids := make(map[string]int)
+		for _, n := range g.Nodes {
+			ids[n.Name] = n.ID
+		}
+		edge := injecting.GraphEdge{From: ids["App"], To: ids["runner"]}
+		assert.That(t, slices.Contains(g.Edges, edge)).True()
+	})
+
+	t.Run("startup summary", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.profiles.active", "dev, test", fileID)
+		app := NewApp()
+		assert.That(t, app.StartupSummary()).Nil()
+
+		m := gsmock.NewManager()
+		r := gs.NewServerMockImpl(m)
+		r.MockShutdown().ReturnDefault()
+		r.MockListenAndServe().Handle(func(sig gs.ReadySignal) error {
+			<-sig.TriggerAndWait()
+			return nil
+		})
+		app.C.Object(&addrServer{r, ":8080"}).AsServer().Name("s1")
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+
+		s := app.StartupSummary()
+		assert.That(t, s.Profiles).Equal([]string{"dev", "test"})
+		assert.That(t, s.Addresses).Equal([]string{":8080"})
+		assert.That(t, s.Beans > 0).True()
+		var phases []string
+		for _, p := range s.Phases {
+			phases = append(phases, p.Name)
+		}
+		assert.That(t, phases).Equal([]string{"config", "container", "runners", "servers"})
+		assert.String(t, logBuf.String()).Contains("startup summary:")
+		assert.String(t, logBuf.String()).Contains("servers: :8080")
+	})
+
+	t.Run("startup summary disabled", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.startup-summary.enabled", "false", fileID)
+		app := NewApp()
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+		assert.That(t, app.StartupSummary()).NotNil()
+		assert.That(t, strings.Contains(logBuf.String(), "startup summary:")).False()
+	})
+
+	t.Run("startup report", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.startup.report", "true", fileID)
+		app := NewApp()
+		assert.That(t, app.StartupReport()).Nil()
+
+		app.C.Root(app.C.Provide(func() *http.Client {
+			time.Sleep(20 * time.Millisecond)
+			return &http.Client{}
+		}).Name("slow"))
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+
+		r := app.StartupReport()
+		var phases []string
+		for _, p := range r.Phases {
+			phases = append(phases, p.Name)
+		}
+		assert.That(t, phases).Equal([]string{"config", "container", "runners", "servers"})
+		container := r.Phases[1].Children
+		assert.That(t, len(container)).Equal(2)
+		var steps []string
+		for _, s := range container[0].Children {
+			steps = append(steps, s.Name)
+		}
+		assert.That(t, steps).Equal([]string{"modules", "auto-configs", "scan", "conditions"})
+		assert.That(t, container[1].Children[0].Name).Equal("bean slow")
+		assert.That(t, container[1].Children[0].Duration >= 20*time.Millisecond).True()
+		assert.String(t, logBuf.String()).Contains("startup report (")
+		assert.String(t, r.String()).Contains("      bean slow")
+	})
+
+	t.Run("config schema check", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("db.hots", "h1", fileID)
+		s, err := conf.NewSchema("db", reflect.TypeFor[struct {
+			Host string `value:"${host:=}"`
+		}]())
+		assert.That(t, err).Nil()
+
+		app := NewApp()
+		app.AddSchema(s)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err = app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+		assert.String(t, logBuf.String()).Contains(`unknown property \"db.hots\", did you mean \"db.host\"?`)
+
+		Reset()
+		fileID = gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("db.hots", "h1", fileID)
+		_ = gs_conf.SysConf.Set("spring.config.schema-check", "fail", fileID)
+		app = NewApp()
+		app.AddSchema(s)
+		err = app.Start()
+		assert.Error(t, err).Matches("config schema check error")
+	})
+
+	t.Run("get bean", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		_, err := app.GetBean(context.Background(), reflect.TypeFor[*http.Server](), "")
+		assert.Error(t, err).Matches("application is not started")
+
+		app.C.Object(&http.Server{Addr: ":9090"}).Name("server")
+		r := gs.FuncRunner(func() error { return nil })
+		app.C.Object(r).Name("runner").AsRunner()
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			app.ShutDown()
+		}()
+		err = app.Start()
+		assert.That(t, err).Nil()
+		app.WaitForShutdown()
+
+		v, err := app.GetBean(context.Background(), reflect.TypeFor[*http.Server](), "server")
+		assert.That(t, err).Nil()
+		assert.That(t, v.Interface().(*http.Server).Addr).Equal(":9090")
+	})
+
 	t.Run("job panic", func(t *testing.T) {
 		Reset()
 		t.Cleanup(Reset)
@@ -166,6 +714,7 @@ func TestApp(t *testing.T) {
 		assert.That(t, err).Nil()
 		time.Sleep(50 * time.Millisecond)
 		assert.String(t, logBuf.String()).Contains("panic: job panic")
+		assert.That(t, app.JobStats()).Equal(JobStats{Started: 1, Failed: 1})
 	})
 
 	t.Run("job return error", func(t *testing.T) {
@@ -178,10 +727,15 @@ func TestApp(t *testing.T) {
 
 		app := NewApp()
 		app.C.Object(r).AsJob()
+		assert.That(t, app.Phase()).Equal(PhaseCreated)
 		err := app.Start()
 		assert.That(t, err).Nil()
 		time.Sleep(50 * time.Millisecond)
 		assert.String(t, logBuf.String()).Contains("job run error: job return error")
+		assert.That(t, app.JobStats()).Equal(JobStats{Started: 1, Failed: 1})
+		assert.That(t, app.Phase()).Equal(PhaseStopping)
+		app.WaitForShutdown()
+		assert.String(t, app.Phase().String()).Equal("stopped")
 	})
 
 	t.Run("job context cancel", func(t *testing.T) {
@@ -350,3 +904,127 @@ func TestApp(t *testing.T) {
 		assert.String(t, logBuf.String()).Contains("shutdown server failed: server shutdown error")
 	})
 }
+
+func TestValidate(t *testing.T) {
+
+	t.Run("beans not created", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		ran := false
+		app := NewApp()
+		app.C.Root(app.C.Provide(func() (*http.Server, error) {
+			return nil, errors.New("fail to create bean")
+		}))
+		app.C.Object(gs.FuncRunner(func() error {
+			ran = true
+			return nil
+		})).AsRunner()
+		err := app.Validate()
+		assert.That(t, err).Nil()
+		assert.That(t, ran).False()
+	})
+
+	t.Run("wiring errors", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		app.C.Provide(func(s *http.Server) *http.Client {
+			return &http.Client{}
+		})
+		app.C.Provide(func(c *http.Client, m *http.ServeMux) *http.Transport {
+			return &http.Transport{}
+		})
+		err := app.Validate()
+		assert.Error(t, err).Matches(`can't find bean, bean:"" type:"\*http.Server"`)
+		assert.Error(t, err).Matches(`can't find bean, bean:"" type:"\*http.ServeMux"`)
+	})
+}
+
+func TestShutdown(t *testing.T) {
+
+	t.Run("report", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		app := NewApp()
+		assert.That(t, app.ShutdownReport()).Nil()
+
+		var destroyed bool
+		app.C.Root(app.C.Object(&http.Server{}).Destroy(func(*http.Server) {
+			destroyed = true
+		}))
+		m := gsmock.NewManager()
+		r := gs.NewServerMockImpl(m)
+		stop := make(chan struct{})
+		r.MockShutdown().Handle(func(ctx context.Context) error {
+			close(stop)
+			return nil
+		})
+		r.MockListenAndServe().Handle(func(sig gs.ReadySignal) error {
+			<-sig.TriggerAndWait()
+			<-stop
+			return nil
+		})
+		app.C.Object(r).AsServer()
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.ShutDown()
+		app.WaitForShutdown()
+		assert.That(t, destroyed).True()
+
+		report := app.ShutdownReport()
+		assert.That(t, report.Timeout).Equal(30 * time.Second)
+		var phases []string
+		for _, p := range report.Phases {
+			phases = append(phases, p.Name)
+		}
+		assert.That(t, phases).Equal([]string{"servers", "drain", "beans"})
+		assert.That(t, report.Exceeded()).Nil()
+		assert.String(t, report.String()).Contains("phase drain:")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("app_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.shutdown.timeout", "100ms", fileID)
+		app := NewApp()
+
+		m := gsmock.NewManager()
+		r := gs.NewServerMockImpl(m)
+		r.MockShutdown().Handle(func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+		r.MockListenAndServe().Handle(func(sig gs.ReadySignal) error {
+			<-sig.TriggerAndWait()
+			return nil
+		})
+		app.C.Object(r).AsServer()
+
+		j := gs.FuncJob(func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+		app.C.Object(j).AsJob()
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.ShutDown()
+		start := time.Now()
+		app.WaitForShutdown()
+		assert.That(t, time.Since(start) < 500*time.Millisecond).True()
+
+		report := app.ShutdownReport()
+		assert.That(t, report.Exceeded()).Equal([]string{
+			"server *gs.ServerMockImpl",
+			"job gs.FuncJob",
+			"beans",
+		})
+		assert.String(t, logBuf.String()).Contains("shutdown timeout after 100ms, still running: server *gs.ServerMockImpl")
+	})
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_app
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// reportBeans is the number of the slowest beans printed in the report.
+const reportBeans = 20
+
+// reportBarWidth is the width of the bar of a step taking all the time.
+const reportBarWidth = 40
+
+// StartupNode is a timed step of the application startup, with the steps
+// it consists of, in their running order.
+type StartupNode struct {
+	Name     string
+	Duration time.Duration
+	Children []StartupNode
+}
+
+// StartupReport is the time spent starting the application by phase and,
+// within the container refresh, by step and by bean, the beans sorted by
+// the time spent creating them, dependencies excluded. It's logged when
+// the application is ready if "spring.app.startup.report" is true.
+type StartupReport struct {
+	Phases   []StartupNode
+	Duration time.Duration
+}
+
+// String returns the report as an indented tree, in the manner of a flame
+// graph: each step is followed by its duration and a bar proportional to
+// its share of the startup time. Only the slowest beans are listed.
+func (r StartupReport) String() string {
+	type line struct {
+		name string
+		d    time.Duration
+	}
+	var lines []line
+	var walk func(nodes []StartupNode, indent string)
+	walk = func(nodes []StartupNode, indent string) {
+		for i, n := range nodes {
+			if i == reportBeans && strings.HasPrefix(n.Name, "bean ") {
+				lines = append(lines, line{name: fmt.Sprintf("%s... %d more beans", indent, len(nodes)-i), d: -1})
+				return
+			}
+			lines = append(lines, line{name: indent + n.Name, d: n.Duration})
+			walk(n.Children, indent+"  ")
+		}
+	}
+	walk(r.Phases, "  ")
+
+	width := 0
+	for _, l := range lines {
+		width = max(width, len(l.name))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "startup report (%s):", r.Duration)
+	for _, l := range lines {
+		if l.d < 0 {
+			fmt.Fprintf(&sb, "\n%s", l.name)
+			continue
+		}
+		bar := 0
+		if r.Duration > 0 {
+			bar = int(int64(reportBarWidth) * int64(l.d) / int64(r.Duration))
+		}
+		text := fmt.Sprintf("%-*s %12s %s", width, l.name, l.d, strings.Repeat("#", min(bar, reportBarWidth)))
+		sb.WriteString("\n" + strings.TrimRight(text, " "))
+	}
+	return sb.String()
+}
+
+// buildReport collects the startup report of the application, with the
+// phases recorded by t.
+func (app *App) buildReport(t *startupTimer) StartupReport {
+	r := StartupReport{Duration: time.Since(t.start)}
+	for _, p := range t.phases {
+		n := StartupNode{Name: p.Name, Duration: p.Duration}
+		if p.Name == "container" {
+			n.Children = app.containerNodes()
+		}
+		r.Phases = append(r.Phases, n)
+	}
+	return r
+}
+
+// containerNodes returns the phases of the container refresh, with their
+// steps, and the beans wired by the inject phase.
+func (app *App) containerNodes() []StartupNode {
+	var nodes []StartupNode
+	for _, p := range app.C.Phases() {
+		n := StartupNode{Name: p.Name, Duration: p.End.Sub(p.Start)}
+		for _, s := range p.Steps {
+			n.Children = append(n.Children, StartupNode{Name: s.Name, Duration: s.End.Sub(s.Start)})
+		}
+		if p.Name == "inject" && app.C.Injecting != nil {
+			for _, b := range app.C.Timings() {
+				n.Children = append(n.Children, StartupNode{
+					Name:     "bean " + b.Bean.Name(),
+					Duration: b.Duration,
+				})
+			}
+			slices.SortStableFunc(n.Children, func(a, b StartupNode) int {
+				return cmp.Compare(b.Duration, a.Duration)
+			})
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
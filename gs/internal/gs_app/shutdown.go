@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// ShutdownPhase is a phase of the application shutdown.
+type ShutdownPhase struct {
+	Name     string        // name of the phase
+	Duration time.Duration // time spent in the phase
+	Exceeded []string      // components still running when the timeout expired
+}
+
+// ShutdownReport describes how the application shut down.
+type ShutdownReport struct {
+	Timeout  time.Duration   // global shutdown timeout, 0 if unlimited
+	Phases   []ShutdownPhase // shutdown phases, in their running order
+	Duration time.Duration   // total time spent shutting down
+}
+
+// Exceeded returns the components that exceeded the shutdown timeout,
+// in the order of the phases.
+func (r ShutdownReport) Exceeded() []string {
+	var ret []string
+	for _, p := range r.Phases {
+		ret = append(ret, p.Exceeded...)
+	}
+	return ret
+}
+
+// String returns the report as a multi-line text.
+func (r ShutdownReport) String() string {
+	var sb strings.Builder
+	sb.WriteString("shutdown report:\n")
+	for _, p := range r.Phases {
+		fmt.Fprintf(&sb, "  phase %s: %s", p.Name, p.Duration)
+		if len(p.Exceeded) > 0 {
+			fmt.Fprintf(&sb, " (exceeded: %s)", strings.Join(p.Exceeded, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "  stopped in %s", r.Duration)
+	return sb.String()
+}
+
+// shutdownTask is a component stopped during a shutdown phase, either by
+// running fn, or by waiting for done to be closed if fn is nil.
+type shutdownTask struct {
+	name string
+	fn   func(ctx context.Context)
+	done <-chan struct{}
+}
+
+// shutdownManager runs the shutdown phases one after another, within a
+// global timeout shared by all of them.
+type shutdownManager struct {
+	start    time.Time
+	deadline time.Time // zero if there is no timeout
+	report   ShutdownReport
+}
+
+// newShutdownManager creates a shutdownManager started now. A timeout
+// that is not positive means no limit.
+func newShutdownManager(timeout time.Duration) *shutdownManager {
+	m := &shutdownManager{start: time.Now()}
+	if timeout > 0 {
+		m.deadline = m.start.Add(timeout)
+		m.report.Timeout = timeout
+	}
+	return m
+}
+
+// run runs the tasks of a phase concurrently, and waits for them to finish
+// until the deadline. The tasks still running at the deadline are reported
+// as exceeded, and their context is cancelled.
+func (m *shutdownManager) run(name string, tasks []shutdownTask) {
+	start := time.Now()
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if !m.deadline.IsZero() {
+		ctx, cancel = context.WithDeadline(ctx, m.deadline)
+	}
+	defer cancel()
+
+	done := make([]<-chan struct{}, len(tasks))
+	for i, t := range tasks {
+		if t.fn == nil {
+			done[i] = t.done
+			continue
+		}
+		ch := make(chan struct{})
+		goutil.Go(ctx, func(ctx context.Context) {
+			defer close(ch)
+			t.fn(ctx)
+		})
+		done[i] = ch
+	}
+
+	phase := ShutdownPhase{Name: name}
+	for i, t := range tasks {
+		select {
+		case <-done[i]:
+			continue // finished, even if the deadline has passed too
+		default:
+		}
+		select {
+		case <-done[i]:
+		case <-ctx.Done():
+			phase.Exceeded = append(phase.Exceeded, t.name)
+		}
+	}
+	phase.Duration = time.Since(start)
+	m.report.Phases = append(m.report.Phases, phase)
+}
+
+// done returns the report of the shutdown.
+func (m *shutdownManager) done() ShutdownReport {
+	m.report.Duration = time.Since(m.start)
+	return m.report
+}
+
+// HandleSignals shuts down the application when the process receives
+// SIGINT or SIGTERM, until the application is shut down.
+func (app *App) HandleSignals() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	goutil.Go(app.ctx, func(ctx context.Context) {
+		select {
+		case sig := <-ch:
+			log.Infof(ctx, log.TagAppDef, "Received signal: %v", sig)
+			app.ShutDown()
+		case <-ctx.Done():
+		}
+		signal.Stop(ch)
+	})
+}
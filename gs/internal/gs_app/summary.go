@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+// StartupPhase is the time spent in one phase of the application startup.
+type StartupPhase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// StartupSummary describes how the application started. It's logged when
+// the application is ready, unless "spring.app.startup-summary.enabled"
+// is false.
+type StartupSummary struct {
+	Profiles    []string       // active profiles
+	ConfigFiles []string       // configuration files, in their merge order
+	Beans       int            // number of beans wired by the container
+	Addresses   []string       // addresses of the servers that report them
+	Phases      []StartupPhase // startup phases, in their running order
+	Duration    time.Duration  // total time spent starting the application
+}
+
+// String returns the summary as a multi-line text.
+func (s StartupSummary) String() string {
+	var sb strings.Builder
+	sb.WriteString("startup summary:\n")
+	fmt.Fprintf(&sb, "  active profiles: %s\n", joinOrNone(s.Profiles))
+	fmt.Fprintf(&sb, "  config files: %s\n", joinOrNone(s.ConfigFiles))
+	fmt.Fprintf(&sb, "  beans: %d\n", s.Beans)
+	fmt.Fprintf(&sb, "  servers: %s\n", joinOrNone(s.Addresses))
+	for _, p := range s.Phases {
+		fmt.Fprintf(&sb, "  phase %s: %s\n", p.Name, p.Duration)
+	}
+	fmt.Fprintf(&sb, "  started in %s", s.Duration)
+	return sb.String()
+}
+
+// joinOrNone joins the strings with commas, or returns "none" if empty.
+func joinOrNone(a []string) string {
+	if len(a) == 0 {
+		return "none"
+	}
+	return strings.Join(a, ", ")
+}
+
+// startupTimer records the phases of the application startup.
+type startupTimer struct {
+	start  time.Time
+	last   time.Time
+	phases []StartupPhase
+}
+
+// newStartupTimer creates a startupTimer started now.
+func newStartupTimer() *startupTimer {
+	now := time.Now()
+	return &startupTimer{start: now, last: now}
+}
+
+// mark ends the current phase with the given name and starts the next one.
+func (t *startupTimer) mark(name string) {
+	now := time.Now()
+	t.phases = append(t.phases, StartupPhase{Name: name, Duration: now.Sub(t.last)})
+	t.last = now
+}
+
+// buildSummary collects the startup summary of the application, with the
+// phases recorded by t.
+func (app *App) buildSummary(p conf.Properties, t *startupTimer) StartupSummary {
+	s := StartupSummary{
+		Phases:   t.phases,
+		Duration: time.Since(t.start),
+	}
+	if profiles, err := p.Resolve("${spring.profiles.active:=}"); err == nil {
+		for v := range strings.SplitSeq(profiles, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				s.Profiles = append(s.Profiles, v)
+			}
+		}
+	}
+	layers := app.P.LayerOrder()
+	for _, name := range app.P.Sources() {
+		if !slices.Contains(layers, name) {
+			s.ConfigFiles = append(s.ConfigFiles, name)
+		}
+	}
+	if app.C.Injecting != nil {
+		s.Beans = len(app.C.Timings())
+	}
+	if app.EnableServers {
+		for _, svr := range app.Servers {
+			if a, ok := svr.(gs.ServerAddr); ok {
+				s.Addresses = append(s.Addresses, a.Addr())
+			}
+		}
+	}
+	return s
+}
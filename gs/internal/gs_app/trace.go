@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_app
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/gs/internal/gs_core"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// Tracer records the spans of the application, e.g. with OpenTelemetry
+// when the gs/otel package is imported. The application doesn't depend
+// on a tracing library itself.
+type Tracer interface {
+	// TraceStartup records the startup of the application, started at
+	// start and made of the phases, with the phases of the container c.
+	TraceStartup(ctx context.Context, c *gs_core.Container, start time.Time, phases []StartupPhase)
+
+	// StartRefresh starts the span of a properties refresh started at
+	// start, and returns the function ending it with the refresh error.
+	StartRefresh(ctx context.Context, start time.Time) (end func(err error))
+
+	// GoroutineSpans returns the spans of the goroutines launched by
+	// goutil: children of the span of the caller, or new traces linked
+	// to it if link is true.
+	GoroutineSpans(link bool) goutil.SpanStarter
+}
+
+// traceStartup records the startup timed by t, if tracing is enabled.
+func (app *App) traceStartup(t *startupTimer) {
+	if app.Tracer == nil || !app.EnableTracing {
+		return
+	}
+	app.Tracer.TraceStartup(app.ctx, app.C, t.start, t.phases)
+}
+
+// startRefreshSpan starts the span of a properties refresh, and returns
+// the function ending it, which does nothing if tracing is disabled.
+func (app *App) startRefreshSpan(start time.Time) func(err error) {
+	if app.Tracer == nil || !app.EnableTracing {
+		return func(error) {}
+	}
+	return app.Tracer.StartRefresh(app.ctx, start)
+}
+
+// traceGoroutines configures the spans of the goroutines launched by
+// goutil, by the "spring.app.goroutine-spans" property: "none" leaves
+// goutil unchanged, "child" starts a child span of the caller in each
+// goroutine, and "link" a new trace linked to the span of the caller.
+// The spans are only started if the application has a Tracer.
+func (app *App) traceGoroutines() error {
+	switch app.GoroutineSpans {
+	case "none":
+	case "child", "link":
+		if app.Tracer != nil {
+			goutil.Spans = app.Tracer.GoroutineSpans(app.GoroutineSpans == "link")
+		}
+	default:
+		return util.FormatError(nil, "invalid goroutine spans %q", app.GoroutineSpans)
+	}
+	return nil
+}
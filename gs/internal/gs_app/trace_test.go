@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_core"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// testTracer records the calls of the application to its tracer.
+type testTracer struct {
+	phases    []string
+	refreshes []error
+	link      []bool
+}
+
+func (t *testTracer) TraceStartup(ctx context.Context, c *gs_core.Container, start time.Time, phases []StartupPhase) {
+	for _, p := range phases {
+		t.phases = append(t.phases, p.Name)
+	}
+}
+
+func (t *testTracer) StartRefresh(ctx context.Context, start time.Time) func(err error) {
+	return func(err error) { t.refreshes = append(t.refreshes, err) }
+}
+
+func (t *testTracer) GoroutineSpans(link bool) goutil.SpanStarter {
+	t.link = append(t.link, link)
+	return nil
+}
+
+func TestTrace(t *testing.T) {
+
+	t.Run("tracer", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.goroutine-spans", "link", fileID)
+		app := NewApp()
+		tracer := &testTracer{}
+		app.Tracer = tracer
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		err = app.RefreshProperties()
+		assert.That(t, err).Nil()
+		app.ShutDown()
+		app.WaitForShutdown()
+
+		assert.That(t, tracer.phases).Equal([]string{"config", "container", "runners"})
+		assert.That(t, tracer.refreshes).Equal([]error{nil})
+		assert.That(t, tracer.link).Equal([]bool{true})
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.enable.otel-tracing", "false", fileID)
+		app := NewApp()
+		tracer := &testTracer{}
+		app.Tracer = tracer
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		err = app.RefreshProperties()
+		assert.That(t, err).Nil()
+		app.ShutDown()
+		app.WaitForShutdown()
+		assert.That(t, len(tracer.phases)).Equal(0)
+		assert.That(t, len(tracer.refreshes)).Equal(0)
+	})
+
+	t.Run("no tracer", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.goroutine-spans", "child", fileID)
+		app := NewApp()
+		err := app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, app.RefreshProperties()).Nil()
+		assert.That(t, goutil.Spans).Nil()
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("invalid goroutine spans", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.goroutine-spans", "all", fileID)
+		app := NewApp()
+		app.C.Object(gs.FuncJob(func(ctx context.Context) error { return nil })).AsJob()
+		err := app.Start()
+		assert.Error(t, err).Matches("invalid goroutine spans \"all\"")
+	})
+}
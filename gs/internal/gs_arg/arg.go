@@ -256,6 +256,19 @@ func (r *Callable) Call(ctx gs.ArgContext) ([]reflect.Value, error) {
 	return reflect.ValueOf(r.fn).Call(ret), nil
 }
 
+// Validate resolves all arguments like Call, but doesn't invoke the
+// underlying function.
+func (r *Callable) Validate(ctx gs.ArgContext) error {
+	_, err := r.argList.get(ctx)
+	return err
+}
+
+// dryRunner is implemented by the ArgContext only checking the arguments,
+// which doesn't call the bound functions.
+type dryRunner interface {
+	DryRun() bool
+}
+
 // BindArg represents a bound function ready to be executed conditionally.
 type BindArg struct {
 	r          *Callable      // Wrapped Callable
@@ -323,6 +336,14 @@ func (arg *BindArg) GetArgValue(ctx gs.ArgContext, t reflect.Type) (reflect.Valu
 		}
 	}
 
+	// Only check the arguments of the function in a dry run.
+	if d, ok := ctx.(dryRunner); ok && d.DryRun() {
+		if err := arg.r.Validate(ctx); err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.Zero(t), nil
+	}
+
 	// Execute the function.
 	out, err := arg.r.Call(ctx)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/gs/internal/gs"
@@ -71,11 +72,14 @@ type BeanMetadata struct {
 	f             *gs_arg.Callable   // Callable for constructor functions
 	init          gs.BeanInitFunc    // Bean initialization function
 	destroy       gs.BeanDestroyFunc // Bean destruction function
+	destroyTime   time.Duration      // Time limit of destroying the bean
 	dependsOn     []gs.BeanSelector  // Explicit dependencies of the bean
 	exports       []reflect.Type     // Interfaces exported by this bean
 	conditions    []gs.Condition     // Conditions controlling bean creation
 	status        BeanStatus         // Current lifecycle status
 	mocked        bool               // Indicates if the bean is mocked
+	lazy          bool               // Indicates if the bean is constructed on demand
+	scope         gs.Scope           // Scope of the bean, singleton if empty
 	fileLine      string             // File and line where bean is defined
 	configuration *gs.Configuration  // Configuration for sub/child beans
 }
@@ -85,6 +89,24 @@ func (d *BeanMetadata) Mocked() bool {
 	return d.mocked
 }
 
+// Lazy returns true if the bean is constructed on demand.
+func (d *BeanMetadata) Lazy() bool {
+	return d.lazy
+}
+
+// SetLazy marks the bean to be constructed on demand.
+func (d *BeanMetadata) SetLazy() {
+	d.lazy = true
+}
+
+// Scope returns the scope of the bean.
+func (d *BeanMetadata) Scope() gs.Scope {
+	if d.scope == "" {
+		return gs.ScopeSingleton
+	}
+	return d.scope
+}
+
 // validLifeCycleFunc checks if the given function is a valid lifecycle function.
 // Valid lifecycle functions must have the signature:
 //
@@ -113,6 +135,17 @@ func (d *BeanMetadata) Destroy() gs.BeanDestroyFunc {
 	return d.destroy
 }
 
+// DestroyTimeout returns the time limit of destroying the bean, or 0 if
+// the default one applies.
+func (d *BeanMetadata) DestroyTimeout() time.Duration {
+	return d.destroyTime
+}
+
+// SetDestroyTimeout sets the time limit of destroying the bean.
+func (d *BeanMetadata) SetDestroyTimeout(timeout time.Duration) {
+	d.destroyTime = timeout
+}
+
 // DependsOn returns the list of dependencies for the bean.
 func (d *BeanMetadata) DependsOn() []gs.BeanSelector {
 	return d.dependsOn
@@ -173,9 +206,13 @@ func (d *BeanMetadata) SetFileLine(file string, line int) {
 
 // BeanRuntime holds runtime information about the bean.
 type BeanRuntime struct {
-	v    reflect.Value // The value of the bean.
-	t    reflect.Type  // The type of the bean.
-	name string        // The name of the bean.
+	v        reflect.Value // The value of the bean.
+	t        reflect.Type  // The type of the bean.
+	name     string        // The name of the bean.
+	primary  bool          // Preferred when several beans match.
+	fallback bool          // Used only when no other bean matches.
+	order    *int          // Position in the injected collections.
+	qualify  []string      // Qualifiers selecting the bean in collections.
 }
 
 // Name returns the bean's name.
@@ -203,6 +240,30 @@ func (d *BeanRuntime) Callable() *gs_arg.Callable {
 	return nil
 }
 
+// Primary returns true if the bean is preferred when several beans match.
+func (d *BeanRuntime) Primary() bool {
+	return d.primary
+}
+
+// Fallback returns true if the bean is used only when no other bean matches.
+func (d *BeanRuntime) Fallback() bool {
+	return d.fallback
+}
+
+// Order returns the position of the bean in the injected collections,
+// and false if it is not set.
+func (d *BeanRuntime) Order() (int, bool) {
+	if d.order == nil {
+		return 0, false
+	}
+	return *d.order, true
+}
+
+// Qualifiers returns the qualifiers of the bean.
+func (d *BeanRuntime) Qualifiers() []string {
+	return d.qualify
+}
+
 // Status returns the current status of the bean.
 func (d *BeanRuntime) Status() BeanStatus {
 	return StatusWired
@@ -242,9 +303,13 @@ func (d *BeanDefinition) SetMock(obj any) {
 			mocked:  true,
 		},
 		BeanRuntime: &BeanRuntime{
-			t:    reflect.TypeOf(obj),
-			v:    reflect.ValueOf(obj),
-			name: d.name,
+			t:        reflect.TypeOf(obj),
+			v:        reflect.ValueOf(obj),
+			name:     d.name,
+			primary:  d.primary,
+			fallback: d.fallback,
+			order:    d.order,
+			qualify:  d.qualify,
 		},
 	}
 }
@@ -259,6 +324,34 @@ func (d *BeanDefinition) SetName(name string) {
 	d.name = name
 }
 
+// SetPrimary marks the bean to be preferred when several beans match
+// an injection without a name.
+func (d *BeanDefinition) SetPrimary() {
+	if d.fallback {
+		panic("bean can't be both primary and fallback")
+	}
+	d.primary = true
+}
+
+// SetFallback marks the bean to be used only when no other bean matches
+// an injection without a name.
+func (d *BeanDefinition) SetFallback() {
+	if d.primary {
+		panic("bean can't be both primary and fallback")
+	}
+	d.fallback = true
+}
+
+// SetOrder sets the position of the bean in the injected collections.
+func (d *BeanDefinition) SetOrder(order int) {
+	d.order = &order
+}
+
+// SetQualifier adds the qualifiers of the bean.
+func (d *BeanDefinition) SetQualifier(qualifiers ...string) {
+	d.qualify = append(d.qualify, qualifiers...)
+}
+
 // Status returns the bean's current lifecycle status.
 func (d *BeanDefinition) Status() BeanStatus {
 	return d.status
@@ -321,21 +414,24 @@ func (d *BeanDefinition) SetExport(exports ...reflect.Type) {
 	}
 }
 
+// SetScope sets the scope of the bean. Only beans created by a
+// constructor can be prototype or request scoped.
+func (d *BeanDefinition) SetScope(scope gs.Scope) {
+	switch scope {
+	case gs.ScopeSingleton:
+	case gs.ScopePrototype, gs.ScopeRequest:
+		if d.f == nil {
+			panic(fmt.Sprintf("%s bean must be created by a constructor", scope))
+		}
+	default:
+		panic(fmt.Sprintf("unknown scope %q", scope))
+	}
+	d.scope = scope
+}
+
 // OnProfiles adds conditions based on active profiles.
 func (d *BeanDefinition) OnProfiles(profiles string) {
-	d.SetCondition(gs_cond.OnFunc(func(ctx gs.ConditionContext) (bool, error) {
-		val := strings.TrimSpace(ctx.Prop("spring.profiles.active"))
-		if val == "" {
-			return false, nil
-		}
-		ss := strings.Split(strings.TrimSpace(profiles), ",")
-		for s := range strings.SplitSeq(val, ",") {
-			if slices.Contains(ss, s) {
-				return true, nil
-			}
-		}
-		return false, nil
-	}))
+	d.SetCondition(gs_cond.OnProfile(profiles))
 }
 
 // TypeAndName returns the bean's type and name.
@@ -284,6 +284,25 @@ func TestBeanDefinition(t *testing.T) {
 		bean.SetMock(bytes.NewBufferString(""))
 		assert.That(t, bean.Mocked()).True()
 	})
+
+	t.Run("primary and fallback", func(t *testing.T) {
+		v := reflect.ValueOf(&TestBean{})
+		bean := makeBean(v.Type(), v, nil, "test")
+		bean.SetPrimary()
+		assert.That(t, bean.Primary()).True()
+		assert.That(t, bean.Fallback()).False()
+		assert.Panic(t, func() {
+			bean.SetFallback()
+		}, "bean can't be both primary and fallback")
+
+		bean = makeBean(v.Type(), v, nil, "test")
+		bean.SetFallback()
+		bean.SetMock(&TestBean{})
+		assert.That(t, bean.Fallback()).True()
+		assert.Panic(t, func() {
+			bean.SetPrimary()
+		}, "bean can't be both primary and fallback")
+	})
 }
 
 func TestNewBean(t *testing.T) {
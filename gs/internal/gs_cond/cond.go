@@ -21,16 +21,22 @@
 //
 //   - OnFunc:          Uses a custom function to evaluate a condition.
 //   - OnProperty:      Matches based on the presence or value of a property.
+//   - OnMissingProperty: Matches if a property is missing.
+//   - OnProfile / OnNotProfile: Match if any / none of the profiles is active.
 //   - OnBean:          Matches if at least one bean exists for a given selector.
 //   - OnMissingBean:   Matches if no beans exist for a given selector.
 //   - OnSingleBean:    Matches if exactly one bean exists for a given selector.
-//   - OnExpression:    Evaluates a custom expression (currently unimplemented).
+//   - OnExpression:    Evaluates a boolean expression of property placeholders.
+//   - OnReachable:     Matches if a TCP endpoint accepts a connection.
 //   - Not / Or / And / None: Logical combinators for composing multiple conditions.
 package gs_cond
 
 import (
 	"fmt"
+	"net"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/gs/internal/gs"
@@ -145,6 +151,79 @@ func (c *onProperty) String() string {
 	return sb.String()
 }
 
+/**************************** OnMissingProperty *****************************/
+
+// onMissingProperty represents a condition that matches if a property
+// doesn't exist in the context.
+type onMissingProperty struct {
+	name string // Property name to check
+}
+
+// OnMissingProperty creates a condition that matches if the property is missing.
+func OnMissingProperty(name string) gs.Condition {
+	return &onMissingProperty{name: name}
+}
+
+// Matches checks that the property doesn't exist.
+func (c *onMissingProperty) Matches(ctx gs.ConditionContext) (bool, error) {
+	return !ctx.Has(c.name), nil
+}
+
+func (c *onMissingProperty) String() string {
+	return fmt.Sprintf("OnMissingProperty(name=%s)", c.name)
+}
+
+/******************************* OnProfile ***********************************/
+
+// activeProfilesProp is the property of the comma-separated active profiles.
+const activeProfilesProp = "spring.profiles.active"
+
+// onProfile represents a condition that checks the active profiles.
+type onProfile struct {
+	profiles []string // Profiles to check
+	not      bool     // Whether to match if none of the profiles is active
+}
+
+// splitProfiles splits profiles separated by '|' or ','.
+func splitProfiles(s string) []string {
+	var ret []string
+	for _, p := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' }) {
+		if p = strings.TrimSpace(p); p != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// OnProfile creates a condition that matches if any of the profiles,
+// separated by '|' or ',', e.g. "dev|test", is active.
+func OnProfile(profiles string) gs.Condition {
+	return &onProfile{profiles: splitProfiles(profiles)}
+}
+
+// OnNotProfile creates a condition that matches if none of the profiles,
+// separated by '|' or ',', e.g. "dev|test", is active.
+func OnNotProfile(profiles string) gs.Condition {
+	return &onProfile{profiles: splitProfiles(profiles), not: true}
+}
+
+// Matches checks the profiles against the active profiles.
+func (c *onProfile) Matches(ctx gs.ConditionContext) (bool, error) {
+	active := splitProfiles(ctx.Prop(activeProfilesProp))
+	found := slices.ContainsFunc(c.profiles, func(s string) bool {
+		return slices.Contains(active, s)
+	})
+	return found != c.not, nil
+}
+
+func (c *onProfile) String() string {
+	name := "OnProfile"
+	if c.not {
+		name = "OnNotProfile"
+	}
+	return fmt.Sprintf("%s(profiles=%s)", name, strings.Join(c.profiles, "|"))
+}
+
 /********************************* OnBean ************************************/
 
 // onBean represents a condition that checks for the existence of beans
@@ -252,21 +331,57 @@ type onExpression struct {
 	expression string // Expression string to evaluate
 }
 
-// OnExpression creates a condition that evaluates a custom boolean expression.
+// OnExpression creates a condition that evaluates a custom boolean expression,
+// in the syntax of github.com/expr-lang/expr, whose "${key}" and
+// "${key:=default}" placeholders are the values of the properties, e.g.
+// "${http.server.enabled} && ${env} == 'prod'". Functions registered by
+// RegisterExpressFunc are available in the expression.
 func OnExpression(expression string) gs.Condition {
 	return &onExpression{expression: expression}
 }
 
-// Matches evaluates the expression (currently unimplemented).
+// Matches evaluates the expression with the properties in the context.
 func (c *onExpression) Matches(ctx gs.ConditionContext) (bool, error) {
-	err := util.ErrUnimplementedMethod
-	return false, util.FormatError(err, "condition %s matches error", c)
+	ok, err := evalPropExpr(ctx, c.expression)
+	if err != nil {
+		return false, util.FormatError(err, "condition %s matches error", c)
+	}
+	return ok, nil
 }
 
 func (c *onExpression) String() string {
 	return fmt.Sprintf("OnExpression(expression=%s)", c.expression)
 }
 
+/****************************** OnReachable **********************************/
+
+// onReachable represents a condition that matches if a TCP endpoint
+// can be connected within the timeout.
+type onReachable struct {
+	addr    string        // TCP address to probe, e.g. "127.0.0.1:9090"
+	timeout time.Duration // Dial timeout of the probe
+}
+
+// OnReachable creates a condition that probes the TCP endpoint when the
+// condition is evaluated and matches if it accepts a connection in time.
+func OnReachable(addr string, timeout time.Duration) gs.Condition {
+	return &onReachable{addr: addr, timeout: timeout}
+}
+
+// Matches dials the endpoint; an unreachable endpoint is not an error.
+func (c *onReachable) Matches(ctx gs.ConditionContext) (bool, error) {
+	conn, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return false, nil
+	}
+	_ = conn.Close()
+	return true, nil
+}
+
+func (c *onReachable) String() string {
+	return fmt.Sprintf("OnReachable(addr=%s, timeout=%s)", c.addr, c.timeout)
+}
+
 /********************************** Not ***************************************/
 
 // onNot represents a condition that inverts the result of another condition.
@@ -19,11 +19,12 @@ package gs_cond
 import (
 	"errors"
 	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/go-spring/gs-mock/gsmock"
 	"github.com/go-spring/spring-base/testing/assert"
-	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/gs/internal/gs"
 )
 
@@ -61,6 +62,18 @@ func TestConditionString(t *testing.T) {
 	c = OnSingleBeanSelector(gs.BeanSelectorFor[error]())
 	assert.That(t, fmt.Sprint(c)).Equal(`OnSingleBean(selector={Type:error})`)
 
+	c = OnMissingProperty("a")
+	assert.That(t, fmt.Sprint(c)).Equal(`OnMissingProperty(name=a)`)
+
+	c = OnProfile("dev, test")
+	assert.That(t, fmt.Sprint(c)).Equal(`OnProfile(profiles=dev|test)`)
+
+	c = OnNotProfile("prod")
+	assert.That(t, fmt.Sprint(c)).Equal(`OnNotProfile(profiles=prod)`)
+
+	c = OnReachable("127.0.0.1:80", time.Second)
+	assert.That(t, fmt.Sprint(c)).Equal(`OnReachable(addr=127.0.0.1:80, timeout=1s)`)
+
 	c = OnExpression("a")
 	assert.That(t, fmt.Sprint(c)).Equal(`OnExpression(expression=a)`)
 
@@ -217,6 +230,61 @@ func TestOnProperty(t *testing.T) {
 	})
 }
 
+func TestOnMissingProperty(t *testing.T) {
+
+	t.Run("property exist", func(t *testing.T) {
+		m := gsmock.NewManager()
+		ctx := gs.NewConditionContextMockImpl(m)
+		ctx.MockHas().ReturnValue(true)
+
+		ok, err := OnMissingProperty("test.prop").Matches(ctx)
+		assert.That(t, ok).False()
+		assert.That(t, err).Nil()
+	})
+
+	t.Run("property not exist", func(t *testing.T) {
+		m := gsmock.NewManager()
+		ctx := gs.NewConditionContextMockImpl(m)
+		ctx.MockHas().ReturnValue(false)
+
+		ok, err := OnMissingProperty("test.prop").Matches(ctx)
+		assert.That(t, ok).True()
+		assert.That(t, err).Nil()
+	})
+}
+
+func TestOnProfile(t *testing.T) {
+
+	testcases := []struct {
+		active   string
+		profiles string
+		on       bool
+	}{
+		{"", "dev", false},
+		{"dev", "dev", true},
+		{"prod", "dev|test", false},
+		{"prod, test", "dev|test", true},
+		{"test", "dev,test", true},
+	}
+
+	for _, c := range testcases {
+		m := gsmock.NewManager()
+		ctx := gs.NewConditionContextMockImpl(m)
+		ctx.MockProp().Handle(func(key string, def []string) string {
+			assert.That(t, key).Equal("spring.profiles.active")
+			return c.active
+		})
+
+		ok, err := OnProfile(c.profiles).Matches(ctx)
+		assert.That(t, err).Nil()
+		assert.That(t, ok).Equal(c.on)
+
+		ok, err = OnNotProfile(c.profiles).Matches(ctx)
+		assert.That(t, err).Nil()
+		assert.That(t, ok).Equal(!c.on)
+	}
+}
+
 func TestOnBean(t *testing.T) {
 
 	t.Run("found bean", func(t *testing.T) {
@@ -324,12 +392,113 @@ func TestOnSingleBean(t *testing.T) {
 }
 
 func TestOnExpression(t *testing.T) {
-	m := gsmock.NewManager()
-	ctx := gs.NewConditionContextMockImpl(m)
 
-	cond := OnExpression("1+1==2")
-	_, err := cond.Matches(ctx)
-	assert.That(t, errors.Is(err, util.ErrUnimplementedMethod)).True()
+	newContext := func(props map[string]string) gs.ConditionContext {
+		m := gsmock.NewManager()
+		ctx := gs.NewConditionContextMockImpl(m)
+		ctx.MockHas().Handle(func(key string) bool {
+			_, ok := props[key]
+			return ok
+		})
+		ctx.MockProp().Handle(func(key string, def []string) string {
+			if v, ok := props[key]; ok {
+				return v
+			}
+			if len(def) > 0 {
+				return def[0]
+			}
+			return ""
+		})
+		return ctx
+	}
+
+	ctx := newContext(map[string]string{
+		"http.server.enabled": "true",
+		"env":                 "prod",
+		"http.server.port":    "8080",
+		"ratio":               "0.5",
+	})
+
+	testcases := []struct {
+		expression string
+		expect     bool
+	}{
+		{"1+1==2", true},
+		{"${http.server.enabled} && ${env} == 'prod'", true},
+		{"${http.server.enabled} && ${env} == 'dev'", false},
+		{"${http.server.port} > 8000 && ${ratio} < 1", true},
+		{"${cache.enabled:=false} || ${env} in ['prod', 'test']", true},
+		{"${cache.enabled:=false}", false},
+		{"${env:=dev} == 'prod'", true},
+		{"${cache.env:=${env}} == 'prod'", true},
+		{"${cache.port:=${cache.base:=${http.server.port}}} == 8080", true},
+	}
+	for _, c := range testcases {
+		ok, err := OnExpression(c.expression).Matches(ctx)
+		assert.That(t, err).Nil()
+		assert.That(t, ok).Equal(c.expect)
+	}
+
+	t.Run("register func", func(t *testing.T) {
+		RegisterExpressFunc("isProd", func(s string) bool { return s == "prod" })
+		ok, err := OnExpression("isProd(${env})").Matches(ctx)
+		assert.That(t, err).Nil()
+		assert.That(t, ok).True()
+	})
+
+	t.Run("property not found", func(t *testing.T) {
+		_, err := OnExpression("${cache.enabled}").Matches(ctx)
+		assert.Error(t, err).Matches("property cache.enabled not found")
+	})
+
+	t.Run("default property not found", func(t *testing.T) {
+		_, err := OnExpression("${cache.env:=${cache.default}}").Matches(ctx)
+		assert.Error(t, err).Matches("property cache.default not found")
+	})
+
+	t.Run("unclosed placeholder", func(t *testing.T) {
+		_, err := OnExpression("${env == 'prod'").Matches(ctx)
+		assert.Error(t, err).Matches("unclosed placeholder")
+	})
+
+	t.Run("invalid placeholder", func(t *testing.T) {
+		_, err := OnExpression("${} == 1").Matches(ctx)
+		assert.Error(t, err).Matches("eval .* returns error")
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := OnExpression("${env} ==").Matches(ctx)
+		assert.Error(t, err).Matches("condition OnExpression\\(expression=\\$\\{env\\} ==\\) matches error")
+	})
+
+	t.Run("not bool", func(t *testing.T) {
+		_, err := OnExpression("${env}").Matches(ctx)
+		assert.Error(t, err).Matches("doesn't return bool value")
+	})
+}
+
+func TestOnReachable(t *testing.T) {
+
+	t.Run("reachable", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.That(t, err).Nil()
+		defer func() { _ = l.Close() }()
+
+		ok, err := OnReachable(l.Addr().String(), time.Second).Matches(nil)
+		assert.That(t, ok).True()
+		assert.That(t, err).Nil()
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.That(t, err).Nil()
+		addr := l.Addr().String()
+		_ = l.Close()
+
+		ok, err := OnReachable(addr, time.Second).Matches(nil)
+		assert.That(t, ok).False()
+		assert.That(t, err).Nil()
+	})
 }
 
 func TestNot(t *testing.T) {
@@ -17,10 +17,14 @@
 package gs_cond
 
 import (
+	"fmt"
 	"maps"
+	"strings"
 
 	"github.com/expr-lang/expr"
 	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
 )
 
 // funcMap stores registered functions that can be referenced in expressions.
@@ -50,3 +54,98 @@ func EvalExpr(input string, val string) (bool, error) {
 	}
 	return ret, nil
 }
+
+// evalPropExpr evaluates a boolean expression whose "${key}" and
+// "${key:=default}" placeholders are the values of the properties, e.g.
+// "${http.server.enabled} && ${env} == 'prod'". A value is a number or a
+// boolean if it reads as one, otherwise a string. Placeholders of missing
+// properties without default values are errors.
+func evalPropExpr(ctx gs.ConditionContext, input string) (bool, error) {
+	env := map[string]any{}
+	var sb strings.Builder
+	for s := input; ; {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			sb.WriteString(s)
+			break
+		}
+		j := placeholderEnd(s, i)
+		if j < 0 {
+			return false, util.FormatError(nil, "eval %q returns error: unclosed placeholder", input)
+		}
+		val, err := propValue(ctx, s[i:j+1])
+		if err != nil {
+			return false, util.FormatError(err, "eval %q returns error", input)
+		}
+		name := fmt.Sprintf("__p%d", len(env))
+		env[name] = conf.ExprValue(val)
+		sb.WriteString(s[:i])
+		sb.WriteString(name)
+		s = s[j+1:]
+	}
+
+	maps.Copy(env, funcMap)
+	r, err := expr.Eval(sb.String(), env)
+	if err != nil {
+		return false, util.FormatError(err, "eval %q returns error", input)
+	}
+	ret, ok := r.(bool)
+	if !ok {
+		return false, util.FormatError(nil, "eval %q doesn't return bool value", input)
+	}
+	return ret, nil
+}
+
+// propValue returns the value of the property of the placeholder, or its
+// default value, whose own placeholders are resolved in turn, e.g. the
+// value of "b" for "${a:=${b}}" if "a" is missing.
+func propValue(ctx gs.ConditionContext, placeholder string) (string, error) {
+	tag, err := conf.ParseTag(placeholder)
+	if err != nil {
+		return "", err
+	}
+	if ctx.Has(tag.Key) {
+		return ctx.Prop(tag.Key), nil
+	}
+	if !tag.HasDef {
+		return "", util.FormatError(nil, "property %s not found", tag.Key)
+	}
+	var sb strings.Builder
+	for s := tag.Def; ; {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			sb.WriteString(s)
+			break
+		}
+		j := placeholderEnd(s, i)
+		if j < 0 {
+			return "", util.FormatError(nil, "unclosed placeholder")
+		}
+		val, err := propValue(ctx, s[i:j+1])
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s[:i])
+		sb.WriteString(val)
+		s = s[j+1:]
+	}
+	return sb.String(), nil
+}
+
+// placeholderEnd returns the index of the '}' closing the placeholder
+// that starts at i, or -1 if it's unclosed.
+func placeholderEnd(s string, i int) int {
+	depth := 0
+	for j := i + 2; j < len(s); j++ {
+		switch s[j] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return j
+			}
+			depth--
+		}
+	}
+	return -1
+}
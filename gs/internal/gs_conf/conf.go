@@ -24,6 +24,7 @@
 //   - Local configuration files (e.g., ./conf/app.yaml)
 //   - Remote configuration files (from config servers)
 //   - Dynamically supplied remote properties
+//   - Dotenv files (.env and .env.{profile}) in the working directory
 //   - Operating system environment variables
 //   - Command-line arguments
 //
@@ -38,13 +39,19 @@
 package gs_conf
 
 import (
+	"context"
 	"errors"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
 )
 
 // osStat only for test.
@@ -84,42 +91,82 @@ func (c *NamedPropertyCopier) CopyTo(out *conf.MutableProperties) error {
 /******************************** SysConfig **********************************/
 
 // SysConfig represents the init-level configuration layer
-// composed of environment variables and command-line arguments.
+// composed of dotenv files, environment variables and command-line arguments.
 type SysConfig struct {
 	Environment *Environment // Environment variables as configuration source.
 	CommandArgs *CommandArgs // Command-line arguments as configuration source.
 }
 
 // Refresh collects properties from the system configuration sources
-// (built-in SysConf, environment variables, and command-line arguments)
-// and merges them into a single immutable conf.Properties.
+// (built-in SysConf, dotenv files, environment variables, and command-line
+// arguments) and merges them into a single immutable conf.Properties.
 func (c *SysConfig) Refresh() (conf.Properties, error) {
-	return merge(
-		NewNamedPropertyCopier("sys", SysConf),
-		NewNamedPropertyCopier("env", c.Environment),
-		NewNamedPropertyCopier("cmd", c.CommandArgs),
-	)
+	p, _, err := c.refresh()
+	return p, err
+}
+
+// refresh is like Refresh, but also returns the dotenv sources, which
+// the other layers merge right before the environment variables.
+func (c *SysConfig) refresh() (conf.Properties, []*NamedPropertyCopier, error) {
+	dotEnv, err := loadDotEnv()
+	if err != nil {
+		return nil, nil, util.WrapError(err, "merge error in source dotenv")
+	}
+	var sources []*NamedPropertyCopier
+	sources = append(sources, NewNamedPropertyCopier("sys", SysConf))
+	sources = append(sources, dotEnv...)
+	sources = append(sources, NewNamedPropertyCopier("env", c.Environment))
+	sources = append(sources, NewNamedPropertyCopier("cmd", c.CommandArgs))
+	p, err := merge(sources...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, dotEnv, nil
 }
 
 /******************************** AppConfig **********************************/
 
 // AppConfig represents a layered configuration for the application runtime.
-// The layers, in their merge order, typically include:
+// The layers, in their default merge order, include:
 //
 //  1. System defaults (SysConf)
-//  2. Local configuration files
+//  2. Local configuration files, then the Kubernetes volumes (KubeDirs)
 //  3. Remote configuration files
 //  4. Dynamically supplied remote properties
-//  5. Environment variables
-//  6. Command-line arguments
+//  5. Properties fetched from a remote config provider
+//  6. Dotenv files, ".env" and ".env.{profile}"
+//  7. Environment variables
+//  8. Command-line arguments
+//  9. Properties set at runtime, e.g. through admin endpoints
 //
 // Layers appearing later in the list override earlier ones when keys conflict.
+// The order can be changed by SetLayerOrder, and custom layers can be added
+// by AddLayer.
 type AppConfig struct {
 	LocalFile   *PropertySources // Configuration sources from local files.
 	RemoteFile  *PropertySources // Configuration sources from remote files.
+	KubeDirs    *KubeSources     // Kubernetes ConfigMap and Secret volumes.
 	RemoteProp  conf.Properties  // Properties fetched from a remote server.
 	Environment *Environment     // Environment variables as configuration source.
 	CommandArgs *CommandArgs     // Command-line arguments as configuration source.
+
+	// RemoteProvider provides properties from a config server. If nil,
+	// the provider registered for the scheme of "spring.app.config-remote.url"
+	// is created when the url is set in the system configuration.
+	RemoteProvider RemoteConfigProvider
+
+	providerMutex sync.Mutex // Guards the creation of RemoteProvider.
+
+	runtimeMutex sync.Mutex                             // Serializes SetProperty calls.
+	runtimeProp  atomic.Pointer[conf.MutableProperties] // Properties set at runtime.
+
+	layerMutex sync.RWMutex                     // Guards layers, strategies, sources and conflicts.
+	layers     layers                           // Merge order of the layers and custom layers.
+	strategies map[string]conf.ConflictStrategy // Conflict strategies of the layers.
+	sources    []string                         // Names of the sources merged by the last Refresh.
+	conflicts  []conf.Conflict                  // Conflicts resolved by the last Refresh.
+
+	effective atomic.Pointer[conf.MutableProperties] // Properties merged by the last Refresh.
 }
 
 // NewAppConfig creates a new instance of AppConfig.
@@ -127,6 +174,7 @@ func NewAppConfig() *AppConfig {
 	return &AppConfig{
 		LocalFile:   NewPropertySources(ConfigTypeLocal, "app"),
 		RemoteFile:  NewPropertySources(ConfigTypeRemote, "app"),
+		KubeDirs:    NewKubeSources(),
 		Environment: NewEnvironment(),
 		CommandArgs: NewCommandArgs(),
 	}
@@ -137,6 +185,15 @@ func NewAppConfig() *AppConfig {
 // later sources override earlier ones. If any source fails to copy,
 // the merge aborts and returns an error indicating the failing source.
 func merge(sources ...*NamedPropertyCopier) (conf.Properties, error) {
+	out, err := mergeProperties(sources...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// mergeProperties is like merge, but returns the mutable properties.
+func mergeProperties(sources ...*NamedPropertyCopier) (*conf.MutableProperties, error) {
 	out := conf.New()
 	for _, s := range sources {
 		if s != nil {
@@ -148,38 +205,165 @@ func merge(sources ...*NamedPropertyCopier) (conf.Properties, error) {
 	return out, nil
 }
 
+// mergeSource merges the source into out, resolving the conflicts between
+// them by the strategy, and returns the conflicts resolved.
+func mergeSource(out *conf.MutableProperties, s *NamedPropertyCopier, strategy conf.ConflictStrategy) ([]conf.Conflict, error) {
+	if strategy == conf.ConflictError {
+		if err := s.CopyTo(out); err != nil {
+			return nil, util.WrapError(err, "merge error in source %s", s.Name)
+		}
+		return nil, nil
+	}
+	p := conf.New()
+	if err := s.CopyTo(p); err != nil {
+		return nil, util.WrapError(err, "merge error in source %s", s.Name)
+	}
+	cs, err := out.Merge(p, strategy)
+	if err != nil {
+		return nil, util.WrapError(err, "merge error in source %s", s.Name)
+	}
+	return cs, nil
+}
+
 // Refresh merges all layers of configurations into a read-only properties.
+// The conflicts between the layers are resolved by the strategies of the
+// layers merged, see SetConflictStrategy.
 func (c *AppConfig) Refresh() (conf.Properties, error) {
-	p, err := new(SysConfig).Refresh()
+	p, dotEnv, err := new(SysConfig).refresh()
 	if err != nil {
 		return nil, util.WrapError(err, "refresh error in source sys")
 	}
 
-	localFiles, err := c.LocalFile.loadFiles(p)
+	localFiles, err := c.LocalFile.LoadFiles(p)
 	if err != nil {
 		return nil, util.WrapError(err, "refresh error in source local")
 	}
 
-	remoteFiles, err := c.RemoteFile.loadFiles(p)
+	kubeDirs, err := c.KubeDirs.LoadDirs(p)
+	if err != nil {
+		return nil, util.WrapError(err, "refresh error in source kube")
+	}
+
+	remoteFiles, err := c.RemoteFile.LoadFiles(p)
 	if err != nil {
 		return nil, util.WrapError(err, "refresh error in source remote")
 	}
 
-	var sources []*NamedPropertyCopier
-	sources = append(sources, NewNamedPropertyCopier("sys", SysConf))
-	sources = append(sources, localFiles...)
-	sources = append(sources, remoteFiles...)
-	sources = append(sources, NewNamedPropertyCopier("remote", c.RemoteProp))
-	sources = append(sources, NewNamedPropertyCopier("env", c.Environment))
-	sources = append(sources, NewNamedPropertyCopier("cmd", c.CommandArgs))
-	return merge(sources...)
+	var providerProp conf.Properties
+	if provider, err := c.GetRemoteProvider(p); err != nil {
+		return nil, util.WrapError(err, "refresh error in source remote-provider")
+	} else if provider != nil {
+		if providerProp, err = provider.Fetch(context.Background()); err != nil {
+			return nil, util.WrapError(err, "refresh error in source remote-provider")
+		}
+	}
+
+	layerSources := map[string][]*NamedPropertyCopier{
+		LayerSys:        {NewNamedPropertyCopier(LayerSys, SysConf)},
+		LayerLocal:      append(localFiles, kubeDirs...),
+		LayerRemote:     remoteFiles,
+		LayerRemoteProp: {NewNamedPropertyCopier(LayerRemoteProp, c.RemoteProp)},
+		LayerDotEnv:     dotEnv,
+		LayerEnv:        {NewNamedPropertyCopier(LayerEnv, c.Environment)},
+		LayerCmd:        {NewNamedPropertyCopier(LayerCmd, c.CommandArgs)},
+	}
+	if providerProp != nil {
+		layerSources[LayerRemoteProvider] = []*NamedPropertyCopier{NewNamedPropertyCopier(LayerRemoteProvider, providerProp)}
+	}
+	if p := c.runtimeProp.Load(); p != nil {
+		layerSources[LayerRuntime] = []*NamedPropertyCopier{NewNamedPropertyCopier(LayerRuntime, p)}
+	}
+
+	c.layerMutex.RLock()
+	order := c.layers.names()
+	for name, p := range c.layers.custom {
+		layerSources[name] = []*NamedPropertyCopier{NewNamedPropertyCopier(name, p)}
+	}
+	strategies := maps.Clone(c.strategies)
+	c.layerMutex.RUnlock()
+
+	out := conf.New()
+	var (
+		names     []string
+		conflicts []conf.Conflict
+	)
+	for _, name := range order {
+		strategy, ok := strategies[name]
+		if !ok {
+			s := p.Get(ConflictStrategyPrefix + name)
+			if strategy, err = conf.ParseConflictStrategy(s); err != nil {
+				return nil, util.WrapError(err, "refresh error in source %s", name)
+			}
+		}
+		for _, s := range layerSources[name] {
+			cs, err := mergeSource(out, s, strategy)
+			if err != nil {
+				return nil, err
+			}
+			for _, conflict := range cs {
+				conflict.Source = s.Name
+				conflicts = append(conflicts, conflict)
+			}
+			names = append(names, s.Name)
+		}
+	}
+
+	c.layerMutex.Lock()
+	c.sources = names
+	c.conflicts = conflicts
+	c.layerMutex.Unlock()
+	c.effective.Store(out)
+	return out, nil
+}
+
+// GetRemoteProvider returns the remote config provider, creating the one
+// configured by the system properties p if none is set.
+// It returns nil if there is no remote config provider.
+func (c *AppConfig) GetRemoteProvider(p conf.Properties) (RemoteConfigProvider, error) {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	if c.RemoteProvider != nil {
+		return c.RemoteProvider, nil
+	}
+	var cfg RemoteProviderConfig
+	if err := p.Bind(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.URL == "" {
+		return nil, nil
+	}
+	provider, err := NewRemoteProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.RemoteProvider = provider
+	return provider, nil
+}
+
+// SetProperty sets a property in the runtime layer, which overrides all
+// other layers. The change takes effect on the next Refresh. It is safe
+// to call concurrently with Refresh.
+func (c *AppConfig) SetProperty(key, val string) error {
+	c.runtimeMutex.Lock()
+	defer c.runtimeMutex.Unlock()
+	p := conf.New()
+	if old := c.runtimeProp.Load(); old != nil {
+		if err := old.CopyTo(p); err != nil {
+			return err
+		}
+	}
+	if err := p.Set(key, val, p.AddFile("runtime")); err != nil {
+		return err
+	}
+	c.runtimeProp.Store(p)
+	return nil
 }
 
 /******************************** BootConfig *********************************/
 
 // BootConfig represents a layered configuration used during application boot.
-// It typically includes only system, local file, environment and command-line
-// sources — no remote sources.
+// It typically includes only system, local file, dotenv, environment and
+// command-line sources — no remote sources.
 type BootConfig struct {
 	LocalFile   *PropertySources // Configuration sources from local files.
 	Environment *Environment     // Environment variables as configuration source.
@@ -197,12 +381,12 @@ func NewBootConfig() *BootConfig {
 
 // Refresh merges all layers of configurations into a read-only properties.
 func (c *BootConfig) Refresh() (conf.Properties, error) {
-	p, err := new(SysConfig).Refresh()
+	p, dotEnv, err := new(SysConfig).refresh()
 	if err != nil {
 		return nil, util.WrapError(err, "refresh error in source sys")
 	}
 
-	localFiles, err := c.LocalFile.loadFiles(p)
+	localFiles, err := c.LocalFile.LoadFiles(p)
 	if err != nil {
 		return nil, util.WrapError(err, "refresh error in source local")
 	}
@@ -210,6 +394,7 @@ func (c *BootConfig) Refresh() (conf.Properties, error) {
 	var sources []*NamedPropertyCopier
 	sources = append(sources, NewNamedPropertyCopier("sys", SysConf))
 	sources = append(sources, localFiles...)
+	sources = append(sources, dotEnv...)
 	sources = append(sources, NewNamedPropertyCopier("env", c.Environment))
 	sources = append(sources, NewNamedPropertyCopier("cmd", c.CommandArgs))
 	return merge(sources...)
@@ -225,15 +410,28 @@ const (
 	ConfigTypeRemote ConfigType = "remote"
 )
 
+// Optionality tells whether an extra directory or file of PropertySources
+// that doesn't exist is skipped or fails the refresh.
+type Optionality int8
+
+const (
+	Optional Optionality = iota // skipped if it doesn't exist, the default
+	Required                    // fails the refresh if it doesn't exist
+)
+
 // PropertySources represents a collection of configuration files
 // associated with a particular configuration type and logical name.
 // It supports both default directories and additional user-supplied
 // directories or files.
 type PropertySources struct {
-	configType ConfigType // Type of the configuration (local or remote).
-	configName string     // Base name of the configuration files.
-	extraDirs  []string   // Extra directories to search for configuration files.
-	extraFiles []string   // Extra individual files to include.
+	configType ConfigType      // Type of the configuration (local or remote).
+	configName string          // Base name of the configuration files.
+	extraDirs  []string        // Extra directories to search for configuration files.
+	extraFiles []string        // Extra individual files to include.
+	required   map[string]bool // Extra directories and files that are Required.
+
+	importMutex sync.Mutex // Guards importPaths.
+	importPaths []string   // Local paths imported by the last LoadFiles.
 }
 
 // NewPropertySources creates a new instance of PropertySources.
@@ -248,44 +446,116 @@ func NewPropertySources(configType ConfigType, configName string) *PropertySourc
 func (p *PropertySources) Reset() {
 	p.extraFiles = nil
 	p.extraDirs = nil
+	p.required = nil
 }
 
-// AddDir registers one or more additional directories to search for
-// configuration files. Non-existent directories are silently ignored,
-// but if the path exists and is not a directory, it panics.
-func (p *PropertySources) AddDir(dirs ...string) {
-	for _, d := range dirs {
-		info, err := osStat(d)
+// setRequired records whether the extra directory or file is Required.
+func (p *PropertySources) setRequired(path string, opt []Optionality) {
+	if len(opt) == 0 || opt[0] != Required {
+		delete(p.required, path)
+		return
+	}
+	if p.required == nil {
+		p.required = make(map[string]bool)
+	}
+	p.required[path] = true
+}
+
+// AddDir registers an additional directory to search for configuration
+// files. A non-existent directory is silently ignored, unless it's
+// Required, in which case the refresh fails while it doesn't exist. If
+// the path exists and is not a directory, it panics.
+//
+// The directory may also be a pattern, expanded at each refresh, whose
+// elements are matched as by filepath.Match, except "**" that matches
+// zero or more directories. A pattern ending with "**", e.g. "./conf/**",
+// searches every directory it matches, and any other pattern, e.g.
+// "./conf/**/*.yaml", loads every file it matches whatever its name, both
+// in the lexical order of filepath.WalkDir. A Required pattern fails the
+// refresh while it matches nothing.
+func (p *PropertySources) AddDir(dir string, opt ...Optionality) {
+	if !hasMeta(dir) {
+		info, err := osStat(dir)
 		if err != nil {
 			if !os.IsNotExist(err) {
 				panic(err)
 			}
-			continue
-		}
-		if !info.IsDir() {
-			panic(util.FormatError(nil, "should be a directory %s", d))
+		} else if !info.IsDir() {
+			panic(util.FormatError(nil, "should be a directory %s", dir))
 		}
 	}
-	p.extraDirs = append(p.extraDirs, dirs...)
+	p.extraDirs = append(p.extraDirs, dir)
+	p.setRequired(dir, opt)
 }
 
-// AddFile registers one or more additional configuration files.
-// Non-existent files are silently ignored, but if the path exists
-// and is a directory, it panics.
-func (p *PropertySources) AddFile(files ...string) {
-	for _, f := range files {
-		info, err := osStat(f)
+// AddFile registers an additional configuration file. A non-existent file
+// is silently ignored, unless it's Required, in which case the refresh
+// fails while it doesn't exist. If the path exists and is a directory, it
+// panics. A file may also be an http or https url, fetched at each
+// refresh as set by URLFileConfig, which may end with the checksum of the
+// file, e.g. "#sha256=<hex>".
+func (p *PropertySources) AddFile(file string, opt ...Optionality) {
+	if !isHTTPURL(file) {
+		info, err := osStat(file)
 		if err != nil {
 			if !os.IsNotExist(err) {
 				panic(err)
 			}
+		} else if info.IsDir() {
+			panic(util.FormatError(nil, "should be a file %s", file))
+		}
+	}
+	p.extraFiles = append(p.extraFiles, file)
+	p.setRequired(file, opt)
+}
+
+// checkRequiredDirs returns an error if a Required extra directory
+// doesn't exist, or a Required pattern matches nothing.
+func (p *PropertySources) checkRequiredDirs(resolver conf.Properties) error {
+	for _, dir := range p.extraDirs {
+		if !p.required[dir] {
+			continue
+		}
+		s, err := resolver.Resolve(dir)
+		if err != nil {
+			return err
+		}
+		if hasMeta(s) {
+			paths, err := globPaths(s, filepath.Base(s) == "**")
+			if err != nil {
+				return err
+			}
+			if len(paths) == 0 {
+				return util.FormatError(nil, "required config dir %s matches nothing", s)
+			}
 			continue
 		}
-		if info.IsDir() {
-			panic(util.FormatError(nil, "should be a file %s", f))
+		if _, err = osStat(s); err != nil {
+			if os.IsNotExist(err) {
+				return util.FormatError(nil, "required config dir %s not found", s)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRequiredFiles returns an error if a Required extra file isn't
+// among the sources loaded.
+func (p *PropertySources) checkRequiredFiles(sources []*NamedPropertyCopier, resolver conf.Properties) error {
+	for _, file := range p.extraFiles {
+		if !p.required[file] {
+			continue
+		}
+		s, err := resolver.Resolve(file)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(sources, func(c *NamedPropertyCopier) bool { return c.Name == s }) {
+			return util.FormatError(nil, "required config file %s not found", s)
 		}
 	}
-	p.extraFiles = append(p.extraFiles, files...)
+	return nil
 }
 
 // getDefaultDir determines the default configuration directory
@@ -301,38 +571,111 @@ func (p *PropertySources) getDefaultDir(resolver conf.Properties) (string, error
 	}
 }
 
+// configExtensions are the extensions of config files, in loading order.
+var configExtensions = []string{".properties", ".yaml", ".yml", ".toml", ".tml", ".json", ".ini", ".conf"}
+
 // getFiles generates the list of configuration file paths to try,
 // including both the base config name and profile-specific variants.
 // For example, with profile "dev", it will try "app-dev.yaml" etc.
 func (p *PropertySources) getFiles(dir string, resolver conf.Properties) ([]string, error) {
-	extensions := []string{".properties", ".yaml", ".yml", ".toml", ".tml", ".json"}
-
 	var files []string
-	for _, ext := range extensions {
+	for _, ext := range configExtensions {
 		files = append(files, filepath.Join(dir, p.configName+ext))
 	}
 
-	activeProfiles, err := resolver.Resolve("${spring.profiles.active:=}")
+	profiles, err := activeProfiles(resolver)
 	if err != nil {
 		return nil, err
 	}
+	for _, s := range profiles {
+		for _, ext := range configExtensions {
+			files = append(files, filepath.Join(dir, p.configName+"-"+s+ext))
+		}
+	}
+	return files, nil
+}
 
-	if activeProfiles = strings.TrimSpace(activeProfiles); activeProfiles != "" {
-		for s := range strings.SplitSeq(activeProfiles, ",") {
-			if s = strings.TrimSpace(s); s != "" {
-				for _, ext := range extensions {
-					files = append(files, filepath.Join(dir, p.configName+"-"+s+ext))
-				}
-			}
+// dirFiles returns the candidate files of the resolved directory or
+// pattern given to AddDir.
+func (p *PropertySources) dirFiles(dir string, resolver conf.Properties) ([]string, error) {
+	if !hasMeta(dir) {
+		return p.getFiles(dir, resolver)
+	}
+	if filepath.Base(dir) != "**" {
+		return globPaths(dir, false)
+	}
+	dirs, err := globPaths(dir, true)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, d := range dirs {
+		temp, err := p.getFiles(d, resolver)
+		if err != nil {
+			return nil, err
 		}
+		files = append(files, temp...)
 	}
 	return files, nil
 }
 
-// loadFiles loads all candidate configuration files in order and wraps
-// successfully loaded ones as NamedPropertyCopier. Non-existent files
-// are skipped silently, while other loading errors abort the process.
-func (p *PropertySources) loadFiles(resolver conf.Properties) ([]*NamedPropertyCopier, error) {
+// activeProfiles returns the profiles listed in "spring.profiles.active".
+func activeProfiles(resolver conf.Properties) ([]string, error) {
+	s, err := resolver.Resolve("${spring.profiles.active:=}")
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	for s := range strings.SplitSeq(s, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			profiles = append(profiles, s)
+		}
+	}
+	return profiles, nil
+}
+
+// LoadFiles loads all candidate configuration files concurrently and wraps
+// successfully loaded ones as NamedPropertyCopier, in the order of the
+// candidates, each preceded by the sources it includes through IncludeProp
+// and followed by the sources it imports through ImportProp.
+// Non-existent files are skipped silently, unless they're Required, while
+// other loading errors abort the process.
+func (p *PropertySources) LoadFiles(resolver conf.Properties) ([]*NamedPropertyCopier, error) {
+	if err := p.checkRequiredDirs(resolver); err != nil {
+		return nil, err
+	}
+	files, err := p.candidateFiles(resolver)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := activeProfiles(resolver)
+	if err != nil {
+		return nil, err
+	}
+	var cfg URLFileConfig
+	if err = resolver.Bind(&cfg); err != nil {
+		return nil, err
+	}
+	sources, err := loadFiles(files, profiles, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.checkRequiredFiles(sources, resolver); err != nil {
+		return nil, err
+	}
+	sources, paths, err := loadImports(sources, resolver)
+	if err != nil {
+		return nil, err
+	}
+	p.importMutex.Lock()
+	p.importPaths = paths
+	p.importMutex.Unlock()
+	return sources, nil
+}
+
+// candidateFiles returns the resolved paths of all candidate files, from
+// the default directory, the extra directories and the extra files.
+func (p *PropertySources) candidateFiles(resolver conf.Properties) ([]string, error) {
 	defaultDir, err := p.getDefaultDir(resolver)
 	if err != nil {
 		return nil, err
@@ -341,28 +684,70 @@ func (p *PropertySources) loadFiles(resolver conf.Properties) ([]*NamedPropertyC
 
 	var files []string
 	for _, dir := range dirs {
-		temp, err := p.getFiles(dir, resolver)
+		if dir, err = resolver.Resolve(dir); err != nil {
+			return nil, err
+		}
+		temp, err := p.dirFiles(dir, resolver)
 		if err != nil {
 			return nil, err
 		}
 		files = append(files, temp...)
 	}
-	files = append(files, p.extraFiles...)
-
-	var ret []*NamedPropertyCopier
-	for _, s := range files {
-		filename, err := resolver.Resolve(s)
-		if err != nil {
+	for _, s := range p.extraFiles {
+		if s, err = resolver.Resolve(s); err != nil {
 			return nil, err
 		}
-		c, err := conf.Load(filename)
+		files = append(files, s)
+	}
+	return files, nil
+}
+
+// maxConcurrentLoads limits the number of files loaded at the same time.
+const maxConcurrentLoads = 8
+
+// loadFiles loads the files concurrently, since reading them may be slow
+// on network file systems, and returns them in the given order. The
+// profiles select the documents of multi-document files, and cfg is how
+// the files given by urls are fetched. Once a file fails to load, the
+// files after it that aren't loaded yet are skipped, and the error of the
+// first failed file is returned.
+func loadFiles(files []string, profiles []string, cfg URLFileConfig) ([]*NamedPropertyCopier, error) {
+	var failed atomic.Int64 // index of the first failed file so far
+	failed.Store(int64(len(files)))
+
+	sem := make(chan struct{}, maxConcurrentLoads)
+	loads := make([]*goutil.ValueStatus[*conf.MutableProperties], len(files))
+	for i, filename := range files {
+		loads[i] = goutil.GoValue(context.Background(), func(ctx context.Context) (*conf.MutableProperties, error) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if int64(i) > failed.Load() {
+				return nil, nil // skipped
+			}
+			c, err := loadFile(ctx, filename, profiles, cfg)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				for n := failed.Load(); int64(i) < n; n = failed.Load() {
+					if failed.CompareAndSwap(n, int64(i)) {
+						break
+					}
+				}
+			}
+			return c, err
+		})
+	}
+
+	var ret []*NamedPropertyCopier
+	for i, l := range loads {
+		c, err := l.Wait()
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
 				continue
 			}
 			return nil, err
 		}
-		ret = append(ret, NewNamedPropertyCopier(filename, c))
+		if c != nil {
+			ret = append(ret, NewNamedPropertyCopier(files[i], c))
+		}
 	}
 	return ret, nil
 }
@@ -18,7 +18,9 @@ package gs_conf
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-spring/spring-base/testing/assert"
@@ -29,6 +31,7 @@ func clean() {
 	os.Args = nil
 	os.Clearenv()
 	SysConf = conf.New()
+	ResetEnvRules()
 }
 
 func TestAppConfig(t *testing.T) {
@@ -62,6 +65,26 @@ func TestAppConfig(t *testing.T) {
 		})
 	})
 
+	t.Run("profile documents", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		src := "http.server.addr: 0.0.0.0:8080\n" +
+			"---\nspring.config.activate.on-profile: dev\nhttp.server.addr: 127.0.0.1:8080\n" +
+			"---\nspring.config.activate.on-profile: prod\nhttp.server.addr: 0.0.0.0:80\n"
+		assert.That(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(src), 0644)).Nil()
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_DIR", dir)
+
+		p, err := NewAppConfig().Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("http.server.addr")).Equal("0.0.0.0:8080")
+		assert.That(t, p.Has("spring.config.activate.on-profile")).False()
+
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+		p, err = NewAppConfig().Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("http.server.addr")).Equal("127.0.0.1:8080")
+	})
+
 	t.Run("merge error - env", func(t *testing.T) {
 		t.Cleanup(clean)
 		_ = os.Setenv("GS_A", "a")
@@ -87,6 +110,26 @@ func TestAppConfig(t *testing.T) {
 		assert.That(t, err).Nil()
 		assert.That(t, p.Get("spring.app.name")).Equal("sysconf-test")
 	})
+
+	t.Run("runtime properties", func(t *testing.T) {
+		t.Cleanup(clean)
+		_ = os.Setenv("GS_SPRING_APP_NAME", "env")
+		c := NewAppConfig()
+		err := c.SetProperty("spring.app.name", "runtime")
+		assert.That(t, err).Nil()
+		err = c.SetProperty("logging.level.root", "debug")
+		assert.That(t, err).Nil()
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("spring.app.name")).Equal("runtime")
+		assert.That(t, p.Get("logging.level.root")).Equal("debug")
+
+		err = c.SetProperty("logging.level.root.x", "debug")
+		assert.Error(t, err).Matches("property conflict at path logging.level.root.x")
+		p, err = c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("logging.level.root")).Equal("debug")
+	})
 }
 
 func TestBootConfig(t *testing.T) {
@@ -235,6 +278,8 @@ func TestPropertySources(t *testing.T) {
 			"conf/app.toml",
 			"conf/app.tml",
 			"conf/app.json",
+			"conf/app.ini",
+			"conf/app.conf",
 		})
 	})
 
@@ -253,18 +298,24 @@ func TestPropertySources(t *testing.T) {
 			"conf/app.toml",
 			"conf/app.tml",
 			"conf/app.json",
+			"conf/app.ini",
+			"conf/app.conf",
 			"conf/app-dev.properties",
 			"conf/app-dev.yaml",
 			"conf/app-dev.yml",
 			"conf/app-dev.toml",
 			"conf/app-dev.tml",
 			"conf/app-dev.json",
+			"conf/app-dev.ini",
+			"conf/app-dev.conf",
 			"conf/app-test.properties",
 			"conf/app-test.yaml",
 			"conf/app-test.yml",
 			"conf/app-test.toml",
 			"conf/app-test.tml",
 			"conf/app-test.json",
+			"conf/app-test.ini",
+			"conf/app-test.conf",
 		})
 	})
 
@@ -272,7 +323,7 @@ func TestPropertySources(t *testing.T) {
 		t.Cleanup(clean)
 		ps := NewPropertySources(ConfigTypeLocal, "app")
 		ps.AddFile("./testdata/conf/app.properties")
-		files, err := ps.loadFiles(conf.Map(nil))
+		files, err := ps.LoadFiles(conf.Map(nil))
 		assert.That(t, err).Nil()
 		assert.That(t, 1).Equal(len(files))
 	})
@@ -280,7 +331,7 @@ func TestPropertySources(t *testing.T) {
 	t.Run("unknown config type", func(t *testing.T) {
 		t.Cleanup(clean)
 		ps := NewPropertySources("invalid", "app")
-		_, err := ps.loadFiles(conf.Map(nil))
+		_, err := ps.LoadFiles(conf.Map(nil))
 		assert.Error(t, err).Matches("unknown config type: invalid")
 	})
 
@@ -290,7 +341,7 @@ func TestPropertySources(t *testing.T) {
 			"spring.profiles.active": "${a}",
 		})
 		ps := NewPropertySources(ConfigTypeLocal, "app")
-		_, err := ps.loadFiles(p)
+		_, err := ps.LoadFiles(p)
 		assert.Error(t, err).Matches(`resolve string "\${a}" error: property \"a\" not exist`)
 	})
 
@@ -298,7 +349,7 @@ func TestPropertySources(t *testing.T) {
 		t.Cleanup(clean)
 		ps := NewPropertySources(ConfigTypeLocal, "app")
 		ps.AddFile("./testdata/conf/app-${a}.properties")
-		_, err := ps.loadFiles(conf.Map(nil))
+		_, err := ps.LoadFiles(conf.Map(nil))
 		assert.Error(t, err).Matches("property \"a\" not exist")
 	})
 
@@ -306,15 +357,86 @@ func TestPropertySources(t *testing.T) {
 		t.Cleanup(clean)
 		ps := NewPropertySources(ConfigTypeLocal, "app")
 		ps.AddFile("./testdata/conf/error.json")
-		_, err := ps.loadFiles(conf.Map(nil))
+		_, err := ps.LoadFiles(conf.Map(nil))
 		assert.Error(t, err).Matches("cannot unmarshal .*")
 	})
 
+	t.Run("load files concurrently", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		ps := NewPropertySources(ConfigTypeLocal, "app")
+		var want []string
+		for i := range 3 * maxConcurrentLoads {
+			name := filepath.Join(dir, fmt.Sprintf("app-%02d.properties", i))
+			err := os.WriteFile(name, fmt.Appendf(nil, "key=%d", i), 0644)
+			assert.That(t, err).Nil()
+			ps.AddFile(name)
+			want = append(want, name)
+		}
+		ps.AddFile(filepath.Join(dir, "missing.properties"))
+
+		files, err := ps.LoadFiles(conf.Map(nil))
+		assert.That(t, err).Nil()
+		var got []string
+		for _, f := range files {
+			got = append(got, f.Name)
+		}
+		assert.That(t, got).Equal(want)
+
+		p, err := merge(files...)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("key")).Equal(fmt.Sprint(3*maxConcurrentLoads - 1))
+	})
+
+	t.Run("required sources", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		name := filepath.Join(dir, "app.properties")
+		err := os.WriteFile(name, []byte("key=value"), 0644)
+		assert.That(t, err).Nil()
+
+		ps := NewPropertySources(ConfigTypeLocal, "app")
+		ps.AddDir(dir, Required)
+		ps.AddFile(name, Required)
+		ps.AddFile(filepath.Join(dir, "missing.properties"), Optional)
+		files, err := ps.LoadFiles(conf.Map(nil))
+		assert.That(t, err).Nil()
+		assert.That(t, len(files)).Equal(2)
+
+		ps.AddFile(filepath.Join(dir, "other.properties"), Required)
+		_, err = ps.LoadFiles(conf.Map(nil))
+		assert.Error(t, err).Matches(`required config file .*other\.properties not found`)
+
+		ps.Reset()
+		ps.AddDir(filepath.Join(dir, "conf"), Required)
+		_, err = ps.LoadFiles(conf.Map(nil))
+		assert.Error(t, err).Matches(`required config dir .*conf not found`)
+
+		ps.Reset()
+		ps.AddDir(filepath.Join(dir, "conf"))
+		_, err = ps.LoadFiles(conf.Map(nil))
+		assert.That(t, err).Nil()
+	})
+
+	t.Run("load files concurrently with errors", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		ps := NewPropertySources(ConfigTypeLocal, "app")
+		for i := range 3 * maxConcurrentLoads {
+			name := filepath.Join(dir, fmt.Sprintf("app-%02d.json", i))
+			err := os.WriteFile(name, []byte(`{"key":`), 0644)
+			assert.That(t, err).Nil()
+			ps.AddFile(name)
+		}
+		_, err := ps.LoadFiles(conf.Map(nil))
+		assert.Error(t, err).Matches(`read file .*app-00\.json error`)
+	})
+
 	t.Run("load files with non-existent dir", func(t *testing.T) {
 		t.Cleanup(clean)
 		ps := NewPropertySources(ConfigTypeLocal, "app")
 		ps.AddDir("non_existent_dir")
-		files, err := ps.loadFiles(conf.Map(nil))
+		files, err := ps.LoadFiles(conf.Map(nil))
 		assert.That(t, err).Nil()
 		assert.That(t, 0).Equal(len(files))
 	})
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// DotEnvFile is the name of the dotenv file loaded from the working
+// directory, the file of a profile is named with the profile as suffix,
+// e.g. ".env.dev".
+const DotEnvFile = ".env"
+
+// DotEnv represents the variables of a dotenv file, which are mapped to
+// properties by the same rules as environment variables. They don't
+// change the environment of the process.
+type DotEnv struct {
+	file    string
+	environ []string
+}
+
+// CopyTo adds the variables of the dotenv file.
+func (c *DotEnv) CopyTo(p *conf.MutableProperties) error {
+	return copyEnv(p, c.file, c.environ)
+}
+
+// loadDotEnv loads ".env" and then ".env.{profile}" of the active profiles
+// from the working directory, so the latter override the former. The
+// profiles are resolved with ".env" applied, so it may activate them.
+// Non-existent files are skipped.
+func loadDotEnv() ([]*NamedPropertyCopier, error) {
+	base, err := readDotEnv(DotEnvFile)
+	if err != nil || base == nil {
+		return nil, err
+	}
+	sources := []*NamedPropertyCopier{NewNamedPropertyCopier("dotenv", base)}
+
+	p, err := merge(
+		NewNamedPropertyCopier("sys", SysConf),
+		sources[0],
+		NewNamedPropertyCopier("env", NewEnvironment()),
+		NewNamedPropertyCopier("cmd", NewCommandArgs()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	activeProfiles, err := p.Resolve("${spring.profiles.active:=}")
+	if err != nil {
+		return nil, err
+	}
+	for s := range strings.SplitSeq(activeProfiles, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		c, err := readDotEnv(DotEnvFile + "." + s)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			sources = append(sources, NewNamedPropertyCopier("dotenv", c))
+		}
+	}
+	return sources, nil
+}
+
+// readDotEnv reads the dotenv file, it returns nil if the file doesn't exist.
+func readDotEnv(file string) (*DotEnv, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, util.FormatError(err, "read file %s error", file)
+	}
+	environ, err := parseDotEnv(b)
+	if err != nil {
+		return nil, util.FormatError(err, "read file %s error", file)
+	}
+	return &DotEnv{file: file, environ: environ}, nil
+}
+
+// parseDotEnv parses lines like "KEY=value" into the "key=value" form of
+// environment variables. Blank lines, comments starting with '#' and the
+// "export " prefix are ignored. A value can be double-quoted, with escape
+// sequences, or single-quoted, taken literally. An unquoted value ends at
+// " #", which starts a comment.
+func parseDotEnv(b []byte) ([]string, error) {
+	var environ []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		if k = strings.TrimSpace(k); !ok || k == "" {
+			return nil, util.FormatError(nil, "line %d: invalid variable %q", n, line)
+		}
+		v = strings.TrimSpace(v)
+		switch {
+		case strings.HasPrefix(v, `"`):
+			i := closingQuote(v)
+			if i < 0 {
+				return nil, util.FormatError(nil, "line %d: missing closing quote", n)
+			}
+			s, err := strconv.Unquote(v[:i+1])
+			if err != nil {
+				return nil, util.FormatError(err, "line %d: invalid value %s", n, v[:i+1])
+			}
+			v = s
+		case strings.HasPrefix(v, "'"):
+			i := strings.IndexByte(v[1:], '\'')
+			if i < 0 {
+				return nil, util.FormatError(nil, "line %d: missing closing quote", n)
+			}
+			v = v[1 : i+1]
+		default:
+			if i := strings.Index(v, " #"); i >= 0 {
+				v = strings.TrimSpace(v[:i])
+			}
+		}
+		environ = append(environ, k+"="+v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return environ, nil
+}
+
+// closingQuote returns the index of the unescaped '"' closing the string
+// that starts with '"', or -1 if there is none.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestParseDotEnv(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		environ, err := parseDotEnv([]byte(`
+			# comment
+			GS_DB_HOST=localhost
+			export GS_DB_PORT = 3306
+			EMPTY=
+			DOUBLE="a \"b\"\nc" # comment
+			SINGLE='a \n b'
+			UNQUOTED=a#b # comment
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, environ).Equal([]string{
+			"GS_DB_HOST=localhost",
+			"GS_DB_PORT=3306",
+			"EMPTY=",
+			"DOUBLE=a \"b\"\nc",
+			`SINGLE=a \n b`,
+			"UNQUOTED=a#b",
+		})
+	})
+
+	t.Run("invalid variable", func(t *testing.T) {
+		_, err := parseDotEnv([]byte("A=1\nB"))
+		assert.Error(t, err).Matches(`line 2: invalid variable "B"`)
+	})
+
+	t.Run("missing closing quote", func(t *testing.T) {
+		_, err := parseDotEnv([]byte(`A="1`))
+		assert.Error(t, err).Matches(`line 1: missing closing quote`)
+		_, err = parseDotEnv([]byte(`A='1`))
+		assert.Error(t, err).Matches(`line 1: missing closing quote`)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := parseDotEnv([]byte(`A="\x"`))
+		assert.Error(t, err).Matches(`line 1: invalid value`)
+	})
+}
+
+func TestDotEnv(t *testing.T) {
+	clean()
+
+	write := func(t *testing.T, name, content string) {
+		err := os.WriteFile(name, []byte(content), 0644)
+		assert.That(t, err).Nil()
+	}
+
+	t.Run("no dotenv file", func(t *testing.T) {
+		t.Cleanup(clean)
+		t.Chdir(t.TempDir())
+		p, err := NewAppConfig().Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Has("db.host")).False()
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Cleanup(clean)
+		t.Chdir(t.TempDir())
+		write(t, ".env", "GS_DB_HOST=localhost\nGS_DB_PORT=3306\nGS_DB_USER=root\nGS_SPRING_PROFILES_ACTIVE=dev")
+		write(t, ".env.dev", "GS_DB_PORT=3307\nGS_DB_USER=dev")
+		write(t, ".env.test", "GS_DB_PORT=3308")
+		_ = os.Setenv("GS_DB_USER", "admin")
+		fileID := SysConf.AddFile("sys")
+		_ = SysConf.Set("db.pool", "10", fileID)
+		_ = SysConf.Set("db.host", "127.0.0.1", fileID)
+
+		p, err := new(SysConfig).Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("db.host")).Equal("localhost")
+		assert.That(t, p.Get("db.port")).Equal("3307")
+
+		p, err = NewAppConfig().Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("db.pool")).Equal("10")
+		assert.That(t, p.Get("db.host")).Equal("localhost")
+		assert.That(t, p.Get("db.port")).Equal("3307")
+		assert.That(t, p.Get("db.user")).Equal("admin")
+
+		p, err = NewBootConfig().Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("db.port")).Equal("3307")
+		assert.That(t, p.Get("db.user")).Equal("admin")
+	})
+
+	t.Run("env rules", func(t *testing.T) {
+		t.Cleanup(clean)
+		t.Chdir(t.TempDir())
+		write(t, ".env", "APP_DB_HOST=localhost\nAWS_KEY=secret")
+		SetEnvPrefix("APP_")
+		err := ExcludeEnv("AWS_*")
+		assert.That(t, err).Nil()
+		p, err := NewAppConfig().Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("db.host")).Equal("localhost")
+		assert.That(t, p.Has("AWS_KEY")).False()
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		t.Cleanup(clean)
+		t.Chdir(t.TempDir())
+		write(t, ".env", "GS_DB_HOST")
+		_, err := NewAppConfig().Refresh()
+		assert.Error(t, err).Matches(`refresh error in source sys << merge error in source dotenv << read file .env error: line 1: invalid variable`)
+	})
+
+	t.Run("profile syntax error", func(t *testing.T) {
+		t.Cleanup(clean)
+		t.Chdir(t.TempDir())
+		write(t, ".env", "GS_SPRING_PROFILES_ACTIVE=dev")
+		write(t, ".env.dev", "GS_DB_HOST")
+		_, err := NewBootConfig().Refresh()
+		assert.Error(t, err).Matches(`read file .env.dev error: line 1: invalid variable`)
+	})
+}
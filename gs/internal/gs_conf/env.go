@@ -18,12 +18,87 @@ package gs_conf
 
 import (
 	"os"
+	"path"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/conf"
 )
 
+// DefaultEnvPrefix is the default prefix of the environment variables
+// whose names are mapped to property keys.
+const DefaultEnvPrefix = "GS_"
+
+// EnvKeyMapper maps the name of an environment variable, with the prefix
+// removed, to a property key. It returns false to skip the variable.
+type EnvKeyMapper func(name string) (key string, ok bool)
+
+// DefaultEnvKeyMapper replaces the underscores '_' in the name with dots
+// '.' and converts it to lowercase, e.g. "DB_HOST" becomes "db.host".
+func DefaultEnvKeyMapper(name string) (string, bool) {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ".")), true
+}
+
+// envRules holds the rules by which environment variables are mapped to
+// properties. They're shared by all configuration layers, so that the
+// system layer resolves the config locations with the same rules.
+var envRules = struct {
+	sync.RWMutex
+	prefix   string
+	excludes []string
+	mapper   EnvKeyMapper
+}{
+	prefix: DefaultEnvPrefix,
+	mapper: DefaultEnvKeyMapper,
+}
+
+// SetEnvPrefix sets the prefix of the environment variables whose names
+// are mapped to property keys, "GS_" by default. With an empty prefix,
+// the names of all variables are mapped.
+func SetEnvPrefix(prefix string) {
+	envRules.Lock()
+	defer envRules.Unlock()
+	envRules.prefix = prefix
+}
+
+// ExcludeEnv skips the environment variables whose names match any of the
+// patterns, in the syntax of [path.Match], e.g. "AWS_*".
+func ExcludeEnv(patterns ...string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return util.FormatError(err, "invalid env pattern %s", pattern)
+		}
+	}
+	envRules.Lock()
+	defer envRules.Unlock()
+	envRules.excludes = append(envRules.excludes, patterns...)
+	return nil
+}
+
+// SetEnvKeyMapper sets the function that maps the names of the prefixed
+// environment variables to property keys. A nil fn restores the default
+// [DefaultEnvKeyMapper].
+func SetEnvKeyMapper(fn EnvKeyMapper) {
+	if fn == nil {
+		fn = DefaultEnvKeyMapper
+	}
+	envRules.Lock()
+	defer envRules.Unlock()
+	envRules.mapper = fn
+}
+
+// ResetEnvRules restores the default prefix and key mapper, and removes
+// all exclusion patterns.
+func ResetEnvRules() {
+	envRules.Lock()
+	defer envRules.Unlock()
+	envRules.prefix = DefaultEnvPrefix
+	envRules.excludes = nil
+	envRules.mapper = DefaultEnvKeyMapper
+}
+
 // Environment represents the environment configuration.
 type Environment struct{}
 
@@ -33,20 +108,29 @@ func NewEnvironment() *Environment {
 }
 
 // CopyTo adds environment variables.
-// Variables with the prefix "GS_" are transformed:
-//   - Prefix "GS_" is removed.
-//   - Remaining underscores '_' are replaced by dots '.'.
-//   - Keys are converted to lowercase.
+// Variables with the prefix ("GS_" by default) are transformed:
+//   - The prefix is removed.
+//   - The rest is mapped by the key mapper, which by default replaces
+//     underscores '_' by dots '.' and converts keys to lowercase.
 //
-// All other variables are stored as-is.
+// All other variables are stored as-is. Variables matching an exclusion
+// pattern are skipped.
 func (c *Environment) CopyTo(p *conf.MutableProperties) error {
-	environ := os.Environ()
+	return copyEnv(p, "Environment", os.Environ())
+}
+
+// copyEnv adds the variables in the "key=value" form from the source by
+// the environment rules.
+func copyEnv(p *conf.MutableProperties, source string, environ []string) error {
 	if len(environ) == 0 {
 		return nil
 	}
 
-	const prefix = "GS_"
-	fileID := p.AddFile("Environment")
+	envRules.RLock()
+	prefix, excludes, mapper := envRules.prefix, envRules.excludes, envRules.mapper
+	envRules.RUnlock()
+
+	fileID := p.AddFile(source)
 
 	for _, env := range environ {
 		ss := strings.SplitN(env, "=", 2)
@@ -59,13 +143,21 @@ func (c *Environment) CopyTo(p *conf.MutableProperties) error {
 			v = ss[1]
 		}
 
+		if slices.ContainsFunc(excludes, func(pattern string) bool {
+			ok, _ := path.Match(pattern, k)
+			return ok
+		}) {
+			continue
+		}
+
 		propKey := k
 		if s, ok := strings.CutPrefix(k, prefix); ok {
-			propKey = strings.ReplaceAll(s, "_", ".")
-			propKey = strings.ToLower(propKey)
+			if propKey, ok = mapper(s); !ok {
+				continue
+			}
 		}
 
-		if err := p.Set(propKey, v, fileID); err != nil {
+		if err := p.SetNamed(propKey, v, fileID, k); err != nil {
 			return util.FormatError(err, "set env %s error", env)
 		}
 	}
@@ -18,6 +18,7 @@ package gs_conf
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/go-spring/spring-base/testing/assert"
@@ -71,4 +72,72 @@ func TestEnvironment(t *testing.T) {
 		err := NewEnvironment().CopyTo(props)
 		assert.Error(t, err).Matches("property conflict at path db.host")
 	})
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Cleanup(ResetEnvRules)
+		SetEnvPrefix("MY_APP_")
+		_ = os.Setenv("MY_APP_DB_HOST", "db1")
+		_ = os.Setenv("GS_DB_PORT", "3306")
+		defer func() {
+			_ = os.Unsetenv("MY_APP_DB_HOST")
+			_ = os.Unsetenv("GS_DB_PORT")
+		}()
+		props := conf.New()
+		err := NewEnvironment().CopyTo(props)
+		assert.That(t, err).Nil()
+		assert.That(t, props.Get("db.host")).Equal("db1")
+		assert.That(t, props.Get("GS_DB_PORT")).Equal("3306")
+		assert.That(t, props.Has("db.port")).False()
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		t.Cleanup(ResetEnvRules)
+		err := ExcludeEnv("[")
+		assert.Error(t, err).Matches("invalid env pattern \\[")
+		err = ExcludeEnv("GS_SECRET_*", "API_*")
+		assert.That(t, err).Nil()
+		_ = os.Setenv("GS_SECRET_KEY", "secret")
+		_ = os.Setenv("GS_DB_HOST", "db1")
+		_ = os.Setenv("API_KEY", "key123")
+		defer func() {
+			_ = os.Unsetenv("GS_SECRET_KEY")
+			_ = os.Unsetenv("GS_DB_HOST")
+			_ = os.Unsetenv("API_KEY")
+		}()
+		props := conf.New()
+		err = NewEnvironment().CopyTo(props)
+		assert.That(t, err).Nil()
+		assert.That(t, props.Keys()).Equal([]string{"db.host"})
+	})
+
+	t.Run("key mapper", func(t *testing.T) {
+		t.Cleanup(ResetEnvRules)
+		SetEnvPrefix("MY_APP_")
+		SetEnvKeyMapper(func(name string) (string, bool) {
+			if strings.HasPrefix(name, "INTERNAL_") {
+				return "", false
+			}
+			// "__" separates the segments and "_" joins words.
+			var ss []string
+			for s := range strings.SplitSeq(name, "__") {
+				ss = append(ss, strings.ToLower(strings.ReplaceAll(s, "_", "-")))
+			}
+			return strings.Join(ss, "."), true
+		})
+		_ = os.Setenv("MY_APP_HTTP__SERVER_ADDR", ":8080")
+		_ = os.Setenv("MY_APP_INTERNAL_ID", "1")
+		defer func() {
+			_ = os.Unsetenv("MY_APP_HTTP__SERVER_ADDR")
+			_ = os.Unsetenv("MY_APP_INTERNAL_ID")
+		}()
+		props := conf.New()
+		err := NewEnvironment().CopyTo(props)
+		assert.That(t, err).Nil()
+		assert.That(t, props.Keys()).Equal([]string{"http.server-addr"})
+
+		// the default mapper can't map "__"
+		SetEnvKeyMapper(nil)
+		err = NewEnvironment().CopyTo(conf.New())
+		assert.Error(t, err).Matches(`invalid key "http..server.addr"`)
+	})
 }
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// BindFlags merges the flags of the parsed flag set into SysConf, using
+// the flag names as the property keys. The flags that aren't set provide
+// their defaults. A flag whose value implements [flag.Getter] and gets a
+// []string or a map[string]string is stored as a list or a map, so that
+// it binds back to a slice or a map; other flags are stored as the text
+// of their values, e.g. "1m30s" for a time.Duration.
+func BindFlags(fs *flag.FlagSet) error {
+	if !fs.Parsed() {
+		return util.FormatError(nil, "flag set %s isn't parsed", fs.Name())
+	}
+	p := conf.New()
+	fileID := p.AddFile("Flags")
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err == nil {
+			if err = setFlag(p, f, fileID); err != nil {
+				err = util.FormatError(err, "bind flag %s error", f.Name)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return p.CopyTo(SysConf)
+}
+
+// setFlag sets the value of the flag f in p.
+func setFlag(p *conf.MutableProperties, f *flag.Flag, fileID int8) error {
+	var v any
+	if g, ok := f.Value.(flag.Getter); ok {
+		v = g.Get()
+	}
+	switch v := v.(type) {
+	case []string:
+		for i, s := range v {
+			if err := p.Set(fmt.Sprintf("%s[%d]", f.Name, i), s, fileID); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]string:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if err := p.Set(f.Name+"."+k, v[k], fileID); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return p.Set(f.Name, f.Value.String(), fileID)
+	}
+}
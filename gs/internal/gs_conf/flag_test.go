@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+// listFlag is a flag.Getter that collects repeated values.
+type listFlag []string
+
+func (f *listFlag) String() string     { return strings.Join(*f, ",") }
+func (f *listFlag) Set(s string) error { *f = append(*f, s); return nil }
+func (f *listFlag) Get() any           { return []string(*f) }
+
+// mapFlag is a flag.Getter that collects key=value pairs.
+type mapFlag map[string]string
+
+func (f mapFlag) String() string { return "" }
+func (f mapFlag) Get() any       { return map[string]string(f) }
+func (f mapFlag) Set(s string) error {
+	k, v, _ := strings.Cut(s, "=")
+	f[k] = v
+	return nil
+}
+
+func TestBindFlags(t *testing.T) {
+	clean()
+	t.Cleanup(clean)
+
+	t.Run("not parsed", func(t *testing.T) {
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		err := BindFlags(fs)
+		assert.Error(t, err).Matches("flag set app isn't parsed")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Cleanup(clean)
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		fs.String("server.addr", ":8080", "")
+		fs.Int("server.port", 0, "")
+		fs.Bool("debug", false, "")
+		fs.Duration("timeout", time.Second, "")
+		var hosts listFlag
+		fs.Var(&hosts, "hosts", "")
+		labels := mapFlag{}
+		fs.Var(labels, "labels", "")
+		err := fs.Parse([]string{
+			"-server.port=9090", "-debug", "-timeout=1m30s",
+			"-hosts=a", "-hosts=b", "-labels=env=dev", "-labels=zone=1",
+		})
+		assert.That(t, err).Nil()
+
+		err = BindFlags(fs)
+		assert.That(t, err).Nil()
+		assert.That(t, SysConf.Get("server.addr")).Equal(":8080")
+		assert.That(t, SysConf.Get("server.port")).Equal("9090")
+		assert.That(t, SysConf.Get("debug")).Equal("true")
+
+		var cfg struct {
+			Port    int               `value:"${server.port}"`
+			Timeout time.Duration     `value:"${timeout}"`
+			Hosts   []string          `value:"${hosts}"`
+			Labels  map[string]string `value:"${labels}"`
+		}
+		err = SysConf.Bind(&cfg)
+		assert.That(t, err).Nil()
+		assert.That(t, cfg.Port).Equal(9090)
+		assert.That(t, cfg.Timeout).Equal(90 * time.Second)
+		assert.That(t, cfg.Hosts).Equal([]string{"a", "b"})
+		assert.That(t, cfg.Labels).Equal(map[string]string{"env": "dev", "zone": "1"})
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Cleanup(clean)
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		fs.String("server", "", "")
+		fs.String("server.addr", "", "")
+		assert.That(t, fs.Parse(nil)).Nil()
+		err := BindFlags(fs)
+		assert.Error(t, err).Matches("bind flag server.addr error")
+	})
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// hasMeta reports whether the path contains any of the magic characters
+// of filepath.Match, that is, whether it's a pattern.
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// globPaths returns the paths matching the pattern, whose elements are
+// matched as by filepath.Match, except "**" that matches zero or more
+// directories. It returns the directories matched if dirs is true, and
+// the other files otherwise, in the lexical order of filepath.WalkDir.
+// The directories no path of which can match are not walked.
+func globPaths(pattern string, dirs bool) ([]string, error) {
+	segs := strings.Split(filepath.ToSlash(filepath.Clean(pattern)), "/")
+	n := 0
+	for n < len(segs) && !hasMeta(segs[n]) {
+		n++
+	}
+	for _, s := range segs[n:] {
+		if _, err := filepath.Match(s, ""); err != nil {
+			return nil, util.FormatError(err, "bad config dir pattern %s", pattern)
+		}
+	}
+
+	root := filepath.FromSlash(strings.Join(segs[:n], "/"))
+	if root == "" {
+		if n > 0 {
+			root = string(filepath.Separator)
+		} else {
+			root = "."
+		}
+	}
+
+	var ret []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() && dirs {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		var names []string
+		if rel != "." {
+			names = strings.Split(filepath.ToSlash(rel), "/")
+		}
+		if d.IsDir() == dirs && matchSegments(segs[n:], names) {
+			ret = append(ret, path)
+		}
+		if d.IsDir() && !matchBelow(segs[n:], names) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, util.WrapError(err, "glob config dir pattern %s error", pattern)
+	}
+	return ret, nil
+}
+
+// matchBelow reports whether a path below the directory of the path
+// elements may match the pattern elements, so that the walk skips the
+// directories deeper than the pattern or not matching its leading elements.
+func matchBelow(pattern, names []string) bool {
+	for len(names) > 0 {
+		if len(pattern) == 0 {
+			return false
+		}
+		if pattern[0] == "**" {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern[0], names[0]); !ok {
+			return false
+		}
+		pattern, names = pattern[1:], names[1:]
+	}
+	return len(pattern) > 0
+}
+
+// matchSegments reports whether the path elements match the pattern
+// elements, in which "**" matches zero or more path elements.
+func matchSegments(pattern, names []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(pattern[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], names[0]); !ok {
+			return false
+		}
+		pattern, names = pattern[1:], names[1:]
+	}
+	return len(names) == 0
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestGlobDir(t *testing.T) {
+
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"app.yaml":               "a: root",
+		"team-a/app.yaml":        "a: team-a",
+		"team-a/db/app.yaml":     "a: team-a-db",
+		"team-a/db/extra.yaml":   "a: extra",
+		"team-b/app-dev.yaml":    "a: team-b-dev",
+		"team-b/app.properties":  "a=team-b",
+		"team-b/notes/readme.md": "not a config",
+	} {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(name), os.ModePerm)
+		assert.That(t, err).Nil()
+		err = os.WriteFile(name, []byte(data), 0644)
+		assert.That(t, err).Nil()
+	}
+
+	// load returns the names relative to dir of the files loaded from the
+	// pattern, and the value of "a" after merging them.
+	load := func(pattern string, opt ...Optionality) ([]string, string, error) {
+		ps := NewPropertySources(ConfigTypeLocal, "app")
+		ps.AddDir(filepath.Join(dir, filepath.FromSlash(pattern)), opt...)
+		files, err := ps.LoadFiles(conf.Map(map[string]any{
+			"spring.app.config-local.dir": filepath.Join(dir, "none"),
+			"spring.profiles.active":      "dev",
+		}))
+		if err != nil {
+			return nil, "", err
+		}
+		var names []string
+		for _, f := range files {
+			rel, err := filepath.Rel(dir, f.Name)
+			assert.That(t, err).Nil()
+			names = append(names, filepath.ToSlash(rel))
+		}
+		p, err := merge(files...)
+		if err != nil {
+			return nil, "", err
+		}
+		return names, p.Get("a"), nil
+	}
+
+	t.Run("match files", func(t *testing.T) {
+		names, a, err := load("**/*.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, names).Equal([]string{
+			"app.yaml",
+			"team-a/app.yaml",
+			"team-a/db/app.yaml",
+			"team-a/db/extra.yaml",
+			"team-b/app-dev.yaml",
+		})
+		assert.That(t, a).Equal("team-b-dev")
+
+		names, _, err = load("team-*/app.*")
+		assert.That(t, err).Nil()
+		assert.That(t, names).Equal([]string{
+			"team-a/app.yaml",
+			"team-b/app.properties",
+		})
+	})
+
+	t.Run("recursive dirs", func(t *testing.T) {
+		names, a, err := load("**")
+		assert.That(t, err).Nil()
+		assert.That(t, names).Equal([]string{
+			"app.yaml",
+			"team-a/app.yaml",
+			"team-a/db/app.yaml",
+			"team-b/app.properties",
+			"team-b/app-dev.yaml",
+		})
+		assert.That(t, a).Equal("team-b-dev")
+
+		names, _, err = load("team-a/**")
+		assert.That(t, err).Nil()
+		assert.That(t, names).Equal([]string{
+			"team-a/app.yaml",
+			"team-a/db/app.yaml",
+		})
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		names, _, err := load("missing/**/*.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, len(names)).Equal(0)
+
+		_, _, err = load("missing/**/*.yaml", Required)
+		assert.Error(t, err).Matches(`required config dir .*missing/\*\*/\*\.yaml matches nothing`)
+
+		_, _, err = load("team-a/**", Required)
+		assert.That(t, err).Nil()
+	})
+
+	t.Run("bad pattern", func(t *testing.T) {
+		_, _, err := load("[/*.yaml")
+		assert.Error(t, err).Matches(`bad config dir pattern .*: syntax error in pattern`)
+	})
+}
+
+func TestMatchSegments(t *testing.T) {
+	for _, c := range []struct {
+		pattern []string
+		names   []string
+		match   bool
+	}{
+		{[]string{"**"}, nil, true},
+		{[]string{"**"}, []string{"a", "b"}, true},
+		{[]string{"**", "*.yaml"}, []string{"a.yaml"}, true},
+		{[]string{"**", "*.yaml"}, []string{"a", "b", "c.yaml"}, true},
+		{[]string{"**", "*.yaml"}, []string{"a", "b.json"}, false},
+		{[]string{"a", "**", "b"}, []string{"a", "b"}, true},
+		{[]string{"a", "**", "b"}, []string{"a", "x", "y", "b"}, true},
+		{[]string{"a", "**", "b"}, []string{"a", "b", "c"}, false},
+		{[]string{"*"}, []string{"a", "b"}, false},
+	} {
+		assert.That(t, matchSegments(c.pattern, c.names)).Equal(c.match)
+	}
+}
+
+func TestMatchBelow(t *testing.T) {
+	for _, c := range []struct {
+		pattern []string
+		names   []string
+		match   bool
+	}{
+		{[]string{"*"}, nil, true},
+		{[]string{"*"}, []string{"a"}, false},
+		{[]string{"team-*", "*.yaml"}, []string{"team-a"}, true},
+		{[]string{"team-*", "*.yaml"}, []string{"docs"}, false},
+		{[]string{"team-*", "*.yaml"}, []string{"team-a", "db"}, false},
+		{[]string{"a", "**", "b"}, []string{"a", "x", "y"}, true},
+		{[]string{"**"}, []string{"a", "b"}, true},
+	} {
+		assert.That(t, matchBelow(c.pattern, c.names)).Equal(c.match)
+	}
+}
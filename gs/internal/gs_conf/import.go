@@ -0,0 +1,305 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// ImportProp is the property by which a config source imports more
+// sources, given as a list or a comma-separated string. Each one is a
+// file, a directory whose config files are imported in name order, or a
+// url whose scheme has a registered remote provider. Relative paths are
+// relative to the importing file. A source prefixed with "optional:" is
+// skipped if it doesn't exist, otherwise it's an error.
+const ImportProp = "spring.config.import"
+
+// IncludeProp is the property by which a config source includes shared
+// fragments, e.g. common database settings, given like ImportProp. Unlike
+// the imported sources, which override the importing one, the included
+// sources are merged before the including one, which overrides them.
+const IncludeProp = "spring.config.include"
+
+// IncludeDirective is the short form of IncludeProp in properties files,
+// e.g. "include=common.properties,shared/". It is removed from the
+// properties of the file.
+const IncludeDirective = "include"
+
+// importer loads the sources imported by config sources, depth first and
+// in the order of the imports, so that the result is deterministic.
+type importer struct {
+	resolver conf.Properties
+	profiles []string        // active profiles
+	loaded   map[string]bool // sources already loaded
+	stack    []string        // sources being imported, to detect cycles
+	paths    []string        // local files and directories imported
+}
+
+// loadImports returns the sources, each preceded by the sources it
+// includes and followed by the sources it imports, and the local paths
+// included or imported. A source included or imported more than once is
+// only loaded the first time, and a cycle is an error.
+func loadImports(sources []*NamedPropertyCopier, resolver conf.Properties) ([]*NamedPropertyCopier, []string, error) {
+	profiles, err := activeProfiles(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+	im := &importer{
+		resolver: resolver,
+		profiles: profiles,
+		loaded:   make(map[string]bool),
+	}
+	for _, s := range sources {
+		im.loaded[sourceKey(s.Name)] = true
+	}
+	var ret []*NamedPropertyCopier
+	for _, s := range sources {
+		var err error
+		if ret, err = im.expand(ret, s); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ret, im.paths, nil
+}
+
+// expand appends the sources the source includes, the source, and then
+// the sources it imports.
+func (im *importer) expand(ret []*NamedPropertyCopier, s *NamedPropertyCopier) ([]*NamedPropertyCopier, error) {
+	p, ok := s.PropertyCopier.(conf.Properties)
+	if !ok {
+		return append(ret, s), nil
+	}
+
+	includeKey := IncludeProp
+	if m, ok := p.(*conf.MutableProperties); ok && filepath.Ext(s.Name) == ".properties" && m.Has(IncludeDirective) {
+		includeKey = IncludeDirective
+		without, err := withoutKey(m, IncludeDirective)
+		if err != nil {
+			return nil, util.FormatError(err, "include error in source %s", s.Name)
+		}
+		s = NewNamedPropertyCopier(s.Name, without)
+	}
+	hasIncludes := p.Has(includeKey)
+	if !hasIncludes && !p.Has(ImportProp) {
+		return append(ret, s), nil
+	}
+
+	im.stack = append(im.stack, sourceKey(s.Name))
+	defer func() { im.stack = im.stack[:len(im.stack)-1] }()
+
+	var err error
+	if hasIncludes {
+		if ret, err = im.expandAll(ret, p, includeKey, s.Name); err != nil {
+			return nil, util.FormatError(err, "include error in source %s", s.Name)
+		}
+	}
+	ret = append(ret, s)
+	if ret, err = im.expandAll(ret, p, ImportProp, s.Name); err != nil {
+		return nil, util.FormatError(err, "import error in source %s", s.Name)
+	}
+	return ret, nil
+}
+
+// expandAll loads and expands the sources listed by the key in p, the
+// properties of the source from.
+func (im *importer) expandAll(ret []*NamedPropertyCopier, p conf.Properties, key, from string) ([]*NamedPropertyCopier, error) {
+	locations, err := im.locations(p, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, location := range locations {
+		location, optional := strings.CutPrefix(location, "optional:")
+		children, err := im.load(location, from, optional)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range children {
+			if ret, err = im.expand(ret, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ret, nil
+}
+
+// withoutKey returns a copy of p without the key and its elements.
+func withoutKey(p *conf.MutableProperties, key string) (*conf.MutableProperties, error) {
+	out := conf.New()
+	for k, v := range p.RawData() {
+		if k == key || strings.HasPrefix(k, key+"[") {
+			continue
+		}
+		o, _ := p.Origin(k)
+		if err := out.SetNamed(k, v.Value, out.AddFile(o.File), o.Name); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// locations returns the sources listed by the key in p, resolving
+// references to both the properties of p and the resolver.
+func (im *importer) locations(p conf.Properties, key string) ([]string, error) {
+	merged := conf.New()
+	if err := p.CopyTo(merged); err != nil {
+		return nil, err
+	}
+	if err := im.resolver.CopyTo(merged); err != nil {
+		return nil, err
+	}
+	var imports []string
+	if merged.Has(key + "[0]") {
+		if err := merged.Bind(&imports, "${"+key+"}"); err != nil {
+			return nil, err
+		}
+		return imports, nil
+	}
+	// resolves the string before splitting it, since the split elements
+	// can't reference other properties.
+	s, err := merged.Resolve("${" + key + ":=}")
+	if err != nil {
+		return nil, err
+	}
+	for location := range strings.SplitSeq(s, ",") {
+		if location = strings.TrimSpace(location); location != "" {
+			imports = append(imports, location)
+		}
+	}
+	return imports, nil
+}
+
+// load loads the source at the location, which is relative to the
+// importing file from, or to the working directory if from is a url. It returns nothing for an optional source that
+// doesn't exist, or for a source that's already loaded.
+func (im *importer) load(location, from string, optional bool) ([]*NamedPropertyCopier, error) {
+	if isURL(location) {
+		return im.loadURL(location, optional)
+	}
+
+	if !filepath.IsAbs(location) && !isURL(from) {
+		location = filepath.Join(filepath.Dir(from), location)
+	}
+	info, err := osStat(location)
+	if err != nil {
+		if os.IsNotExist(err) && optional {
+			return nil, nil
+		}
+		return nil, err
+	}
+	im.paths = append(im.paths, location)
+	if !info.IsDir() {
+		return im.loadFiles(location)
+	}
+
+	entries, err := os.ReadDir(location)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries { // sorted by name
+		if !e.IsDir() && slices.Contains(configExtensions, filepath.Ext(e.Name())) {
+			files = append(files, filepath.Join(location, e.Name()))
+		}
+	}
+	im.paths = append(im.paths, files...)
+	return im.loadFiles(files...)
+}
+
+// loadFiles loads the files that aren't loaded yet.
+func (im *importer) loadFiles(files ...string) ([]*NamedPropertyCopier, error) {
+	var ret []*NamedPropertyCopier
+	for _, file := range files {
+		ok, err := im.check(sourceKey(file))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		c, err := conf.Load(file, im.profiles...)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, NewNamedPropertyCopier(file, c))
+	}
+	return ret, nil
+}
+
+// loadURL fetches the source at the url if it isn't loaded yet.
+func (im *importer) loadURL(location string, optional bool) ([]*NamedPropertyCopier, error) {
+	if ok, err := im.check(location); err != nil || !ok {
+		return nil, err
+	}
+	provider, err := NewRemoteProvider(RemoteProviderConfig{
+		URL:      location,
+		Interval: 30 * time.Second,
+		Timeout:  5 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p, err := provider.Fetch(context.Background())
+	if err != nil {
+		if optional {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []*NamedPropertyCopier{NewNamedPropertyCopier(location, p)}, nil
+}
+
+// check reports whether the source should be loaded, i.e. it isn't loaded
+// yet, and marks it as loaded. Importing a source that is importing it,
+// directly or not, is an import cycle.
+func (im *importer) check(key string) (bool, error) {
+	if i := slices.Index(im.stack, key); i >= 0 {
+		cycle := append(slices.Clone(im.stack[i:]), key)
+		return false, util.FormatError(nil, "import cycle %s", strings.Join(cycle, " -> "))
+	}
+	if im.loaded[key] {
+		return false, nil
+	}
+	im.loaded[key] = true
+	return true, nil
+}
+
+// isURL reports whether the location is a url with a registered scheme.
+func isURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && remoteProviders[u.Scheme] != nil
+}
+
+// sourceKey returns the key identifying a source: the url itself, or the
+// absolute path of a file.
+func sourceKey(name string) string {
+	if isURL(name) {
+		return name
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return name
+}
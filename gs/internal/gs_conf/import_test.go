@@ -0,0 +1,254 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestLoadImports(t *testing.T) {
+
+	// load loads the local app files in dir and merges them.
+	load := func(dir string) (conf.Properties, []*NamedPropertyCopier, error) {
+		resolver := conf.Map(map[string]any{
+			"spring.app.config-local.dir": dir,
+		})
+		sources, err := NewPropertySources(ConfigTypeLocal, "app").LoadFiles(resolver)
+		if err != nil {
+			return nil, nil, err
+		}
+		p, err := merge(sources...)
+		return p, sources, err
+	}
+
+	// write writes the files, relative to dir.
+	write := func(t *testing.T, dir string, files map[string]string) {
+		t.Helper()
+		for name, s := range files {
+			file := filepath.Join(dir, name)
+			assert.That(t, os.MkdirAll(filepath.Dir(file), 0755)).Nil()
+			assert.That(t, os.WriteFile(file, []byte(s), 0644)).Nil()
+		}
+	}
+
+	t.Run("files and dirs", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "a=app\nb=app\nc=app\n" +
+				"spring.config.import=extra/db.yaml,conf.d",
+			"extra/db.yaml":     "a: db\nspring.config.import: ../shared.properties",
+			"shared.properties": "b=shared",
+			"conf.d/2.json":     `{"c":"2"}`,
+			"conf.d/1.toml":     `c = "1"`,
+			"conf.d/README.md":  "ignored",
+		})
+		p, sources, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("db")
+		assert.That(t, p.Get("b")).Equal("shared")
+		assert.That(t, p.Get("c")).Equal("2")
+
+		var names []string
+		for _, s := range sources {
+			name, _ := filepath.Rel(dir, s.Name)
+			names = append(names, filepath.ToSlash(name))
+		}
+		assert.That(t, names).Equal([]string{
+			"app.properties",
+			"extra/db.yaml",
+			"shared.properties",
+			"conf.d/1.toml",
+			"conf.d/2.json",
+		})
+	})
+
+	t.Run("list", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.yaml":    "name: b\nspring.config.import:\n  - a.yaml\n  - ${name}.yaml",
+			"a.yaml":      "x: a",
+			"b.yaml":      "x: b",
+			"unused.yaml": "x: unused",
+		})
+		p, _, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("x")).Equal("b")
+	})
+
+	t.Run("watched", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties":       "spring.config.import=conf.d",
+			"conf.d/db.properties": "a=1",
+		})
+		resolver := conf.Map(map[string]any{
+			"spring.app.config-local.dir": dir,
+		})
+		s := NewPropertySources(ConfigTypeLocal, "app")
+		_, err := s.LoadFiles(resolver)
+		assert.That(t, err).Nil()
+		states, err := s.stat(resolver)
+		assert.That(t, err).Nil()
+		_, ok := states[filepath.Join(dir, "conf.d")]
+		assert.That(t, ok).True()
+		_, ok = states[filepath.Join(dir, "conf.d", "db.properties")]
+		assert.That(t, ok).True()
+	})
+
+	t.Run("loaded once", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "spring.config.import=a.properties,b.properties",
+			"a.properties":   "spring.config.import=c.properties",
+			"b.properties":   "spring.config.import=c.properties\nx=b",
+			"c.properties":   "x=c",
+		})
+		p, sources, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, len(sources)).Equal(4)
+		assert.That(t, p.Get("x")).Equal("b")
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "spring.config.import=optional:none.yaml,optional:http://127.0.0.1:1/none",
+		})
+		_, sources, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, len(sources)).Equal(1)
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "spring.config.import=none.yaml",
+		})
+		_, _, err := load(dir)
+		assert.Error(t, err).Matches("import error in source .*app.properties.* no such file or directory")
+	})
+
+	t.Run("reference", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties":  "name=db\nspring.config.import=${name}.properties",
+			"db.properties":   "a=db",
+			"none.properties": "a=none",
+		})
+		p, _, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("db")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "spring.config.import=a.properties",
+			"a.properties":   "spring.config.import=b.properties",
+			"b.properties":   "spring.config.import=a.properties",
+		})
+		_, _, err := load(dir)
+		assert.Error(t, err).Matches(`import cycle .*a.properties -> .*b.properties -> .*a.properties`)
+
+		write(t, dir, map[string]string{
+			"app.properties": "spring.config.import=app.properties",
+		})
+		_, _, err = load(dir)
+		assert.Error(t, err).Matches(`import cycle .*app.properties -> .*app.properties`)
+	})
+
+	t.Run("include", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties":         "include=common/db.properties,shared/\nb=app\nc=app",
+			"common/db.properties":   "a=db\nb=db",
+			"shared/cache.yaml":      "c: shared\nd: shared",
+			"shared/include.yaml":    "include: value",
+			"common/none.properties": "a=none",
+		})
+		p, sources, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("db")
+		assert.That(t, p.Get("b")).Equal("app")
+		assert.That(t, p.Get("c")).Equal("app")
+		assert.That(t, p.Get("d")).Equal("shared")
+		assert.That(t, p.Get("include")).Equal("value")
+
+		var names []string
+		for _, s := range sources {
+			name, _ := filepath.Rel(dir, s.Name)
+			names = append(names, filepath.ToSlash(name))
+		}
+		assert.That(t, names).Equal([]string{
+			"common/db.properties",
+			"shared/cache.yaml",
+			"shared/include.yaml",
+			"app.properties",
+		})
+		assert.That(t, sources[3].PropertyCopier.(conf.Properties).Has("include")).False()
+	})
+
+	t.Run("include and import", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.yaml":  "x: app\nspring.config.include: base.yaml\nspring.config.import: over.yaml",
+			"base.yaml": "x: base\ny: base\nspring.config.include: optional:none.yaml",
+			"over.yaml": "x: over",
+		})
+		p, sources, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("x")).Equal("over")
+		assert.That(t, p.Get("y")).Equal("base")
+		assert.That(t, len(sources)).Equal(3)
+		assert.That(t, filepath.Base(sources[0].Name)).Equal("base.yaml")
+	})
+
+	t.Run("include cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "include=a.properties",
+			"a.properties":   "include=app.properties",
+		})
+		_, _, err := load(dir)
+		assert.Error(t, err).Matches(`include error in source .*app.properties.* cycle .*app.properties -> .*a.properties -> .*app.properties`)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		s := &configServer{}
+		s.set("a=remote", "")
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		dir := t.TempDir()
+		write(t, dir, map[string]string{
+			"app.properties": "a=app\nspring.config.import=" + svr.URL + "/app.properties",
+		})
+		p, _, err := load(dir)
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("remote")
+
+		s.setDown(true)
+		_, _, err = load(dir)
+		assert.Error(t, err).Matches("import error in source .* status 503")
+	})
+}
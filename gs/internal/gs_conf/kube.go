@@ -0,0 +1,259 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// KubeDir is a directory where a Kubernetes ConfigMap or Secret volume
+// is mounted. Each file in it is a property, keyed by the file name under
+// the Prefix, whose value is the content of the file.
+type KubeDir struct {
+	Dir    string
+	Prefix string
+}
+
+// ParseKubeDir parses a directory in the "[prefix:]dir" form, e.g.
+// "/etc/config" or "db:/etc/secrets/db".
+func ParseKubeDir(s string) KubeDir {
+	if prefix, dir, ok := strings.Cut(s, ":"); ok {
+		return KubeDir{Dir: dir, Prefix: prefix}
+	}
+	return KubeDir{Dir: s}
+}
+
+// KubeSources holds the directories of the Kubernetes volumes that are
+// loaded into the local layer, after the local configuration files.
+//
+// Kubernetes projects each key of a ConfigMap or Secret as a file in the
+// volume, through symlinks into a hidden timestamped directory that is
+// swapped atomically by renaming the "..data" symlink on updates. The
+// hidden entries, whose names start with '.', are therefore skipped, and
+// the files are read from the directory "..data" points to, resolved once
+// per load, so that a change is seen all at once.
+type KubeSources struct {
+	mutex sync.Mutex
+	dirs  []KubeDir
+}
+
+// NewKubeSources creates a new empty KubeSources.
+func NewKubeSources() *KubeSources {
+	return &KubeSources{}
+}
+
+// Reset removes all the added directories.
+func (s *KubeSources) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dirs = nil
+}
+
+// AddDir adds a directory whose files are mapped to the properties under
+// the prefix, or to top-level properties if the prefix is empty.
+func (s *KubeSources) AddDir(dir, prefix string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dirs = append(s.dirs, KubeDir{Dir: dir, Prefix: prefix})
+}
+
+// candidateDirs returns the added directories, followed by the ones set
+// by "spring.app.config-kube.dirs", resolved by the resolver.
+func (s *KubeSources) candidateDirs(resolver conf.Properties) ([]KubeDir, error) {
+	var dirs []string
+	if err := resolver.Bind(&dirs, "${spring.app.config-kube.dirs:=}"); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	ret := slices.Clone(s.dirs)
+	s.mutex.Unlock()
+	for _, d := range dirs {
+		ret = append(ret, ParseKubeDir(d))
+	}
+	for i, d := range ret {
+		dir, err := resolver.Resolve(d.Dir)
+		if err != nil {
+			return nil, err
+		}
+		ret[i].Dir = dir
+	}
+	return ret, nil
+}
+
+// LoadDirs loads the properties of the directories, in their order, each
+// as a source named "kube:{dir}". Non-existent directories are skipped.
+func (s *KubeSources) LoadDirs(resolver conf.Properties) ([]*NamedPropertyCopier, error) {
+	dirs, err := s.candidateDirs(resolver)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*NamedPropertyCopier
+	for _, d := range dirs {
+		p, err := loadKubeDir(d)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			ret = append(ret, NewNamedPropertyCopier("kube:"+d.Dir, p))
+		}
+	}
+	return ret, nil
+}
+
+// kubeDataLink is the symlink to the directory holding the current files
+// of a Kubernetes volume, which is swapped atomically on updates.
+const kubeDataLink = "..data"
+
+// maxKubeReads limits the reads of a directory swapped while being read.
+const maxKubeReads = 3
+
+// osReadFile only for test.
+var osReadFile = os.ReadFile
+
+// loadKubeDir maps the files of the directory to properties. A trailing
+// newline, which files created by shell commands often end with, is
+// removed from the values. It returns nil if the directory doesn't exist.
+// The files are read from the target of the "..data" symlink, if any, and
+// read again if the symlink is swapped meanwhile, so that the values are
+// never mixed from two versions.
+func loadKubeDir(d KubeDir) (*conf.MutableProperties, error) {
+	for range maxKubeReads {
+		data, err := kubeDataDir(d.Dir)
+		if err != nil {
+			return nil, err
+		}
+		p, err := readKubeDir(d, data)
+		now, dataErr := kubeDataDir(d.Dir)
+		if dataErr != nil {
+			return nil, dataErr
+		}
+		if now == data {
+			return p, err
+		}
+	}
+	return nil, util.FormatError(nil, "read dir %s error: changed while reading", d.Dir)
+}
+
+// kubeDataDir returns the directory holding the current files of the
+// volume, i.e. the target of its "..data" symlink, or the directory itself
+// if there's no such symlink.
+func kubeDataDir(dir string) (string, error) {
+	data, err := filepath.EvalSymlinks(filepath.Join(dir, kubeDataLink))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return dir, nil
+		}
+		return "", util.FormatError(err, "read dir %s error", dir)
+	}
+	return data, nil
+}
+
+// readKubeDir maps the files of the volume to properties, reading their
+// contents from data, the directory holding the current files, except for
+// the files that aren't there, which are read from the volume itself.
+func readKubeDir(d KubeDir, data string) (*conf.MutableProperties, error) {
+	files, err := kubeFiles(d.Dir)
+	if err != nil || files == nil {
+		return nil, err
+	}
+	p := conf.New()
+	for _, name := range files {
+		file := filepath.Join(d.Dir, name)
+		b, err := osReadFile(filepath.Join(data, name))
+		if errors.Is(err, os.ErrNotExist) && data != d.Dir {
+			b, err = osReadFile(file)
+		}
+		if err != nil {
+			return nil, util.FormatError(err, "read file %s error", file)
+		}
+		key := name
+		if d.Prefix != "" {
+			key = d.Prefix + "." + name
+		}
+		s := strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r")
+		if err = p.Set(key, s, p.AddFile(file)); err != nil {
+			return nil, util.FormatError(err, "set property %s error", key)
+		}
+	}
+	return p, nil
+}
+
+// kubeFiles returns the sorted names of the visible regular files in the
+// directory, following symlinks. It returns nil if the directory doesn't
+// exist.
+func kubeFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, util.FormatError(err, "read dir %s error", dir)
+	}
+	files := []string{}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := osStat(filepath.Join(dir, e.Name()))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // dangling symlink during an update
+			}
+			return nil, err
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, e.Name())
+		}
+	}
+	return files, nil
+}
+
+// stat returns the states of the files in the directories, which change
+// along with the target of the "..data" symlink on Kubernetes updates.
+func (s *KubeSources) stat(resolver conf.Properties) (map[string]fileState, error) {
+	dirs, err := s.candidateDirs(resolver)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]fileState)
+	for _, d := range dirs {
+		files, err := kubeFiles(d.Dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range files {
+			file := filepath.Join(d.Dir, name)
+			info, err := osStat(file)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			ret[file] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+		}
+	}
+	return ret, nil
+}
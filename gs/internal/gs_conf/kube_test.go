@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// writeKubeVolume writes the files into the dir in the layout of the
+// Kubernetes projected volumes, swapping the "..data" symlink atomically.
+func writeKubeVolume(t *testing.T, dir, version string, files map[string]string) {
+	t.Helper()
+	data := filepath.Join(dir, "..2025_"+version)
+	assert.That(t, os.MkdirAll(data, 0755)).Nil()
+	for name, s := range files {
+		assert.That(t, os.WriteFile(filepath.Join(data, name), []byte(s), 0644)).Nil()
+	}
+	tmp := filepath.Join(dir, "..data_tmp")
+	assert.That(t, os.Symlink(filepath.Base(data), tmp)).Nil()
+	assert.That(t, os.Rename(tmp, filepath.Join(dir, "..data"))).Nil()
+	for name := range files {
+		link := filepath.Join(dir, name)
+		if _, err := os.Lstat(link); err == nil {
+			continue
+		}
+		assert.That(t, os.Symlink(filepath.Join("..data", name), link)).Nil()
+	}
+}
+
+func TestParseKubeDir(t *testing.T) {
+	assert.That(t, ParseKubeDir("/etc/config")).Equal(KubeDir{Dir: "/etc/config"})
+	assert.That(t, ParseKubeDir("db:/etc/secrets/db")).Equal(KubeDir{Dir: "/etc/secrets/db", Prefix: "db"})
+}
+
+func TestKubeSources(t *testing.T) {
+	clean()
+
+	t.Run("success", func(t *testing.T) {
+		t.Cleanup(clean)
+		configDir := t.TempDir()
+		writeKubeVolume(t, configDir, "01", map[string]string{
+			"server.port": "8080\n",
+			"log.level":   "info",
+		})
+		secretDir := t.TempDir()
+		writeKubeVolume(t, secretDir, "01", map[string]string{
+			"password": "s3cret\r\n",
+		})
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-KUBE_DIRS", "db:"+secretDir)
+
+		c := NewAppConfig()
+		c.KubeDirs.AddDir(configDir, "")
+		c.KubeDirs.AddDir(filepath.Join(configDir, "none"), "")
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("server.port")).Equal("8080")
+		assert.That(t, p.Get("log.level")).Equal("info")
+		assert.That(t, p.Get("db.password")).Equal("s3cret")
+		assert.That(t, p.Has("..data")).False()
+		assert.That(t, c.Sources()).Equal([]string{
+			"sys", "kube:" + configDir, "kube:" + secretDir,
+			"remote-prop", "env", "cmd",
+		})
+
+		c.KubeDirs.Reset()
+		_ = os.Unsetenv("GS_SPRING_APP_CONFIG-KUBE_DIRS")
+		p, err = c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Has("server.port")).False()
+	})
+
+	t.Run("dir resolve error", func(t *testing.T) {
+		t.Cleanup(clean)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-KUBE_DIRS", "${a}")
+		_, err := NewAppConfig().Refresh()
+		assert.Error(t, err).Matches(`refresh error in source kube`)
+	})
+
+	t.Run("conflicting keys", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		writeKubeVolume(t, dir, "01", map[string]string{
+			"a":   "1",
+			"a.b": "2",
+		})
+		c := NewAppConfig()
+		c.KubeDirs.AddDir(dir, "")
+		_, err := c.Refresh()
+		assert.Error(t, err).Matches(`set property a.b error`)
+	})
+}
+
+func TestWatchKube(t *testing.T) {
+	clean()
+	t.Cleanup(clean)
+
+	dir := t.TempDir()
+	writeKubeVolume(t, dir, "01", map[string]string{"a": "1"})
+	_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_DIR", t.TempDir())
+	_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_WATCH-INTERVAL", "10ms")
+
+	c := NewAppConfig()
+	c.KubeDirs.AddDir(dir, "kube")
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	ch, err := c.WatchLocal(ctx)
+	assert.That(t, err).Nil()
+
+	receive := func() conf.Properties {
+		t.Helper()
+		select {
+		case p := <-ch:
+			return p
+		case <-time.After(5 * time.Second):
+			t.Fatal("no properties received")
+			return nil
+		}
+	}
+
+	// updated and added keys
+	writeKubeVolume(t, dir, "02", map[string]string{"a": "22", "b": "3"})
+	p := receive()
+	assert.That(t, p.Get("kube.a")).Equal("22")
+	assert.That(t, p.Get("kube.b")).Equal("3")
+
+	// removed key
+	assert.That(t, os.Remove(filepath.Join(dir, "b"))).Nil()
+	p = receive()
+	assert.That(t, p.Has("kube.b")).False()
+}
+
+func TestLoadKubeDir(t *testing.T) {
+	defer func() { osReadFile = os.ReadFile }()
+
+	t.Run("swapped while reading", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKubeVolume(t, dir, "01", map[string]string{"a": "1", "b": "1"})
+
+		// the volume is swapped after the first file is read
+		swaps := 0
+		osReadFile = func(name string) ([]byte, error) {
+			b, err := os.ReadFile(name)
+			if swaps++; swaps == 1 {
+				writeKubeVolume(t, dir, "02", map[string]string{"a": "2", "b": "2"})
+			}
+			return b, err
+		}
+		p, err := loadKubeDir(KubeDir{Dir: dir})
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("2")
+		assert.That(t, p.Get("b")).Equal("2")
+		o, _ := p.Origin("a")
+		assert.That(t, o.File).Equal(filepath.Join(dir, "a"))
+	})
+
+	t.Run("keeps changing", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKubeVolume(t, dir, "00", map[string]string{"a": "0"})
+
+		swaps := 0
+		osReadFile = func(name string) ([]byte, error) {
+			swaps++
+			writeKubeVolume(t, dir, fmt.Sprintf("%02d", swaps), map[string]string{"a": fmt.Sprint(swaps)})
+			return os.ReadFile(name)
+		}
+		_, err := loadKubeDir(KubeDir{Dir: dir})
+		assert.Error(t, err).Matches("read dir .* error: changed while reading")
+	})
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"slices"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// Names of the built-in layers of AppConfig.
+const (
+	LayerSys            = "sys"             // SysConf
+	LayerLocal          = "local"           // Local configuration files
+	LayerRemote         = "remote"          // Remote configuration files
+	LayerRemoteProp     = "remote-prop"     // AppConfig.RemoteProp
+	LayerRemoteProvider = "remote-provider" // AppConfig.RemoteProvider
+	LayerDotEnv         = "dotenv"          // Dotenv files
+	LayerEnv            = "env"             // Environment variables
+	LayerCmd            = "cmd"             // Command-line arguments
+	LayerRuntime        = "runtime"         // AppConfig.SetProperty
+)
+
+// DefaultLayerOrder is the merge order of the built-in layers, layers
+// later in the list override earlier ones when keys conflict.
+var DefaultLayerOrder = []string{
+	LayerSys,
+	LayerLocal,
+	LayerRemote,
+	LayerRemoteProp,
+	LayerRemoteProvider,
+	LayerDotEnv,
+	LayerEnv,
+	LayerCmd,
+	LayerRuntime,
+}
+
+// layers holds the merge order of the layers and the custom layers.
+type layers struct {
+	order  []string                  // nil means DefaultLayerOrder
+	custom map[string]PropertyCopier // custom layers by name
+}
+
+// names returns the layers in their merge order.
+func (l *layers) names() []string {
+	if l.order == nil {
+		return slices.Clone(DefaultLayerOrder)
+	}
+	return slices.Clone(l.order)
+}
+
+// known reports whether the name is a built-in or a custom layer.
+func (l *layers) known(name string) bool {
+	_, ok := l.custom[name]
+	return ok || slices.Contains(DefaultLayerOrder, name)
+}
+
+// LayerOrder returns the names of the layers in their merge order.
+func (c *AppConfig) LayerOrder() []string {
+	c.layerMutex.RLock()
+	defer c.layerMutex.RUnlock()
+	return c.layers.names()
+}
+
+// SetLayerOrder reorders the layers, e.g. to make remote files override
+// local ones. The order must contain every built-in and custom layer
+// exactly once. It takes effect on the next Refresh.
+func (c *AppConfig) SetLayerOrder(order ...string) error {
+	c.layerMutex.Lock()
+	defer c.layerMutex.Unlock()
+	for i, name := range order {
+		if !c.layers.known(name) {
+			return util.FormatError(nil, "unknown layer %s", name)
+		}
+		if slices.Contains(order[:i], name) {
+			return util.FormatError(nil, "duplicate layer %s", name)
+		}
+	}
+	for _, name := range c.layers.names() {
+		if !slices.Contains(order, name) {
+			return util.FormatError(nil, "missing layer %s", name)
+		}
+	}
+	c.layers.order = slices.Clone(order)
+	return nil
+}
+
+// AddLayer inserts a custom layer right before the layer named before,
+// or at the end, overriding all other layers, if before is empty. It
+// takes effect on the next Refresh.
+func (c *AppConfig) AddLayer(name string, p PropertyCopier, before string) error {
+	c.layerMutex.Lock()
+	defer c.layerMutex.Unlock()
+	if name == "" {
+		return util.FormatError(nil, "layer name is empty")
+	}
+	if c.layers.known(name) {
+		return util.FormatError(nil, "duplicate layer %s", name)
+	}
+	order := c.layers.names()
+	i := len(order)
+	if before != "" {
+		if i = slices.Index(order, before); i < 0 {
+			return util.FormatError(nil, "unknown layer %s", before)
+		}
+	}
+	if c.layers.custom == nil {
+		c.layers.custom = make(map[string]PropertyCopier)
+	}
+	c.layers.custom[name] = p
+	c.layers.order = slices.Insert(order, i, name)
+	return nil
+}
+
+// ConflictStrategyPrefix is the prefix of the properties that set the
+// conflict strategies of the layers, e.g. "spring.config.conflict.remote"
+// for the remote layer, see SetConflictStrategy.
+const ConflictStrategyPrefix = "spring.config.conflict."
+
+// SetConflictStrategy sets how the conflicts between the properties of
+// the layer and those of the layers merged before it are resolved, e.g.
+// a key that is a value in the local files and a map in the remote ones.
+// It overrides the property ConflictStrategyPrefix + layer, which the
+// system configuration may set, and defaults to conf.ConflictError. It
+// takes effect on the next Refresh.
+func (c *AppConfig) SetConflictStrategy(layer string, strategy conf.ConflictStrategy) error {
+	c.layerMutex.Lock()
+	defer c.layerMutex.Unlock()
+	if !c.layers.known(layer) {
+		return util.FormatError(nil, "unknown layer %s", layer)
+	}
+	strategy, err := conf.ParseConflictStrategy(string(strategy))
+	if err != nil {
+		return err
+	}
+	if c.strategies == nil {
+		c.strategies = make(map[string]conf.ConflictStrategy)
+	}
+	c.strategies[layer] = strategy
+	return nil
+}
+
+// Conflicts returns the conflicts resolved by the last Refresh, with the
+// names of the sources whose properties were merged.
+func (c *AppConfig) Conflicts() []conf.Conflict {
+	c.layerMutex.RLock()
+	defer c.layerMutex.RUnlock()
+	return slices.Clone(c.conflicts)
+}
+
+// Sources returns the names of the sources merged by the last Refresh,
+// in their merge order. Files are named by their paths.
+func (c *AppConfig) Sources() []string {
+	c.layerMutex.RLock()
+	defer c.layerMutex.RUnlock()
+	return slices.Clone(c.sources)
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestLayerOrder(t *testing.T) {
+	clean()
+
+	// setup writes "a" into a local and a remote file, and returns their paths.
+	setup := func(t *testing.T) (string, string) {
+		dir := t.TempDir()
+		local := filepath.Join(dir, "app.properties")
+		remote := filepath.Join(dir, "remote", "app.properties")
+		assert.That(t, os.MkdirAll(filepath.Dir(remote), os.ModePerm)).Nil()
+		assert.That(t, os.WriteFile(local, []byte("a=local\nb=local"), 0644)).Nil()
+		assert.That(t, os.WriteFile(remote, []byte("a=remote"), 0644)).Nil()
+		fileID := SysConf.AddFile("test")
+		_ = SysConf.Set("spring.app.config-local.dir", dir, fileID)
+		_ = SysConf.Set("spring.app.config-remote.dir", filepath.Dir(remote), fileID)
+		return local, remote
+	}
+
+	t.Run("default order", func(t *testing.T) {
+		t.Cleanup(clean)
+		local, remote := setup(t)
+		c := NewAppConfig()
+		assert.That(t, c.LayerOrder()).Equal(DefaultLayerOrder)
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("remote")
+		assert.That(t, c.Sources()).Equal([]string{
+			"sys", local, remote, "remote-prop", "env", "cmd",
+		})
+	})
+
+	t.Run("remote before local", func(t *testing.T) {
+		t.Cleanup(clean)
+		setup(t)
+		c := NewAppConfig()
+		err := c.SetLayerOrder("sys", "remote", "local", "remote-prop",
+			"remote-provider", "dotenv", "env", "cmd", "runtime")
+		assert.That(t, err).Nil()
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("local")
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		c := NewAppConfig()
+		err := c.SetLayerOrder("sys", "unknown")
+		assert.Error(t, err).Matches("unknown layer unknown")
+		err = c.SetLayerOrder("sys", "sys")
+		assert.Error(t, err).Matches("duplicate layer sys")
+		err = c.SetLayerOrder("sys", "local")
+		assert.Error(t, err).Matches("missing layer remote")
+		assert.That(t, c.LayerOrder()).Equal(DefaultLayerOrder)
+	})
+
+	t.Run("add layer", func(t *testing.T) {
+		t.Cleanup(clean)
+		local, remote := setup(t)
+		c := NewAppConfig()
+		err := c.AddLayer("vault", conf.Map(map[string]any{"a": "vault", "b": "vault"}), "remote")
+		assert.That(t, err).Nil()
+		err = c.AddLayer("last", conf.Map(map[string]any{"c": "last"}), "")
+		assert.That(t, err).Nil()
+		assert.That(t, c.LayerOrder()).Equal([]string{
+			"sys", "local", "vault", "remote", "remote-prop", "remote-provider",
+			"dotenv", "env", "cmd", "runtime", "last",
+		})
+		assert.That(t, c.SetProperty("c", "runtime")).Nil()
+
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("a")).Equal("remote")
+		assert.That(t, p.Get("b")).Equal("vault")
+		assert.That(t, p.Get("c")).Equal("last")
+		assert.That(t, c.Sources()).Equal([]string{
+			"sys", local, "vault", remote, "remote-prop", "env", "cmd", "runtime", "last",
+		})
+
+		err = c.SetLayerOrder("sys", "local", "vault", "remote", "remote-prop",
+			"remote-provider", "dotenv", "env", "cmd", "runtime")
+		assert.Error(t, err).Matches("missing layer last")
+	})
+
+	t.Run("invalid layer", func(t *testing.T) {
+		c := NewAppConfig()
+		err := c.AddLayer("", conf.New(), "")
+		assert.Error(t, err).Matches("layer name is empty")
+		err = c.AddLayer("env", conf.New(), "")
+		assert.Error(t, err).Matches("duplicate layer env")
+		err = c.AddLayer("vault", conf.New(), "unknown")
+		assert.Error(t, err).Matches("unknown layer unknown")
+	})
+
+	t.Run("layer error", func(t *testing.T) {
+		t.Cleanup(clean)
+		c := NewAppConfig()
+		err := c.AddLayer("vault", conf.Map(map[string]any{"spring": "x"}), "")
+		assert.That(t, err).Nil()
+		_ = SysConf.Set("spring.app.name", "test", SysConf.AddFile("test"))
+		_, err = c.Refresh()
+		assert.Error(t, err).Matches("merge error in source vault")
+	})
+
+	t.Run("conflict strategy", func(t *testing.T) {
+		t.Cleanup(clean)
+		local, _ := setup(t)
+		vault := conf.Map(map[string]any{"b": map[string]any{"c": "vault"}})
+
+		c := NewAppConfig()
+		assert.That(t, c.AddLayer("vault", vault, "")).Nil()
+		_, err := c.Refresh()
+		assert.Error(t, err).Matches("merge error in source vault << property conflict at path b.c")
+
+		err = c.SetConflictStrategy("unknown", conf.ConflictLastWins)
+		assert.Error(t, err).Matches("unknown layer unknown")
+		err = c.SetConflictStrategy("vault", "unknown")
+		assert.Error(t, err).Matches(`unknown conflict strategy "unknown"`)
+
+		assert.That(t, c.SetConflictStrategy("vault", conf.ConflictLastWins)).Nil()
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("b.c")).Equal("vault")
+		assert.That(t, c.Conflicts()).Equal([]conf.Conflict{{
+			Path:     "b",
+			Source:   "vault",
+			Strategy: conf.ConflictLastWins,
+			Replaced: true,
+			Dropped:  []string{"b"},
+		}})
+		o, _ := p.(*conf.MutableProperties).Origin("b.c")
+		assert.String(t, o.File).Matches("layer_test.go")
+
+		_ = SysConf.Set(ConflictStrategyPrefix+"remote", "first-wins", SysConf.AddFile("test"))
+		c = NewAppConfig()
+		assert.That(t, c.AddLayer("vault", vault, "")).Nil()
+		_, err = c.Refresh()
+		assert.Error(t, err).Matches("merge error in source vault")
+
+		_ = SysConf.Set(ConflictStrategyPrefix+"vault", "first-wins", SysConf.AddFile("test"))
+		p, err = c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, p.Get("b")).Equal("local")
+		assert.That(t, c.Conflicts()).Equal([]conf.Conflict{{
+			Path:     "b",
+			Source:   "vault",
+			Strategy: conf.ConflictFirstWins,
+			Dropped:  []string{"b.c"},
+		}})
+		assert.That(t, c.Sources()[1]).Equal(local)
+
+		_ = SysConf.Set(ConflictStrategyPrefix+"vault", "bad", SysConf.AddFile("test"))
+		_, err = c.Refresh()
+		assert.Error(t, err).Matches(`refresh error in source vault << unknown conflict strategy "bad"`)
+	})
+}
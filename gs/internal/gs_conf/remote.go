@@ -0,0 +1,287 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// RemoteConfigProvider provides properties from a remote configuration
+// server. It's the remote provider layer of [AppConfig].
+type RemoteConfigProvider interface {
+	// Fetch returns the current properties.
+	Fetch(ctx context.Context) (conf.Properties, error)
+	// Watch sends the properties on the returned channel each time they
+	// change, until ctx is done, then closes the channel.
+	Watch(ctx context.Context) (<-chan conf.Properties, error)
+}
+
+// RemoteProviderConfig holds the configuration of a remote config provider.
+type RemoteProviderConfig struct {
+	// URL is the endpoint of the configuration server. Its scheme selects
+	// the provider, e.g. "https://config/app.yaml", "consul://host/app" or
+	// "vault://host:8200/secret/app".
+	URL string `value:"${spring.app.config-remote.url:=}"`
+
+	// Format is the file extension of the response body, e.g. ".yaml".
+	// If empty, it's derived from the URL, then from the content type.
+	Format string `value:"${spring.app.config-remote.format:=}"`
+
+	// Interval is the polling interval of Watch.
+	Interval time.Duration `value:"${spring.app.config-remote.interval:=30s}"`
+
+	// Timeout is the timeout of each request.
+	Timeout time.Duration `value:"${spring.app.config-remote.timeout:=5s}"`
+
+	// CacheFile is where the key-value providers, e.g. consul or ssm, save
+	// the last fetched properties, so that the application can still start
+	// from them when the server is unreachable. Empty disables the cache.
+	CacheFile string `value:"${spring.app.config-remote.cache-file:=}"`
+}
+
+// RemoteProviderFactory creates a remote config provider from its config.
+type RemoteProviderFactory func(cfg RemoteProviderConfig) (RemoteConfigProvider, error)
+
+// remoteProviders holds the provider factories by URL scheme.
+var remoteProviders = map[string]RemoteProviderFactory{}
+
+func init() {
+	newHTTP := func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewHTTPConfigProvider(cfg)
+	}
+	RegisterRemoteProvider("http", newHTTP)
+	RegisterRemoteProvider("https", newHTTP)
+}
+
+// RegisterRemoteProvider registers a provider factory for the URL scheme.
+func RegisterRemoteProvider(scheme string, f RemoteProviderFactory) {
+	remoteProviders[scheme] = f
+}
+
+// NewRemoteProvider creates the provider registered for the URL scheme.
+func NewRemoteProvider(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+	u, err := parseURL(cfg.URL)
+	if err != nil {
+		return nil, util.FormatError(err, "invalid remote config url %s", redactURL(cfg.URL))
+	}
+	f, ok := remoteProviders[u.Scheme]
+	if !ok {
+		return nil, util.FormatError(nil, "unsupported remote config url %s", redactURL(cfg.URL))
+	}
+	return f(cfg)
+}
+
+// parseURL parses the url of a remote source. Unlike url.Parse, the error
+// doesn't include the url, whose userinfo may hold credentials.
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if e, ok := err.(*url.Error); ok {
+		return nil, e.Err
+	}
+	return u, err
+}
+
+// redactURL returns the url without its userinfo, which may hold a token
+// or a password, to name a remote source in property origins and logs.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		scheme, rest, ok := strings.Cut(s, "://")
+		if !ok {
+			return s
+		}
+		host, _, _ := strings.Cut(rest, "/")
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			return scheme + "://" + rest[i+1:]
+		}
+		return s
+	}
+	if u.User == nil {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
+
+// HTTPConfigProvider is a RemoteConfigProvider that polls an HTTP endpoint.
+// It sends the ETag of the last response in the If-None-Match header, so
+// that the server can answer 304 Not Modified when nothing has changed.
+// Once properties have been fetched, a failed request falls back to them,
+// so that a temporarily unavailable server doesn't fail the refresh.
+type HTTPConfigProvider struct {
+	cfg    RemoteProviderConfig
+	client *http.Client
+
+	mutex sync.Mutex
+	etag  string          // ETag of the last good response
+	body  []byte          // body of the last good response
+	last  conf.Properties // last good snapshot
+}
+
+// NewHTTPConfigProvider creates a new HTTPConfigProvider.
+func NewHTTPConfigProvider(cfg RemoteProviderConfig) (*HTTPConfigProvider, error) {
+	if cfg.URL == "" {
+		return nil, util.FormatError(nil, "remote config url is empty")
+	}
+	if cfg.Interval <= 0 {
+		return nil, util.FormatError(nil, "invalid remote config interval %s", cfg.Interval)
+	}
+	return &HTTPConfigProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Fetch returns the current properties, or the last good snapshot if the
+// request fails after a successful one.
+func (p *HTTPConfigProvider) Fetch(ctx context.Context) (conf.Properties, error) {
+	prop, _, err := p.fetch(ctx)
+	return prop, err
+}
+
+// fetch requests the properties and returns whether they have changed.
+func (p *HTTPConfigProvider) fetch(ctx context.Context) (conf.Properties, bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	prop, changed, err := p.request(ctx)
+	if err != nil {
+		if p.last == nil {
+			return nil, false, err
+		}
+		log.Warnf(ctx, log.TagAppDef, "fetch remote config error, using the last good snapshot: %v", err)
+		return p.last, false, nil
+	}
+	return prop, changed, nil
+}
+
+// request sends a conditional request and updates the last good snapshot.
+func (p *HTTPConfigProvider) request(ctx context.Context) (conf.Properties, bool, error) {
+	// the userinfo of the url, if any, is kept out of the origin and errors
+	source := redactURL(p.cfg.URL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.URL, nil)
+	if err != nil {
+		return nil, false, util.FormatError(err, "fetch remote config %s error", source)
+	}
+	if p.last != nil && p.etag != "" {
+		req.Header.Set("If-None-Match", p.etag)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, false, util.FormatError(err, "fetch remote config %s error", source)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && p.last != nil {
+		return p.last, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, util.FormatError(nil, "fetch remote config %s error: status %s", source, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, util.FormatError(err, "fetch remote config %s error", source)
+	}
+	if p.last != nil && bytes.Equal(body, p.body) {
+		p.etag = resp.Header.Get("ETag")
+		return p.last, false, nil
+	}
+
+	prop, err := conf.Parse(body, p.format(resp), source)
+	if err != nil {
+		return nil, false, util.FormatError(err, "parse remote config %s error", source)
+	}
+	p.etag = resp.Header.Get("ETag")
+	p.body = body
+	p.last = prop
+	return prop, true, nil
+}
+
+// format returns the file extension of the response body.
+func (p *HTTPConfigProvider) format(resp *http.Response) string {
+	if p.cfg.Format != "" {
+		return p.cfg.Format
+	}
+	if ext := path.Ext(resp.Request.URL.Path); ext != "" {
+		return ext
+	}
+	return contentFormat(resp)
+}
+
+// contentFormat returns the file extension of the response body by its
+// content type, ".properties" by default.
+func contentFormat(resp *http.Response) string {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		return ".json"
+	case strings.HasSuffix(mediaType, "yaml"):
+		return ".yaml"
+	case strings.HasSuffix(mediaType, "toml"):
+		return ".toml"
+	default:
+		return ".properties"
+	}
+}
+
+// Watch polls the endpoint at the configured interval and sends the
+// properties each time they change.
+func (p *HTTPConfigProvider) Watch(ctx context.Context) (<-chan conf.Properties, error) {
+	ch := make(chan conf.Properties)
+	goutil.Go(ctx, func(ctx context.Context) {
+		defer close(ch)
+		ticker := time.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			prop, changed, err := p.fetch(ctx)
+			if err != nil {
+				log.Warnf(ctx, log.TagAppDef, "watch remote config error: %v", err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			select {
+			case ch <- prop:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return ch, nil
+}
@@ -0,0 +1,331 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+func init() {
+	RegisterRemoteProvider("ssm", func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewSSMConfigProvider(cfg)
+	})
+	RegisterRemoteProvider("awssm", func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewSecretsManagerConfigProvider(cfg)
+	})
+}
+
+// awsClient sends the JSON requests of the AWS APIs, signed by the
+// Signature Version 4 with the credentials of the environment variables
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+type awsClient struct {
+	endpoint string // url of the service
+	region   string
+	service  string // signing name of the service, e.g. "ssm"
+	target   string // prefix of the X-Amz-Target header, e.g. "AmazonSSM"
+	keyID    string
+	secret   string
+	token    string
+	client   *http.Client
+	now      func() time.Time
+}
+
+// newAWSClient creates an awsClient from a url of the form
+// "{scheme}://{region}/{path}", in which the query parameter "endpoint"
+// overrides the url of the service, e.g. for a local emulator.
+func newAWSClient(cfg RemoteProviderConfig, service, target string) (*awsClient, *url.URL, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" {
+		return nil, nil, util.FormatError(err, "invalid aws config url %s", cfg.URL)
+	}
+	c := &awsClient{
+		endpoint: "https://" + service + "." + u.Host + ".amazonaws.com",
+		region:   u.Host,
+		service:  service,
+		target:   target,
+		keyID:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		secret:   os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		token:    os.Getenv("AWS_SESSION_TOKEN"),
+		client:   &http.Client{Timeout: cfg.Timeout},
+		now:      time.Now,
+	}
+	if c.keyID == "" || c.secret == "" {
+		return nil, nil, util.FormatError(nil, "no aws credentials for %s", cfg.URL)
+	}
+	if s := u.Query().Get("endpoint"); s != "" {
+		c.endpoint = strings.TrimSuffix(s, "/")
+	}
+	return c, u, nil
+}
+
+// call invokes the action of the service and decodes the response.
+func (c *awsClient) call(ctx context.Context, action string, in, out any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", c.target+"."+action)
+	if c.token != "" {
+		req.Header.Set("X-Amz-Security-Token", c.token)
+	}
+	c.sign(req, b)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(body, &e)
+		if e.Type != "" {
+			return util.FormatError(nil, "status %s: %s %s", resp.Status, e.Type, e.Message)
+		}
+		return util.FormatError(nil, "status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sign adds the X-Amz-Date and Authorization headers of the Signature
+// Version 4 to the request, signing all of its headers and the host.
+func (c *awsClient) sign(req *http.Request, body []byte) {
+	t := c.now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := slices.Sorted(maps.Keys(headers))
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := date + "/" + c.region + "/" + c.service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secret), date)
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, c.service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+c.keyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, s string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+// ssmStore lists the parameters under a path of the AWS Systems Manager
+// Parameter Store.
+type ssmStore struct {
+	*awsClient
+	path string
+}
+
+// NewSSMConfigProvider creates a RemoteConfigProvider that reads the
+// parameters under a path of the AWS Systems Manager Parameter Store,
+// e.g. "ssm://us-east-1/app/prod" reads "/app/prod/db/url" as the
+// property "db.url". SecureString parameters are decrypted.
+func NewSSMConfigProvider(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+	c, u, err := newAWSClient(cfg, "ssm", "AmazonSSM")
+	if err != nil {
+		return nil, err
+	}
+	path := "/" + strings.Trim(u.Path, "/")
+	if path != "/" {
+		path += "/"
+	}
+	return newKVConfigProvider(cfg, &ssmStore{awsClient: c, path: path})
+}
+
+// list returns the parameters under the path, page by page.
+func (s *ssmStore) list(ctx context.Context) (map[string]string, error) {
+	data := make(map[string]string)
+	var next string
+	for {
+		var resp struct {
+			Parameters []struct {
+				Name  string
+				Value string
+			}
+			NextToken string
+		}
+		req := map[string]any{
+			"Path":           strings.TrimSuffix(s.path, "/"),
+			"Recursive":      true,
+			"WithDecryption": true,
+		}
+		if s.path == "/" {
+			req["Path"] = "/"
+		}
+		if next != "" {
+			req["NextToken"] = next
+		}
+		if err := s.call(ctx, "GetParametersByPath", req, &resp); err != nil {
+			return nil, err
+		}
+		for _, p := range resp.Parameters {
+			if key, ok := kvKey(p.Name, s.path); ok {
+				data[key] = p.Value
+			}
+		}
+		if next = resp.NextToken; next == "" {
+			return data, nil
+		}
+	}
+}
+
+// secretsManagerStore reads the secrets whose names start with a prefix
+// from AWS Secrets Manager.
+type secretsManagerStore struct {
+	*awsClient
+	prefix string
+}
+
+// NewSecretsManagerConfigProvider creates a RemoteConfigProvider that
+// reads the secrets whose names start with a prefix from AWS Secrets
+// Manager, e.g. "awssm://us-east-1/app/prod" reads the secret
+// "app/prod/db" as the properties under "db". The fields of a secret
+// holding a JSON object are properties, with nested objects flattened,
+// and any other secret is a single property.
+func NewSecretsManagerConfigProvider(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+	c, u, err := newAWSClient(cfg, "secretsmanager", "secretsmanager")
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return newKVConfigProvider(cfg, &secretsManagerStore{awsClient: c, prefix: prefix})
+}
+
+// list returns the values of the secrets under the prefix, page by page.
+func (s *secretsManagerStore) list(ctx context.Context) (map[string]string, error) {
+	data := make(map[string]string)
+	var next string
+	for {
+		var resp struct {
+			SecretValues []struct {
+				Name         string
+				SecretString string
+			}
+			Errors []struct {
+				SecretId  string
+				ErrorCode string
+			}
+			NextToken string
+		}
+		req := map[string]any{}
+		if s.prefix != "" {
+			req["Filters"] = []map[string]any{{"Key": "name", "Values": []string{s.prefix}}}
+		}
+		if next != "" {
+			req["NextToken"] = next
+		}
+		if err := s.call(ctx, "BatchGetSecretValue", req, &resp); err != nil {
+			return nil, err
+		}
+		if len(resp.Errors) > 0 {
+			e := resp.Errors[0]
+			return nil, util.FormatError(nil, "get secret %s error: %s", e.SecretId, e.ErrorCode)
+		}
+		for _, v := range resp.SecretValues {
+			key, ok := kvKey(v.Name, s.prefix)
+			if !ok {
+				continue
+			}
+			if err := flattenSecretString(data, key, v.SecretString); err != nil {
+				return nil, util.FormatError(err, "parse secret %s error", v.Name)
+			}
+		}
+		if next = resp.NextToken; next == "" {
+			return data, nil
+		}
+	}
+}
+
+// flattenSecretString adds the fields of a secret holding a JSON object
+// under the key, or the secret itself as the value of the key.
+func flattenSecretString(data map[string]string, key, s string) error {
+	if strings.HasPrefix(strings.TrimSpace(s), "{") {
+		var m map[string]any
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			return err
+		}
+		return flattenSecret(data, key, m)
+	}
+	data[key] = s
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+// awsServer serves the GetParametersByPath action of SSM and the
+// BatchGetSecretValue action of Secrets Manager, two items per page.
+type awsServer struct {
+	mutex   sync.Mutex
+	params  map[string]string
+	secrets map[string]string
+	auth    []string // Authorization headers received
+}
+
+func (s *awsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+
+	var req struct {
+		Path      string
+		Filters   []struct{ Values []string }
+		NextToken string
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	var items []map[string]string
+	switch r.Header.Get("X-Amz-Target") {
+	case "AmazonSSM.GetParametersByPath":
+		for name, v := range s.params {
+			if strings.HasPrefix(name, req.Path+"/") {
+				items = append(items, map[string]string{"Name": name, "Value": v})
+			}
+		}
+	case "secretsmanager.BatchGetSecretValue":
+		for name, v := range s.secrets {
+			if len(req.Filters) == 0 || strings.HasPrefix(name, req.Filters[0].Values[0]) {
+				items = append(items, map[string]string{"Name": name, "SecretString": v})
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"__type": "UnknownOperationException"})
+		return
+	}
+	slices.SortFunc(items, func(a, b map[string]string) int {
+		return strings.Compare(a["Name"], b["Name"])
+	})
+
+	start := 0
+	if req.NextToken != "" {
+		start = 2
+	}
+	end := min(start+2, len(items))
+	resp := map[string]any{}
+	if end < len(items) {
+		resp["NextToken"] = "page2"
+	}
+	if r.Header.Get("X-Amz-Target") == "AmazonSSM.GetParametersByPath" {
+		resp["Parameters"] = items[start:end]
+	} else {
+		resp["SecretValues"] = items[start:end]
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestAWSSign(t *testing.T) {
+	// the "get-vanilla" case of the Signature Version 4 test suite
+	c := &awsClient{
+		region:  "us-east-1",
+		service: "service",
+		keyID:   "AKIDEXAMPLE",
+		secret:  "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		now: func() time.Time {
+			return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+		},
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	assert.That(t, err).Nil()
+	c.sign(req, nil)
+	assert.That(t, req.Header.Get("Authorization")).Equal("AWS4-HMAC-SHA256 " +
+		"Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31")
+}
+
+func TestSSMConfigProvider(t *testing.T) {
+
+	t.Run("no credentials", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "")
+		_, err := NewSSMConfigProvider(RemoteProviderConfig{URL: "ssm://us-east-1/app", Interval: time.Second})
+		assert.Error(t, err).Matches("no aws credentials for ssm://us-east-1/app")
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		s := &awsServer{params: map[string]string{
+			"/app/prod/db/url":  "mysql://localhost",
+			"/app/prod/db/pool": "8",
+			"/app/prod/name":    "demo",
+			"/app/dev/name":     "ignored",
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "ssm://us-east-1/app/prod?endpoint=" + svr.URL
+		p, err := NewRemoteProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Keys()).Equal([]string{"db.pool", "db.url", "name"})
+		assert.That(t, prop.Get("db.url")).Equal("mysql://localhost")
+		assert.That(t, len(s.auth)).Equal(2)
+		assert.String(t, s.auth[0]).Matches(`^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/us-east-1/ssm/aws4_request, ` +
+			`SignedHeaders=content-type;host;x-amz-date;x-amz-target, Signature=[0-9a-f]{64}$`)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"AccessDeniedException","message":"denied"}`))
+		}))
+		defer svr.Close()
+
+		url := "ssm://us-east-1/app?endpoint=" + svr.URL
+		p, err := NewSSMConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("status 400 Bad Request: AccessDeniedException denied")
+	})
+}
+
+func TestSecretsManagerConfigProvider(t *testing.T) {
+
+	t.Run("fetch", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		t.Setenv("AWS_SESSION_TOKEN", "session")
+		s := &awsServer{secrets: map[string]string{
+			"app/prod/db":    `{"user":"admin","password":"s3cret","pool":{"max":8}}`,
+			"app/prod/token": "t0ken",
+			"app/prod/mq":    `{"url":"amqp://localhost"}`,
+			"other/db":       `{"user":"ignored"}`,
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "awssm://us-east-1/app/prod?endpoint=" + svr.URL
+		p, err := NewRemoteProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Keys()).Equal([]string{"db.password", "db.pool.max", "db.user", "mq.url", "token"})
+		assert.That(t, prop.Get("db.pool.max")).Equal("8")
+		assert.That(t, prop.Get("token")).Equal("t0ken")
+		assert.String(t, s.auth[0]).Contains("x-amz-security-token")
+	})
+
+	t.Run("invalid secret", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		s := &awsServer{secrets: map[string]string{"app/db": `{"user":`}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "awssm://us-east-1/app?endpoint=" + svr.URL
+		p, err := NewSecretsManagerConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("parse secret app/db error")
+	})
+}
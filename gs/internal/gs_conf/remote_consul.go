@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+func init() {
+	RegisterRemoteProvider("consul", func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewConsulConfigProvider(cfg)
+	})
+}
+
+// consulStore lists the keys under a prefix of Consul KV.
+type consulStore struct {
+	endpoint string // url of the recursive KV request
+	prefix   string
+	token    string
+	client   *http.Client
+}
+
+// NewConsulConfigProvider creates a RemoteConfigProvider that reads the
+// keys under a prefix of Consul KV, e.g. "consul://token@host:8500/app"
+// reads "app/db/url" as the property "db.url". The optional token is sent
+// as the ACL token, and query parameters such as "dc" are passed on. The
+// query parameter "tls=true" connects to the agent over https, which
+// should be used whenever there's a token.
+func NewConsulConfigProvider(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+	u, err := parseURL(cfg.URL)
+	if err != nil || u.Scheme != "consul" || u.Host == "" {
+		return nil, util.FormatError(err, "invalid consul config url %s", redactURL(cfg.URL))
+	}
+	prefix := strings.TrimPrefix(u.Path, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	query := u.Query()
+	scheme := "http"
+	if query.Get("tls") == "true" {
+		scheme = "https"
+	}
+	query.Del("tls")
+	query.Set("recurse", "true")
+	endpoint := url.URL{
+		Scheme:   scheme,
+		Host:     u.Host,
+		Path:     "/v1/kv/" + prefix,
+		RawQuery: query.Encode(),
+	}
+	s := &consulStore{
+		endpoint: endpoint.String(),
+		prefix:   prefix,
+		token:    u.User.Username(),
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}
+	return newKVConfigProvider(cfg, s)
+}
+
+// list returns the values under the prefix, decoded from the response of
+// the recursive KV request. A missing prefix has no values.
+func (s *consulStore) list(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Consul-Token", s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]string{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.FormatError(nil, "status %s", resp.Status)
+	}
+
+	var pairs []struct {
+		Key   string
+		Value []byte // base64 encoded, null for folders
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, err
+	}
+	data := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if key, ok := kvKey(pair.Key, s.prefix); ok {
+			data[key] = string(pair.Value)
+		}
+	}
+	return data, nil
+}
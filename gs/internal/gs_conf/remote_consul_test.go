@@ -0,0 +1,239 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// consulServer serves the KV endpoint of Consul from a map.
+type consulServer struct {
+	mutex sync.Mutex
+	kvs   map[string]string
+	token string
+	down  bool
+}
+
+func (s *consulServer) set(key, val string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.kvs[key] = val
+}
+
+func (s *consulServer) setDown(down bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.down = down
+}
+
+func (s *consulServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.down {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("X-Consul-Token") != s.token {
+		http.Error(w, "denied", http.StatusForbidden)
+		return
+	}
+	prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	type pair struct {
+		Key   string
+		Value []byte
+	}
+	var pairs []pair
+	for k, v := range s.kvs {
+		if strings.HasPrefix(k, prefix) {
+			var b []byte
+			if !strings.HasSuffix(k, "/") {
+				b = []byte(v)
+			}
+			pairs = append(pairs, pair{Key: k, Value: b})
+		}
+	}
+	if len(pairs) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(pairs)
+}
+
+func TestConsulConfigProvider(t *testing.T) {
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := NewConsulConfigProvider(RemoteProviderConfig{URL: "consul:///app", Interval: time.Second})
+		assert.Error(t, err).Matches("invalid consul config url consul:///app")
+		_, err = NewConsulConfigProvider(RemoteProviderConfig{URL: "consul://localhost/app"})
+		assert.Error(t, err).Matches("invalid remote config interval 0s")
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		s := &consulServer{token: "secret", kvs: map[string]string{
+			"app/":         "",
+			"app/db/":      "",
+			"app/db/url":   "mysql://localhost",
+			"app/db/pool":  "8",
+			"apple/db/url": "ignored",
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "consul://secret@" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewRemoteProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("db.url")).Equal("mysql://localhost")
+		assert.That(t, prop.Get("db.pool")).Equal("8")
+		assert.That(t, prop.Keys()).Equal([]string{"db.pool", "db.url"})
+
+		// the token is kept out of the origin
+		o, _ := prop.(*conf.MutableProperties).Origin("db.url")
+		assert.That(t, o.File).Equal(strings.Replace(url, "secret@", "", 1))
+
+		// unchanged
+		prop2, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop2).Equal(prop)
+
+		// fallback to the last good snapshot
+		s.setDown(true)
+		prop, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("db.pool")).Equal("8")
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		s := &consulServer{kvs: map[string]string{}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "consul://" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewConsulConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Keys()).Equal([]string{})
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		s := &consulServer{token: "secret", kvs: map[string]string{}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "consul://" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewConsulConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("fetch remote config .* error: status 403 Forbidden")
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		s := &consulServer{token: "secret", kvs: map[string]string{"app/a": "1"}}
+		svr := httptest.NewTLSServer(s)
+		defer svr.Close()
+
+		host := strings.TrimPrefix(svr.URL, "https://")
+		p, err := NewConsulConfigProvider(RemoteProviderConfig{URL: "consul://secret@" + host + "/app?tls=true", Interval: time.Second})
+		assert.That(t, err).Nil()
+		kv := p.(*kvConfigProvider)
+		kv.store.(*consulStore).client = svr.Client()
+		assert.That(t, kv.store.(*consulStore).endpoint).Equal("https://" + host + "/v1/kv/app/?recurse=true")
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("a")).Equal("1")
+
+		// the token is kept out of the errors
+		p, err = NewConsulConfigProvider(RemoteProviderConfig{URL: "consul://wrong@" + host + "/app?tls=true", Interval: time.Second})
+		assert.That(t, err).Nil()
+		p.(*kvConfigProvider).store.(*consulStore).client = svr.Client()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches(`fetch remote config consul://127\.0\.0\.1:\d+/app\?tls=true error: status 403 Forbidden`)
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		s := &consulServer{kvs: map[string]string{"app/a": "1"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "consul://" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewConsulConfigProvider(RemoteProviderConfig{URL: url, Interval: 10 * time.Millisecond})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+
+		ch, err := p.Watch(t.Context())
+		assert.That(t, err).Nil()
+		s.set("app/a", "2")
+		select {
+		case prop := <-ch:
+			assert.That(t, prop.Get("a")).Equal("2")
+		case <-time.After(5 * time.Second):
+			t.Fatal("no properties received")
+		}
+	})
+}
+
+func TestKVConfigProviderCache(t *testing.T) {
+	s := &consulServer{kvs: map[string]string{"app/a": "1"}}
+	svr := httptest.NewServer(s)
+	defer svr.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "remote.json")
+	url := "consul://" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+	cfg := RemoteProviderConfig{URL: url, Interval: time.Second, CacheFile: cacheFile}
+
+	// no cache yet
+	s.setDown(true)
+	p, err := NewConsulConfigProvider(cfg)
+	assert.That(t, err).Nil()
+	_, err = p.Fetch(t.Context())
+	assert.Error(t, err).Matches("status 503 Service Unavailable")
+
+	// saved on fetch
+	s.setDown(false)
+	prop, err := p.Fetch(t.Context())
+	assert.That(t, err).Nil()
+	assert.That(t, prop.Get("a")).Equal("1")
+
+	// a new provider starts from the cache while the server is down
+	s.setDown(true)
+	p, err = NewConsulConfigProvider(cfg)
+	assert.That(t, err).Nil()
+	prop, err = p.Fetch(t.Context())
+	assert.That(t, err).Nil()
+	assert.That(t, prop.Get("a")).Equal("1")
+
+	// and picks up changes once it's back
+	s.setDown(false)
+	s.set("app/a", "2")
+	prop, err = p.Fetch(t.Context())
+	assert.That(t, err).Nil()
+	assert.That(t, prop.Get("a")).Equal("2")
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+func init() {
+	RegisterRemoteProvider("etcd", func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewEtcdConfigProvider(cfg)
+	})
+}
+
+// etcdStore lists the keys under a prefix of etcd through the JSON
+// gateway of its v3 API.
+type etcdStore struct {
+	endpoint string // base url of the gateway
+	prefix   string
+	user     *url.Userinfo
+	client   *http.Client
+}
+
+// NewEtcdConfigProvider creates a RemoteConfigProvider that reads the
+// keys under a prefix of etcd, e.g. "etcd://host:2379/app" reads
+// "/app/db/url" as the property "db.url". If the url has a user and a
+// password, each request is authenticated with a token for them. The
+// query parameter "tls=true" connects to the gateway over https, which
+// should be used whenever there's a password.
+func NewEtcdConfigProvider(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+	u, err := parseURL(cfg.URL)
+	if err != nil || u.Scheme != "etcd" || u.Host == "" {
+		return nil, util.FormatError(err, "invalid etcd config url %s", redactURL(cfg.URL))
+	}
+	prefix := u.Path
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	scheme := "http"
+	if u.Query().Get("tls") == "true" {
+		scheme = "https"
+	}
+	s := &etcdStore{
+		endpoint: scheme + "://" + u.Host,
+		prefix:   prefix,
+		user:     u.User,
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}
+	return newKVConfigProvider(cfg, s)
+}
+
+// list returns the values under the prefix, read by a range request.
+func (s *etcdStore) list(ctx context.Context) (map[string]string, error) {
+	token, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Kvs []struct {
+			Key   []byte
+			Value []byte
+		}
+	}
+	req := map[string][]byte{
+		"key":       []byte(s.prefix),
+		"range_end": prefixEnd(s.prefix),
+	}
+	if err = s.post(ctx, "/v3/kv/range", token, req, &resp); err != nil {
+		return nil, err
+	}
+	data := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if key, ok := kvKey(string(kv.Key), s.prefix); ok {
+			data[key] = string(kv.Value)
+		}
+	}
+	return data, nil
+}
+
+// authenticate returns a token for the user, or "" if there's no user.
+func (s *etcdStore) authenticate(ctx context.Context) (string, error) {
+	password, ok := s.user.Password()
+	if !ok {
+		return "", nil
+	}
+	var resp struct {
+		Token string
+	}
+	req := map[string]string{
+		"name":     s.user.Username(),
+		"password": password,
+	}
+	if err := s.post(ctx, "/v3/auth/authenticate", "", req, &resp); err != nil {
+		return "", util.FormatError(err, "authenticate error")
+	}
+	return resp.Token, nil
+}
+
+// post sends the JSON request to the gateway and decodes the response.
+func (s *etcdStore) post(ctx context.Context, path, token string, in, out any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return util.FormatError(nil, "status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// prefixEnd returns the end of the key range with the prefix, i.e. the
+// prefix with its last byte incremented.
+func prefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0} // all keys
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// etcdServer serves the range and authenticate endpoints of the etcd
+// JSON gateway from a map.
+type etcdServer struct {
+	mutex    sync.Mutex
+	kvs      map[string]string
+	password string
+}
+
+func (s *etcdServer) set(key, val string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.kvs[key] = val
+}
+
+func (s *etcdServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch r.URL.Path {
+	case "/v3/auth/authenticate":
+		var req struct{ Name, Password string }
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Name != "root" || req.Password != s.password {
+			http.Error(w, "denied", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "token"})
+	case "/v3/kv/range":
+		if s.password != "" && r.Header.Get("Authorization") != "token" {
+			http.Error(w, "denied", http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Key      []byte `json:"key"`
+			RangeEnd []byte `json:"range_end"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		type kv struct {
+			Key   []byte `json:"key"`
+			Value []byte `json:"value"`
+		}
+		var kvs []kv
+		for k, v := range s.kvs {
+			if k >= string(req.Key) && k < string(req.RangeEnd) {
+				kvs = append(kvs, kv{Key: []byte(k), Value: []byte(v)})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"kvs": kvs})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestEtcdConfigProvider(t *testing.T) {
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := NewEtcdConfigProvider(RemoteProviderConfig{URL: "etcd:///app", Interval: time.Second})
+		assert.Error(t, err).Matches("invalid etcd config url etcd:///app")
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		s := &etcdServer{password: "pass", kvs: map[string]string{
+			"/app/db/url":   "mysql://localhost",
+			"/app/db/pool":  "8",
+			"/apple/db/url": "ignored",
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "etcd://root:pass@" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewRemoteProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("db.url")).Equal("mysql://localhost")
+		assert.That(t, prop.Keys()).Equal([]string{"db.pool", "db.url"})
+
+		// fallback to the last good snapshot
+		svr.Close()
+		prop, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("db.pool")).Equal("8")
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		s := &etcdServer{password: "pass", kvs: map[string]string{}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "etcd://root:wrong@" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewEtcdConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("authenticate error: status 401 Unauthorized")
+		assert.String(t, err.Error()).Contains("etcd://" + strings.TrimPrefix(svr.URL, "http://") + "/app error")
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		s := &etcdServer{password: "pass", kvs: map[string]string{"/app/a": "1"}}
+		svr := httptest.NewTLSServer(s)
+		defer svr.Close()
+
+		host := strings.TrimPrefix(svr.URL, "https://")
+		p, err := NewEtcdConfigProvider(RemoteProviderConfig{URL: "etcd://root:pass@" + host + "/app?tls=true", Interval: time.Second})
+		assert.That(t, err).Nil()
+		kv := p.(*kvConfigProvider)
+		kv.store.(*etcdStore).client = svr.Client()
+		assert.That(t, kv.store.(*etcdStore).endpoint).Equal("https://" + host)
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("a")).Equal("1")
+
+		// the password is kept out of the origin
+		o, _ := prop.(*conf.MutableProperties).Origin("a")
+		assert.That(t, o.File).Equal("etcd://" + host + "/app?tls=true")
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		s := &etcdServer{kvs: map[string]string{
+			"/app/a":   "1",
+			"/app/a/b": "2",
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "etcd://" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewEtcdConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("parse remote config .* error")
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		s := &etcdServer{kvs: map[string]string{"/app/a": "1"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "etcd://" + strings.TrimPrefix(svr.URL, "http://") + "/app"
+		p, err := NewEtcdConfigProvider(RemoteProviderConfig{URL: url, Interval: 10 * time.Millisecond})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+
+		ch, err := p.Watch(t.Context())
+		assert.That(t, err).Nil()
+		s.set("/app/a", "2")
+		select {
+		case prop := <-ch:
+			assert.That(t, prop.Get("a")).Equal("2")
+		case <-time.After(5 * time.Second):
+			t.Fatal("no properties received")
+		}
+	})
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.That(t, prefixEnd("/app/")).Equal([]byte("/app0"))
+	assert.That(t, prefixEnd("a\xff")).Equal([]byte("b"))
+	assert.That(t, prefixEnd("\xff")).Equal([]byte{0})
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+func init() {
+	RegisterRemoteProvider("gcpsm", func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewGCPSecretManagerConfigProvider(cfg)
+	})
+}
+
+// gcpSecretStore reads the secrets whose names start with a prefix from
+// Google Cloud Secret Manager.
+type gcpSecretStore struct {
+	endpoint string // url of the service
+	project  string
+	prefix   string
+	client   *http.Client
+
+	mutex   sync.Mutex
+	token   string    // access token
+	expires time.Time // expiry of the access token
+}
+
+// NewGCPSecretManagerConfigProvider creates a RemoteConfigProvider that
+// reads the latest versions of the secrets whose names start with a
+// prefix from Google Cloud Secret Manager, e.g. "gcpsm://my-project/app_"
+// reads the secret "app_db_url" as the property "db.url". Since secret
+// names can't contain dots, underscores '_' separate the key segments.
+// The fields of a secret holding a JSON object are properties, with nested
+// objects flattened.
+//
+// The access token is taken from the GOOGLE_OAUTH_ACCESS_TOKEN environment
+// variable, or else from the metadata server of the instance, whose host
+// can be set by GCE_METADATA_HOST. The query parameter "endpoint"
+// overrides the url of the service, e.g. for a local emulator.
+func NewGCPSecretManagerConfigProvider(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Scheme != "gcpsm" || u.Host == "" {
+		return nil, util.FormatError(err, "invalid gcp config url %s", cfg.URL)
+	}
+	s := &gcpSecretStore{
+		endpoint: "https://secretmanager.googleapis.com",
+		project:  u.Host,
+		prefix:   strings.Trim(u.Path, "/"),
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}
+	if e := u.Query().Get("endpoint"); e != "" {
+		s.endpoint = strings.TrimSuffix(e, "/")
+	}
+	return newKVConfigProvider(cfg, s)
+}
+
+// list returns the values of the secrets under the prefix.
+func (s *gcpSecretStore) list(ctx context.Context) (map[string]string, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return nil, util.FormatError(err, "get gcp access token error")
+	}
+	names, err := s.secrets(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]string)
+	for _, name := range names {
+		key, ok := strings.CutPrefix(name, s.prefix)
+		key = strings.Trim(key, "_")
+		if !ok || key == "" {
+			continue
+		}
+		var resp struct {
+			Payload struct {
+				Data string `json:"data"`
+			} `json:"payload"`
+		}
+		if err = s.get(ctx, token, "/v1/projects/"+s.project+"/secrets/"+name+"/versions/latest:access", &resp); err != nil {
+			return nil, util.FormatError(err, "access secret %s error", name)
+		}
+		b, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+		if err != nil {
+			return nil, util.FormatError(err, "decode secret %s error", name)
+		}
+		key = strings.ReplaceAll(key, "_", ".")
+		if err = flattenSecretString(data, key, string(b)); err != nil {
+			return nil, util.FormatError(err, "parse secret %s error", name)
+		}
+	}
+	return data, nil
+}
+
+// secrets returns the ids of the secrets of the project, page by page.
+func (s *gcpSecretStore) secrets(ctx context.Context, token string) ([]string, error) {
+	var names []string
+	var next string
+	for {
+		var resp struct {
+			Secrets []struct {
+				Name string `json:"name"` // "projects/{project}/secrets/{id}"
+			} `json:"secrets"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		query := url.Values{"pageSize": {"250"}}
+		if next != "" {
+			query.Set("pageToken", next)
+		}
+		if err := s.get(ctx, token, "/v1/projects/"+s.project+"/secrets?"+query.Encode(), &resp); err != nil {
+			return nil, util.FormatError(err, "list secrets error")
+		}
+		for _, secret := range resp.Secrets {
+			names = append(names, path.Base(secret.Name))
+		}
+		if next = resp.NextPageToken; next == "" {
+			return names, nil
+		}
+	}
+}
+
+// accessToken returns the access token of the environment, or the one
+// of the metadata server, which is cached until shortly before it expires.
+func (s *gcpSecretStore) accessToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.token != "" && time.Now().Before(s.expires) {
+		return s.token, nil
+	}
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = "metadata.google.internal"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"http://"+host+"/computeMetadata/v1/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err = s.do(req, &resp); err != nil {
+		return "", err
+	}
+	s.token = resp.AccessToken
+	s.expires = time.Now().Add(time.Duration(resp.ExpiresIn)*time.Second - time.Minute)
+	return s.token, nil
+}
+
+// get sends a request to the service with the token.
+func (s *gcpSecretStore) get(ctx context.Context, token, uri string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+uri, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return s.do(req, out)
+}
+
+// do sends the request and decodes the JSON response.
+func (s *gcpSecretStore) do(req *http.Request, out any) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		if e.Error.Message != "" {
+			return util.FormatError(nil, "status %s: %s", resp.Status, e.Error.Message)
+		}
+		return util.FormatError(nil, "status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
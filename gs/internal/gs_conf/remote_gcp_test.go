@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+// gcpServer serves the secrets of a project of Secret Manager, one per
+// page, and the token endpoint of the metadata server.
+type gcpServer struct {
+	secrets map[string]string
+	token   string
+	tokens  atomic.Int32 // number of tokens issued
+}
+
+func (s *gcpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/computeMetadata/v1/instance/service-accounts/default/token" {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		s.tokens.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": s.token, "expires_in": 3600})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid credentials"}}`))
+		return
+	}
+	if r.URL.Path == "/v1/projects/demo/secrets" {
+		var names []string
+		for name := range s.secrets {
+			names = append(names, name)
+		}
+		resp := map[string]any{}
+		if token := r.URL.Query().Get("pageToken"); token != "" {
+			resp["secrets"] = []map[string]string{{"name": "projects/demo/secrets/" + token}}
+		} else {
+			resp["secrets"] = []map[string]string{{"name": "projects/demo/secrets/" + names[0]}}
+			if len(names) > 1 {
+				resp["nextPageToken"] = names[1]
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+	name, ok := strings.CutPrefix(r.URL.Path, "/v1/projects/demo/secrets/")
+	if name, ok = strings.CutSuffix(name, "/versions/latest:access"); ok {
+		if v, ok := s.secrets[name]; ok {
+			data := base64.StdEncoding.EncodeToString([]byte(v))
+			_ = json.NewEncoder(w).Encode(map[string]any{"payload": map[string]string{"data": data}})
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func TestGCPSecretManagerConfigProvider(t *testing.T) {
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := NewGCPSecretManagerConfigProvider(RemoteProviderConfig{URL: "gcpsm:///app", Interval: time.Second})
+		assert.Error(t, err).Matches("invalid gcp config url gcpsm:///app")
+	})
+
+	t.Run("metadata token", func(t *testing.T) {
+		s := &gcpServer{token: "ya29", secrets: map[string]string{
+			"app_db_url": "mysql://localhost",
+			"app_mq":     `{"url":"amqp://localhost"}`,
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+		t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "")
+		t.Setenv("GCE_METADATA_HOST", strings.TrimPrefix(svr.URL, "http://"))
+
+		url := "gcpsm://demo/app_?endpoint=" + svr.URL
+		p, err := NewRemoteProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Keys()).Equal([]string{"db.url", "mq.url"})
+		assert.That(t, prop.Get("db.url")).Equal("mysql://localhost")
+
+		// the token is cached
+		_, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, s.tokens.Load()).Equal(int32(1))
+	})
+
+	t.Run("env token", func(t *testing.T) {
+		s := &gcpServer{token: "env", secrets: map[string]string{"name": "demo"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+		t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "env")
+
+		url := "gcpsm://demo?endpoint=" + svr.URL
+		p, err := NewGCPSecretManagerConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("name")).Equal("demo")
+		assert.That(t, s.tokens.Load()).Equal(int32(0))
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		s := &gcpServer{token: "ya29", secrets: map[string]string{"a": "1"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+		t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "expired")
+
+		url := "gcpsm://demo?endpoint=" + svr.URL
+		p, err := NewGCPSecretManagerConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("list secrets error: status 401 Unauthorized: invalid credentials")
+	})
+}
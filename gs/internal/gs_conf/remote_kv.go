@@ -0,0 +1,211 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// kvStore lists the configuration stored under a prefix of a key-value
+// store, such as Consul KV or etcd.
+type kvStore interface {
+	// list returns the values by their keys relative to the prefix, with
+	// the '/' separators replaced by '.'.
+	list(ctx context.Context) (map[string]string, error)
+}
+
+// kvConfigProvider is a RemoteConfigProvider that reads each key under
+// a prefix of a key-value store as a property. Like HTTPConfigProvider,
+// it falls back to the last good snapshot when a request fails, and its
+// Watch polls the store at the configured interval. If a cache file is
+// set, the snapshot is saved in it, and the first fetch falls back to it.
+type kvConfigProvider struct {
+	url       string
+	store     kvStore
+	interval  time.Duration
+	cacheFile string
+
+	mutex sync.Mutex
+	data  map[string]string // data of the last good snapshot
+	last  conf.Properties   // last good snapshot
+}
+
+// newKVConfigProvider creates a new kvConfigProvider.
+func newKVConfigProvider(cfg RemoteProviderConfig, store kvStore) (*kvConfigProvider, error) {
+	if cfg.Interval <= 0 {
+		return nil, util.FormatError(nil, "invalid remote config interval %s", cfg.Interval)
+	}
+	return &kvConfigProvider{
+		url:       redactURL(cfg.URL),
+		store:     store,
+		interval:  cfg.Interval,
+		cacheFile: cfg.CacheFile,
+	}, nil
+}
+
+// Fetch returns the current properties, or the last good snapshot if the
+// request fails after a successful one.
+func (p *kvConfigProvider) Fetch(ctx context.Context) (conf.Properties, error) {
+	prop, _, err := p.fetch(ctx)
+	return prop, err
+}
+
+// fetch lists the properties and returns whether they have changed.
+func (p *kvConfigProvider) fetch(ctx context.Context) (conf.Properties, bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	prop, changed, err := p.load(ctx)
+	if err != nil {
+		if p.last == nil {
+			prop, cacheErr := p.loadCache()
+			if cacheErr != nil {
+				return nil, false, err
+			}
+			log.Warnf(ctx, log.TagAppDef, "fetch remote config error, using the cache file %s: %v", p.cacheFile, err)
+			return prop, true, nil
+		}
+		log.Warnf(ctx, log.TagAppDef, "fetch remote config error, using the last good snapshot: %v", err)
+		return p.last, false, nil
+	}
+	return prop, changed, nil
+}
+
+// load lists the properties and updates the last good snapshot.
+func (p *kvConfigProvider) load(ctx context.Context) (conf.Properties, bool, error) {
+	data, err := p.store.list(ctx)
+	if err != nil {
+		return nil, false, util.FormatError(err, "fetch remote config %s error", p.url)
+	}
+	if p.last != nil && maps.Equal(data, p.data) {
+		return p.last, false, nil
+	}
+	prop, err := p.update(data, p.url)
+	if err != nil {
+		return nil, false, err
+	}
+	if err = p.saveCache(data); err != nil {
+		log.Warnf(ctx, log.TagAppDef, "save remote config cache error: %v", err)
+	}
+	return prop, true, nil
+}
+
+// update builds the properties from the data, recorded as read from the
+// source, and makes them the last good snapshot.
+func (p *kvConfigProvider) update(data map[string]string, source string) (conf.Properties, error) {
+	prop := conf.New()
+	fileID := prop.AddFile(source)
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if err := prop.Set(key, data[key], fileID); err != nil {
+			return nil, util.FormatError(err, "parse remote config %s error", source)
+		}
+	}
+	p.data = data
+	p.last = prop
+	return prop, nil
+}
+
+// saveCache writes the data to the cache file, through a temporary file
+// renamed over it, so that a crash never leaves a partial cache.
+func (p *kvConfigProvider) saveCache(data map[string]string) error {
+	if p.cacheFile == "" {
+		return nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	tmp := p.cacheFile + ".tmp"
+	if err = os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.cacheFile)
+}
+
+// loadCache makes the data in the cache file the last good snapshot.
+func (p *kvConfigProvider) loadCache() (conf.Properties, error) {
+	if p.cacheFile == "" {
+		return nil, os.ErrNotExist
+	}
+	b, err := os.ReadFile(p.cacheFile)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]string
+	if err = json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return p.update(data, p.cacheFile)
+}
+
+// Watch polls the store at the configured interval and sends the
+// properties each time they change.
+func (p *kvConfigProvider) Watch(ctx context.Context) (<-chan conf.Properties, error) {
+	ch := make(chan conf.Properties)
+	goutil.Go(ctx, func(ctx context.Context) {
+		defer close(ch)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			prop, changed, err := p.fetch(ctx)
+			if err != nil {
+				log.Warnf(ctx, log.TagAppDef, "watch remote config error: %v", err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			select {
+			case ch <- prop:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return ch, nil
+}
+
+// kvKey converts a key of a key-value store under the prefix to a
+// property key, returning false if it's the prefix itself or a folder.
+func kvKey(key, prefix string) (string, bool) {
+	key, ok := strings.CutPrefix(key, prefix)
+	if !ok {
+		return "", false
+	}
+	key = strings.TrimPrefix(key, "/")
+	if key == "" || strings.HasSuffix(key, "/") {
+		return "", false
+	}
+	return strings.ReplaceAll(key, "/", "."), true
+}
@@ -0,0 +1,372 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+func init() {
+	RegisterRemoteProvider("vault", func(cfg RemoteProviderConfig) (RemoteConfigProvider, error) {
+		return NewVaultConfigProvider(cfg)
+	})
+}
+
+// vaultLease is the lease of a secret read from Vault.
+type vaultLease struct {
+	ID        string
+	Duration  time.Duration
+	Renewable bool
+}
+
+// vaultStore reads a secret of the KV v2 secrets engine of Vault.
+type vaultStore struct {
+	endpoint string // base url of the server
+	path     string // path of the secret, "/v1/{mount}/data/{path}"
+	prefix   string // prefix of the property keys
+	token    string
+	client   *http.Client
+
+	mutex sync.Mutex
+	lease vaultLease // lease of the last read secret
+}
+
+// VaultConfigProvider is a RemoteConfigProvider that reads a secret of
+// the KV v2 secrets engine of Vault, each field of which is a property.
+//
+// Its Watch polls the secret at the configured interval, so that a new
+// version written by a rotation is sent as a change. It also renews the
+// token, if it's renewable, and the lease of the secret, if it has one,
+// in the background. A leased secret isn't polled, since each read may
+// issue new credentials, but is read again when its lease can no longer
+// be renewed, which sends the rotated credentials as a change.
+type VaultConfigProvider struct {
+	*kvConfigProvider
+	store *vaultStore
+}
+
+// NewVaultConfigProvider creates a VaultConfigProvider from a url of the
+// form "vault://token@host:8200/{mount}/{path}", e.g.
+// "vault://host:8200/secret/app?prefix=db" reads the field "password" of
+// the secret "app" in the "secret" mount as the property "db.password".
+// If the url has no token, the VAULT_TOKEN environment variable is used.
+// The server is connected over https, unless the query parameter
+// "tls=false" explicitly asks for plain http, e.g. for a dev server.
+func NewVaultConfigProvider(cfg RemoteProviderConfig) (*VaultConfigProvider, error) {
+	u, err := parseURL(cfg.URL)
+	if err != nil || u.Scheme != "vault" || u.Host == "" {
+		return nil, util.FormatError(err, "invalid vault config url %s", redactURL(cfg.URL))
+	}
+	mount, path, ok := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	if !ok || mount == "" || path == "" {
+		return nil, util.FormatError(nil, "invalid vault config url %s: no secret path", redactURL(cfg.URL))
+	}
+	scheme := "https"
+	if u.Query().Get("tls") == "false" {
+		scheme = "http"
+	}
+	token := u.User.Username()
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	s := &vaultStore{
+		endpoint: scheme + "://" + u.Host,
+		path:     "/v1/" + mount + "/data/" + path,
+		prefix:   u.Query().Get("prefix"),
+		token:    token,
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}
+	p, err := newKVConfigProvider(cfg, s)
+	if err != nil {
+		return nil, err
+	}
+	return &VaultConfigProvider{kvConfigProvider: p, store: s}, nil
+}
+
+// Watch renews the token and the lease of the secret in the background,
+// and sends the properties each time the secret changes.
+func (p *VaultConfigProvider) Watch(ctx context.Context) (<-chan conf.Properties, error) {
+	goutil.Go(ctx, p.renewToken)
+	ch := make(chan conf.Properties)
+	goutil.Go(ctx, func(ctx context.Context) {
+		defer close(ch)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		var (
+			lease vaultLease
+			timer *time.Timer
+			final bool // whether the lease reached its max ttl
+		)
+		// schedule renews the lease after two thirds of its duration.
+		schedule := func(d time.Duration) {
+			if timer != nil {
+				timer.Stop()
+				timer = nil
+			}
+			if lease.ID != "" && d > 0 {
+				timer = time.NewTimer(d * 2 / 3)
+			}
+		}
+		reset := func() {
+			lease, final = p.store.currentLease(), false
+			schedule(lease.Duration)
+		}
+		reset()
+		defer func() { schedule(0) }()
+
+		for {
+			var expired <-chan time.Time
+			if timer != nil {
+				expired = timer.C
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if lease.ID != "" {
+					continue // a leased secret is read again on expiry
+				}
+			case <-expired:
+				if !final && lease.Renewable {
+					d, err := p.store.renewLease(ctx, lease.ID, lease.Duration)
+					if err == nil && d > 0 {
+						final = d < lease.Duration
+						schedule(d)
+						continue
+					}
+					if err != nil {
+						log.Warnf(ctx, log.TagAppDef, "renew vault lease %s error: %v", lease.ID, err)
+					}
+				}
+			}
+
+			prop, changed, err := p.fetch(ctx)
+			if err != nil {
+				log.Warnf(ctx, log.TagAppDef, "watch remote config error: %v", err)
+				continue
+			}
+			if expired != nil || lease.ID != p.store.currentLease().ID {
+				reset()
+			}
+			if !changed {
+				continue
+			}
+			select {
+			case ch <- prop:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return ch, nil
+}
+
+// renewToken renews the token after two thirds of its ttl, until it's no
+// longer renewable or reaches its max ttl.
+func (p *VaultConfigProvider) renewToken(ctx context.Context) {
+	ttl, renewable, err := p.store.lookupToken(ctx)
+	if err != nil {
+		log.Warnf(ctx, log.TagAppDef, "lookup vault token error: %v", err)
+		return
+	}
+	if !renewable || ttl <= 0 {
+		return
+	}
+	wait := ttl * 2 / 3
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		d, err := p.store.renewToken(ctx, ttl)
+		if err != nil {
+			log.Warnf(ctx, log.TagAppDef, "renew vault token error: %v", err)
+			wait = p.interval
+			continue
+		}
+		if d < ttl {
+			log.Warnf(ctx, log.TagAppDef, "vault token reaches its max ttl, expires in %s", d)
+			return
+		}
+		wait = d * 2 / 3
+	}
+}
+
+// currentLease returns the lease of the last read secret.
+func (s *vaultStore) currentLease() vaultLease {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.lease
+}
+
+// list returns the fields of the secret under the prefix, with nested
+// objects flattened. A missing or deleted secret has no values.
+func (s *vaultStore) list(ctx context.Context) (map[string]string, error) {
+	var resp struct {
+		LeaseID       string `json:"lease_id"`
+		LeaseDuration int64  `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+		Data          struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	found, err := s.do(ctx, http.MethodGet, s.path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	s.lease = vaultLease{
+		ID:        resp.LeaseID,
+		Duration:  time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable: resp.Renewable,
+	}
+	s.mutex.Unlock()
+	data := make(map[string]string)
+	if found {
+		if err = flattenSecret(data, s.prefix, resp.Data.Data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// flattenSecret adds the fields of the secret to data, joining the keys
+// of nested objects with '.'. Values other than strings and objects are
+// added in their JSON form.
+func flattenSecret(data map[string]string, prefix string, m map[string]any) error {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch x := v.(type) {
+		case nil:
+		case string:
+			data[key] = x
+		case map[string]any:
+			if err := flattenSecret(data, key, x); err != nil {
+				return err
+			}
+		default:
+			b, err := json.Marshal(x)
+			if err != nil {
+				return err
+			}
+			data[key] = string(b)
+		}
+	}
+	return nil
+}
+
+// lookupToken returns the ttl of the token and whether it's renewable.
+func (s *vaultStore) lookupToken(ctx context.Context) (time.Duration, bool, error) {
+	var resp struct {
+		Data struct {
+			TTL       int64 `json:"ttl"`
+			Renewable bool  `json:"renewable"`
+		} `json:"data"`
+	}
+	if _, err := s.do(ctx, http.MethodGet, "/v1/auth/token/lookup-self", nil, &resp); err != nil {
+		return 0, false, err
+	}
+	return time.Duration(resp.Data.TTL) * time.Second, resp.Data.Renewable, nil
+}
+
+// renewToken extends the ttl of the token by the increment, and returns
+// the granted ttl, which is shorter once the max ttl is reached.
+func (s *vaultStore) renewToken(ctx context.Context, increment time.Duration) (time.Duration, error) {
+	var resp struct {
+		Auth struct {
+			LeaseDuration int64 `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	req := map[string]any{"increment": int64(increment / time.Second)}
+	if _, err := s.do(ctx, http.MethodPost, "/v1/auth/token/renew-self", req, &resp); err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.Auth.LeaseDuration) * time.Second, nil
+}
+
+// renewLease extends the lease by the increment, and returns the granted
+// duration, which is shorter once the max ttl is reached.
+func (s *vaultStore) renewLease(ctx context.Context, id string, increment time.Duration) (time.Duration, error) {
+	var resp struct {
+		LeaseDuration int64 `json:"lease_duration"`
+	}
+	req := map[string]any{"lease_id": id, "increment": int64(increment / time.Second)}
+	if _, err := s.do(ctx, http.MethodPut, "/v1/sys/leases/renew", req, &resp); err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// do sends the request with the token and decodes the JSON response. It
+// returns false without an error if the path isn't found.
+func (s *vaultStore) do(ctx context.Context, method, path string, in, out any) (bool, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return false, err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+path, body)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("X-Vault-Token", s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		if len(e.Errors) > 0 {
+			return false, util.FormatError(nil, "status %s: %s", resp.Status, strings.Join(e.Errors, "; "))
+		}
+		return false, util.FormatError(nil, "status %s", resp.Status)
+	}
+	return true, json.NewDecoder(resp.Body).Decode(out)
+}
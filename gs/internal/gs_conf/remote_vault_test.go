@@ -0,0 +1,252 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// vaultServer serves a secret of the KV v2 secrets engine, and the token
+// and lease endpoints of Vault.
+type vaultServer struct {
+	mutex  sync.Mutex
+	token  string
+	data   map[string]any
+	lease  string // lease id of the secret, "" for a KV secret
+	ttl    int64  // ttl of the token and the lease, in seconds
+	reads  int    // number of reads of the secret
+	tokens int    // number of token renewals
+	leases int    // number of lease renewals
+}
+
+func (s *vaultServer) set(data map[string]any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.data = data
+}
+
+func (s *vaultServer) counts() (reads, tokens, leases int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.reads, s.tokens, s.leases
+}
+
+func (s *vaultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if r.Header.Get("X-Vault-Token") != s.token {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+	var resp any
+	switch r.URL.Path {
+	case "/v1/secret/data/app":
+		if s.data == nil {
+			http.NotFound(w, r)
+			return
+		}
+		s.reads++
+		var (
+			lease    string
+			duration int64
+		)
+		if s.lease != "" {
+			lease, duration = fmt.Sprintf("%s/%d", s.lease, s.reads), s.ttl
+		}
+		resp = map[string]any{
+			"lease_id":       lease,
+			"lease_duration": duration,
+			"renewable":      lease != "",
+			"data":           map[string]any{"data": s.data},
+		}
+	case "/v1/auth/token/lookup-self":
+		resp = map[string]any{"data": map[string]any{"ttl": s.ttl, "renewable": true}}
+	case "/v1/auth/token/renew-self":
+		s.tokens++
+		resp = map[string]any{"auth": map[string]any{"lease_duration": s.ttl}}
+	case "/v1/sys/leases/renew":
+		s.leases++
+		resp = map[string]any{"lease_duration": 0} // max ttl reached
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestVaultConfigProvider(t *testing.T) {
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := NewVaultConfigProvider(RemoteProviderConfig{URL: "vault:///secret/app", Interval: time.Second})
+		assert.Error(t, err).Matches("invalid vault config url vault:///secret/app")
+		_, err = NewVaultConfigProvider(RemoteProviderConfig{URL: "vault://localhost/secret", Interval: time.Second})
+		assert.Error(t, err).Matches("invalid vault config url vault://localhost/secret: no secret path")
+		_, err = NewVaultConfigProvider(RemoteProviderConfig{URL: "vault://localhost/secret/app"})
+		assert.Error(t, err).Matches("invalid remote config interval 0s")
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		s := &vaultServer{token: "root", data: map[string]any{
+			"password": "s3cret",
+			"pool":     8,
+			"replica":  map[string]any{"host": "db-2"},
+			"none":     nil,
+		}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "vault://root@" + strings.TrimPrefix(svr.URL, "http://") + "/secret/app?prefix=db&tls=false"
+		p, err := NewRemoteProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Keys()).Equal([]string{"db.password", "db.pool", "db.replica.host"})
+		assert.That(t, prop.Get("db.password")).Equal("s3cret")
+		assert.That(t, prop.Get("db.pool")).Equal("8")
+		assert.That(t, prop.Get("db.replica.host")).Equal("db-2")
+	})
+
+	t.Run("https by default", func(t *testing.T) {
+		s := &vaultServer{token: "root", data: map[string]any{"a": "1"}}
+		svr := httptest.NewTLSServer(s)
+		defer svr.Close()
+
+		host := strings.TrimPrefix(svr.URL, "https://")
+		p, err := NewVaultConfigProvider(RemoteProviderConfig{URL: "vault://root@" + host + "/secret/app", Interval: time.Second})
+		assert.That(t, err).Nil()
+		p.store.client = svr.Client()
+		assert.That(t, p.store.endpoint).Equal("https://" + host)
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("a")).Equal("1")
+
+		// the token is kept out of the origin
+		o, _ := prop.(*conf.MutableProperties).Origin("a")
+		assert.That(t, o.File).Equal("vault://" + host + "/secret/app")
+	})
+
+	t.Run("token from env", func(t *testing.T) {
+		s := &vaultServer{token: "env-token", data: map[string]any{"a": "1"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		t.Setenv("VAULT_TOKEN", "env-token")
+		url := "vault://" + strings.TrimPrefix(svr.URL, "http://") + "/secret/app?tls=false"
+		p, err := NewVaultConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Get("a")).Equal("1")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		s := &vaultServer{}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "vault://" + strings.TrimPrefix(svr.URL, "http://") + "/secret/app?tls=false"
+		p, err := NewVaultConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		prop, err := p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, prop.Keys()).Equal([]string{})
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		t.Setenv("VAULT_TOKEN", "")
+		s := &vaultServer{token: "root", data: map[string]any{}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "vault://" + strings.TrimPrefix(svr.URL, "http://") + "/secret/app?tls=false"
+		p, err := NewVaultConfigProvider(RemoteProviderConfig{URL: url, Interval: time.Second})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.Error(t, err).Matches("fetch remote config .* error: status 403 Forbidden: permission denied")
+	})
+
+	t.Run("watch rotation", func(t *testing.T) {
+		s := &vaultServer{token: "root", ttl: 1, data: map[string]any{"password": "v1"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "vault://root@" + strings.TrimPrefix(svr.URL, "http://") + "/secret/app?tls=false"
+		p, err := NewVaultConfigProvider(RemoteProviderConfig{URL: url, Interval: 10 * time.Millisecond})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+
+		ch, err := p.Watch(t.Context())
+		assert.That(t, err).Nil()
+		s.set(map[string]any{"password": "v2"})
+		select {
+		case prop := <-ch:
+			assert.That(t, prop.Get("password")).Equal("v2")
+		case <-time.After(5 * time.Second):
+			t.Fatal("no properties received")
+		}
+
+		// the token is renewed after two thirds of its ttl
+		time.Sleep(time.Second)
+		_, tokens, _ := s.counts()
+		assert.That(t, tokens > 0).True()
+	})
+
+	t.Run("watch lease", func(t *testing.T) {
+		s := &vaultServer{token: "root", ttl: 1, lease: "database/creds", data: map[string]any{"password": "v1"}}
+		svr := httptest.NewServer(s)
+		defer svr.Close()
+
+		url := "vault://root@" + strings.TrimPrefix(svr.URL, "http://") + "/secret/app?tls=false"
+		p, err := NewVaultConfigProvider(RemoteProviderConfig{URL: url, Interval: 10 * time.Millisecond})
+		assert.That(t, err).Nil()
+		_, err = p.Fetch(t.Context())
+		assert.That(t, err).Nil()
+
+		ch, err := p.Watch(t.Context())
+		assert.That(t, err).Nil()
+
+		// a leased secret isn't polled
+		s.set(map[string]any{"password": "v2"})
+		time.Sleep(100 * time.Millisecond)
+		reads, _, _ := s.counts()
+		assert.That(t, reads).Equal(1)
+
+		// read again once the lease can't be renewed
+		select {
+		case prop := <-ch:
+			assert.That(t, prop.Get("password")).Equal("v2")
+		case <-time.After(5 * time.Second):
+			t.Fatal("no properties received")
+		}
+		reads, _, leases := s.counts()
+		assert.That(t, reads).Equal(2)
+		assert.That(t, leases).Equal(1)
+	})
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"slices"
+	"strings"
+)
+
+// MaskedValue replaces the values of secret keys in reports.
+const MaskedValue = "******"
+
+// SecretKeyWords are the words that mark a key as a secret when its last
+// segment contains any of them, case-insensitively, e.g. "db.password"
+// or "oauth.client-secret".
+var SecretKeyWords = []string{
+	"password", "passwd", "pwd", "secret", "token",
+	"credential", "private-key", "access-key", "api-key", "apikey",
+}
+
+// IsSecretKey reports whether the value of the key should be masked.
+func IsSecretKey(key string) bool {
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		key = key[i+1:]
+	}
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(SecretKeyWords, func(word string) bool {
+		return strings.Contains(key, word)
+	})
+}
+
+// ReportEntry is a key of the effective configuration.
+type ReportEntry struct {
+	Key    string // The property key.
+	Value  string // The value, MaskedValue if the key is a secret.
+	Origin string // Where the value comes from, see conf.Origin.
+}
+
+// Report is the effective configuration, sorted by key.
+type Report []ReportEntry
+
+// String returns the report with one "key=value [origin]" line per key.
+func (r Report) String() string {
+	var sb strings.Builder
+	for _, e := range r {
+		sb.WriteString(e.Key)
+		sb.WriteString("=")
+		sb.WriteString(e.Value)
+		sb.WriteString(" [")
+		sb.WriteString(e.Origin)
+		sb.WriteString("]\n")
+	}
+	return sb.String()
+}
+
+// Report returns the configuration merged by the last Refresh, each key
+// with the origin of its value and secrets masked. It refreshes the
+// configuration if it has never been refreshed.
+func (c *AppConfig) Report() (Report, error) {
+	p := c.effective.Load()
+	if p == nil {
+		if _, err := c.Refresh(); err != nil {
+			return nil, err
+		}
+		p = c.effective.Load()
+	}
+	var r Report
+	for _, key := range p.Keys() {
+		e := ReportEntry{Key: key, Value: p.Get(key)}
+		if IsSecretKey(key) {
+			e.Value = MaskedValue
+		}
+		if o, ok := p.Origin(key); ok {
+			e.Origin = o.String()
+		}
+		r = append(r, e)
+	}
+	return r, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	assert.That(t, IsSecretKey("db.password")).True()
+	assert.That(t, IsSecretKey("oauth.Client-Secret")).True()
+	assert.That(t, IsSecretKey("aws.access-key")).True()
+	assert.That(t, IsSecretKey("password.policy")).False()
+	assert.That(t, IsSecretKey("db.host")).False()
+}
+
+func TestAppConfig_Report(t *testing.T) {
+	clean()
+
+	t.Run("success", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		file := filepath.Join(dir, "app.properties")
+		err := os.WriteFile(file, []byte("db.host=localhost\ndb.password=123456"), 0644)
+		assert.That(t, err).Nil()
+		_ = SysConf.Set("spring.app.config-local.dir", dir, SysConf.AddFile("sys"))
+		_ = os.Setenv("GS_DB_PORT", "3306")
+		os.Args = []string{"test", "-Ddb.user=root"}
+
+		c := NewAppConfig()
+		assert.That(t, c.SetProperty("db.token", "abc")).Nil()
+		r, err := c.Report()
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(Report{
+			{Key: "db.host", Value: "localhost", Origin: file},
+			{Key: "db.password", Value: MaskedValue, Origin: file},
+			{Key: "db.port", Value: "3306", Origin: "Environment(GS_DB_PORT)"},
+			{Key: "db.token", Value: MaskedValue, Origin: "runtime"},
+			{Key: "db.user", Value: "root", Origin: "Args"},
+			{Key: "spring.app.config-local.dir", Value: dir, Origin: "sys"},
+		})
+		assert.That(t, r[:1].String()).Equal("db.host=localhost [" + file + "]\n")
+	})
+
+	t.Run("last refresh", func(t *testing.T) {
+		t.Cleanup(clean)
+		c := NewAppConfig()
+		_, err := c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, c.SetProperty("a", "1")).Nil()
+		r, err := c.Report()
+		assert.That(t, err).Nil()
+		assert.That(t, len(r)).Equal(0)
+	})
+
+	t.Run("refresh error", func(t *testing.T) {
+		t.Cleanup(clean)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_DIR", "${a}")
+		_, err := NewAppConfig().Report()
+		assert.Error(t, err).Matches("refresh error in source local")
+	})
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+)
+
+// URLFileConfig holds the settings of fetching the config files added
+// by their http or https urls, see PropertySources.AddFile.
+type URLFileConfig struct {
+	// Timeout is the timeout of each request.
+	Timeout time.Duration `value:"${spring.app.config-url.timeout:=5s}"`
+
+	// Retries is the number of times a failed request is retried, after
+	// a network error or a server error.
+	Retries int `value:"${spring.app.config-url.retries:=2}"`
+
+	// RetryInterval is the time waited before retrying a request.
+	RetryInterval time.Duration `value:"${spring.app.config-url.retry-interval:=1s}"`
+}
+
+// isHTTPURL reports whether the file is an http or https url.
+func isHTTPURL(file string) bool {
+	return strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://")
+}
+
+// loadFile loads the config file, fetching it if it's an http or https
+// url, see fetchFile.
+func loadFile(ctx context.Context, file string, profiles []string, cfg URLFileConfig) (*conf.MutableProperties, error) {
+	if isHTTPURL(file) {
+		return fetchFile(ctx, file, profiles, cfg)
+	}
+	return conf.Load(file, profiles...)
+}
+
+// fetchFile fetches the config file at the url and parses it like
+// conf.Load. The url may end with the checksum of the file, in the form
+// "#sha256=<hex>" or "#sha512=<hex>", which is verified before parsing.
+// A file not found is an error wrapping os.ErrNotExist, so that it's
+// skipped like a local file that doesn't exist, unless its checksum is
+// given, since a file pinned that way is expected to exist.
+func fetchFile(ctx context.Context, file string, profiles []string, cfg URLFileConfig) (*conf.MutableProperties, error) {
+	u, err := url.Parse(file)
+	if err != nil {
+		return nil, util.FormatError(err, "invalid config url %s", file)
+	}
+	checksum := u.Fragment
+	u.Fragment = ""
+	name := u.String()
+
+	var body []byte
+	var resp *http.Response
+	for i := 0; ; i++ {
+		var retry bool
+		if resp, body, retry, err = get(ctx, name, cfg.Timeout); err == nil || !retry || i >= cfg.Retries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, util.FormatError(ctx.Err(), "fetch config %s error", name)
+		case <-time.After(cfg.RetryInterval):
+		}
+	}
+	if err != nil {
+		if checksum != "" && errors.Is(err, os.ErrNotExist) {
+			return nil, util.FormatError(nil, "fetch config %s error: not found, but its checksum is given", name)
+		}
+		return nil, util.FormatError(err, "fetch config %s error", name)
+	}
+
+	if checksum != "" {
+		if err = verifyChecksum(body, checksum); err != nil {
+			return nil, util.FormatError(err, "fetch config %s error", name)
+		}
+	}
+	ext := path.Ext(u.Path)
+	if ext == "" {
+		ext = contentFormat(resp)
+	}
+	p, err := conf.Parse(body, ext, name, profiles...)
+	if err != nil {
+		return nil, util.FormatError(err, "parse config %s error", name)
+	}
+	return p, nil
+}
+
+// get sends a GET request to the url, and returns the response with its
+// body, or an error and whether the request may be retried.
+func get(ctx context.Context, target string, timeout time.Duration) (*http.Response, []byte, bool, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil, false, util.FormatError(os.ErrNotExist, "status %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return nil, nil, retry, util.FormatError(nil, "status %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	return resp, body, false, nil
+}
+
+// verifyChecksum verifies the checksum of the body, which is given as
+// "sha256=<hex>" or "sha512=<hex>".
+func verifyChecksum(body []byte, checksum string) error {
+	algo, want, _ := strings.Cut(checksum, "=")
+	var h hash.Hash
+	switch algo {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return util.FormatError(nil, "unsupported checksum %s", checksum)
+	}
+	h.Write(body)
+	got := h.Sum(nil)
+	if w, err := hex.DecodeString(want); err != nil || !bytes.Equal(w, got) {
+		return util.FormatError(nil, "checksum mismatch: expected %s, got %s=%s", checksum, algo, hex.EncodeToString(got))
+	}
+	return nil
+}
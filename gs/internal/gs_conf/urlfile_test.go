@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestURLFile(t *testing.T) {
+
+	const body = "a: 1\nb:\n  c: 2\n"
+	sum := sha256.Sum256([]byte(body))
+
+	var failures atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.yaml":
+			if failures.Load() > 0 {
+				failures.Add(-1)
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		case "/app":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"a":"json"}`))
+		case "/bad.properties":
+			http.Error(w, "bad request", http.StatusBadRequest)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer svr.Close()
+
+	// load loads the files added to a new PropertySources.
+	load := func(files ...string) ([]*NamedPropertyCopier, error) {
+		ps := NewPropertySources(ConfigTypeLocal, "app")
+		for _, file := range files {
+			ps.AddFile(file)
+		}
+		return ps.LoadFiles(conf.Map(map[string]any{
+			"spring.app.config-local.dir":          t.TempDir(),
+			"spring.app.config-url.retry-interval": "1ms",
+		}))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		sources, err := load(svr.URL+"/app.yaml#sha256="+hex.EncodeToString(sum[:]), svr.URL+"/app")
+		assert.That(t, err).Nil()
+		assert.That(t, len(sources)).Equal(2)
+		assert.That(t, sources[0].Name).Equal(svr.URL + "/app.yaml#sha256=" + hex.EncodeToString(sum[:]))
+		p := sources[0].PropertyCopier.(conf.Properties)
+		assert.That(t, p.Get("b.c")).Equal("2")
+		p = sources[1].PropertyCopier.(conf.Properties)
+		assert.That(t, p.Get("a")).Equal("json")
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		failures.Store(2)
+		sources, err := load(svr.URL + "/app.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, len(sources)).Equal(1)
+
+		failures.Store(3)
+		_, err = load(svr.URL + "/app.yaml")
+		assert.Error(t, err).Matches("fetch config .*/app.yaml error: status 503")
+		failures.Store(0)
+	})
+
+	t.Run("not retried", func(t *testing.T) {
+		_, err := load(svr.URL + "/bad.properties")
+		assert.Error(t, err).Matches("fetch config .*/bad.properties error: status 400")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		sources, err := load(svr.URL + "/none.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, len(sources)).Equal(0)
+
+		// a file pinned by its checksum is never skipped
+		_, err = load(svr.URL + "/none.yaml#sha256=" + hex.EncodeToString(sum[:]))
+		assert.Error(t, err).Matches("fetch config .*/none.yaml error: not found, but its checksum is given")
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		_, err := load(svr.URL + "/app.yaml#sha256=00")
+		assert.Error(t, err).Matches("checksum mismatch: expected sha256=00, got sha256=" + hex.EncodeToString(sum[:]))
+		_, err = load(svr.URL + "/app.yaml#md5=00")
+		assert.Error(t, err).Matches("unsupported checksum md5=00")
+	})
+}
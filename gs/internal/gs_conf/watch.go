@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"maps"
+	"os"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// fileState is the state of a file used to detect its changes.
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// stat returns the states of the local candidate files that exist, and of
+// the local paths imported by the last LoadFiles.
+func (p *PropertySources) stat(resolver conf.Properties) (map[string]fileState, error) {
+	files, err := p.candidateFiles(resolver)
+	if err != nil {
+		return nil, err
+	}
+	p.importMutex.Lock()
+	files = append(files, p.importPaths...)
+	p.importMutex.Unlock()
+	ret := make(map[string]fileState)
+	for _, file := range files {
+		if isHTTPURL(file) {
+			continue
+		}
+		info, err := osStat(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		ret[file] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+	}
+	return ret, nil
+}
+
+// statLocal returns the states of the local configuration files and of
+// the files in the Kubernetes volumes.
+func (c *AppConfig) statLocal(resolver conf.Properties) (map[string]fileState, error) {
+	ret, err := c.LocalFile.stat(resolver)
+	if err != nil {
+		return nil, err
+	}
+	kube, err := c.KubeDirs.stat(resolver)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(ret, kube)
+	return ret, nil
+}
+
+// WatchLocal watches the local configuration files and the Kubernetes
+// volumes for changes, including created and deleted files, and sends the
+// properties refreshed from all layers on the returned channel after each
+// change. The channel is closed when ctx is done.
+//
+// Files are polled every "spring.app.config-local.watch-interval" (1s by
+// default), which works on any file system, including network ones that
+// don't deliver file events. The config directory and the active profiles
+// are resolved once, when the watch starts. A refresh that fails, e.g. on
+// a partially written file, is logged and retried on the next change.
+func (c *AppConfig) WatchLocal(ctx context.Context) (<-chan conf.Properties, error) {
+	p, err := new(SysConfig).Refresh()
+	if err != nil {
+		return nil, util.WrapError(err, "refresh error in source sys")
+	}
+
+	var interval time.Duration
+	if err = p.Bind(&interval, "${spring.app.config-local.watch-interval:=1s}"); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, util.FormatError(nil, "invalid watch interval %s", interval)
+	}
+
+	last, err := c.statLocal(p)
+	if err != nil {
+		return nil, util.WrapError(err, "watch error in source local")
+	}
+
+	ch := make(chan conf.Properties)
+	goutil.Go(ctx, func(ctx context.Context) {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			curr, err := c.statLocal(p)
+			if err != nil {
+				log.Warnf(ctx, log.TagAppDef, "watch local config files error: %v", err)
+				continue
+			}
+			if maps.Equal(curr, last) {
+				continue
+			}
+			last = curr
+
+			prop, err := c.Refresh()
+			if err != nil {
+				log.Warnf(ctx, log.TagAppDef, "refresh changed local config files error: %v", err)
+				continue
+			}
+			select {
+			case ch <- prop:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return ch, nil
+}
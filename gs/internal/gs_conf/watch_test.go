@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+)
+
+func TestWatchLocal(t *testing.T) {
+	clean()
+
+	// receive waits for the next refreshed properties.
+	receive := func(t *testing.T, ch <-chan conf.Properties) conf.Properties {
+		t.Helper()
+		select {
+		case p, ok := <-ch:
+			assert.That(t, ok).True()
+			return p
+		case <-time.After(5 * time.Second):
+			t.Fatal("no properties received")
+			return nil
+		}
+	}
+
+	t.Run("invalid interval", func(t *testing.T) {
+		t.Cleanup(clean)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_WATCH-INTERVAL", "0s")
+		_, err := NewAppConfig().WatchLocal(t.Context())
+		assert.Error(t, err).Matches("invalid watch interval 0s")
+	})
+
+	t.Run("local dir resolve error", func(t *testing.T) {
+		t.Cleanup(clean)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_DIR", "${a}")
+		_, err := NewAppConfig().WatchLocal(t.Context())
+		assert.Error(t, err).Matches(`watch error in source local`)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Cleanup(clean)
+		dir := t.TempDir()
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_DIR", dir)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG-LOCAL_WATCH-INTERVAL", "10ms")
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+
+		file := filepath.Join(dir, "app.properties")
+		assert.That(t, os.WriteFile(file, []byte("a=1"), 0644)).Nil()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		ch, err := NewAppConfig().WatchLocal(ctx)
+		assert.That(t, err).Nil()
+
+		// modified file
+		assert.That(t, os.WriteFile(file, []byte("a=22"), 0644)).Nil()
+		p := receive(t, ch)
+		assert.That(t, p.Get("a")).Equal("22")
+
+		// created profile file
+		devFile := filepath.Join(dir, "app-dev.yaml")
+		assert.That(t, os.WriteFile(devFile, []byte("a: 3"), 0644)).Nil()
+		p = receive(t, ch)
+		assert.That(t, p.Get("a")).Equal("3")
+
+		// a broken file is skipped until it's fixed
+		assert.That(t, os.WriteFile(devFile, []byte("a: [3"), 0644)).Nil()
+		time.Sleep(50 * time.Millisecond)
+		assert.That(t, os.Remove(devFile)).Nil()
+		p = receive(t, ch)
+		assert.That(t, p.Get("a")).Equal("22")
+
+		cancel()
+		for range ch { // drained until closed
+		}
+	})
+}
@@ -20,6 +20,9 @@
 package gs_core
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/go-spring/spring-core/conf"
 	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
 	"github.com/go-spring/spring-core/gs/internal/gs_core/resolving"
@@ -32,6 +35,18 @@ import (
 type Container struct {
 	*resolving.Resolving
 	*injecting.Injecting
+	wiring atomic.Pointer[injecting.Injecting]
+	autos  []resolving.AutoConfigOutcome
+	phases []PhaseTiming
+}
+
+// PhaseTiming is the time range of a phase of the container refresh, with
+// the steps of the phase, if recorded.
+type PhaseTiming struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+	Steps []PhaseTiming
 }
 
 // New creates and returns a new IoC container instance.
@@ -45,17 +60,62 @@ func New() *Container {
 func (c *Container) Refresh(p conf.Properties) error {
 
 	// Step 1: Resolve and prepare all bean definitions.
+	start := time.Now()
 	if err := c.Resolving.Refresh(p); err != nil {
 		return err
 	}
+	resolve := PhaseTiming{Name: "resolve", Start: start, End: time.Now()}
+	for _, s := range c.Resolving.Steps() {
+		resolve.Steps = append(resolve.Steps, PhaseTiming{Name: s.Name, Start: s.Start, End: s.End})
+	}
+	c.phases = []PhaseTiming{resolve}
 
 	// Step 2: Run the injecting phase and perform dependency wiring.
+	start = time.Now()
 	c.Injecting = injecting.New(p)
+	c.wiring.Store(c.Injecting)
 	if err := c.Injecting.Refresh(c.Roots(), c.Beans()); err != nil {
 		return err
 	}
+	c.phases = append(c.phases, PhaseTiming{Name: "inject", Start: start, End: time.Now()})
 
 	// Clear the resolving phase reference to free resources.
+	c.autos = c.Resolving.AutoConfigOutcomes()
 	c.Resolving = nil
 	return nil
 }
+
+// Validate resolves the bean definitions and checks the wiring of the
+// active beans without constructing them, returning all the wiring errors
+// joined. The container can't be refreshed after being validated.
+func (c *Container) Validate(p conf.Properties) error {
+	if err := c.Resolving.Refresh(p); err != nil {
+		return err
+	}
+	return injecting.New(p).Validate(c.Beans())
+}
+
+// Phases returns the phases of the refresh completed so far, in their
+// running order.
+func (c *Container) Phases() []PhaseTiming {
+	return c.phases
+}
+
+// AutoConfigOutcomes returns the auto-configurations evaluated during
+// refresh, together with their results.
+func (c *Container) AutoConfigOutcomes() []resolving.AutoConfigOutcome {
+	if c.Resolving != nil {
+		return c.Resolving.AutoConfigOutcomes()
+	}
+	return c.autos
+}
+
+// WiringPath returns the chain of beans currently being wired, or an empty
+// string if wiring has not started or no bean is being wired. Unlike the
+// other methods, it is safe to call while Refresh is running.
+func (c *Container) WiringPath() string {
+	if i := c.wiring.Load(); i != nil {
+		return i.WiringPath()
+	}
+	return ""
+}
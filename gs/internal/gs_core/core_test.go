@@ -38,6 +38,23 @@ func TestContainer(t *testing.T) {
 		c.Close()
 	})
 
+	t.Run("auto-config report", func(t *testing.T) {
+		c := New()
+		c.AutoConfig("server", []gs.Condition{
+			gs_cond.OnMissingBean[*http.Server](),
+		}, func(p conf.Properties) error {
+			c.Root(c.Object(&http.Server{}))
+			return nil
+		})
+		err := c.Refresh(conf.New())
+		assert.That(t, err).Nil()
+		assert.That(t, c.Resolving).Nil()
+		report := c.AutoConfigOutcomes()
+		assert.That(t, len(report)).Equal(1)
+		assert.String(t, report[0].String()).Equal("auto-config server: matched")
+		c.Close()
+	})
+
 	t.Run("resolve error", func(t *testing.T) {
 		c := New()
 		c.Root(c.Object(&http.Server{}).Condition(
@@ -77,6 +94,21 @@ func TestContainer(t *testing.T) {
 		assert.That(t, err).Nil()
 	})
 
+	t.Run("wiring path", func(t *testing.T) {
+		c := New()
+		assert.String(t, c.WiringPath()).Equal("")
+
+		var path string
+		c.Root(c.Provide(func() *http.Server {
+			path = c.WiringPath()
+			return &http.Server{}
+		}).Name("server"))
+		err := c.Refresh(conf.New())
+		assert.That(t, err).Nil()
+		assert.String(t, path).Matches("^=> name=server .* ↩$")
+		assert.String(t, c.WiringPath()).Equal("")
+	})
+
 	t.Run("provide with missing dependency", func(t *testing.T) {
 		c := New()
 
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package injecting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+)
+
+// Graph is the dependency graph of the beans wired during refresh.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a bean of the graph, identified by its index in Nodes.
+type GraphNode struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	FileLine string `json:"fileLine,omitempty"`
+}
+
+// GraphEdge means that the bean From was injected with the bean To.
+type GraphEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// Graph returns the dependency graph of the beans wired during refresh,
+// in the order the beans finished wiring.
+func (c *Injecting) Graph() *Graph {
+	g := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	ids := make(map[*gs_bean.BeanDefinition]int)
+	node := func(b *gs_bean.BeanDefinition) int {
+		if id, ok := ids[b]; ok {
+			return id
+		}
+		id := len(g.Nodes)
+		ids[b] = id
+		g.Nodes = append(g.Nodes, GraphNode{
+			ID:       id,
+			Name:     b.Name(),
+			Type:     b.Type().String(),
+			FileLine: b.FileLine(),
+		})
+		return id
+	}
+	for _, t := range c.timings {
+		node(t.Bean)
+	}
+	for _, d := range c.deps {
+		g.Edges = append(g.Edges, GraphEdge{From: node(d.From), To: node(d.To)})
+	}
+	return g
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language, each bean being
+// labeled with its name and type.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph beans {"); err != nil {
+		return err
+	}
+	for _, n := range g.Nodes {
+		label := strconv.Quote(n.Name + "\n" + n.Type)
+		if _, err := fmt.Fprintf(w, "  n%d [label=%s];\n", n.ID, label); err != nil {
+			return err
+		}
+	}
+	for _, e := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  n%d -> n%d;\n", e.From, e.To); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package injecting
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+func TestGraph(t *testing.T) {
+	r := New(conf.New())
+	beans := []*gs.BeanDefinition{
+		objectBean(&SimpleLogger{}).Name("a").DependsOn(gs.BeanSelectorFor[*ZeroLogger]()),
+		objectBean(&ZeroLogger{}),
+	}
+	err := r.Refresh(extractBeans(beans))
+	assert.That(t, err).Nil()
+
+	g := r.Graph()
+	assert.That(t, g.Nodes).Equal([]GraphNode{
+		{ID: 0, Name: "ZeroLogger", Type: "*injecting.ZeroLogger"},
+		{ID: 1, Name: "a", Type: "*injecting.SimpleLogger"},
+	})
+	assert.That(t, g.Edges).Equal([]GraphEdge{{From: 1, To: 0}})
+
+	var buf bytes.Buffer
+	err = g.WriteDOT(&buf)
+	assert.That(t, err).Nil()
+	assert.String(t, buf.String()).Equal(`digraph beans {
+  n0 [label="ZeroLogger\n*injecting.ZeroLogger"];
+  n1 [label="a\n*injecting.SimpleLogger"];
+  n1 -> n0;
+}
+`)
+
+	buf.Reset()
+	err = g.WriteJSON(&buf)
+	assert.That(t, err).Nil()
+	assert.String(t, buf.String()).Equal(`{
+  "nodes": [
+    {
+      "id": 0,
+      "name": "ZeroLogger",
+      "type": "*injecting.ZeroLogger"
+    },
+    {
+      "id": 1,
+      "name": "a",
+      "type": "*injecting.SimpleLogger"
+    }
+  ],
+  "edges": [
+    {
+      "from": 1,
+      "to": 0
+    }
+  ]
+}
+`)
+}
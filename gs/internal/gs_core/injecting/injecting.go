@@ -18,14 +18,19 @@ package injecting
 
 import (
 	"bytes"
+	"cmp"
 	"container/list"
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 	"slices"
-	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-spring/log"
 	"github.com/go-spring/spring-base/util"
@@ -35,6 +40,7 @@ import (
 	"github.com/go-spring/spring-core/gs/internal/gs_bean"
 	"github.com/go-spring/spring-core/gs/internal/gs_dync"
 	"github.com/go-spring/spring-core/gs/internal/gs_util"
+	"github.com/go-spring/spring-core/util/goutil"
 	"github.com/spf13/cast"
 )
 
@@ -46,6 +52,10 @@ type BeanRuntime interface {
 	Interface() any             // The underlying Go interface of the bean
 	Callable() *gs_arg.Callable // Optional constructor or factory metadata
 	Status() gs_bean.BeanStatus // Lifecycle status of the bean
+	Primary() bool              // Preferred when several beans match
+	Fallback() bool             // Used only when no other bean matches
+	Order() (int, bool)         // Position in the injected collections
+	Qualifiers() []string       // Qualifiers selecting the bean in collections
 	String() string             // A readable string representation
 }
 
@@ -56,15 +66,44 @@ const (
 	RefreshDefault = refreshState(iota) // Not refreshed yet
 	Refreshing                          // Currently refreshing
 	Refreshed                           // Successfully refreshed
+	Validating                          // Checking the wiring only
 )
 
 // Injecting is the IoC component that handles dependency injection and
 // lifecycle management for beans once they have been resolved.
 type Injecting struct {
-	p           *gs_dync.Properties            // Dynamic properties provider
-	beansByName map[string][]BeanRuntime       // Beans indexed by name
-	beansByType map[reflect.Type][]BeanRuntime // Beans indexed by type
-	destroyers  []func()                       // Cleanup functions in reverse order
+	p           *gs_dync.Properties              // Dynamic properties provider, nil if released
+	released    atomic.Pointer[propertiesHolder] // Current properties once p is released
+	beansByName map[string][]BeanRuntime         // Beans indexed by name
+	beansByType map[reflect.Type][]BeanRuntime   // Beans indexed by type
+	destroyers  []func()                         // Cleanup functions in reverse order
+	stack       atomic.Pointer[Stack]            // Wiring stack of the running refresh
+	deps        []Dependency                     // Dependencies resolved during refresh
+	timings     []BeanTiming                     // Creation times of the wired beans
+	mutex       sync.Mutex                       // Serializes wiring on demand
+	lazy        *Injector                        // Injector for wiring on demand
+	parallel    atomic.Bool                      // Whether Refresh is wiring beans in parallel
+}
+
+// propertiesHolder wraps properties in order to store them atomically.
+type propertiesHolder struct {
+	conf.Properties
+}
+
+// BeanTiming records how long a bean took to be created, excluding the
+// time spent creating its dependencies.
+type BeanTiming struct {
+	Bean     *gs_bean.BeanDefinition
+	Duration time.Duration
+	Start    time.Time     // when the bean started wiring
+	Elapsed  time.Duration // time spent wiring, including its dependencies
+}
+
+// Dependency represents an edge of the wiring graph: From was injected
+// with To, either as a field, a constructor argument, or via DependsOn.
+type Dependency struct {
+	From *gs_bean.BeanDefinition
+	To   *gs_bean.BeanDefinition
 }
 
 // New creates a new Injecting instance.
@@ -74,11 +113,33 @@ func New(p conf.Properties) *Injecting {
 	}
 }
 
+// Properties returns the current dynamic property source of the container.
+func (c *Injecting) Properties() conf.Properties {
+	if c.p == nil {
+		return c.released.Load().Properties
+	}
+	return c.p.Data()
+}
+
 // RefreshProperties updates the dynamic property source for the container.
 func (c *Injecting) RefreshProperties(p conf.Properties) error {
+	if c.p == nil { // no refreshable objects
+		c.released.Store(&propertiesHolder{p})
+		return nil
+	}
 	return c.p.Refresh(p)
 }
 
+// RefreshPropertiesDiff is like RefreshProperties, with the changes from
+// old to p already computed by gs_dync.Diff, see gs_dync.RefreshDiff.
+func (c *Injecting) RefreshPropertiesDiff(old, p conf.Properties, diff []gs_dync.Change) error {
+	if c.p == nil { // no refreshable objects
+		c.released.Store(&propertiesHolder{p})
+		return nil
+	}
+	return c.p.RefreshDiff(old, p, diff)
+}
+
 // Refresh wires all provided beans and prepares them for use.
 //
 // It performs the following steps:
@@ -89,20 +150,15 @@ func (c *Injecting) RefreshProperties(p conf.Properties) error {
 //  5. Optionally cleans up metadata if running outside testing.
 func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error) {
 	allowCircularReferences := cast.ToBool(c.p.Data().Get("spring.allow-circular-references"))
+	allowBeanLookup := cast.ToBool(c.p.Data().Get("spring.allow-bean-lookup"))
+	parallelInit := cast.ToBool(c.p.Data().Get("spring.parallel-init"))
 	forceAutowireIsNullable := cast.ToBool(c.p.Data().Get("spring.force-autowire-is-nullable"))
 
 	// Index beans by name and type for lookup
-	c.beansByName = make(map[string][]BeanRuntime)
-	c.beansByType = make(map[reflect.Type][]BeanRuntime)
-	for _, b := range beans {
-		c.beansByName[b.Name()] = append(c.beansByName[b.Name()], b)
-		c.beansByType[b.Type()] = append(c.beansByType[b.Type()], b)
-		for _, t := range b.Exports() { // Register additional exported types
-			c.beansByType[t] = append(c.beansByType[t], b)
-		}
-	}
+	c.indexBeans(beans)
 
 	stack := NewStack()
+	c.stack.Store(stack)
 	defer func() {
 		// If an error occurred, or there are unresolved beans in the stack,
 		// enrich the error message with the dependency path for easier debugging.
@@ -118,36 +174,87 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 		beansByName:             c.beansByName,
 		beansByType:             c.beansByType,
 		forceAutowireIsNullable: forceAutowireIsNullable,
+		resolve:                 c.resolve,
+		destroyTimeout:          cast.ToDuration(c.p.Data().Get("spring.destroy-timeout")),
 	}
+	c.lazy = r
 
-	// Step 1: Wire all root beans.
 	r.state = Refreshing
+
+	// Step 1: Wire the bean post-processors before the beans they process.
+	var processors []gs.BeanPostProcessor
+	for _, b := range c.beansByType[reflect.TypeFor[gs.BeanPostProcessor]()] {
+		bd := b.(*gs_bean.BeanDefinition)
+		if err = r.wireBean(bd, stack); err != nil {
+			return err
+		}
+		if v := bd.Value(); v.IsValid() && !v.IsNil() {
+			processors = append(processors, v.Interface().(gs.BeanPostProcessor))
+		}
+	}
+	r.processors = processors
+
+	// Step 2: Wire all root beans, except the lazy ones, in parallel if
+	// enabled, and then serially the ones left by the parallel wiring.
+	if parallelInit {
+		workers := cast.ToInt(c.p.Data().Get("spring.parallel-init-workers", "0"))
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		c.parallel.Store(true)
+		err = r.wireParallel(roots, beans, workers, stack)
+		c.parallel.Store(false)
+		if err != nil {
+			return err
+		}
+	}
 	for _, b := range roots {
+		if b.Lazy() {
+			continue
+		}
 		if err = r.wireBean(b, stack); err != nil {
 			return err
 		}
 	}
 	r.state = Refreshed
 
-	// Step 2: Handle lazy fields caused by circular dependencies.
+	// Step 3: Handle lazy fields caused by circular dependencies.
 	if allowCircularReferences {
 		for _, f := range stack.lazyFields {
 			tag := strings.TrimSuffix(f.tag, ",lazy")
+			if f.bean != nil {
+				stack.pushBean(f.bean)
+			}
 			if err = r.autowire(f.v, tag, stack); err != nil {
 				return err
 			}
+			if f.bean != nil {
+				stack.popBean()
+			}
 		}
 	} else if len(stack.lazyFields) > 0 {
-		return util.FormatError(nil, "found circular autowire")
+		var paths []string
+		for _, f := range stack.lazyFields {
+			paths = append(paths, f.path)
+		}
+		return util.FormatError(nil, "found circular autowire: lazy fields %s "+
+			"need spring.allow-circular-references=true", strings.Join(paths, ", "))
 	}
 
-	// Step 3: Collect destroyer callbacks in dependency-safe order.
-	c.destroyers = stack.getSortedDestroyers()
+	// Step 4: Collect destroyer callbacks in dependency-safe order.
+	c.destroyers = append(stack.getSortedDestroyers(r.destroyTimeout), stack.instances...)
+	c.deps = stack.deps
+	c.timings = stack.timings
 
 	// Optional cleanup in non-testing environments.
 	forceClean := cast.ToBool(c.p.Data().Get("spring.force-clean"))
 	if !testing.Testing() || forceClean {
-		if c.p.ObjectsCount() == 0 {
+		// Without lazy handles or lookups, nothing will be wired on demand.
+		if stack.handles == 0 && !allowBeanLookup {
+			c.lazy = nil
+		}
+		if c.p.ObjectsCount() == 0 && c.lazy == nil {
+			c.released.Store(&propertiesHolder{c.p.Data()})
 			c.p = nil
 		}
 		c.beansByName = nil
@@ -168,6 +275,66 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 	return nil
 }
 
+// indexBeans indexes the beans by name and type for lookup.
+func (c *Injecting) indexBeans(beans []*gs_bean.BeanDefinition) {
+	c.beansByName = make(map[string][]BeanRuntime)
+	c.beansByType = make(map[reflect.Type][]BeanRuntime)
+	for _, b := range beans {
+		c.beansByName[b.Name()] = append(c.beansByName[b.Name()], b)
+		c.beansByType[b.Type()] = append(c.beansByType[b.Type()], b)
+		for _, t := range b.Exports() { // Register additional exported types
+			c.beansByType[t] = append(c.beansByType[t], b)
+		}
+	}
+}
+
+// Validate checks the wiring of the beans without constructing them.
+// It resolves the constructor arguments and the injected and bound fields
+// of each bean, reporting the missing or ambiguous dependencies and the
+// binding errors of all the beans at once. The constructors, the functions
+// bound by [gs_arg.Bind] and the init methods are not called, so the errors
+// they would return, and the circular dependencies, are not detected.
+func (c *Injecting) Validate(beans []*gs_bean.BeanDefinition) error {
+	c.indexBeans(beans)
+	r := &Injector{
+		state:                   Validating,
+		p:                       c.p,
+		beansByName:             c.beansByName,
+		beansByType:             c.beansByType,
+		forceAutowireIsNullable: cast.ToBool(c.p.Data().Get("spring.force-autowire-is-nullable")),
+		resolve:                 c.resolve,
+	}
+	var errs []error
+	for _, b := range beans {
+		if err := r.validateBean(b, NewStack()); err != nil {
+			errs = append(errs, util.FormatError(err, "validate bean %s error", b))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WiringPath returns the chain of beans currently being wired by a running
+// refresh, or an empty string if no bean is being wired. It is safe to call
+// from another goroutine, e.g. to diagnose a refresh that does not return.
+func (c *Injecting) WiringPath() string {
+	if s := c.stack.Load(); s != nil {
+		return s.Path()
+	}
+	return ""
+}
+
+// Dependencies returns the dependencies between beans resolved during
+// the refresh, in the order they were resolved.
+func (c *Injecting) Dependencies() []Dependency {
+	return c.deps
+}
+
+// Timings returns the creation time of every bean wired during refresh,
+// in the order the beans finished wiring.
+func (c *Injecting) Timings() []BeanTiming {
+	return c.timings
+}
+
 // Wire injects dependencies into an externally provided object.
 func (c *Injecting) Wire(obj any) error {
 	r := &Injector{
@@ -176,6 +343,7 @@ func (c *Injecting) Wire(obj any) error {
 		beansByName:             c.beansByName,
 		beansByType:             c.beansByType,
 		forceAutowireIsNullable: true,
+		resolve:                 c.resolve,
 	}
 	stack := NewStack()
 	t := reflect.TypeOf(obj)
@@ -192,10 +360,87 @@ func (c *Injecting) Wire(obj any) error {
 	return nil
 }
 
+// Get returns the bean of type t, or a collection of beans if t is a
+// slice or map, matching the name if it is not empty. The beans are
+// constructed on demand if they are not wired yet, and the request scoped
+// ones are got from the request scope of ctx. Outside testing, it needs
+// spring.allow-bean-lookup=true.
+func (c *Injecting) Get(ctx context.Context, t reflect.Type, name string) (reflect.Value, error) {
+	return c.resolve(ctx, t, name)
+}
+
+// resolve wires a value of type t with the tag on demand, constructing
+// the matched beans and their dependencies if they are not wired yet.
+// Beans wired on demand are destroyed before the ones wired by Refresh,
+// except the prototype beans created in the request scope of ctx, which
+// are destroyed with the scope.
+//
+// The beans can't be looked up while Refresh wires them in parallel, since
+// a lookup isn't seen by the dependency analysis, and may need a bean that
+// another goroutine is creating.
+func (c *Injecting) resolve(ctx context.Context, t reflect.Type, tag string) (reflect.Value, error) {
+	if c.parallel.Load() {
+		return reflect.Value{}, util.FormatError(nil, "can't wire %s on demand while wiring beans in parallel, "+
+			"disable spring.parallel-init to look up beans during the refresh", t)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.lazy == nil {
+		return reflect.Value{}, util.FormatError(nil, "container is not refreshed or doesn't allow bean lookup")
+	}
+
+	r := &Injector{
+		state:                   Refreshing,
+		p:                       c.lazy.p,
+		beansByName:             c.lazy.beansByName,
+		beansByType:             c.lazy.beansByType,
+		forceAutowireIsNullable: c.lazy.forceAutowireIsNullable,
+		resolve:                 c.resolve,
+		scope:                   gs.RequestScopeFrom(ctx),
+		destroyTimeout:          c.lazy.destroyTimeout,
+		processors:              c.lazy.processors,
+	}
+
+	stack := NewStack()
+	v := reflect.New(t).Elem()
+	err := r.autowire(v, tag, stack)
+	if err == nil {
+		for _, f := range stack.lazyFields {
+			if f.bean != nil {
+				stack.pushBean(f.bean)
+			}
+			if err = r.autowire(f.v, strings.TrimSuffix(f.tag, ",lazy"), stack); err != nil {
+				break
+			}
+			if f.bean != nil {
+				stack.popBean()
+			}
+		}
+	}
+	if err != nil {
+		return reflect.Value{}, util.FormatError(err, "wire %s on demand error", t)
+	}
+	c.destroyers = append(c.destroyers, stack.getSortedDestroyers(r.destroyTimeout)...)
+	if r.scope == nil {
+		c.destroyers = append(c.destroyers, stack.instances...)
+		return v, nil
+	}
+	for _, fn := range stack.instances {
+		if err = r.scope.AddDestroyer(fn); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return v, nil
+}
+
 // Close shuts down the container by invoking all registered destroyer
 // callbacks in reverse registration order, ensuring dependent resources
 // are released safely.
 func (c *Injecting) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	for _, f := range slices.Backward(c.destroyers) {
 		f()
 	}
@@ -209,6 +454,11 @@ type Injector struct {
 	beansByName             map[string][]BeanRuntime       // Beans indexed by name
 	beansByType             map[reflect.Type][]BeanRuntime // Beans indexed by type
 	forceAutowireIsNullable bool                           // Treat missing references as nullable
+	resolve                 func(ctx context.Context, t reflect.Type, tag string) (reflect.Value, error)
+	scope                   *gs.RequestScope // Request scope of the wiring on demand
+	destroyTimeout          time.Duration    // Default time limit of destroying a bean
+	processors              []gs.BeanPostProcessor
+	locks                   map[*gs_bean.BeanDefinition]*sync.Mutex // Guards the beans wired in parallel
 }
 
 // findBeans retrieves all beans that match a given selector.
@@ -308,16 +558,36 @@ func (c *Injector) getBean(t reflect.Type, tag WireTag, stack *Stack) (BeanRunti
 		return nil, util.FormatError(nil, "can't find bean, bean:%q type:%q", tag, t)
 	}
 
+	// Prefer the primary bean, or else the only bean that isn't a fallback.
 	if len(foundBeans) > 1 {
-		msg := fmt.Sprintf("found %d beans, bean:%q type:%q [", len(foundBeans), tag, t)
+		var primaries, others []BeanRuntime
 		for _, b := range foundBeans {
-			msg += "( " + b.String() + " ), "
+			if b.Primary() {
+				primaries = append(primaries, b)
+			} else if !b.Fallback() {
+				others = append(others, b)
+			}
+		}
+		switch {
+		case len(primaries) > 1:
+			return nil, ambiguousError("primary beans", primaries, tag, t)
+		case len(primaries) == 1:
+			foundBeans = primaries
+		case len(others) == 1:
+			foundBeans = others
+		default:
+			return nil, ambiguousError("beans", foundBeans, tag, t)
 		}
-		msg = msg[:len(msg)-2] + "]"
-		return nil, util.FormatError(nil, "%s", msg)
 	}
 
 	b := foundBeans[0]
+	stack.addDependency(b)
+	if c.state == Validating {
+		return b, nil
+	}
+	if bd, ok := b.(*gs_bean.BeanDefinition); ok && bd.Scope() != gs.ScopeSingleton {
+		return c.getScopedBean(bd, stack)
+	}
 	if c.state == Refreshing {
 		if err := c.wireBean(b.(*gs_bean.BeanDefinition), stack); err != nil {
 			return nil, err
@@ -326,8 +596,20 @@ func (c *Injector) getBean(t reflect.Type, tag WireTag, stack *Stack) (BeanRunti
 	return b, nil
 }
 
+// ambiguousError returns the error of several beans matching a WireTag.
+func ambiguousError(kind string, beans []BeanRuntime, tag WireTag, t reflect.Type) error {
+	msg := fmt.Sprintf("found %d %s, bean:%q type:%q [", len(beans), kind, tag, t)
+	for _, b := range beans {
+		msg += "( " + b.String() + " ), "
+	}
+	msg = msg[:len(msg)-2] + "]"
+	return util.FormatError(nil, "%s", msg)
+}
+
 // getBeans retrieves a slice or map of beans that match the required element type and optional WireTags.
-// It supports filtering and ordering via tags, including the "*" wildcard to include unordered beans.
+// It supports filtering and ordering via tags, including the "*" wildcard to include unordered beans,
+// and "@qualifier" items to select the beans having any of the qualifiers. The beans not listed by
+// name are sorted by their orders.
 func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool, stack *Stack) ([]BeanRuntime, error) {
 
 	et := t.Elem()
@@ -336,21 +618,25 @@ func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool, stack
 	}
 
 	beans := c.beansByType[et]
+	beans, items := filterByQualifiers(beans, tags)
+
+	// The range of the beans to be sorted by their orders
+	lo, hi := 0, len(beans)
 
 	// Process bean tags to filter and order beans
-	if len(tags) > 0 {
+	if len(items) > 0 {
 		var (
 			anyBeans  []int // indices of beans to be placed in the '*' section
 			afterAny  []int // beans to appear after the '*'
 			beforeAny []int // beans to appear before the '*'
 		)
 		foundAny := false
-		for _, item := range tags {
+		for _, item := range items {
 
 			// If we see the "*" wildcard, record its presence
 			if item.beanName == "*" {
 				if foundAny {
-					return nil, util.FormatError(nil, "more than one * in collection %q", tags)
+					return nil, util.FormatError(nil, "more than one * in collection %q", items)
 				}
 				foundAny = true
 				continue
@@ -403,6 +689,7 @@ func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool, stack
 
 		// Assemble beans in the correct order: beforeAny -> anyBeans -> afterAny
 		n := len(beforeAny) + len(anyBeans) + len(afterAny)
+		lo, hi = len(beforeAny), len(beforeAny)+len(anyBeans)
 		arr := make([]BeanRuntime, 0, n)
 		for _, i := range beforeAny {
 			arr = append(arr, beans[i])
@@ -425,6 +712,13 @@ func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool, stack
 		return nil, util.FormatError(nil, "no beans collected for %q", toWireString(tags))
 	}
 
+	for _, b := range beans {
+		stack.addDependency(b)
+	}
+	if c.state == Validating {
+		return beans, nil
+	}
+
 	// If the container is in the refreshing state, wire the beans before returning them
 	if c.state == Refreshing {
 		for _, b := range beans {
@@ -433,9 +727,85 @@ func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool, stack
 			}
 		}
 	}
+
+	// Replace the scoped beans with their instances, without changing the index
+	beans = slices.Clone(beans)
+	for i, b := range beans {
+		if bd, ok := b.(*gs_bean.BeanDefinition); ok && bd.Scope() != gs.ScopeSingleton {
+			r, err := c.getScopedBean(bd, stack)
+			if err != nil {
+				return nil, err
+			}
+			beans[i] = r
+		}
+	}
+	sortByOrder(beans[lo:hi])
 	return beans, nil
 }
 
+// filterByQualifiers returns the beans having any of the qualifiers in
+// the tags, or all the beans if there is none, and the other tags.
+func filterByQualifiers(beans []BeanRuntime, tags []WireTag) ([]BeanRuntime, []WireTag) {
+	var (
+		qualifiers []string
+		items      []WireTag
+	)
+	for _, tag := range tags {
+		if q, ok := strings.CutPrefix(tag.beanName, "@"); ok {
+			qualifiers = append(qualifiers, q)
+		} else {
+			items = append(items, tag)
+		}
+	}
+	if len(qualifiers) == 0 {
+		return beans, items
+	}
+	var ret []BeanRuntime
+	for _, b := range beans {
+		for _, q := range b.Qualifiers() {
+			if slices.Contains(qualifiers, q) {
+				ret = append(ret, b)
+				break
+			}
+		}
+	}
+	return ret, items
+}
+
+// orderOf returns the order set at the registration of the bean, or the
+// one returned by its Order method.
+func orderOf(b BeanRuntime) (int, bool) {
+	if n, ok := b.Order(); ok {
+		return n, true
+	}
+	if v := b.Value(); v.IsValid() && v.CanInterface() {
+		if o, ok := v.Interface().(gs.Ordered); ok && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+			return o.Order(), true
+		}
+	}
+	return 0, false
+}
+
+// sortByOrder sorts the beans by their orders, with the beans having no
+// order last, and by their names for a deterministic order.
+func sortByOrder(beans []BeanRuntime) {
+	slices.SortStableFunc(beans, func(a, b BeanRuntime) int {
+		x, okX := orderOf(a)
+		y, okY := orderOf(b)
+		switch {
+		case okX && okY:
+			if r := cmp.Compare(x, y); r != 0 {
+				return r
+			}
+		case okX:
+			return -1
+		case okY:
+			return 1
+		}
+		return strings.Compare(a.Name(), b.Name())
+	})
+}
+
 // autowire injects dependencies into a single field or a collection (slice/map) based on its kind and tag.
 func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 	// Resolve placeholder expressions (e.g., ${...}) from configuration
@@ -482,10 +852,6 @@ func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 			// Populate the collection field with the resolved beans
 			switch v.Kind() {
 			case reflect.Slice:
-				// Sort beans by name for deterministic order
-				sort.Slice(beans, func(i, j int) bool {
-					return beans[i].Name() < beans[j].Name()
-				})
 				ret := reflect.MakeSlice(v.Type(), 0, 0)
 				for _, b := range beans {
 					ret = reflect.Append(ret, b.Value())
@@ -522,19 +888,15 @@ func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 // injected, initialized, and registered for destruction.
 func (c *Injector) wireBean(b *gs_bean.BeanDefinition, stack *Stack) error {
 
-	haveDestroy := false
-
-	// Ensure that the destroyer is popped from the stack
-	defer func() {
-		if haveDestroy {
-			stack.popDestroyer()
-		}
-	}()
+	// Scoped beans are created per injection, not wired as a whole.
+	if b.Scope() != gs.ScopeSingleton {
+		return nil
+	}
 
-	// If the bean has a destroy callback, record it for later execution
-	if b.Destroy() != nil {
-		haveDestroy = true
-		stack.pushDestroyer(b)
+	// Wait for the goroutine wiring the bean in parallel, if any
+	if l := c.locks[b]; l != nil && !slices.Contains(stack.beans, b) {
+		l.Lock()
+		defer l.Unlock()
 	}
 
 	stack.pushBean(b)
@@ -542,7 +904,7 @@ func (c *Injector) wireBean(b *gs_bean.BeanDefinition, stack *Stack) error {
 	// Detect circular dependencies
 	if b.Status() == gs_bean.StatusCreating && b.Callable() != nil {
 		if slices.Contains(stack.beans, b) {
-			return util.FormatError(nil, "found circular autowire")
+			return circularError(stack.cyclePath(b))
 		}
 	}
 
@@ -555,10 +917,15 @@ func (c *Injector) wireBean(b *gs_bean.BeanDefinition, stack *Stack) error {
 	// Mark the bean as currently being created
 	b.SetStatus(gs_bean.StatusCreating)
 
+	// Time the bean, excluding the dependencies wired in between
+	start, nested := time.Now(), stack.nested
+	stack.nested = 0
+
 	// Wire all dependent beans before creating the current bean
 	for _, s := range b.DependsOn() {
 		beans := c.findBeans(s)
 		for _, d := range beans {
+			stack.addDependency(d)
 			err := c.wireBean(d.(*gs_bean.BeanDefinition), stack)
 			if err != nil {
 				return err
@@ -583,21 +950,363 @@ func (c *Injector) wireBean(b *gs_bean.BeanDefinition, stack *Stack) error {
 		}
 
 		// Invoke the bean's initialization method if defined
-		if b.Init() != nil {
-			fnValue := reflect.ValueOf(b.Init())
-			out := fnValue.Call([]reflect.Value{b.Value()})
-			if len(out) > 0 && !out[0].IsNil() {
-				return out[0].Interface().(error)
-			}
+		if err = c.initBean(b, b.Value()); err != nil {
+			return err
+		}
+
+		// Record the bean to be destroyed on Close
+		if destroyFunc(b, b.Value()) != nil {
+			stack.destroyables = append(stack.destroyables, b)
 		}
 	}
 
 	// Mark the bean as fully wired and remove it from the stack
 	b.SetStatus(gs_bean.StatusWired)
+	elapsed := time.Since(start)
+	stack.timings = append(stack.timings, BeanTiming{
+		Bean:     b,
+		Duration: elapsed - stack.nested,
+		Start:    start,
+		Elapsed:  elapsed,
+	})
+	stack.nested = nested + elapsed
 	stack.popBean()
 	return nil
 }
 
+// wireParallel wires the beans reachable from the roots level by level in
+// the topological order of their dependencies, the beans of a level by at
+// most n goroutines at once. The results of the goroutines are merged into
+// the stack in the order of the beans, and the error of the first failed
+// bean of a level is returned, so that the outcome doesn't depend on the
+// scheduling. The beans on dependency cycles, and the ones depending on
+// them, are left to be wired serially.
+func (c *Injector) wireParallel(roots, beans []*gs_bean.BeanDefinition, n int, stack *Stack) error {
+	levels, err := c.sortLevels(roots)
+	if err != nil {
+		log.Warnf(context.Background(), log.TagAppDef, "parallel wiring disabled: %v", err)
+		return nil
+	}
+
+	// A dependency not found by sortLevels is wired by the first goroutine
+	// needing it, while the others wait. The lookups are rejected meanwhile,
+	// see Injecting.resolve.
+	c.locks = make(map[*gs_bean.BeanDefinition]*sync.Mutex, len(beans))
+	for _, b := range beans {
+		c.locks[b] = new(sync.Mutex)
+	}
+	defer func() { c.locks = nil }()
+
+	for i, level := range levels {
+		start := time.Now()
+		stacks := make([]*Stack, len(level))
+		errs := make([]error, len(level))
+		g := goutil.NewGroup(context.Background())
+		g.SetLimit(n)
+		for j, b := range level {
+			stacks[j] = NewStack()
+			g.Go(func(context.Context) error {
+				errs[j] = c.wireBean(b, stacks[j])
+				return errs[j]
+			})
+		}
+		gErr := g.Wait()
+		for j, s := range stacks {
+			stack.merge(s)
+			if errs[j] != nil {
+				return errs[j]
+			}
+		}
+		if gErr != nil { // a panic
+			return gErr
+		}
+		log.Debugf(context.Background(), log.TagAppDef, "wired level %d (%d beans) in %s",
+			i, len(level), time.Since(start))
+	}
+	return nil
+}
+
+// sortLevels groups the beans reachable from the roots, except the lazy
+// ones, into levels so that the beans of a level only depend on the beans
+// of the previous levels. The dependencies are found by validating the
+// beans, see [Injecting.Validate]. The beans on dependency cycles, and the
+// ones depending on them, are not in any level.
+func (c *Injector) sortLevels(roots []*gs_bean.BeanDefinition) ([][]*gs_bean.BeanDefinition, error) {
+
+	// Validate the beans without registering their fields for refresh
+	v := *c
+	v.state = Validating
+	v.p = gs_dync.New(c.p.Data())
+
+	var order []*gs_bean.BeanDefinition
+	deps := make(map[*gs_bean.BeanDefinition][]*gs_bean.BeanDefinition)
+
+	var visit func(b *gs_bean.BeanDefinition) error
+	visit = func(b *gs_bean.BeanDefinition) error {
+		if _, ok := deps[b]; ok {
+			return nil
+		}
+		s := NewStack()
+		if err := v.validateBean(b, s); err != nil {
+			return util.FormatError(err, "validate bean %s error", b)
+		}
+		var ds []*gs_bean.BeanDefinition
+		for _, d := range s.deps {
+			ds = append(ds, d.To)
+		}
+		for _, selector := range b.DependsOn() {
+			for _, d := range c.findBeans(selector) {
+				ds = append(ds, d.(*gs_bean.BeanDefinition))
+			}
+		}
+		deps[b] = ds
+		order = append(order, b)
+		for _, d := range ds {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, b := range roots {
+		if b.Lazy() {
+			continue
+		}
+		if err := visit(b); err != nil {
+			return nil, err
+		}
+	}
+
+	var levels [][]*gs_bean.BeanDefinition
+	placed := make(map[*gs_bean.BeanDefinition]bool)
+	for {
+		var level []*gs_bean.BeanDefinition
+		for _, b := range order {
+			if placed[b] {
+				continue
+			}
+			ready := true
+			for _, d := range deps[b] {
+				if d != b && !placed[d] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				level = append(level, b)
+			}
+		}
+		if len(level) == 0 {
+			return levels, nil
+		}
+		for _, b := range level {
+			placed[b] = true
+		}
+		levels = append(levels, level)
+	}
+}
+
+// scopedBean is an instance of a prototype or request scoped bean.
+type scopedBean struct {
+	*gs_bean.BeanDefinition
+	v reflect.Value
+}
+
+// Value returns the instance as reflect.Value.
+func (b *scopedBean) Value() reflect.Value {
+	return b.v
+}
+
+// Interface returns the underlying instance.
+func (b *scopedBean) Interface() any {
+	return b.v.Interface()
+}
+
+// getScopedBean returns the instance of a prototype bean created for the
+// injection, or the instance of a request scoped bean for the request
+// scope of the wiring on demand.
+func (c *Injector) getScopedBean(b *gs_bean.BeanDefinition, stack *Stack) (BeanRuntime, error) {
+	if b.Scope() == gs.ScopeRequest {
+		if c.scope == nil {
+			return nil, util.FormatError(nil, "request scoped bean %s must be got by a factory in a request scope", b)
+		}
+		if v, ok := c.scope.Load(b); ok {
+			return &scopedBean{BeanDefinition: b, v: v}, nil
+		}
+	}
+	v, destroy, err := c.newInstance(b, stack)
+	if err != nil {
+		return nil, err
+	}
+	if b.Scope() == gs.ScopeRequest {
+		if err = c.scope.Store(b, v, destroy); err != nil {
+			return nil, err
+		}
+	} else if destroy != nil {
+		stack.instances = append(stack.instances, destroy)
+	}
+	return &scopedBean{BeanDefinition: b, v: v}, nil
+}
+
+// newInstance creates, injects and initializes a new instance of a scoped
+// bean, and returns its destroy callback if the bean has one.
+func (c *Injector) newInstance(b *gs_bean.BeanDefinition, stack *Stack) (reflect.Value, func(), error) {
+
+	// Detect circular dependencies
+	if slices.Contains(stack.beans, b) {
+		return reflect.Value{}, nil, circularError(stack.cyclePath(b))
+	}
+
+	stack.pushBean(b)
+
+	// Wire all dependent beans before creating the instance
+	for _, s := range b.DependsOn() {
+		for _, d := range c.findBeans(s) {
+			stack.addDependency(d)
+			if err := c.wireBean(d.(*gs_bean.BeanDefinition), stack); err != nil {
+				return reflect.Value{}, nil, err
+			}
+		}
+	}
+
+	dst := reflect.New(b.Type()).Elem()
+	v, err := c.construct(b, dst, stack)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	if !v.IsValid() {
+		stack.popBean()
+		return v, nil, nil
+	}
+
+	if b.Mocked() {
+		stack.popBean()
+		return dst, nil, nil
+	}
+
+	if err = c.wireBeanValue(v, v.Type(), stack); err != nil {
+		return reflect.Value{}, nil, err
+	}
+	if err = c.initBean(b, dst); err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	stack.popBean()
+
+	var destroy func()
+	if fn := destroyFunc(b, dst); fn != nil {
+		destroy = destroyWithTimeout(b, fn, c.destroyTimeout)
+	}
+	return dst, destroy, nil
+}
+
+// initBean initializes v, the value of the bean b, by the init function
+// of the bean, or else by [gs.Initializing], and lets the bean
+// post-processors process it before and after.
+func (c *Injector) initBean(b *gs_bean.BeanDefinition, v reflect.Value) error {
+	for _, p := range c.processors {
+		r, err := p.BeforeInit(v.Interface(), b.Name())
+		if err != nil {
+			return err
+		}
+		if err = replaceBean(b, v, r); err != nil {
+			return err
+		}
+	}
+	if fn := b.Init(); fn != nil {
+		out := reflect.ValueOf(fn).Call([]reflect.Value{v})
+		if len(out) > 0 && !out[0].IsNil() {
+			return out[0].Interface().(error)
+		}
+	} else if i, ok := v.Interface().(gs.Initializing); ok {
+		if err := i.PostConstruct(); err != nil {
+			return err
+		}
+	}
+	for _, p := range c.processors {
+		r, err := p.AfterInit(v.Interface(), b.Name())
+		if err != nil {
+			return err
+		}
+		if err = replaceBean(b, v, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceBean replaces v, the value of the bean b, with the bean r
+// returned by a bean post-processor, if r is another bean.
+func replaceBean(b *gs_bean.BeanDefinition, v reflect.Value, r any) error {
+	rv := reflect.ValueOf(r)
+	if !rv.IsValid() {
+		return util.FormatError(nil, "post-processor returns nil for bean %s", b)
+	}
+	cur := v
+	if cur.Kind() == reflect.Interface {
+		cur = cur.Elem()
+	}
+	if rv.Type() == cur.Type() && util.IsBeanType(rv.Type()) && rv.Pointer() == cur.Pointer() {
+		return nil
+	}
+	if !rv.Type().AssignableTo(v.Type()) {
+		return util.FormatError(nil, "post-processor returns %s not assignable to bean %s", rv.Type(), b)
+	}
+	if !v.CanSet() {
+		return util.FormatError(nil, "post-processor can't replace bean %s registered as an object", b)
+	}
+	v.Set(rv)
+	return nil
+}
+
+// destroyFunc returns the function destroying v, the value of the bean b,
+// by the destroy function of the bean, or else by [gs.Disposable]. It
+// returns nil if the bean has nothing to destroy.
+func destroyFunc(b *gs_bean.BeanDefinition, v reflect.Value) func() error {
+	if fn := b.Destroy(); fn != nil {
+		return func() error {
+			out := reflect.ValueOf(fn).Call([]reflect.Value{v})
+			if len(out) > 0 && !out[0].IsNil() {
+				return out[0].Interface().(error)
+			}
+			return nil
+		}
+	}
+	if !v.IsValid() || v.IsNil() {
+		return nil
+	}
+	if d, ok := v.Interface().(gs.Disposable); ok {
+		return d.PreDestroy
+	}
+	return nil
+}
+
+// destroyWithTimeout returns the function calling fn to destroy the bean
+// b and logging its error. It stops waiting for fn after the timeout of
+// the bean, or else the given timeout, if positive.
+func destroyWithTimeout(b *gs_bean.BeanDefinition, fn func() error, timeout time.Duration) func() {
+	if t := b.DestroyTimeout(); t > 0 {
+		timeout = t
+	}
+	return func() {
+		var err error
+		if timeout <= 0 {
+			err = fn()
+		} else {
+			done := make(chan error, 1)
+			go func() { done <- fn() }()
+			select {
+			case err = <-done:
+			case <-time.After(timeout):
+				err = util.FormatError(nil, "destroy %s timeout after %s", b, timeout)
+			}
+		}
+		if err != nil {
+			log.Errorf(context.Background(), log.TagAppDef, "%v", err)
+		}
+	}
+}
+
 // getBeanValue invokes the constructor (if present) of a bean and handles return values and errors.
 func (c *Injector) getBeanValue(b BeanRuntime, stack *Stack) (reflect.Value, error) {
 
@@ -605,6 +1314,12 @@ func (c *Injector) getBeanValue(b BeanRuntime, stack *Stack) (reflect.Value, err
 	if b.Callable() == nil {
 		return b.Value(), nil
 	}
+	return c.construct(b, b.Value(), stack)
+}
+
+// construct invokes the constructor of a bean, stores the returned value
+// into dst, and returns the value with interfaces unwrapped.
+func (c *Injector) construct(b BeanRuntime, dst reflect.Value, stack *Stack) (reflect.Value, error) {
 
 	// Invoke the constructor
 	out, err := b.Callable().Call(NewArgContext(c, stack))
@@ -633,30 +1348,72 @@ func (c *Injector) getBeanValue(b BeanRuntime, stack *Stack) (reflect.Value, err
 		if !val.IsNil() && val.Kind() == reflect.Interface && util.IsPropBindingTarget(val.Elem().Type()) {
 			v := reflect.New(val.Elem().Type())
 			v.Elem().Set(val.Elem())
-			b.Value().Set(v)
+			dst.Set(v)
 		} else {
-			b.Value().Set(val)
+			dst.Set(val)
 		}
 	} else {
-		b.Value().Elem().Set(val)
+		dst.Elem().Set(val)
 	}
 
 	// Ensure the value is not nil
-	if b.Value().IsNil() {
+	if dst.IsNil() {
 		return reflect.Value{}, util.FormatError(nil, "%s return nil", b.String())
 	}
 
 	// If the value is an interface, unwrap it
-	v := b.Value()
+	v := dst
 	if v.Kind() == reflect.Interface {
 		v = v.Elem()
 	}
 	return v, nil
 }
 
+// validateBean checks the constructor arguments and the fields of a bean
+// like wiring it, but on a copy of the bean value, or a zero value if the
+// bean has a constructor, which is not called.
+func (c *Injector) validateBean(b *gs_bean.BeanDefinition, stack *Stack) error {
+	stack.pushBean(b)
+
+	t := b.Type()
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		if b.Callable() != nil {
+			return b.Callable().Validate(NewArgContext(c, stack))
+		}
+		return nil
+	}
+
+	v := reflect.New(t.Elem())
+	if b.Callable() != nil {
+		if err := b.Callable().Validate(NewArgContext(c, stack)); err != nil {
+			return err
+		}
+		// The prefix of the properties is only known after construction
+		if _, ok := v.Interface().(gs.PropertiesHandle); ok {
+			return nil
+		}
+		// The fields of the mocks are not wired
+		if b.Mocked() {
+			return nil
+		}
+	} else if bv := b.Value(); !bv.IsNil() {
+		v.Elem().Set(bv.Elem())
+	}
+	return c.wireBeanValue(v, t, stack)
+}
+
 // wireBeanValue injects dependencies into a bean's struct fields.
 func (c *Injector) wireBeanValue(v reflect.Value, t reflect.Type, stack *Stack) error {
 
+	// Bind and refresh the properties held by a properties handle
+	if v.Kind() == reflect.Ptr && v.CanInterface() {
+		if h, ok := v.Interface().(gs.PropertiesHandle); ok {
+			target, prefix := h.PropertiesTarget()
+			param := conf.BindParam{Key: prefix, Path: t.String()}
+			return c.p.RefreshField(reflect.ValueOf(target), param)
+		}
+	}
+
 	// Dereference pointers to obtain the underlying struct
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -678,6 +1435,30 @@ func (c *Injector) wireBeanValue(v reflect.Value, t reflect.Type, stack *Stack)
 	return c.wireStruct(v, t, param, stack)
 }
 
+// providerType returns T if t is of type func() (T, error).
+func providerType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Func || t.NumIn() != 0 || t.NumOut() != 2 {
+		return nil, false
+	}
+	if t.Out(1) != reflect.TypeFor[error]() {
+		return nil, false
+	}
+	return t.Out(0), true
+}
+
+// newProvider returns a func() (T, error) of type ft resolving a value of
+// type t with the tag on each call. The value is zero if the tag is
+// nullable and no bean matches.
+func (c *Injector) newProvider(ft, t reflect.Type, tag string) reflect.Value {
+	return reflect.MakeFunc(ft, func([]reflect.Value) []reflect.Value {
+		v, err := c.resolve(context.Background(), t, tag)
+		if err != nil {
+			return []reflect.Value{reflect.Zero(t), reflect.ValueOf(&err).Elem()}
+		}
+		return []reflect.Value{v, reflect.Zero(ft.Out(1))}
+	})
+}
+
 // wireStruct inspects struct fields and performs autowiring or configuration binding as needed.
 func (c *Injector) wireStruct(v reflect.Value, t reflect.Type, opt conf.BindParam, stack *Stack) error {
 	for i := range t.NumField() {
@@ -697,9 +1478,33 @@ func (c *Injector) wireStruct(v reflect.Value, t reflect.Type, opt conf.BindPara
 			tag, ok = ft.Tag.Lookup("inject")
 		}
 		if ok {
+			// Defer the injection of lazy handles until they are used
+			if h, isLazy := fv.Addr().Interface().(gs.LazyHandle); isLazy {
+				lt := h.LazyType()
+				h.SetLazyResolver(func() (reflect.Value, error) {
+					return c.resolve(context.Background(), lt, tag)
+				})
+				stack.handles++
+				continue
+			}
+			// Defer the creation of factory products until they are got
+			if h, isFactory := fv.Addr().Interface().(gs.FactoryHandle); isFactory {
+				ft := h.FactoryType()
+				h.SetFactoryResolver(func(ctx context.Context) (reflect.Value, error) {
+					return c.resolve(ctx, ft, tag)
+				})
+				stack.handles++
+				continue
+			}
+			// Inject the providers of lazy func() (T, error) fields
+			if pt, isProvider := providerType(ft.Type); isProvider && strings.HasSuffix(tag, ",lazy") {
+				fv.Set(c.newProvider(ft.Type, pt, strings.TrimSuffix(tag, ",lazy")))
+				stack.handles++
+				continue
+			}
 			// Handle lazy-injected fields
 			if strings.HasSuffix(tag, ",lazy") {
-				f := LazyField{v: fv, path: fieldPath, tag: tag}
+				f := LazyField{v: fv, path: fieldPath, tag: tag, bean: stack.top()}
 				stack.lazyFields = append(stack.lazyFields, f)
 			} else {
 				if err := c.autowire(fv, tag, stack); err != nil {
@@ -755,59 +1560,133 @@ func (d *destroyer) isDependOn(b *gs_bean.BeanDefinition) bool {
 	return slices.Contains(d.depends, b)
 }
 
-// dependOn adds the given bean to this destroyer's dependency list
-// if it is not already present.
-func (d *destroyer) dependOn(b *gs_bean.BeanDefinition) {
-	if d.isDependOn(b) {
-		return
-	}
-	d.depends = append(d.depends, b)
-}
-
 // LazyField represents a field in a struct that should be injected lazily.
 type LazyField struct {
-	v    reflect.Value // The field value that will be injected later
-	path string        // Hierarchical path of the field
-	tag  string        // Original tag (e.g. "autowire") for this field
+	v    reflect.Value           // The field value that will be injected later
+	path string                  // Hierarchical path of the field
+	tag  string                  // Original tag (e.g. "autowire") for this field
+	bean *gs_bean.BeanDefinition // The bean owning the field, if any
 }
 
 // Stack represents the runtime context during bean wiring.
 // It keeps track of the current wiring call stack, lazily injected fields,
 // and the ordering of destroyers for proper shutdown.
 type Stack struct {
+	mutex        sync.Mutex                // Guards beans for concurrent Path calls
 	beans        []*gs_bean.BeanDefinition // The stack of beans currently being wired
 	lazyFields   []LazyField               // Fields deferred due to lazy injection
-	destroyers   *list.List                // Ordered list of destroyers
-	destroyerMap map[gs.BeanID]*destroyer  // Fast lookup map for destroyers by bean ID
+	destroyables []*gs_bean.BeanDefinition // Wired beans having something to destroy
+	deps         []Dependency              // Dependencies resolved so far
+	depSet       map[Dependency]struct{}   // Deduplicates recorded dependencies
+	timings      []BeanTiming              // Creation times of the wired beans
+	nested       time.Duration             // Time spent wiring nested beans
+	handles      int                       // Number of lazy handles and factories found
+	instances    []func()                  // Destroyers of the scoped instances
 }
 
 // NewStack creates and initializes a new Stack for a fresh Refresh or Wire operation.
 func NewStack() *Stack {
 	return &Stack{
-		destroyers:   list.New(),
-		destroyerMap: make(map[gs.BeanID]*destroyer),
+		depSet: make(map[Dependency]struct{}),
+	}
+}
+
+// merge appends the results of the wiring done with another stack, e.g.
+// by a goroutine of the parallel wiring.
+func (s *Stack) merge(o *Stack) {
+	s.mutex.Lock()
+	s.beans = append(s.beans, o.beans...)
+	s.mutex.Unlock()
+	s.lazyFields = append(s.lazyFields, o.lazyFields...)
+	s.destroyables = append(s.destroyables, o.destroyables...)
+	for _, d := range o.deps {
+		if _, ok := s.depSet[d]; !ok {
+			s.depSet[d] = struct{}{}
+			s.deps = append(s.deps, d)
+		}
+	}
+	s.timings = append(s.timings, o.timings...)
+	s.handles += o.handles
+	s.instances = append(s.instances, o.instances...)
+}
+
+// top returns the bean currently being wired, or nil if there is none.
+func (s *Stack) top() *gs_bean.BeanDefinition {
+	if n := len(s.beans); n > 0 {
+		return s.beans[n-1]
+	}
+	return nil
+}
+
+// addDependency records that the bean currently being wired depends on b.
+func (s *Stack) addDependency(b BeanRuntime) {
+	from := s.top()
+	to, ok := b.(*gs_bean.BeanDefinition)
+	if from == nil || !ok || from == to {
+		return
 	}
+	d := Dependency{From: from, To: to}
+	if _, ok = s.depSet[d]; ok {
+		return
+	}
+	s.depSet[d] = struct{}{}
+	s.deps = append(s.deps, d)
 }
 
 // pushBean pushes a bean onto the wiring stack.
 // Used to keep track of current wiring path for cycle detection.
 func (s *Stack) pushBean(b *gs_bean.BeanDefinition) {
 	log.Debugf(context.Background(), log.TagAppDef, "push %s %s", b, b.Status())
+	s.mutex.Lock()
 	s.beans = append(s.beans, b)
+	s.mutex.Unlock()
 }
 
 // popBean pops the most recently added bean from the wiring stack.
 func (s *Stack) popBean() {
+	s.mutex.Lock()
 	n := len(s.beans)
 	b := s.beans[n-1]
 	s.beans[n-1] = nil // avoid memory leak
 	s.beans = s.beans[:n-1]
+	s.mutex.Unlock()
 	log.Debugf(context.Background(), log.TagAppDef, "pop %s %s", b, b.Status())
 }
 
+// cyclePath returns the chain of beans from b back to b, with the source
+// position of each bean, e.g. "a (a.go:10) → b (b.go:20) → a (a.go:10)".
+func (s *Stack) cyclePath(b *gs_bean.BeanDefinition) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var sb strings.Builder
+	write := func(x *gs_bean.BeanDefinition) {
+		sb.WriteString(x.Name())
+		if x.FileLine() != "" {
+			sb.WriteString(" (" + x.FileLine() + ")")
+		}
+	}
+	for _, x := range s.beans[slices.Index(s.beans, b):] {
+		if x != b || sb.Len() == 0 {
+			write(x)
+			sb.WriteString(" → ")
+		}
+	}
+	write(b)
+	return sb.String()
+}
+
+// circularError returns the error of a cycle of constructor dependencies,
+// which can't be wired; a lazy field or [gs.Lazy] can break the cycle.
+func circularError(path string) error {
+	return util.FormatError(nil, "found circular autowire: %s, "+
+		"use a lazy field or gs.Lazy to break the cycle", path)
+}
+
 // Path returns a formatted string representation of the current wiring stack,
 // which is useful for debugging and error messages.
 func (s *Stack) Path() (path string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if len(s.beans) == 0 {
 		return ""
 	}
@@ -817,32 +1696,6 @@ func (s *Stack) Path() (path string) {
 	return path[:len(path)-1] // Trim the trailing newline
 }
 
-// pushDestroyer registers a destroyer for the given bean.
-// It also records dependencies so that beans are destroyed in the correct order.
-func (s *Stack) pushDestroyer(b *gs_bean.BeanDefinition) {
-	beanID := gs.BeanID{Name: b.Name(), Type: b.Type()}
-
-	// Get or create the destroyer entry for this bean
-	d, ok := s.destroyerMap[beanID]
-	if !ok {
-		d = &destroyer{current: b}
-		s.destroyerMap[beanID] = d
-	}
-
-	// If there is a previously registered destroyer, current depends on it
-	if i := s.destroyers.Back(); i != nil {
-		d.dependOn(i.Value.(*gs_bean.BeanDefinition))
-	}
-
-	// Add the current bean to the end of the destroyer list
-	s.destroyers.PushBack(b)
-}
-
-// popDestroyer removes the last registered destroyer from the ordering list.
-func (s *Stack) popDestroyer() {
-	s.destroyers.Remove(s.destroyers.Back())
-}
-
 // getBeforeDestroyers returns a list of destroyers that the given destroyer depends on.
 // This helper is used during topological sorting of destroyers.
 func getBeforeDestroyers(destroyers *list.List, i any) *list.List {
@@ -857,36 +1710,62 @@ func getBeforeDestroyers(destroyers *list.List, i any) *list.List {
 	return result
 }
 
-// getSortedDestroyers computes and returns a slice of destroyer functions
-// in the correct order, respecting declared dependencies between beans.
-func (s *Stack) getSortedDestroyers() []func() {
-
-	// Helper to wrap a bean's destroy method as a no-argument function
-	destroy := func(v reflect.Value, fn any) func() {
-		return func() {
-			fnValue := reflect.ValueOf(fn)
-			out := fnValue.Call([]reflect.Value{v})
-			if len(out) > 0 && !out[0].IsNil() {
-				log.Errorf(context.Background(), log.TagAppDef, "%v", out[0].Interface())
+// dependents returns the beans depending on b, directly or indirectly.
+func (s *Stack) dependents(b *gs_bean.BeanDefinition) map[*gs_bean.BeanDefinition]struct{} {
+	ret := make(map[*gs_bean.BeanDefinition]struct{})
+	todo := []*gs_bean.BeanDefinition{b}
+	for len(todo) > 0 {
+		x := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+		for _, d := range s.deps {
+			if d.To != x {
+				continue
+			}
+			if _, ok := ret[d.From]; !ok {
+				ret[d.From] = struct{}{}
+				todo = append(todo, d.From)
 			}
 		}
 	}
+	return ret
+}
 
-	// Copy all destroyers into a new list for sorting
+// getSortedDestroyers returns the destroy callbacks of the wired beans,
+// ordered so that calling them in reverse, as Close does, destroys every
+// bean before the beans it depends on, directly or through beans having
+// nothing to destroy. Beans depending on each other have no defined order.
+func (s *Stack) getSortedDestroyers(timeout time.Duration) []func() {
+
+	dependents := make(map[*gs_bean.BeanDefinition]map[*gs_bean.BeanDefinition]struct{})
+	for _, b := range s.destroyables {
+		dependents[b] = s.dependents(b)
+	}
+
+	// A bean is destroyed after the beans depending on it
 	destroyers := list.New()
-	for _, d := range s.destroyerMap {
+	for _, b := range s.destroyables {
+		d := &destroyer{current: b}
+		for _, x := range s.destroyables {
+			if _, ok := dependents[b][x]; !ok || x == b {
+				continue
+			}
+			if _, mutual := dependents[x][b]; !mutual {
+				d.depends = append(d.depends, x)
+			}
+		}
 		destroyers.PushBack(d)
 	}
 
 	// Perform a topological sort to respect dependencies
-	// (e.g. a bean must be destroyed after the beans it depends on)
-	destroyers, _ = gs_util.TripleSort(destroyers, getBeforeDestroyers)
+	if sorted, err := gs_util.TripleSort(destroyers, getBeforeDestroyers); err == nil {
+		destroyers = sorted
+	}
 
-	// Convert the sorted destroyers into a slice of executable cleanup functions
+	// Convert the sorted destroyers into cleanup functions, in reverse
 	var ret []func()
-	for e := destroyers.Front(); e != nil; e = e.Next() {
-		d := e.Value.(*destroyer).current
-		ret = append(ret, destroy(d.Value(), d.Destroy()))
+	for e := destroyers.Back(); e != nil; e = e.Prev() {
+		b := e.Value.(*destroyer).current
+		ret = append(ret, destroyWithTimeout(b, destroyFunc(b, b.Value()), timeout))
 	}
 	return ret
 }
@@ -935,6 +1814,12 @@ func (a *ArgContext) Bind(v reflect.Value, tag string) error {
 	return a.c.p.Data().Bind(v, tag)
 }
 
+// DryRun returns true if the arguments are only checked, not used, in
+// which case the functions bound to the arguments are not called.
+func (a *ArgContext) DryRun() bool {
+	return a.c.state == Validating
+}
+
 // Wire performs dependency injection on the given reflect.Value
 // using the specified tag, leveraging the current wiring stack.
 func (a *ArgContext) Wire(v reflect.Value, tag string) error {
@@ -412,6 +412,70 @@ func TestInjecting(t *testing.T) {
 		assert.Error(t, err).Matches("found 2 beans")
 	})
 
+	t.Run("primary bean for single value", func(t *testing.T) {
+		r := New(conf.New())
+		s := new(struct {
+			Logger Logger `autowire:""`
+		})
+		beans := []*gs.BeanDefinition{
+			objectBean(s),
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()),
+			objectBean(&ZeroLogger{}).Name("b").Export(gs.As[Logger]()).Primary(),
+			objectBean(&BizLogger{}).Name("c").Export(gs.As[Logger]()).Fallback(),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		_, ok := s.Logger.(*ZeroLogger)
+		assert.That(t, ok).True()
+	})
+
+	t.Run("fallback bean for single value", func(t *testing.T) {
+		r := New(conf.New())
+		s := new(struct {
+			Logger Logger `autowire:""`
+			Biz    Logger `autowire:"c"`
+		})
+		beans := []*gs.BeanDefinition{
+			objectBean(s),
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()),
+			objectBean(&ZeroLogger{}).Name("b").Export(gs.As[Logger]()).Fallback(),
+			objectBean(&BizLogger{}).Name("c").Export(gs.As[Logger]()).Fallback(),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		_, ok := s.Logger.(*SimpleLogger)
+		assert.That(t, ok).True()
+		_, ok = s.Biz.(*BizLogger)
+		assert.That(t, ok).True()
+	})
+
+	t.Run("wire error - two primary beans for single value", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(new(struct {
+				Logger Logger `autowire:""`
+			})),
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()).Primary(),
+			objectBean(&ZeroLogger{}).Name("b").Export(gs.As[Logger]()).Primary(),
+			objectBean(&BizLogger{}).Name("c").Export(gs.As[Logger]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("found 2 primary beans")
+	})
+
+	t.Run("wire error - only fallback beans for single value", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(new(struct {
+				Logger Logger `autowire:""`
+			})),
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()).Fallback(),
+			objectBean(&ZeroLogger{}).Name("b").Export(gs.As[Logger]()).Fallback(),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("found 2 beans")
+	})
+
 	t.Run("wire error - slice", func(t *testing.T) {
 		r := New(conf.New())
 		beans := []*gs.BeanDefinition{
@@ -823,7 +887,18 @@ func TestCircularBean(t *testing.T) {
 			provideBean(NewG),
 		}
 		err := r.Refresh(extractBeans(beans))
-		assert.Error(t, err).Matches("found circular autowire")
+		assert.Error(t, err).Matches("found circular autowire: NewE → NewG → NewE, use a lazy field or gs.Lazy to break the cycle")
+	})
+
+	t.Run("found circular - position", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			provideBean(NewE, gs_arg.Tag("?")).Caller(2),
+			objectBean(&F{}),
+			provideBean(NewG).Caller(2),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`found circular autowire: NewE \(.*injecting_test.go:\d+\) → NewG \(.*injecting_test.go:\d+\) → NewE \(`)
 	})
 
 	t.Run("found circular - indirect", func(t *testing.T) {
@@ -834,7 +909,7 @@ func TestCircularBean(t *testing.T) {
 			provideBean(NewJ),
 		}
 		err := r.Refresh(extractBeans(beans))
-		assert.Error(t, err).Matches("found circular autowire")
+		assert.Error(t, err).Matches("found circular autowire: lazy fields J.H need spring.allow-circular-references=true")
 	})
 
 	t.Run("found circular - lazy", func(t *testing.T) {
@@ -949,11 +1024,105 @@ func TestDestroy(t *testing.T) {
 		err = r.Wire(&s)
 		assert.That(t, err).Nil()
 		r.Close()
-		assert.That(t, s.DestroyC.value).Equal(2)
-		assert.That(t, s.DestroyE.value).Equal(1)
+		// DestroyC depends on DestroyE through DestroyD, so it goes first
+		assert.That(t, s.DestroyC.value).Equal(1)
+		assert.That(t, s.DestroyE.value).Equal(2)
+	})
+
+	t.Run("lifecycle interfaces", func(t *testing.T) {
+		r := New(conf.New())
+		var events []string
+		beans := []*gs.BeanDefinition{
+			objectBean(&LifecycleA{events: &events}),
+			objectBean(&LifecycleB{events: &events}),
+			objectBean(&LifecycleC{events: &events}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, events).Equal([]string{"init B", "init A", "init C"})
+		events = nil
+		r.Close()
+		// A and C both depend on B, wired when A was wired
+		assert.That(t, events[2]).Equal("destroy B")
+	})
+
+	t.Run("init error", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(&LifecycleB{err: errors.New("init error")}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("init error")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"destroy-timeout": "10ms",
+			},
+		}))
+		var events []string
+		beans := []*gs.BeanDefinition{
+			objectBean(&LifecycleB{events: &events, delay: time.Second}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		start := time.Now()
+		r.Close()
+		assert.That(t, time.Since(start) < time.Second).True()
 	})
 }
 
+type LifecycleA struct {
+	B      *LifecycleB `autowire:""`
+	events *[]string
+}
+
+func (a *LifecycleA) PostConstruct() error {
+	*a.events = append(*a.events, "init A")
+	return nil
+}
+
+func (a *LifecycleA) PreDestroy() error {
+	*a.events = append(*a.events, "destroy A")
+	return nil
+}
+
+type LifecycleB struct {
+	events *[]string
+	delay  time.Duration
+	err    error
+}
+
+func (b *LifecycleB) PostConstruct() error {
+	if b.err != nil {
+		return b.err
+	}
+	*b.events = append(*b.events, "init B")
+	return nil
+}
+
+func (b *LifecycleB) PreDestroy() error {
+	time.Sleep(b.delay)
+	*b.events = append(*b.events, "destroy B")
+	return nil
+}
+
+type LifecycleC struct {
+	B      *LifecycleB `autowire:""`
+	events *[]string
+}
+
+func (c *LifecycleC) PostConstruct() error {
+	*c.events = append(*c.events, "init C")
+	return nil
+}
+
+func (c *LifecycleC) PreDestroy() error {
+	*c.events = append(*c.events, "destroy C")
+	return nil
+}
+
 type DyncValue struct {
 	Value gs_dync.Value[int] `value:"${:=3}"`
 }
@@ -986,6 +1155,10 @@ func TestForceClean(t *testing.T) {
 		assert.That(t, r.beansByName).Nil()
 		assert.That(t, r.beansByType).Nil()
 
+		err = r.RefreshProperties(conf.Map(map[string]any{"a": "b"}))
+		assert.That(t, err).Nil()
+		assert.That(t, r.Properties().Get("a")).Equal("b")
+
 		runtime.GC()
 		time.Sleep(100 * time.Millisecond)
 		assert.That(t, release).Equal(map[string]struct{}{
@@ -1011,3 +1184,800 @@ func TestForceClean(t *testing.T) {
 		assert.That(t, r.beansByType).Nil()
 	})
 }
+
+func TestDependencies(t *testing.T) {
+
+	depString := func(r *Injecting) []string {
+		var ret []string
+		for _, d := range r.Dependencies() {
+			ret = append(ret, d.From.Name()+"->"+d.To.Name())
+		}
+		return ret
+	}
+
+	t.Run("fields and arguments", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(&C{}),
+			objectBean(&D{}),
+			provideBean(NewE, gs_arg.Index(1, gs_arg.Tag("?"))).Name("E"),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, depString(r)).Equal([]string{"C->D", "D->E", "E->C"})
+	})
+
+	t.Run("depends on", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(&SimpleLogger{}).Name("a").DependsOn(gs.BeanSelectorFor[*ZeroLogger]()),
+			objectBean(&ZeroLogger{}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, depString(r)).Equal([]string{"a->ZeroLogger"})
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"allow-circular-references": true,
+			},
+		}))
+		beans := []*gs.BeanDefinition{
+			provideBean(NewH).Name("H"),
+			objectBean(&I{}),
+			provideBean(NewJ).Name("J"),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, depString(r)).Equal([]string{"H->I", "I->J", "J->H"})
+	})
+}
+
+type SlowParent struct {
+	Child *SlowChild `autowire:""`
+}
+
+type SlowChild struct{}
+
+func NewSlowChild() *SlowChild {
+	time.Sleep(20 * time.Millisecond)
+	return &SlowChild{}
+}
+
+func TestTimings(t *testing.T) {
+	r := New(conf.New())
+	beans := []*gs.BeanDefinition{
+		objectBean(&SlowParent{}),
+		provideBean(NewSlowChild),
+	}
+	err := r.Refresh(extractBeans(beans))
+	assert.That(t, err).Nil()
+
+	timings := r.Timings()
+	assert.That(t, len(timings)).Equal(2)
+	assert.String(t, timings[0].Bean.Name()).Equal("NewSlowChild")
+	assert.String(t, timings[1].Bean.Name()).Equal("SlowParent")
+	assert.That(t, timings[0].Duration >= 20*time.Millisecond).True()
+	assert.That(t, timings[1].Duration < 20*time.Millisecond).True()
+}
+
+type LazyHolder struct {
+	Cache   gs.Lazy[*LazyCache]  `autowire:""`
+	Loggers gs.Lazy[[]Logger]    `autowire:"?"`
+	Missing gs.Lazy[*ZeroLogger] `autowire:""`
+}
+
+type LazyCache struct {
+	Holder    *LazyHolder `autowire:""`
+	destroyed bool
+}
+
+func TestLazy(t *testing.T) {
+
+	t.Run("not injected", func(t *testing.T) {
+		var l gs.Lazy[*LazyCache]
+		_, err := l.Get()
+		assert.Error(t, err).Matches("lazy \\*injecting.LazyCache is not injected")
+	})
+
+	t.Run("on demand", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"force-clean": true,
+			},
+		}))
+		count := 0
+		beans := []*gs.BeanDefinition{
+			objectBean(&LazyHolder{}),
+			provideBean(func() *LazyCache {
+				count++
+				return &LazyCache{}
+			}).Lazy().Destroy(func(c *LazyCache) {
+				c.destroyed = true
+			}),
+		}
+		holder := beans[0].BeanRegistration().Value().Interface().(*LazyHolder)
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, count).Equal(0)
+		assert.That(t, r.p).NotNil()
+
+		c, err := holder.Cache.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, count).Equal(1)
+		assert.That(t, c.Holder).Equal(holder)
+
+		c2, err := holder.Cache.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, c2).Equal(c)
+		assert.That(t, count).Equal(1)
+
+		loggers, err := holder.Loggers.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, len(loggers)).Equal(0)
+
+		_, err = holder.Missing.Get()
+		assert.Error(t, err).Matches("wire \\*injecting.ZeroLogger on demand error: can't find bean")
+
+		r.Close()
+		assert.That(t, c.destroyed).True()
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"force-clean": true,
+			},
+		}))
+		count := 0
+		holder := &struct {
+			Cache   func() (*LazyCache, error)  `autowire:",lazy"`
+			Missing func() (*ZeroLogger, error) `autowire:"?,lazy"`
+			Error   func() (*ZeroLogger, error) `autowire:"zero,lazy"`
+		}{}
+		beans := []*gs.BeanDefinition{
+			objectBean(holder),
+			objectBean(&LazyHolder{}),
+			provideBean(func() *LazyCache {
+				count++
+				return &LazyCache{}
+			}).Lazy(),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, count).Equal(0)
+
+		c, err := holder.Cache()
+		assert.That(t, err).Nil()
+		assert.That(t, c).NotNil()
+		assert.That(t, count).Equal(1)
+
+		c2, err := holder.Cache()
+		assert.That(t, err).Nil()
+		assert.That(t, c2).Equal(c)
+		assert.That(t, count).Equal(1)
+
+		l, err := holder.Missing()
+		assert.That(t, err).Nil()
+		assert.That(t, l).Nil()
+
+		_, err = holder.Error()
+		assert.Error(t, err).Matches("wire \\*injecting.ZeroLogger on demand error: can't find bean")
+		r.Close()
+	})
+}
+
+type ScopedSession struct {
+	Holder    *ScopedHolder `autowire:""`
+	destroyed bool
+}
+
+type ScopedHolder struct {
+	A       *ScopedSession             `autowire:"proto"`
+	B       *ScopedSession             `autowire:"proto"`
+	Proto   gs.Factory[*ScopedSession] `autowire:"proto"`
+	Request gs.Factory[*ScopedSession] `autowire:"request"`
+}
+
+func TestScope(t *testing.T) {
+
+	newSession := func() *ScopedSession { return &ScopedSession{} }
+	destroySession := func(s *ScopedSession) { s.destroyed = true }
+
+	t.Run("object bean", func(t *testing.T) {
+		assert.Panic(t, func() {
+			objectBean(&ScopedSession{}).Scope(gs.ScopePrototype)
+		}, "prototype bean must be created by a constructor")
+		assert.Panic(t, func() {
+			provideBean(newSession).Scope("session")
+		}, `unknown scope "session"`)
+	})
+
+	t.Run("request bean injected", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(&struct {
+				S *ScopedSession `autowire:""`
+			}{}),
+			provideBean(newSession).Scope(gs.ScopeRequest),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("request scoped bean .* must be got by a factory in a request scope")
+	})
+
+	t.Run("prototype and request", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(&ScopedHolder{}),
+			provideBean(newSession).Name("proto").Scope(gs.ScopePrototype).Destroy(destroySession),
+			provideBean(newSession).Name("request").Scope(gs.ScopeRequest).Destroy(destroySession),
+		}
+		holder := beans[0].BeanRegistration().Value().Interface().(*ScopedHolder)
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, holder.A.Holder).Equal(holder)
+		assert.That(t, holder.A != holder.B).True()
+
+		p1, err := holder.Proto.Get(t.Context())
+		assert.That(t, err).Nil()
+		p2, err := holder.Proto.Get(t.Context())
+		assert.That(t, err).Nil()
+		assert.That(t, p1 != p2).True()
+
+		_, err = holder.Request.Get(t.Context())
+		assert.Error(t, err).Matches("must be got by a factory in a request scope")
+
+		ctx, end := gs.WithRequestScope(t.Context())
+		s1, err := holder.Request.Get(ctx)
+		assert.That(t, err).Nil()
+		s2, err := holder.Request.Get(ctx)
+		assert.That(t, err).Nil()
+		assert.That(t, s1).Equal(s2)
+		p3, err := holder.Proto.Get(ctx)
+		assert.That(t, err).Nil()
+
+		ctx2, end2 := gs.WithRequestScope(t.Context())
+		s3, err := holder.Request.Get(ctx2)
+		assert.That(t, err).Nil()
+		assert.That(t, s1 != s3).True()
+
+		end()
+		assert.That(t, s1.destroyed).True()
+		assert.That(t, p3.destroyed).True()
+		assert.That(t, s3.destroyed).False()
+		end2()
+		assert.That(t, s3.destroyed).True()
+
+		_, err = holder.Request.Get(ctx)
+		assert.Error(t, err).Matches("request scope is ended")
+
+		r.Close()
+		assert.That(t, holder.A.destroyed).True()
+		assert.That(t, holder.B.destroyed).True()
+		assert.That(t, p1.destroyed).True()
+		assert.That(t, p2.destroyed).True()
+	})
+}
+
+type namingLogger struct {
+	Logger
+	prefix string
+}
+
+type recordingProcessor struct {
+	names []string
+	wrap  func(bean any) any
+}
+
+func (p *recordingProcessor) BeforeInit(bean any, name string) (any, error) {
+	p.names = append(p.names, "before "+name)
+	return bean, nil
+}
+
+func (p *recordingProcessor) AfterInit(bean any, name string) (any, error) {
+	p.names = append(p.names, "after "+name)
+	if p.wrap != nil {
+		return p.wrap(bean), nil
+	}
+	return bean, nil
+}
+
+func TestBeanPostProcessor(t *testing.T) {
+
+	newLogger := func() Logger { return &BizLogger{} }
+
+	t.Run("process", func(t *testing.T) {
+		r := New(conf.New())
+		p := &recordingProcessor{}
+		p.wrap = func(bean any) any {
+			if l, ok := bean.(Logger); ok {
+				return &namingLogger{Logger: l, prefix: "biz"}
+			}
+			return bean
+		}
+		beans := []*gs.BeanDefinition{
+			objectBean(p).Name("p").Export(gs.As[gs.BeanPostProcessor]()),
+			provideBean(newLogger).Name("logger"),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, p.names).Equal([]string{"before logger", "after logger"})
+
+		var s struct {
+			Logger Logger `autowire:""`
+		}
+		err = r.Wire(&s)
+		assert.That(t, err).Nil()
+		l, ok := s.Logger.(*namingLogger)
+		assert.That(t, ok).True()
+		assert.That(t, l.prefix).Equal("biz")
+	})
+
+	t.Run("not assignable", func(t *testing.T) {
+		r := New(conf.New())
+		p := &recordingProcessor{wrap: func(bean any) any { return &SimpleLogger{} }}
+		beans := []*gs.BeanDefinition{
+			objectBean(p).Export(gs.As[gs.BeanPostProcessor]()),
+			objectBean(&ZeroLogger{}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("post-processor returns \\*injecting.SimpleLogger not assignable to bean")
+	})
+
+	t.Run("replace object", func(t *testing.T) {
+		r := New(conf.New())
+		p := &recordingProcessor{wrap: func(bean any) any { return &ZeroLogger{} }}
+		beans := []*gs.BeanDefinition{
+			objectBean(p).Export(gs.As[gs.BeanPostProcessor]()),
+			objectBean(&ZeroLogger{}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("post-processor can't replace bean .* registered as an object")
+	})
+}
+
+type OrderedFilter struct {
+	name  string
+	order int
+}
+
+func (f *OrderedFilter) Do(ctx context.Context) {}
+
+func (f *OrderedFilter) Order() int { return f.order }
+
+func TestCollectionOrder(t *testing.T) {
+
+	names := func(filters []Filter) []string {
+		var ret []string
+		for _, f := range filters {
+			switch x := f.(type) {
+			case *OrderedFilter:
+				ret = append(ret, x.name)
+			case *FilterImpl:
+				ret = append(ret, "impl")
+			}
+		}
+		return ret
+	}
+
+	t.Run("order", func(t *testing.T) {
+		r := New(conf.New())
+		s := new(struct {
+			Filters []Filter `autowire:""`
+		})
+		beans := []*gs.BeanDefinition{
+			objectBean(s),
+			objectBean(&FilterImpl{}).Name("impl").Export(gs.As[Filter]()),
+			objectBean(&OrderedFilter{name: "a", order: 2}).Name("a").Export(gs.As[Filter]()),
+			objectBean(&OrderedFilter{name: "b", order: 3}).Name("b").Export(gs.As[Filter]()).Order(1),
+			objectBean(&OrderedFilter{name: "c", order: 2}).Name("c").Export(gs.As[Filter]()),
+			objectBean(&OrderedFilter{name: "d", order: 0}).Name("d").Export(gs.As[Filter]()).Order(-1),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, names(s.Filters)).Equal([]string{"d", "b", "a", "c", "impl"})
+	})
+
+	t.Run("named beans keep their positions", func(t *testing.T) {
+		r := New(conf.New())
+		s := new(struct {
+			Filters []Filter `autowire:"a,*,impl"`
+		})
+		beans := []*gs.BeanDefinition{
+			objectBean(s),
+			objectBean(&FilterImpl{}).Name("impl").Export(gs.As[Filter]()).Order(0),
+			objectBean(&OrderedFilter{name: "a", order: 3}).Name("a").Export(gs.As[Filter]()),
+			objectBean(&OrderedFilter{name: "b", order: 2}).Name("b").Export(gs.As[Filter]()),
+			objectBean(&OrderedFilter{name: "c", order: 1}).Name("c").Export(gs.As[Filter]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, names(s.Filters)).Equal([]string{"a", "c", "b", "impl"})
+	})
+
+	t.Run("qualifier", func(t *testing.T) {
+		r := New(conf.New())
+		s := new(struct {
+			Web     []Filter `autowire:"@web"`
+			WebLast []Filter `autowire:"@web,*,a"`
+			Any     []Filter `autowire:"@rpc,@web"`
+			None    []Filter `autowire:"@none?"`
+		})
+		beans := []*gs.BeanDefinition{
+			objectBean(s),
+			objectBean(&FilterImpl{}).Name("impl").Export(gs.As[Filter]()).Qualifier("rpc"),
+			objectBean(&OrderedFilter{name: "a", order: 1}).Name("a").Export(gs.As[Filter]()).Qualifier("web"),
+			objectBean(&OrderedFilter{name: "b", order: 2}).Name("b").Export(gs.As[Filter]()).Qualifier("web", "rpc"),
+			objectBean(&OrderedFilter{name: "c", order: 3}).Name("c").Export(gs.As[Filter]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, names(s.Web)).Equal([]string{"a", "b"})
+		assert.That(t, names(s.WebLast)).Equal([]string{"b", "a"})
+		assert.That(t, names(s.Any)).Equal([]string{"a", "b", "impl"})
+		assert.That(t, len(s.None)).Equal(0)
+	})
+
+	t.Run("qualifier error", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(new(struct {
+				Filters []Filter `autowire:"@web,c"`
+			})),
+			objectBean(&OrderedFilter{name: "a"}).Name("a").Export(gs.As[Filter]()).Qualifier("web"),
+			objectBean(&OrderedFilter{name: "c"}).Name("c").Export(gs.As[Filter]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`can't find bean, bean:"c" type:"\[]injecting.Filter"`)
+	})
+}
+
+func TestGet(t *testing.T) {
+
+	t.Run("not refreshed", func(t *testing.T) {
+		r := New(conf.New())
+		_, err := r.Get(context.Background(), reflect.TypeFor[Logger](), "")
+		assert.Error(t, err).Matches("container is not refreshed or doesn't allow bean lookup")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		r := New(conf.New())
+		count := 0
+		beans := []*gs.BeanDefinition{
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()),
+			provideBean(func() *ZeroLogger {
+				count++
+				return &ZeroLogger{}
+			}).Name("b").Export(gs.As[Logger]()).Lazy(),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, count).Equal(0)
+
+		v, err := r.Get(context.Background(), reflect.TypeFor[Logger](), "b")
+		assert.That(t, err).Nil()
+		_, ok := v.Interface().(*ZeroLogger)
+		assert.That(t, ok).True()
+		assert.That(t, count).Equal(1)
+
+		v, err = r.Get(context.Background(), reflect.TypeFor[[]Logger](), "")
+		assert.That(t, err).Nil()
+		assert.That(t, v.Len()).Equal(2)
+		assert.That(t, count).Equal(1)
+
+		_, err = r.Get(context.Background(), reflect.TypeFor[Logger](), "")
+		assert.Error(t, err).Matches("found 2 beans")
+	})
+
+	t.Run("force clean", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"force-clean": true,
+			},
+		}))
+		beans := []*gs.BeanDefinition{
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		_, err = r.Get(context.Background(), reflect.TypeFor[Logger](), "")
+		assert.Error(t, err).Matches("container is not refreshed or doesn't allow bean lookup")
+	})
+
+	t.Run("allow bean lookup", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"force-clean":       true,
+				"allow-bean-lookup": true,
+			},
+		}))
+		beans := []*gs.BeanDefinition{
+			objectBean(&SimpleLogger{}).Name("a").Export(gs.As[Logger]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		v, err := r.Get(context.Background(), reflect.TypeFor[Logger](), "a")
+		assert.That(t, err).Nil()
+		_, ok := v.Interface().(*SimpleLogger)
+		assert.That(t, ok).True()
+	})
+}
+
+type HTTPConfig struct {
+	Port    int    `value:"${port}" validate:"min=1,max=65535"`
+	Timeout string `value:"${timeout:=1s}"`
+}
+
+type HTTPConfigBean struct {
+	value  gs_dync.Value[HTTPConfig]
+	prefix string
+}
+
+func (c *HTTPConfigBean) PropertiesTarget() (any, string) {
+	return &c.value, c.prefix
+}
+
+func TestPropertiesHandle(t *testing.T) {
+
+	t.Run("bind and refresh", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"http": map[string]any{
+				"server": map[string]any{
+					"port": 8080,
+				},
+			},
+		}))
+		c := &HTTPConfigBean{prefix: "http.server"}
+		s := new(struct {
+			Config *HTTPConfigBean `autowire:""`
+		})
+		beans := []*gs.BeanDefinition{
+			objectBean(s),
+			objectBean(c),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, s.Config).Equal(c)
+		assert.That(t, c.value.Value()).Equal(HTTPConfig{Port: 8080, Timeout: "1s"})
+
+		err = r.RefreshProperties(conf.Map(map[string]any{
+			"http": map[string]any{
+				"server": map[string]any{
+					"port":    9090,
+					"timeout": "3s",
+				},
+			},
+		}))
+		assert.That(t, err).Nil()
+		assert.That(t, c.value.Value()).Equal(HTTPConfig{Port: 9090, Timeout: "3s"})
+
+		err = r.RefreshProperties(conf.Map(map[string]any{
+			"http": map[string]any{
+				"server": map[string]any{
+					"port": 0,
+				},
+			},
+		}))
+		assert.Error(t, err).Matches(`key "http.server.port" .* fails "min=1"`)
+		assert.That(t, c.value.Value()).Equal(HTTPConfig{Port: 9090, Timeout: "3s"})
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"http": map[string]any{
+				"server": map[string]any{
+					"port": 70000,
+				},
+			},
+		}))
+		beans := []*gs.BeanDefinition{
+			objectBean(&HTTPConfigBean{prefix: "http.server"}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`key "http.server.port" .* fails "max=65535"`)
+	})
+
+	t.Run("missing property", func(t *testing.T) {
+		r := New(conf.New())
+		beans := []*gs.BeanDefinition{
+			objectBean(&HTTPConfigBean{prefix: "http.server"}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`property "http.server.port" not exist`)
+	})
+}
+
+type ValidateService struct {
+	Repo *Repository `autowire:""`
+	Port int         `value:"${port}"`
+}
+
+type ValidateClient struct {
+	Addr string `value:"${addr:=localhost}"`
+}
+
+func TestValidate(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"port": 8080,
+		}))
+		called := false
+		beans := []*gs.BeanDefinition{
+			objectBean(&ValidateService{}),
+			provideBean(func() *Repository {
+				called = true
+				return &Repository{}
+			}),
+			provideBean(func(s *ValidateService) *ValidateClient {
+				called = true
+				return &ValidateClient{}
+			}),
+		}
+		_, bs := extractBeans(beans)
+		err := r.Validate(bs)
+		assert.That(t, err).Nil()
+		assert.That(t, called).False()
+	})
+
+	t.Run("all errors", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"port": "abc",
+		}))
+		beans := []*gs.BeanDefinition{
+			objectBean(&ValidateService{}),
+			provideBean(func(c *ValidateClient) *Repository {
+				return &Repository{}
+			}),
+		}
+		_, bs := extractBeans(beans)
+		err := r.Validate(bs)
+		assert.Error(t, err).Matches("validate bean name=ValidateService .* error")
+		assert.Error(t, err).Matches("bind path=.*ValidateService.Port type=int error")
+		assert.Error(t, err).Matches(`can't find bean, bean:"" type:"\*injecting.ValidateClient"`)
+	})
+
+	t.Run("bind arg not called", func(t *testing.T) {
+		r := New(conf.New())
+		called := false
+		beans := []*gs.BeanDefinition{
+			provideBean(func(c *ValidateClient) *Repository {
+				return &Repository{}
+			}, gs_arg.Bind(func(s *ValidateService) (*ValidateClient, error) {
+				called = true
+				return &ValidateClient{}, nil
+			})),
+		}
+		_, bs := extractBeans(beans)
+		err := r.Validate(bs)
+		assert.Error(t, err).Matches(`can't find bean, bean:"" type:"\*injecting.ValidateService"`)
+		assert.That(t, called).False()
+	})
+}
+
+type ParallelLeaf struct {
+	Name string
+}
+
+type ParallelRoot struct {
+	Leaves []*ParallelLeaf `autowire:""`
+}
+
+type ParallelCycleA struct {
+	B *ParallelCycleB `autowire:""`
+}
+
+type ParallelCycleB struct {
+	A *ParallelCycleA `autowire:""`
+}
+
+func TestParallelInit(t *testing.T) {
+
+	newLeaf := func(name string, d time.Duration, err error) *gs.BeanDefinition {
+		return provideBean(func() (*ParallelLeaf, error) {
+			time.Sleep(d)
+			return &ParallelLeaf{Name: name}, err
+		}).Name(name)
+	}
+
+	t.Run("concurrent", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"parallel-init":         true,
+				"parallel-init-workers": 4,
+			},
+		}))
+		root := &ParallelRoot{}
+		beans := []*gs.BeanDefinition{
+			objectBean(root),
+			newLeaf("a", 100*time.Millisecond, nil),
+			newLeaf("b", 100*time.Millisecond, nil),
+			newLeaf("c", 100*time.Millisecond, nil),
+			newLeaf("d", 100*time.Millisecond, nil),
+		}
+		start := time.Now()
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, time.Since(start) < 300*time.Millisecond).True()
+		assert.That(t, len(root.Leaves)).Equal(4)
+		assert.That(t, len(r.Timings())).Equal(5)
+		assert.That(t, len(r.Dependencies())).Equal(4)
+	})
+
+	t.Run("deterministic error", func(t *testing.T) {
+		for range 5 {
+			r := New(conf.Map(map[string]any{
+				"spring": map[string]any{
+					"parallel-init": true,
+				},
+			}))
+			beans := []*gs.BeanDefinition{
+				objectBean(&ParallelRoot{}),
+				newLeaf("a", 20*time.Millisecond, errors.New("error a")),
+				newLeaf("b", 0, errors.New("error b")),
+			}
+			err := r.Refresh(extractBeans(beans))
+			assert.Error(t, err).Matches("error a")
+		}
+	})
+
+	t.Run("cycle wired serially", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"parallel-init": true,
+			},
+		}))
+		a, b := &ParallelCycleA{}, &ParallelCycleB{}
+		beans := []*gs.BeanDefinition{
+			objectBean(a),
+			objectBean(b),
+			objectBean(&ParallelRoot{}),
+			newLeaf("leaf", 0, nil),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, a.B).Equal(b)
+		assert.That(t, b.A).Equal(a)
+	})
+
+	t.Run("lookup rejected", func(t *testing.T) {
+		r := New(conf.Map(map[string]any{
+			"spring": map[string]any{
+				"parallel-init": true,
+			},
+		}))
+		beans := []*gs.BeanDefinition{
+			objectBean(&ParallelRoot{}),
+			newLeaf("a", 20*time.Millisecond, nil),
+			provideBean(func() (*ParallelLeaf, error) {
+				v, err := r.Get(context.Background(), reflect.TypeFor[*ParallelLeaf](), "a")
+				if err != nil {
+					return nil, err
+				}
+				return &ParallelLeaf{Name: "b of " + v.Interface().(*ParallelLeaf).Name}, nil
+			}).Name("b"),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("can't wire \\*injecting.ParallelLeaf on demand while wiring beans in parallel")
+
+		// the lookup is allowed once the beans are wired serially
+		r = New(conf.Map(nil))
+		root := &ParallelRoot{}
+		beans = []*gs.BeanDefinition{
+			objectBean(root),
+			newLeaf("a", 0, nil),
+			provideBean(func() (*ParallelLeaf, error) {
+				v, err := r.Get(context.Background(), reflect.TypeFor[*ParallelLeaf](), "a")
+				if err != nil {
+					return nil, err
+				}
+				return &ParallelLeaf{Name: "b of " + v.Interface().(*ParallelLeaf).Name}, nil
+			}).Name("b"),
+		}
+		err = r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, root.Leaves[1].Name).Equal("b of a")
+	})
+}
@@ -17,9 +17,11 @@
 package resolving
 
 import (
+	"fmt"
 	"reflect"
 	"regexp"
 	"slices"
+	"time"
 
 	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/conf"
@@ -45,6 +47,53 @@ type Module struct {
 	c gs.Condition
 }
 
+// AutoConfig represents an auto-configuration, which registers beans
+// when all its conditions match, evaluated after the modules are applied.
+type AutoConfig struct {
+	name string
+	f    func(p conf.Properties) error
+	c    []gs.Condition
+}
+
+// AutoConfigOutcome records whether an auto-configuration matched, and
+// if not, the condition rejecting it.
+type AutoConfigOutcome struct {
+	Name     string       // name of the auto-configuration
+	Matched  bool         // whether all its conditions were satisfied
+	Rejected gs.Condition // the condition that did not match, if any
+}
+
+// String returns a human-readable description of the outcome.
+func (o AutoConfigOutcome) String() string {
+	if o.Matched {
+		return fmt.Sprintf("auto-config %s: matched", o.Name)
+	}
+	return fmt.Sprintf("auto-config %s: %v did not match", o.Name, o.Rejected)
+}
+
+// ConditionOutcome records the result of evaluating one condition
+// of a bean, a module or an auto-configuration during the refresh phase.
+type ConditionOutcome struct {
+	Bean       *gs_bean.BeanDefinition // nil if the condition belongs to a module
+	AutoConfig string                  // name of the auto-configuration, if any
+	Condition  gs.Condition            // the evaluated condition
+	Matched    bool                    // whether the condition was satisfied
+}
+
+// String returns a human-readable description of the outcome.
+func (o ConditionOutcome) String() string {
+	target := "module"
+	if o.Bean != nil {
+		target = "bean " + o.Bean.String()
+	} else if o.AutoConfig != "" {
+		target = "auto-config " + o.AutoConfig
+	}
+	if o.Matched {
+		return fmt.Sprintf("%s: %v matched", target, o.Condition)
+	}
+	return fmt.Sprintf("%s: %v did not match", target, o.Condition)
+}
+
 // Resolving is the core container responsible for holding bean definitions,
 // processing modules, applying mocks, scanning configuration beans, and
 // resolving beans against conditions.
@@ -54,6 +103,18 @@ type Resolving struct {
 	beans   []*gs_bean.BeanDefinition // all beans managed by the container
 	roots   []*gs_bean.BeanDefinition // root beans to wire at the end
 	modules []Module                  // registered modules
+	autos   []AutoConfig              // registered auto-configurations
+
+	outcomes     []ConditionOutcome  // condition evaluation report
+	autoOutcomes []AutoConfigOutcome // auto-configuration report
+	steps        []StepTiming        // time ranges of the refresh steps
+}
+
+// StepTiming is the time range of a step of the refresh.
+type StepTiming struct {
+	Name  string
+	Start time.Time
+	End   time.Time
 }
 
 // New creates an empty Resolving instance.
@@ -61,9 +122,16 @@ func New() *Resolving {
 	return &Resolving{}
 }
 
-// Roots returns all root beans.
+// Roots returns all active root beans, excluding deleted ones.
 func (c *Resolving) Roots() []*gs_bean.BeanDefinition {
-	return c.roots
+	var roots []*gs_bean.BeanDefinition
+	for _, b := range c.roots {
+		if b.Status() == gs_bean.StatusDeleted {
+			continue
+		}
+		roots = append(roots, b)
+	}
+	return roots
 }
 
 // Beans returns all active bean definitions, excluding deleted ones.
@@ -78,6 +146,32 @@ func (c *Resolving) Beans() []*gs_bean.BeanDefinition {
 	return beans
 }
 
+// Outcomes returns the conditions evaluated during refresh
+// in evaluation order, together with their results.
+func (c *Resolving) Outcomes() []ConditionOutcome {
+	return c.outcomes
+}
+
+// AutoConfigOutcomes returns the auto-configurations evaluated during
+// refresh in registration order, together with their results.
+func (c *Resolving) AutoConfigOutcomes() []AutoConfigOutcome {
+	return c.autoOutcomes
+}
+
+// Steps returns the steps of the refresh completed so far, in their
+// running order.
+func (c *Resolving) Steps() []StepTiming {
+	return c.steps
+}
+
+// step runs f as the named step of the refresh, recording its time range.
+func (c *Resolving) step(name string, f func() error) error {
+	start := time.Now()
+	err := f()
+	c.steps = append(c.steps, StepTiming{Name: name, Start: start, End: time.Now()})
+	return err
+}
+
 // AddMock registers a mock bean which can override an existing bean
 // during the refresh phase.
 func (c *Resolving) AddMock(mock gs.BeanMock) {
@@ -120,6 +214,19 @@ func (c *Resolving) Module(conditions []gs_cond.ConditionOnProperty, fn func(p c
 	})
 }
 
+// AutoConfig registers an auto-configuration that will be executed to
+// add beans when all the conditions match. The conditions are evaluated
+// after the modules are applied, in registration order, so they see the
+// beans registered directly, by the modules and by the auto-configurations
+// before, but not the beans of configuration methods.
+func (c *Resolving) AutoConfig(name string, conditions []gs.Condition, fn func(p conf.Properties) error) {
+	c.autos = append(c.autos, AutoConfig{
+		name: name,
+		f:    fn,
+		c:    conditions,
+	})
+}
+
 // Root marks a registered bean as a root bean.
 func (c *Resolving) Root(b *gs.RegisteredBean) {
 	bd := b.BeanRegistration().(*gs_bean.BeanDefinition)
@@ -128,7 +235,7 @@ func (c *Resolving) Root(b *gs.RegisteredBean) {
 
 // Refresh performs the full lifecycle of container initialization.
 // The phases are as follows:
-//  1. Apply registered modules to register additional beans.
+//  1. Apply registered modules and auto-configurations to register additional beans.
 //  2. Scan configuration beans and register methods as beans.
 //  3. Apply mock beans to override specific target beans.
 //  4. Resolve conditions for all beans and mark inactive ones as deleted.
@@ -140,25 +247,35 @@ func (c *Resolving) Refresh(p conf.Properties) error {
 	}
 	c.state = RefreshPrepare
 
-	if err := c.applyModules(p); err != nil {
+	if err := c.step("modules", func() error {
+		return c.applyModules(p)
+	}); err != nil {
 		return err
 	}
 
-	c.state = Refreshing
-
-	if err := c.scanConfigurations(); err != nil {
+	if err := c.step("auto-configs", func() error {
+		return c.applyAutoConfigs(p)
+	}); err != nil {
 		return err
 	}
 
-	if err := c.applyMocks(); err != nil {
-		return err
-	}
+	c.state = Refreshing
 
-	if err := c.resolveBeans(p); err != nil {
+	if err := c.step("scan", func() error {
+		if err := c.scanConfigurations(); err != nil {
+			return err
+		}
+		return c.applyMocks()
+	}); err != nil {
 		return err
 	}
 
-	if err := c.checkDuplicateBeans(); err != nil {
+	if err := c.step("conditions", func() error {
+		if err := c.resolveBeans(p); err != nil {
+			return err
+		}
+		return c.checkDuplicateBeans()
+	}); err != nil {
 		return err
 	}
 
@@ -180,9 +297,15 @@ func (c *Resolving) applyModules(p conf.Properties) error {
 	ctx := &ConditionContext{p: p, c: c}
 	for _, m := range c.modules {
 		if m.c != nil {
-			if ok, err := m.c.Matches(ctx); err != nil {
+			ok, err := m.c.Matches(ctx)
+			if err != nil {
 				return err
-			} else if !ok {
+			}
+			c.outcomes = append(c.outcomes, ConditionOutcome{
+				Condition: m.c,
+				Matched:   ok,
+			})
+			if !ok {
 				continue
 			}
 		}
@@ -193,6 +316,42 @@ func (c *Resolving) applyModules(p conf.Properties) error {
 	return nil
 }
 
+// applyAutoConfigs executes the auto-configurations whose conditions all
+// match, and records the outcomes.
+func (c *Resolving) applyAutoConfigs(p conf.Properties) error {
+	if len(c.autos) == 0 {
+		return nil
+	}
+	ctx := &ConditionContext{p: p, c: c}
+	for _, a := range c.autos {
+		outcome := AutoConfigOutcome{Name: a.name, Matched: true}
+		for _, cond := range a.c {
+			ok, err := cond.Matches(ctx)
+			if err != nil {
+				return util.FormatError(err, "auto-config %s error", a.name)
+			}
+			c.outcomes = append(c.outcomes, ConditionOutcome{
+				AutoConfig: a.name,
+				Condition:  cond,
+				Matched:    ok,
+			})
+			if !ok {
+				outcome.Matched = false
+				outcome.Rejected = cond
+				break
+			}
+		}
+		c.autoOutcomes = append(c.autoOutcomes, outcome)
+		if !outcome.Matched {
+			continue
+		}
+		if err := a.f(p); err != nil {
+			return util.FormatError(err, "apply auto-config %s error", a.name)
+		}
+	}
+	return nil
+}
+
 // scanConfigurations iterates over all beans that represent configuration
 // objects and scans their methods to register additional beans.
 func (c *Resolving) scanConfigurations() error {
@@ -394,9 +553,16 @@ func (c *ConditionContext) resolveBean(b *gs_bean.BeanDefinition) error {
 	}
 	b.SetStatus(gs_bean.StatusResolving)
 	for _, cond := range b.Conditions() {
-		if ok, err := cond.Matches(c); err != nil {
+		ok, err := cond.Matches(c)
+		if err != nil {
 			return err
-		} else if !ok {
+		}
+		c.c.outcomes = append(c.c.outcomes, ConditionOutcome{
+			Bean:      b,
+			Condition: cond,
+			Matched:   ok,
+		})
+		if !ok {
 			b.SetStatus(gs_bean.StatusDeleted)
 			return nil
 		}
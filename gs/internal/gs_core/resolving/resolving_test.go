@@ -198,6 +198,83 @@ func TestResolving(t *testing.T) {
 		assert.That(t, len(r.Beans())).Equal(0)
 	})
 
+	t.Run("condition outcomes", func(t *testing.T) {
+		r := New()
+		r.Module([]gs_cond.ConditionOnProperty{
+			gs_cond.OnProperty("module.enabled"),
+		}, func(p conf.Properties) error {
+			return nil
+		})
+		r.Root(r.Object(&TestBean{Value: 1}).Condition(
+			gs_cond.OnProperty("test.property").HavingValue("true"),
+		))
+		r.Object(&ZeroLogger{}).Condition(
+			gs_cond.OnBean[*TestBean](),
+		)
+		err := r.Refresh(conf.Map(map[string]any{
+			"module.enabled": "true",
+		}))
+		assert.That(t, err).Nil()
+		assert.That(t, len(r.Roots())).Equal(0)
+
+		outcomes := r.Outcomes()
+		assert.That(t, len(outcomes)).Equal(3)
+		assert.That(t, outcomes[0].Bean).Nil()
+		assert.That(t, outcomes[0].Matched).True()
+		assert.String(t, outcomes[0].String()).Equal("module: OnProperty(name=module.enabled) matched")
+		assert.That(t, outcomes[1].Matched).False()
+		assert.String(t, outcomes[1].String()).Matches("bean name=TestBean .* OnProperty\\(name=test.property, havingValue=true\\) did not match")
+		assert.That(t, outcomes[2].Matched).False()
+		assert.String(t, outcomes[2].String()).Matches("bean name=ZeroLogger .* OnBean\\(selector=\\{Type:\\*resolving.TestBean}\\) did not match")
+	})
+
+	t.Run("auto-config", func(t *testing.T) {
+		r := New()
+		r.Object(&ZeroLogger{})
+		r.AutoConfig("logger", []gs.Condition{
+			gs_cond.OnMissingBean[*ZeroLogger](),
+		}, func(p conf.Properties) error {
+			r.Object(&ZeroLogger{})
+			return nil
+		})
+		r.AutoConfig("bean", []gs.Condition{
+			gs_cond.OnProperty("bean.enabled").MatchIfMissing(),
+			gs_cond.OnMissingBean[*TestBean](),
+		}, func(p conf.Properties) error {
+			r.Object(&TestBean{Value: 1})
+			return nil
+		})
+		r.AutoConfig("bean again", []gs.Condition{
+			gs_cond.OnMissingBean[*TestBean](),
+		}, func(p conf.Properties) error {
+			r.Object(&TestBean{Value: 2})
+			return nil
+		})
+		err := r.Refresh(conf.New())
+		assert.That(t, err).Nil()
+		assert.That(t, len(r.Beans())).Equal(2)
+
+		var ss []string
+		for _, o := range r.AutoConfigOutcomes() {
+			ss = append(ss, o.String())
+		}
+		assert.That(t, ss).Equal([]string{
+			"auto-config logger: OnMissingBean(selector={Type:*resolving.ZeroLogger}) did not match",
+			"auto-config bean: matched",
+			"auto-config bean again: OnMissingBean(selector={Type:*resolving.TestBean}) did not match",
+		})
+		assert.String(t, r.Outcomes()[0].String()).Equal("auto-config logger: OnMissingBean(selector={Type:*resolving.ZeroLogger}) did not match")
+	})
+
+	t.Run("auto-config error", func(t *testing.T) {
+		r := New()
+		r.AutoConfig("broken", nil, func(p conf.Properties) error {
+			return errors.New("no driver")
+		})
+		err := r.Refresh(conf.New())
+		assert.Error(t, err).Matches("apply auto-config broken error: no driver")
+	})
+
 	t.Run("duplicate bean", func(t *testing.T) {
 		r := New()
 		r.Object(&TestBean{Value: 1})
@@ -26,6 +26,7 @@
 //     registered `refreshable` objects.
 //   - Value[T]: a type-safe container for dynamic configuration values.
 //   - Listener: allows components to receive change notifications.
+//   - Listen: subscribes typed callbacks to the changes of a key.
 //   - `refreshable`: interface that application components can implement
 //     to react to configuration updates.
 //
@@ -42,11 +43,15 @@ import (
 
 	"github.com/go-spring/spring-base/util"
 	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
 )
 
 // refreshable represents an object that can be dynamically refreshed.
 type refreshable interface {
-	onRefresh(prop conf.Properties, param conf.BindParam) error
+	// prepare binds the new value from prop without applying it, and
+	// returns the function that applies it. This allows a refresh to
+	// apply the new values only if all of them are valid.
+	prepare(prop conf.Properties, param conf.BindParam) (apply func(), err error)
 }
 
 // Listener holds a channel to receive notifications.
@@ -100,15 +105,26 @@ func (r *Value[T]) Value() T {
 
 // onRefresh updates the stored value with new properties and notifies listeners.
 func (r *Value[T]) onRefresh(prop conf.Properties, param conf.BindParam) error {
+	apply, err := r.prepare(prop, param)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// prepare binds the new value, which is stored and notified by apply.
+func (r *Value[T]) prepare(prop conf.Properties, param conf.BindParam) (func(), error) {
 	t := reflect.TypeFor[T]()
 	v := reflect.New(t).Elem()
 	err := conf.BindValue(prop, v, t, param, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	r.v.Store(v.Interface())
-	r.notifyAll()
-	return nil
+	return func() {
+		r.v.Store(v.Interface())
+		r.notifyAll()
+	}, nil
 }
 
 // MarshalJSON serializes the stored value as JSON.
@@ -124,23 +140,22 @@ type refreshObject struct {
 
 // Properties manages dynamic properties and refreshable objects.
 type Properties struct {
-	prop    conf.Properties  // The current properties.
-	lock    sync.RWMutex     // A read-write lock for thread-safe access.
-	objects []*refreshObject // List of refreshable objects bound to the properties.
+	prop    atomic.Pointer[conf.Properties] // The current properties, read without locks.
+	lock    sync.RWMutex                    // A read-write lock for thread-safe access.
+	objects []*refreshObject                // List of refreshable objects bound to the properties.
 }
 
 // New creates and returns a new Properties instance.
 func New(p conf.Properties) *Properties {
-	return &Properties{
-		prop: p,
-	}
+	r := &Properties{}
+	r.prop.Store(&p)
+	return r
 }
 
-// Data returns the current properties.
+// Data returns the current properties. It never blocks, even during a
+// refresh, which swaps the properties at once when it succeeds.
 func (p *Properties) Data() conf.Properties {
-	p.lock.RLock()
-	defer p.lock.RUnlock()
-	return p.prop
+	return *p.prop.Load()
 }
 
 // ObjectsCount returns the number of registered refreshable objects.
@@ -151,43 +166,126 @@ func (p *Properties) ObjectsCount() int {
 }
 
 // Refresh updates the properties and refreshes all bound objects as necessary.
-func (p *Properties) Refresh(prop conf.Properties) (err error) {
+// The refresh is atomic: the new values of all affected objects are bound
+// first, and they are applied, together with the properties, only if all
+// of them are valid. Otherwise, nothing changes and the errors are returned.
+func (p *Properties) Refresh(prop conf.Properties) error {
+	return p.refresh(prop, nil)
+}
+
+// RefreshDiff is like Refresh, with the changes from old to prop already
+// computed by Diff. The diff is only used if old is still the current
+// properties once the lock is held, otherwise it's computed again, so
+// that a concurrent refresh can't make it skip changed keys.
+func (p *Properties) RefreshDiff(old, prop conf.Properties, diff []Change) error {
+	if diff == nil {
+		diff = []Change{}
+	}
+	return p.refresh(prop, func(cur conf.Properties) []Change {
+		if sameProperties(cur, old) {
+			return diff
+		}
+		return Diff(cur, prop)
+	})
+}
+
+// sameProperties reports whether a and b are the same properties.
+func sameProperties(a, b conf.Properties) bool {
+	if a == nil || b == nil || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// refresh refreshes the objects bound by the changed keys, computing the
+// diff from the current properties if getDiff is nil.
+func (p *Properties) refresh(prop conf.Properties, getDiff func(cur conf.Properties) []Change) (err error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
 	if len(p.objects) == 0 {
-		p.prop = prop
+		p.prop.Store(&prop)
 		return nil
 	}
 
-	old := p.prop
-	p.prop = prop
+	var diff []Change
+	if getDiff != nil {
+		diff = getDiff(p.Data())
+	} else {
+		diff = Diff(p.Data(), prop)
+	}
+	var keys []string
+	for _, c := range diff {
+		keys = append(keys, c.Key)
+	}
+	if err = p.refreshKeys(prop, keys); err != nil {
+		return err
+	}
+	p.prop.Store(&prop)
+	return nil
+}
+
+// Change describes a property key that was added, removed or modified
+// between two versions of the properties.
+type Change struct {
+	Key    string        // the changed key
+	Kind   gs.ChangeKind // whether the key was added, modified or removed
+	Old    string        // the old value, empty if added
+	New    string        // the new value, empty if removed
+	Source string        // source of the new value, or of the old value if removed
+}
+
+// originProperties is implemented by properties that track the source of
+// each value, such as [conf.MutableProperties].
+type originProperties interface {
+	Origin(key string) (conf.Origin, bool)
+}
+
+// source returns the source of the value of key in p, or an empty string
+// if p does not track sources.
+func source(p conf.Properties, key string) string {
+	if r, ok := p.(originProperties); ok {
+		if o, ok := r.Origin(key); ok {
+			return o.File
+		}
+	}
+	return ""
+}
 
+// Diff returns the keys that differ between old and new, sorted by key.
+// The source is only looked up for the keys that differ.
+func Diff(old, new conf.Properties) []Change {
 	oldKeys := make(map[string]struct{})
 	for _, k := range old.Keys() {
 		oldKeys[k] = struct{}{}
 	}
 
-	changes := make(map[string]struct{})
-	for _, k := range prop.Keys() {
+	changes := make(map[string]Change)
+	for _, k := range new.Keys() {
+		c := Change{Key: k, Kind: gs.ChangeAdded, New: new.Get(k)}
 		if _, ok := oldKeys[k]; ok {
 			delete(oldKeys, k)
-			if old.Get(k) == prop.Get(k) {
+			if c.Old = old.Get(k); c.Old == c.New {
 				continue
 			}
+			c.Kind = gs.ChangeModified
 		}
-		changes[k] = struct{}{}
+		c.Source = source(new, k)
+		changes[k] = c
 	}
 	for k := range oldKeys {
-		changes[k] = struct{}{}
+		changes[k] = Change{Key: k, Kind: gs.ChangeRemoved, Old: old.Get(k), Source: source(old, k)}
 	}
 
-	keys := util.OrderedMapKeys(changes)
-	return p.refreshKeys(keys)
+	var ret []Change
+	for _, k := range util.OrderedMapKeys(changes) {
+		ret = append(ret, changes[k])
+	}
+	return ret
 }
 
-// refreshKeys refreshes objects bound by the specified keys.
-func (p *Properties) refreshKeys(keys []string) (err error) {
+// refreshKeys refreshes objects bound by the specified keys with prop.
+func (p *Properties) refreshKeys(prop conf.Properties, keys []string) (err error) {
 	updateIndexes := make(map[int]*refreshObject)
 	for _, key := range keys {
 		for index, o := range p.objects {
@@ -215,7 +313,7 @@ func (p *Properties) refreshKeys(keys []string) (err error) {
 	if len(updateObjects) == 0 {
 		return nil
 	}
-	return p.refreshObjects(updateObjects)
+	return refreshObjects(prop, updateObjects)
 }
 
 // Errors represents a collection of errors.
@@ -247,17 +345,26 @@ func (e *Errors) Error() string {
 	return sb.String()
 }
 
-// refreshObjects refreshes all provided objects and aggregates errors.
-func (p *Properties) refreshObjects(objects []*refreshObject) error {
+// refreshObjects refreshes all provided objects with prop if all of them
+// can be bound, otherwise it refreshes none and aggregates errors.
+func refreshObjects(prop conf.Properties, objects []*refreshObject) error {
 	ret := &Errors{}
+	applies := make([]func(), 0, len(objects))
 	for _, obj := range objects {
-		err := obj.target.onRefresh(p.prop, obj.param)
-		ret.Append(err)
+		apply, err := obj.target.prepare(prop, obj.param)
+		if err != nil {
+			ret.Append(err)
+			continue
+		}
+		applies = append(applies, apply)
 	}
-	if ret.Len() == 0 {
-		return nil
+	if ret.Len() > 0 {
+		return ret
 	}
-	return ret
+	for _, apply := range applies {
+		apply()
+	}
+	return nil
 }
 
 // filter is used to selectively refresh objects and fields.
@@ -275,7 +382,12 @@ func (f *filter) Do(i any, param conf.BindParam) (bool, error) {
 		target: v,
 		param:  param,
 	})
-	return true, v.onRefresh(f.prop, param)
+	apply, err := v.prepare(f.Data(), param)
+	if err != nil {
+		return true, err
+	}
+	apply()
+	return true, nil
 }
 
 // RefreshField refreshes a field of a bean, optionally registering it as refreshable.
@@ -292,5 +404,5 @@ func (p *Properties) RefreshField(v reflect.Value, param conf.BindParam) error {
 			return nil
 		}
 	}
-	return conf.BindValue(p.prop, v.Elem(), v.Elem().Type(), param, f)
+	return conf.BindValue(p.Data(), v.Elem(), v.Elem().Type(), param, f)
 }
@@ -27,18 +27,19 @@ import (
 
 	"github.com/go-spring/spring-base/testing/assert"
 	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
 )
 
 type MockPanicRefreshable struct{}
 
-func (m *MockPanicRefreshable) onRefresh(prop conf.Properties, param conf.BindParam) error {
+func (m *MockPanicRefreshable) prepare(prop conf.Properties, param conf.BindParam) (func(), error) {
 	panic("mock panic")
 }
 
 type MockErrorRefreshable struct{}
 
-func (m *MockErrorRefreshable) onRefresh(prop conf.Properties, param conf.BindParam) error {
-	return errors.New("mock error")
+func (m *MockErrorRefreshable) prepare(prop conf.Properties, param conf.BindParam) (func(), error) {
+	return nil, errors.New("mock error")
 }
 
 func TestValue(t *testing.T) {
@@ -133,6 +134,19 @@ func TestValue_DifferentTypes(t *testing.T) {
 	})
 }
 
+func TestValue_Allocs(t *testing.T) {
+	var v Value[bool]
+	err := v.onRefresh(
+		conf.Map(map[string]any{"feature.enabled": "true"}),
+		conf.BindParam{Key: "feature.enabled"},
+	)
+	assert.That(t, err).Nil()
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = v.Value()
+	})
+	assert.That(t, allocs).Equal(0.0)
+}
+
 func TestValue_ConcurrentAccess(t *testing.T) {
 	var v Value[int]
 
@@ -202,6 +216,72 @@ func TestValue_Listener(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDiff(t *testing.T) {
+	old := conf.New()
+	oldID := old.AddFile("old.yaml")
+	_ = old.Set("a", "1", oldID)
+	_ = old.Set("b", "1", oldID)
+	_ = old.Set("c", "1", oldID)
+
+	p := conf.New()
+	newID := p.AddFile("new.yaml")
+	_ = p.Set("a", "1", newID)
+	_ = p.Set("b", "2", newID)
+	_ = p.Set("d", "1", newID)
+
+	assert.That(t, Diff(old, p)).Equal([]Change{
+		{Key: "b", Kind: gs.ChangeModified, Old: "1", New: "2", Source: "new.yaml"},
+		{Key: "c", Kind: gs.ChangeRemoved, Old: "1", Source: "old.yaml"},
+		{Key: "d", Kind: gs.ChangeAdded, New: "1", Source: "new.yaml"},
+	})
+	assert.That(t, Diff(p, p)).Nil()
+
+	// the source is only looked up for the changed keys
+	c := &countingOrigins{MutableProperties: p}
+	assert.That(t, len(Diff(old, c))).Equal(3)
+	assert.That(t, c.n).Equal(2)
+}
+
+// countingOrigins counts the origins looked up.
+type countingOrigins struct {
+	*conf.MutableProperties
+	n int
+}
+
+func (p *countingOrigins) Origin(key string) (conf.Origin, bool) {
+	p.n++
+	return p.MutableProperties.Origin(key)
+}
+
+func TestRefreshDiff(t *testing.T) {
+	old := conf.Map(map[string]any{"a": 1, "b": 1})
+	p := New(old)
+
+	var a, b Value[int]
+	assert.That(t, p.RefreshField(reflect.ValueOf(&a), conf.BindParam{Key: "a"})).Nil()
+	assert.That(t, p.RefreshField(reflect.ValueOf(&b), conf.BindParam{Key: "b"})).Nil()
+
+	// only the objects bound by the keys of the given diff are refreshed
+	prop := conf.Map(map[string]any{"a": 2, "b": 2})
+	err := p.RefreshDiff(old, prop, []Change{{Key: "a", Kind: gs.ChangeModified, Old: "1", New: "2"}})
+	assert.That(t, err).Nil()
+	assert.That(t, a.Value()).Equal(2)
+	assert.That(t, b.Value()).Equal(1)
+	assert.That(t, p.Data().Get("b")).Equal("2")
+
+	// an empty diff refreshes nothing
+	next := conf.Map(map[string]any{"a": 3})
+	err = p.RefreshDiff(prop, next, nil)
+	assert.That(t, err).Nil()
+	assert.That(t, a.Value()).Equal(2)
+
+	// a diff computed from stale properties is computed again
+	err = p.RefreshDiff(old, conf.Map(map[string]any{"a": 4, "b": 4}), nil)
+	assert.That(t, err).Nil()
+	assert.That(t, a.Value()).Equal(4)
+	assert.That(t, b.Value()).Equal(4)
+}
+
 func TestDync(t *testing.T) {
 
 	t.Run("invalid property format", func(t *testing.T) {
@@ -335,6 +415,38 @@ func TestDync(t *testing.T) {
 		assert.That(t, p.ObjectsCount()).Equal(4)
 	})
 
+	t.Run("atomic refresh", func(t *testing.T) {
+		prop := conf.Map(map[string]any{
+			"config.a": "1",
+			"config.b": "2",
+		})
+		p := New(prop)
+
+		var cfg struct {
+			A Value[int] `value:"${a}"`
+			B Value[int] `value:"${b}"`
+		}
+		err := p.RefreshField(reflect.ValueOf(&cfg), conf.BindParam{Key: "config"})
+		assert.That(t, err).Nil()
+
+		err = p.Refresh(conf.Map(map[string]any{
+			"config.a": "10",
+			"config.b": "xyz",
+		}))
+		assert.Error(t, err).Matches("strconv.ParseInt: parsing \"xyz\": invalid syntax")
+		assert.That(t, cfg.A.Value()).Equal(1)
+		assert.That(t, cfg.B.Value()).Equal(2)
+		assert.That(t, p.Data()).Equal(prop)
+
+		err = p.Refresh(conf.Map(map[string]any{
+			"config.a": "10",
+			"config.b": "20",
+		}))
+		assert.That(t, err).Nil()
+		assert.That(t, cfg.A.Value()).Equal(10)
+		assert.That(t, cfg.B.Value()).Equal(20)
+	})
+
 	t.Run("refresh struct", func(t *testing.T) {
 		p := New(conf.Map(map[string]any{
 			"config.s1.value": "99",
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_dync
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// changeListener is a callback subscribed to the changes of a key.
+type changeListener struct {
+	param   conf.BindParam
+	prepare func(ctx context.Context, old, new conf.Properties) (call func(), err error)
+}
+
+// ChangeListeners holds the callbacks subscribed to the changes of keys
+// by [Listen]. It is safe for concurrent use.
+type ChangeListeners struct {
+	mutex     sync.Mutex
+	listeners []*changeListener
+}
+
+// NewChangeListeners creates an empty ChangeListeners.
+func NewChangeListeners() *ChangeListeners {
+	return &ChangeListeners{}
+}
+
+// Len returns the number of subscribed callbacks.
+func (l *ChangeListeners) Len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.listeners)
+}
+
+// Subscription is the handle of a callback subscribed by [Listen].
+type Subscription struct {
+	l *ChangeListeners
+	c *changeListener
+}
+
+// Cancel unsubscribes the callback, it's safe to call more than once.
+func (s *Subscription) Cancel() {
+	s.l.mutex.Lock()
+	defer s.l.mutex.Unlock()
+	s.l.listeners = slices.DeleteFunc(s.l.listeners, func(c *changeListener) bool {
+		return c == s.c
+	})
+}
+
+// Listen subscribes fn to the changes of the value of type T bound from
+// the key, e.g. a struct bound from "db" is changed by "db.host". The
+// value is the zero value of T when the key doesn't exist. fn is called
+// by [ChangeListeners.Notify] with the old and new values when they are
+// not equal.
+func Listen[T any](l *ChangeListeners, key string, fn func(old, new T)) (*Subscription, error) {
+	var param conf.BindParam
+	if err := param.BindTag("${"+key+"}", ""); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeFor[T]()
+	param.Path = t.String()
+
+	bind := func(p conf.Properties) (T, error) {
+		var v T
+		if p == nil || !p.Has(param.Key) {
+			return v, nil
+		}
+		err := conf.BindValue(p, reflect.ValueOf(&v).Elem(), t, param, nil)
+		return v, err
+	}
+
+	c := &changeListener{param: param}
+	c.prepare = func(ctx context.Context, old, new conf.Properties) (func(), error) {
+		oldValue, _ := bind(old) // the old value was reported when it came
+		newValue, err := bind(new)
+		if err != nil {
+			return nil, util.WrapError(err, "listen %s error", key)
+		}
+		if reflect.DeepEqual(oldValue, newValue) {
+			return nil, nil
+		}
+		return func() {
+			// A panic in fn is recovered and reported by goutil, so
+			// that it doesn't stop the other listeners.
+			goutil.Go(ctx, func(ctx context.Context) {
+				fn(oldValue, newValue)
+			}).Wait()
+		}, nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.listeners = append(l.listeners, c)
+	return &Subscription{l: l, c: c}, nil
+}
+
+// Notify calls the callbacks subscribed to the keys changed from old to
+// new, one at a time in the order they were subscribed. If any of the new
+// values can't be bound, no callback is called and the errors are returned.
+func (l *ChangeListeners) Notify(ctx context.Context, old, new conf.Properties, changes []Change) error {
+	notify, err := l.Prepare(ctx, old, new, changes)
+	if err != nil {
+		return err
+	}
+	notify()
+	return nil
+}
+
+// Prepare is like Notify, but only binds the new values, and returns the
+// function that calls the callbacks. This allows a refresh to validate
+// the new values before applying them.
+func (l *ChangeListeners) Prepare(ctx context.Context, old, new conf.Properties, changes []Change) (notify func(), err error) {
+	l.mutex.Lock()
+	listeners := slices.Clone(l.listeners)
+	l.mutex.Unlock()
+
+	ret := &Errors{}
+	var calls []func()
+	for _, c := range listeners {
+		if !slices.ContainsFunc(changes, func(change Change) bool {
+			return matchKey(change.Key, c.param.Key)
+		}) {
+			continue
+		}
+		call, err := c.prepare(ctx, old, new)
+		if err != nil {
+			ret.Append(err)
+		} else if call != nil {
+			calls = append(calls, call)
+		}
+	}
+	if ret.Len() > 0 {
+		return nil, ret
+	}
+	return func() {
+		for _, call := range calls {
+			call()
+		}
+	}, nil
+}
+
+// matchKey reports whether the changed key is the key or one of its sub
+// keys. The empty key, i.e. the root, matches all keys.
+func matchKey(changed, key string) bool {
+	s, ok := strings.CutPrefix(changed, key)
+	return ok && (s == "" || key == "" || s[0] == '.' || s[0] == '[')
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_dync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+func TestListen(t *testing.T) {
+
+	type DB struct {
+		Host string `value:"${host}"`
+		Port int    `value:"${port:=3306}"`
+	}
+
+	notify := func(l *ChangeListeners, old, new conf.Properties) error {
+		return l.Notify(context.Background(), old, new, Diff(old, new))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		l := NewChangeListeners()
+
+		var ports [][2]int
+		_, err := Listen(l, "db.port", func(old, new int) {
+			ports = append(ports, [2]int{old, new})
+		})
+		assert.That(t, err).Nil()
+
+		var dbs [][2]DB
+		sub, err := Listen(l, "db", func(old, new DB) {
+			dbs = append(dbs, [2]DB{old, new})
+		})
+		assert.That(t, err).Nil()
+		assert.That(t, l.Len()).Equal(2)
+
+		p1 := conf.Map(map[string]any{"a": "1"})
+		p2 := conf.Map(map[string]any{"a": "1", "db.host": "h1"})
+		p3 := conf.Map(map[string]any{"a": "2", "db.host": "h1"})
+		p4 := conf.Map(map[string]any{"a": "2", "db.host": "h2", "db.port": "3307"})
+
+		assert.That(t, notify(l, p1, p2)).Nil()
+		assert.That(t, notify(l, p2, p3)).Nil()
+		assert.That(t, notify(l, p3, p4)).Nil()
+		assert.That(t, ports).Equal([][2]int{{0, 3307}})
+		assert.That(t, dbs).Equal([][2]DB{
+			{{}, {Host: "h1", Port: 3306}},
+			{{Host: "h1", Port: 3306}, {Host: "h2", Port: 3307}},
+		})
+
+		sub.Cancel()
+		sub.Cancel()
+		assert.That(t, l.Len()).Equal(1)
+		assert.That(t, notify(l, p4, p1)).Nil()
+		assert.That(t, ports).Equal([][2]int{{0, 3307}, {3307, 0}})
+		assert.That(t, len(dbs)).Equal(2)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := Listen(NewChangeListeners(), "", func(old, new int) {})
+		assert.Error(t, err).Matches("parse tag '\\${}' error")
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		l := NewChangeListeners()
+		var hosts []string
+		_, err := Listen(l, "host", func(old, new string) { hosts = append(hosts, new) })
+		assert.That(t, err).Nil()
+		_, err = Listen(l, "port", func(old, new int) {})
+		assert.That(t, err).Nil()
+		err = notify(l, conf.New(), conf.Map(map[string]any{"host": "h", "port": "abc"}))
+		assert.Error(t, err).Matches("listen port error")
+		assert.That(t, hosts).Nil()
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		onPanic := goutil.OnPanic
+		goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {}
+		defer func() { goutil.OnPanic = onPanic }()
+
+		l := NewChangeListeners()
+		var values []string
+		_, err := Listen(l, "a", func(old, new string) { panic("oops") })
+		assert.That(t, err).Nil()
+		_, err = Listen(l, "a", func(old, new string) { values = append(values, new) })
+		assert.That(t, err).Nil()
+
+		err = notify(l, conf.New(), conf.Map(map[string]any{"a": "1"}))
+		assert.That(t, err).Nil()
+		assert.That(t, values).Equal([]string{"1"})
+	})
+}
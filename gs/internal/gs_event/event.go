@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gs_event implements the application event bus, a simple
+// synchronous publish/subscribe mechanism that lets beans communicate
+// without depending on each other directly.
+package gs_event
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+// subscription wraps a listener so that identical listener values
+// can be subscribed and unsubscribed independently.
+type subscription struct {
+	l gs.EventListener
+}
+
+// Bus dispatches published events to all subscribed listeners.
+// Listeners are invoked synchronously in subscription order.
+type Bus struct {
+	mutex sync.RWMutex
+	subs  []*subscription
+}
+
+// New creates an empty Bus.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a listener to the bus and returns a function
+// that removes it again.
+func (b *Bus) Subscribe(l gs.EventListener) (unsubscribe func()) {
+	s := &subscription{l: l}
+	b.mutex.Lock()
+	b.subs = append(b.subs, s)
+	b.mutex.Unlock()
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		b.subs = slices.DeleteFunc(b.subs, func(e *subscription) bool {
+			return e == s
+		})
+	}
+}
+
+// Publish delivers the event to all listeners subscribed at the time
+// of the call. Listeners may subscribe or publish from within OnEvent.
+func (b *Bus) Publish(ctx context.Context, event any) {
+	b.mutex.RLock()
+	subs := slices.Clone(b.subs)
+	b.mutex.RUnlock()
+	for _, s := range subs {
+		s.l.OnEvent(ctx, event)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+)
+
+func TestBus(t *testing.T) {
+
+	t.Run("publish", func(t *testing.T) {
+		b := New()
+		var got []any
+		b.Subscribe(gs.FuncEventListener(func(ctx context.Context, event any) {
+			got = append(got, event)
+		}))
+		b.Publish(context.Background(), "a")
+		b.Publish(context.Background(), 1)
+		assert.That(t, got).Equal([]any{"a", 1})
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		b := New()
+		var n int
+		l := gs.FuncEventListener(func(ctx context.Context, event any) { n++ })
+		cancel := b.Subscribe(l)
+		b.Subscribe(l)
+		b.Publish(context.Background(), "a")
+		assert.That(t, n).Equal(2)
+		cancel()
+		cancel()
+		b.Publish(context.Background(), "b")
+		assert.That(t, n).Equal(3)
+	})
+
+	t.Run("publish from listener", func(t *testing.T) {
+		b := New()
+		var got []any
+		b.Subscribe(gs.FuncEventListener(func(ctx context.Context, event any) {
+			got = append(got, event)
+			if event == "a" {
+				b.Publish(ctx, "b")
+			}
+		}))
+		b.Publish(context.Background(), "a")
+		assert.That(t, got).Equal([]any{"a", "b"})
+	})
+}
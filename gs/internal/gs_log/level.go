@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/barky"
+	"github.com/go-spring/spring-base/util"
+)
+
+// RootLogger is the name of the root logger.
+const RootLogger = "root"
+
+// managed holds the logging config currently in effect, so that the
+// levels of the loggers can be changed at runtime.
+var managed struct {
+	mutex     sync.Mutex
+	s         *barky.Storage // nil until a config has been applied
+	slogLevel *slog.LevelVar // level of the slog handler, nil if not bridged
+	handler   slog.Handler   // unfiltered slog handler, nil if not bridged
+	output    *output        // output of the slog handler, nil if not bridged
+
+	levels map[string]log.Level      // levels set for the named loggers
+	vars   map[string]*slog.LevelVar // levels of the loggers from [Logger]
+}
+
+// readers parses log config files by extension.
+var readers = map[string]log.Reader{
+	".xml":        log.ReadXML,
+	".json":       log.ReadJSON,
+	".yml":        log.ReadYAML,
+	".yaml":       log.ReadYAML,
+	".properties": log.ReadProperties,
+}
+
+// RefreshFile applies the log config file and remembers it for later
+// level changes. It replaces [log.RefreshFile].
+func RefreshFile(fileName string) error {
+	r, ok := readers[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return util.FormatError(nil, "unsupported file type %s", fileName)
+	}
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	m, err := r(b)
+	if err != nil {
+		return util.FormatError(err, "read %s error", fileName)
+	}
+	s := barky.NewStorage()
+	fileID := s.AddFile(fileName)
+	for k, v := range barky.FlattenMap(m) {
+		if err = s.Set(k, v, fileID); err != nil {
+			return err
+		}
+	}
+	return refresh(s, nil, nil)
+}
+
+// refresh applies the log config and remembers it, together with the
+// level and the unfiltered handler of slog if logs are bridged to slog.
+func refresh(s *barky.Storage, level *slog.LevelVar, h slog.Handler) error {
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+	if err := log.RefreshConfig(s); err != nil {
+		return err
+	}
+	managed.s = s
+	managed.slogLevel = level
+	managed.handler = h
+	updateNamedLevels()
+	return nil
+}
+
+// current returns the config in effect, or a config equivalent to the
+// default logger if none has been applied.
+func current() *barky.Storage {
+	if managed.s != nil {
+		return managed.s
+	}
+	s := barky.NewStorage()
+	fileID := s.AddFile("default")
+	for k, v := range map[string]string{
+		"appender.console.type":                  "Console",
+		"appender.console.layout.type":           "TextLayout",
+		"appender.console.layout.fileLineLength": "48",
+		"rootLogger.type":                        "Root",
+		"rootLogger.level":                       log.InfoLevel.String(),
+		"rootLogger.appenderRef.ref":             "console",
+	} {
+		_ = s.Set(k, v, fileID)
+	}
+	return s
+}
+
+// levelKey returns the config key holding the level of the logger.
+func levelKey(s *barky.Storage, name string) (string, error) {
+	if strings.EqualFold(name, RootLogger) {
+		return "rootLogger.level", nil
+	}
+	key := "logger." + name + ".level"
+	if !s.Has(key) {
+		return "", util.FormatError(nil, "logger %s not found", name)
+	}
+	return key, nil
+}
+
+// LoggerLevel is the configured level of a logger.
+type LoggerLevel struct {
+	Name  string // logger name, "root" for the root logger
+	Level string // level name, e.g. "INFO"
+}
+
+// Loggers returns the levels of the root logger followed by the other
+// configured loggers, sorted by name, then by the named loggers of
+// [Logger] and [ConfigureLevel], sorted by name.
+func Loggers() []LoggerLevel {
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+	s := current()
+	ret := []LoggerLevel{{Name: RootLogger, Level: normalizeLevel(s.Get("rootLogger.level"))}}
+	names, _ := s.SubKeys("logger")
+	for _, name := range names {
+		ret = append(ret, LoggerLevel{
+			Name:  name,
+			Level: normalizeLevel(s.Get("logger." + name + ".level")),
+		})
+	}
+	for _, name := range namedLoggers(s) {
+		ret = append(ret, LoggerLevel{Name: name, Level: effectiveLevel(name).String()})
+	}
+	return ret
+}
+
+// GetLevel returns the level of the named logger.
+func GetLevel(name string) (string, error) {
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+	s := current()
+	key, err := levelKey(s, name)
+	if err != nil {
+		if isNamed(name) {
+			return effectiveLevel(name).String(), nil
+		}
+		return "", err
+	}
+	return normalizeLevel(s.Get(key)), nil
+}
+
+// normalizeLevel returns the canonical name of a level.
+func normalizeLevel(s string) string {
+	if l, err := log.ParseLevel(s); err == nil {
+		return l.String()
+	}
+	return s
+}
+
+// SetLevel changes the level of the named logger, which is either in the
+// log config or a named logger of [Logger] and [ConfigureLevel]. Since
+// loggers of the log config can't be modified once created, the whole
+// logging system is rebuilt with the new level, so events logged
+// concurrently with the change may be lost.
+func SetLevel(name, level string) error {
+	return setLevel(name, level, false)
+}
+
+// ConfigureLevel changes the level of the named logger like [SetLevel],
+// except that the logger doesn't need to exist: the level then applies to
+// the loggers later returned by [Logger] under the name.
+func ConfigureLevel(name, level string) error {
+	return setLevel(name, level, true)
+}
+
+// setLevel changes the level of the named logger, creating the named
+// logger if it doesn't exist and create is true.
+func setLevel(name, level string, create bool) error {
+	l, err := log.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+
+	s := current()
+	key, err := levelKey(s, name)
+	if err != nil {
+		if !create && !isNamed(name) {
+			return err
+		}
+		setNamedLevel(name, l)
+		return nil
+	}
+	if key != "rootLogger.level" {
+		setNamedLevel(name, l)
+	}
+	if normalizeLevel(s.Get(key)) == l.String() {
+		return nil
+	}
+
+	files := make(map[int8]string)
+	for file, id := range s.RawFile() {
+		files[id] = file
+	}
+	ns := barky.NewStorage()
+	for k, v := range s.RawData() {
+		if k != key {
+			if err = ns.Set(k, v.Value, ns.AddFile(files[v.File])); err != nil {
+				return err
+			}
+		}
+	}
+	if err = ns.Set(key, l.String(), ns.AddFile("runtime")); err != nil {
+		return err
+	}
+
+	log.Destroy()
+	if err = log.RefreshConfig(ns); err != nil {
+		log.Destroy()
+		_ = log.RefreshConfig(s)
+		return err
+	}
+	managed.s = ns
+	if key == "rootLogger.level" && managed.slogLevel != nil {
+		managed.slogLevel.Set(ToSlogLevel(l))
+	}
+	updateNamedLevels()
+	return nil
+}
+
+// reset forgets the config in effect, only for tests.
+func reset() {
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+	log.Destroy()
+	managed.s = nil
+	managed.slogLevel = nil
+	managed.handler = nil
+	managed.output = nil
+	managed.levels = nil
+	managed.vars = nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestSetLevel(t *testing.T) {
+	stdout := log.Stdout
+	t.Cleanup(func() {
+		log.Stdout = stdout
+		reset()
+	})
+
+	t.Run("default", func(t *testing.T) {
+		reset()
+		buf := &bytes.Buffer{}
+		log.Stdout = buf
+
+		assert.That(t, Loggers()).Equal([]LoggerLevel{{Name: "root", Level: "INFO"}})
+		log.Debugf(context.Background(), log.TagAppDef, "hidden")
+		assert.That(t, buf.Len()).Equal(0)
+
+		err := SetLevel("ROOT", "debug")
+		assert.That(t, err).Nil()
+		assert.That(t, Loggers()).Equal([]LoggerLevel{{Name: "root", Level: "DEBUG"}})
+		log.Debugf(context.Background(), log.TagAppDef, "shown")
+		assert.String(t, buf.String()).Contains("shown")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		reset()
+		buf := &bytes.Buffer{}
+		log.Stdout = buf
+
+		file := filepath.Join(t.TempDir(), "log.properties")
+		err := os.WriteFile(file, []byte(`
+appender.console.type=Console
+appender.console.layout.type=TextLayout
+rootLogger.type=Root
+rootLogger.level=warn
+rootLogger.appenderRef.ref=console
+logger.biz.type=Logger
+logger.biz.level=trace
+logger.biz.tags=_biz_.*
+logger.biz.appenderRef.ref=console
+`), os.ModePerm)
+		assert.That(t, err).Nil()
+		err = RefreshFile(file)
+		assert.That(t, err).Nil()
+		assert.That(t, Loggers()).Equal([]LoggerLevel{
+			{Name: "root", Level: "WARN"},
+			{Name: "biz", Level: "TRACE"},
+		})
+
+		err = SetLevel("missing", "info")
+		assert.Error(t, err).Matches("logger missing not found")
+		err = SetLevel("biz", "verbose")
+		assert.Error(t, err).Matches(`invalid log level: "verbose"`)
+
+		err = SetLevel("biz", "error")
+		assert.That(t, err).Nil()
+		assert.That(t, Loggers()).Equal([]LoggerLevel{
+			{Name: "root", Level: "WARN"},
+			{Name: "biz", Level: "ERROR"},
+		})
+		log.Warnf(context.Background(), log.TagBizDef, "biz warn")
+		log.Warnf(context.Background(), log.TagAppDef, "app warn")
+		assert.That(t, bytes.Contains(buf.Bytes(), []byte("biz warn"))).False()
+		assert.String(t, buf.String()).Contains("app warn")
+	})
+
+	t.Run("unsupported file", func(t *testing.T) {
+		err := RefreshFile("log.toml")
+		assert.Error(t, err).Matches("unsupported file type log.toml")
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		reset()
+		prev := slog.Default()
+		defer slog.SetDefault(prev)
+
+		output := filepath.Join(t.TempDir(), "app.log")
+		closer, err := Refresh(SlogConfig{Handler: "text", Level: "info", Output: output})
+		assert.That(t, err).Nil()
+		defer func() { _ = closer.Close() }()
+
+		ctx := context.Background()
+		assert.That(t, slog.Default().Enabled(ctx, slog.LevelDebug)).False()
+		err = SetLevel("root", "debug")
+		assert.That(t, err).Nil()
+		assert.That(t, slog.Default().Enabled(ctx, slog.LevelDebug)).True()
+
+		log.Debugf(ctx, log.TagAppDef, "debug message")
+		b, err := os.ReadFile(output)
+		assert.That(t, err).Nil()
+		assert.String(t, string(b)).Contains("msg=\"debug message\"")
+	})
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/barky"
+)
+
+// levelHandler is a slog handler that drops the records below its level
+// and passes the others to the wrapped handler, whatever its own level.
+type levelHandler struct {
+	slog.Handler
+	level slog.Leveler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= h.level.Level()
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// Logger returns the slog logger of the given name, whose records carry
+// the name as the "logger" attribute. Its level is the one set for the
+// name or, failing that, for its closest dotted ancestor ("gs" for
+// "gs.conf"), else the level of the root logger, and it follows their
+// later changes. The records go to the handler built from the logging
+// properties if logs are bridged to slog, otherwise to the default slog
+// handler at the time of the call.
+func Logger(name string) *slog.Logger {
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+	v, ok := managed.vars[name]
+	if !ok {
+		v = new(slog.LevelVar)
+		v.Set(ToSlogLevel(effectiveLevel(name)))
+		if managed.vars == nil {
+			managed.vars = make(map[string]*slog.LevelVar)
+		}
+		managed.vars[name] = v
+	}
+	h := managed.handler
+	if h == nil {
+		h = slog.Default().Handler()
+	}
+	return slog.New(&levelHandler{Handler: h, level: v}).With("logger", name)
+}
+
+// effectiveLevel returns the level of the named logger, see [Logger].
+func effectiveLevel(name string) log.Level {
+	for n := name; ; {
+		if l, ok := managed.levels[n]; ok {
+			return l
+		}
+		i := strings.LastIndexByte(n, '.')
+		if i < 0 {
+			break
+		}
+		n = n[:i]
+	}
+	if l, err := log.ParseLevel(current().Get("rootLogger.level")); err == nil {
+		return l
+	}
+	return log.InfoLevel
+}
+
+// setNamedLevel sets the level of the named loggers under the name.
+func setNamedLevel(name string, l log.Level) {
+	if managed.levels == nil {
+		managed.levels = make(map[string]log.Level)
+	}
+	managed.levels[name] = l
+	updateNamedLevels()
+}
+
+// updateNamedLevels updates the levels of the loggers returned by
+// [Logger] after a level change.
+func updateNamedLevels() {
+	for name, v := range managed.vars {
+		v.Set(ToSlogLevel(effectiveLevel(name)))
+	}
+}
+
+// isNamed reports whether the logger is one returned by [Logger] or has
+// a level set for its name.
+func isNamed(name string) bool {
+	_, created := managed.vars[name]
+	_, configured := managed.levels[name]
+	return created || configured
+}
+
+// namedLoggers returns the sorted names of the loggers returned by
+// [Logger] or having a level set, except those in the log config.
+func namedLoggers(s *barky.Storage) []string {
+	var names []string
+	for name := range managed.vars {
+		names = append(names, name)
+	}
+	for name := range managed.levels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	return slices.DeleteFunc(names, func(name string) bool {
+		return strings.EqualFold(name, RootLogger) || s.Has("logger."+name+".level")
+	})
+}
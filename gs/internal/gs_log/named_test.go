@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+)
+
+func TestLogger(t *testing.T) {
+	t.Cleanup(reset)
+
+	t.Run("levels", func(t *testing.T) {
+		reset()
+		ctx := context.Background()
+
+		conf := Logger("gs.conf")
+		assert.That(t, conf.Enabled(ctx, slog.LevelInfo)).True()
+		assert.That(t, conf.Enabled(ctx, slog.LevelDebug)).False()
+
+		err := SetLevel("gs", "debug")
+		assert.Error(t, err).Matches("logger gs not found")
+		err = ConfigureLevel("gs", "verbose")
+		assert.Error(t, err).Matches(`invalid log level: "verbose"`)
+
+		err = ConfigureLevel("gs", "debug")
+		assert.That(t, err).Nil()
+		assert.That(t, conf.Enabled(ctx, slog.LevelDebug)).True()
+
+		remote := Logger("gs.conf.remote")
+		err = SetLevel("gs.conf", "warn")
+		assert.That(t, err).Nil()
+		assert.That(t, conf.Enabled(ctx, slog.LevelInfo)).False()
+		assert.That(t, remote.Enabled(ctx, slog.LevelInfo)).False()
+		assert.That(t, remote.Enabled(ctx, slog.LevelWarn)).True()
+
+		other := Logger("other")
+		assert.That(t, other.Enabled(ctx, slog.LevelDebug)).False()
+		err = SetLevel("root", "debug")
+		assert.That(t, err).Nil()
+		assert.That(t, other.Enabled(ctx, slog.LevelDebug)).True()
+		assert.That(t, conf.Enabled(ctx, slog.LevelDebug)).False()
+
+		level, err := GetLevel("gs.conf.remote")
+		assert.That(t, err).Nil()
+		assert.That(t, level).Equal("WARN")
+		assert.That(t, Loggers()).Equal([]LoggerLevel{
+			{Name: "root", Level: "DEBUG"},
+			{Name: "gs", Level: "DEBUG"},
+			{Name: "gs.conf", Level: "WARN"},
+			{Name: "gs.conf.remote", Level: "WARN"},
+			{Name: "other", Level: "DEBUG"},
+		})
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		reset()
+		prev := slog.Default()
+		defer slog.SetDefault(prev)
+
+		output := filepath.Join(t.TempDir(), "app.log")
+		closer, err := Refresh(SlogConfig{Handler: "json", Level: "info", Output: output})
+		assert.That(t, err).Nil()
+		defer func() { _ = closer.Close() }()
+
+		err = ConfigureLevel("orders", "debug")
+		assert.That(t, err).Nil()
+		Logger("orders").Debug("order created", "id", 1)
+		slog.Debug("hidden")
+
+		b, err := os.ReadFile(output)
+		assert.That(t, err).Nil()
+		assert.String(t, string(b)).Matches(`"level":"DEBUG","msg":"order created","logger":"orders","id":1`)
+	})
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// backupTimeFormat is the format of the time suffix of the rolled files,
+// which sorts them chronologically.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// FileConfig holds the rollover and retention settings of a log file.
+type FileConfig struct {
+	// MaxSize is the size a file is rolled over at, e.g. "100MB", or "0"
+	// or empty for no limit.
+	MaxSize string `value:"${max-size:=0}"`
+
+	// Period is the interval a file is rolled over at, e.g. "24h" to roll
+	// over at midnight UTC, or "0" for none.
+	Period time.Duration `value:"${period:=0}"`
+
+	// MaxBackups is the number of rolled files to keep, 0 to keep all.
+	MaxBackups int `value:"${max-backups:=0}"`
+
+	// MaxAge is the age the rolled files are removed at, 0 to keep them.
+	MaxAge time.Duration `value:"${max-age:=0}"`
+}
+
+// ParseSize parses a size like "512", "64KB", "100MB" or "1GB".
+func ParseSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"KB", 1 << 10},
+		{"MB", 1 << 20},
+		{"GB", 1 << 30},
+		{"B", 1},
+	} {
+		if v, ok := strings.CutSuffix(str, u.suffix); ok {
+			str, unit = strings.TrimSpace(v), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || n < 0 {
+		return 0, util.FormatError(nil, "invalid size %q", s)
+	}
+	return n * unit, nil
+}
+
+// RollingFile is a log file that is rolled over once it reaches its size
+// limit or the end of its period. The rolled files are renamed after the
+// time of the rollover, like "app.log.2025-01-02T15-04-05.000", and are
+// removed past the retention limits.
+type RollingFile struct {
+	path       string
+	maxSize    int64
+	period     time.Duration
+	maxBackups int
+	maxAge     time.Duration
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+	next  time.Time // time of the next periodic rollover
+}
+
+// NewRollingFile opens the log file at path, which is appended to, with
+// the rollover and retention settings of cfg.
+func NewRollingFile(path string, cfg FileConfig) (*RollingFile, error) {
+	var maxSize int64
+	if cfg.MaxSize != "" {
+		var err error
+		if maxSize, err = ParseSize(cfg.MaxSize); err != nil {
+			return nil, err
+		}
+	}
+	f := &RollingFile{
+		path:       path,
+		maxSize:    maxSize,
+		period:     cfg.Period,
+		maxBackups: cfg.MaxBackups,
+		maxAge:     cfg.MaxAge,
+	}
+	if err := f.open(time.Now()); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the log file and schedules the next periodic rollover.
+func (f *RollingFile) open(now time.Time) error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file, f.size = file, info.Size()
+	if f.period > 0 {
+		f.next = now.Truncate(f.period).Add(f.period)
+	}
+	return nil
+}
+
+// Write writes b to the log file, rolling it over first if needed.
+func (f *RollingFile) Write(b []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	now := time.Now()
+	if (f.maxSize > 0 && f.size > 0 && f.size+int64(len(b)) > f.maxSize) ||
+		(f.period > 0 && !now.Before(f.next)) {
+		if err := f.rollover(now); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(b)
+	f.size += int64(n)
+	return n, err
+}
+
+// rollover renames the log file after the time, opens a new one, and
+// removes the rolled files past the retention limits.
+func (f *RollingFile) rollover(now time.Time) error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	if err := os.Rename(f.path, f.path+"."+now.Format(backupTimeFormat)); err != nil {
+		return err
+	}
+	if err := f.open(now); err != nil {
+		return err
+	}
+	return f.removeBackups(now)
+}
+
+// removeBackups removes the rolled files beyond MaxBackups or older than
+// MaxAge.
+func (f *RollingFile) removeBackups(now time.Time) error {
+	if f.maxBackups <= 0 && f.maxAge <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return err
+	}
+	type backup struct {
+		path string
+		time time.Time
+	}
+	var backups []backup
+	for _, s := range matches {
+		t, err := time.ParseInLocation(backupTimeFormat, s[len(f.path)+1:], now.Location())
+		if err == nil {
+			backups = append(backups, backup{s, t})
+		}
+	}
+	slices.SortFunc(backups, func(a, b backup) int { return b.time.Compare(a.time) })
+	for i, b := range backups {
+		if (f.maxBackups > 0 && i >= f.maxBackups) || (f.maxAge > 0 && now.Sub(b.time) > f.maxAge) {
+			if err = os.Remove(b.path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the log file.
+func (f *RollingFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
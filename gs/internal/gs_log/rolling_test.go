@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/internal/gs_log"
+)
+
+func TestParseSize(t *testing.T) {
+	for s, n := range map[string]int64{
+		"0":      0,
+		"512":    512,
+		"512B":   512,
+		"64KB":   64 << 10,
+		"100 mb": 100 << 20,
+		"1GB":    1 << 30,
+	} {
+		v, err := gs_log.ParseSize(s)
+		assert.That(t, err).Nil()
+		assert.That(t, v).Equal(n)
+	}
+	_, err := gs_log.ParseSize("1TB")
+	assert.Error(t, err).Matches(`invalid size "1TB"`)
+	_, err = gs_log.ParseSize("-1")
+	assert.Error(t, err).Matches(`invalid size "-1"`)
+}
+
+func TestRollingFile(t *testing.T) {
+
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := gs_log.NewRollingFile(filepath.Join(t.TempDir(), "app.log"), gs_log.FileConfig{MaxSize: "big"})
+		assert.Error(t, err).Matches(`invalid size "big"`)
+	})
+
+	t.Run("size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		f, err := gs_log.NewRollingFile(path, gs_log.FileConfig{MaxSize: "10B", MaxBackups: 2})
+		assert.That(t, err).Nil()
+		for _, s := range []string{"12345678\n", "abcdefgh\n", "ABCDEFGH\n", "last\n"} {
+			_, err = f.Write([]byte(s))
+			assert.That(t, err).Nil()
+			time.Sleep(2 * time.Millisecond)
+		}
+		assert.That(t, f.Close()).Nil()
+
+		b, err := os.ReadFile(path)
+		assert.That(t, err).Nil()
+		assert.String(t, string(b)).Equal("last\n")
+
+		backups, err := filepath.Glob(path + ".*")
+		assert.That(t, err).Nil()
+		assert.That(t, len(backups)).Equal(2)
+		b, err = os.ReadFile(backups[0])
+		assert.That(t, err).Nil()
+		assert.String(t, string(b)).Equal("abcdefgh\n")
+
+		_, err = f.Write([]byte("closed\n"))
+		assert.That(t, err).Equal(os.ErrClosed)
+	})
+
+	t.Run("period", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		f, err := gs_log.NewRollingFile(path, gs_log.FileConfig{Period: 50 * time.Millisecond, MaxAge: time.Hour})
+		assert.That(t, err).Nil()
+		_, err = f.Write([]byte("first\n"))
+		assert.That(t, err).Nil()
+		time.Sleep(60 * time.Millisecond)
+		_, err = f.Write([]byte("second\n"))
+		assert.That(t, err).Nil()
+		assert.That(t, f.Close()).Nil()
+
+		b, err := os.ReadFile(path)
+		assert.That(t, err).Nil()
+		assert.String(t, string(b)).Equal("second\n")
+		backups, err := filepath.Glob(path + ".*")
+		assert.That(t, err).Nil()
+		assert.That(t, len(backups)).Equal(1)
+	})
+}
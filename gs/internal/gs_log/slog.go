@@ -0,0 +1,374 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gs_log bridges the Go-Spring logging system to [log/slog].
+//
+// When enabled, framework logs are forwarded to the default slog handler,
+// which is built from the "logging.*" properties, so that framework and
+// application logs share the same format and sinks.
+package gs_log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/barky"
+	"github.com/go-spring/spring-base/util"
+)
+
+func init() {
+	log.RegisterPlugin[SlogAppender]("Slog", log.PluginTypeAppender)
+}
+
+// SlogConfig holds the configuration of the slog handler.
+type SlogConfig struct {
+	// Handler is the handler type, either "text" or "json".
+	Handler string `value:"${logging.handler:=text}"`
+
+	// Level is the minimum level of the logs, e.g. "debug" or "info".
+	Level string `value:"${logging.level.root:=info}"`
+
+	// Output is the destination of the logs, either "stdout", "stderr",
+	// "syslog" or the path of a file the logs are appended to.
+	Output string `value:"${logging.output:=stdout}"`
+
+	// File holds the rollover settings of the file output.
+	File FileConfig `value:"${logging.file}"`
+
+	// Syslog holds the connection settings of the syslog output.
+	Syslog SyslogConfig `value:"${logging.syslog}"`
+}
+
+// SyslogConfig holds the connection settings of the syslog output.
+type SyslogConfig struct {
+	// Network is the network of the syslog server, e.g. "udp" or "tcp",
+	// or empty for the local syslog daemon.
+	Network string `value:"${network:=}"`
+
+	// Address is the address of the syslog server, like "host:514".
+	Address string `value:"${address:=}"`
+
+	// Tag is the tag of the messages, the program name if empty.
+	Tag string `value:"${tag:=}"`
+}
+
+// ToSlogLevel converts a Go-Spring log level to a slog level. TRACE maps
+// below DEBUG, while PANIC and FATAL map above ERROR.
+func ToSlogLevel(l log.Level) slog.Level {
+	switch {
+	case l.Code() <= log.TraceLevel.Code():
+		return slog.LevelDebug - 4
+	case l.Code() <= log.DebugLevel.Code():
+		return slog.LevelDebug
+	case l.Code() <= log.InfoLevel.Code():
+		return slog.LevelInfo
+	case l.Code() <= log.WarnLevel.Code():
+		return slog.LevelWarn
+	case l.Code() <= log.ErrorLevel.Code():
+		return slog.LevelError
+	case l.Code() <= log.PanicLevel.Code():
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError + 8
+	}
+}
+
+// NewHandler creates the slog handler described by cfg. The returned
+// closer releases the output and must be called when it's no longer used.
+func NewHandler(cfg SlogConfig) (slog.Handler, io.Closer, error) {
+	level, err := log.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newHandler(cfg, ToSlogLevel(level))
+}
+
+// newHandler creates the slog handler described by cfg with the level.
+func newHandler(cfg SlogConfig, level slog.Leveler) (slog.Handler, io.Closer, error) {
+	var (
+		w      io.Writer
+		closer io.Closer = io.NopCloser(nil)
+	)
+	switch cfg.Output {
+	case "stdout":
+		w = os.Stdout
+	case "stderr":
+		w = os.Stderr
+	case "syslog":
+		sw, err := newSyslogWriter(cfg.Syslog)
+		if err != nil {
+			return nil, nil, util.FormatError(err, "connect to syslog error")
+		}
+		w, closer = sw, sw
+	default:
+		f, err := NewRollingFile(cfg.Output, cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, closer = f, f
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(cfg.Handler) {
+	case "text":
+		return slog.NewTextHandler(w, opts), closer, nil
+	case "json":
+		return slog.NewJSONHandler(w, opts), closer, nil
+	default:
+		_ = closer.Close()
+		return nil, nil, util.FormatError(nil, "invalid logging handler: %q", cfg.Handler)
+	}
+}
+
+// Refresh installs a slog handler built from cfg as the default slog
+// logger, and routes all framework logs at or above the configured level
+// to it. Like [log.RefreshConfig], it can only be called once unless
+// [log.Destroy] is called in between. The returned closer releases the
+// output in use, which [Reconfigure] may have replaced.
+func Refresh(cfg SlogConfig) (io.Closer, error) {
+	l, err := log.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	level := new(slog.LevelVar)
+	level.Set(ToSlogLevel(l))
+
+	// The handler keeps all the records, named loggers may be more verbose
+	// than the root logger
+	h, closer, err := newHandler(cfg, slog.Level(math.MinInt))
+	if err != nil {
+		return nil, err
+	}
+	out := &output{}
+	out.box.Store(&handlerBox{h: h, closer: closer})
+
+	s := barky.NewStorage()
+	fileID := s.AddFile("logging")
+	for k, v := range map[string]string{
+		"appender.slog.type":         "Slog",
+		"rootLogger.type":            "Root",
+		"rootLogger.level":           l.String(),
+		"rootLogger.appenderRef.ref": "slog",
+	} {
+		if err = s.Set(k, v, fileID); err != nil {
+			_ = closer.Close()
+			return nil, err
+		}
+	}
+
+	// The handler level follows the level of the root logger
+	sh := &swapHandler{box: &out.box}
+	if err = refresh(s, level, sh); err != nil {
+		_ = closer.Close()
+		return nil, err
+	}
+	managed.output = out
+	slog.SetDefault(slog.New(&levelHandler{Handler: sh, level: level}))
+	return out, nil
+}
+
+// Reconfigure replaces the handler and the output of the logs bridged to
+// slog by [Refresh] with those described by cfg, then releases the former
+// output. The levels are left unchanged, see [SetLevel] to change them.
+func Reconfigure(cfg SlogConfig) error {
+	managed.mutex.Lock()
+	defer managed.mutex.Unlock()
+	if managed.output == nil {
+		return util.FormatError(nil, "logs are not bridged to slog")
+	}
+	h, closer, err := newHandler(cfg, slog.Level(math.MinInt))
+	if err != nil {
+		return err
+	}
+	old := managed.output.box.Swap(&handlerBox{h: h, closer: closer})
+	return old.closer.Close()
+}
+
+// handlerBox holds a slog handler together with the closer of its output.
+type handlerBox struct {
+	h      slog.Handler
+	closer io.Closer
+}
+
+// output holds the slog handler in use, which [Reconfigure] may replace.
+type output struct {
+	box atomic.Pointer[handlerBox]
+}
+
+// Close releases the output of the handler in use.
+func (o *output) Close() error {
+	return o.box.Load().closer.Close()
+}
+
+// swapHandler is a slog handler passing the records to the handler in
+// box, which may be replaced at any time. The attributes and groups added
+// to it are applied to the handler in use, which is cached.
+type swapHandler struct {
+	box   *atomic.Pointer[handlerBox]
+	ops   []func(slog.Handler) slog.Handler
+	cache atomic.Pointer[swapCache]
+}
+
+// swapCache is the handler in box with the attributes and groups applied.
+type swapCache struct {
+	box *handlerBox
+	h   slog.Handler
+}
+
+// handler returns the handler in use with the attributes and groups.
+func (h *swapHandler) handler() slog.Handler {
+	box := h.box.Load()
+	if c := h.cache.Load(); c != nil && c.box == box {
+		return c.h
+	}
+	ret := box.h
+	for _, op := range h.ops {
+		ret = op(ret)
+	}
+	h.cache.Store(&swapCache{box: box, h: ret})
+	return ret
+}
+
+func (h *swapHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.handler().Enabled(ctx, l)
+}
+
+func (h *swapHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler().Handle(ctx, r)
+}
+
+func (h *swapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(h slog.Handler) slog.Handler { return h.WithAttrs(attrs) })
+}
+
+func (h *swapHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(h slog.Handler) slog.Handler { return h.WithGroup(name) })
+}
+
+// with returns a swapHandler applying op after the operations of h.
+func (h *swapHandler) with(op func(slog.Handler) slog.Handler) slog.Handler {
+	return &swapHandler{box: h.box, ops: append(slices.Clip(h.ops), op)}
+}
+
+// SlogAppender is a log appender that forwards events to the handler of
+// the default slog logger. The message of an event becomes the message
+// of the slog record, while its tag, caller, context and other fields
+// become attributes.
+type SlogAppender struct {
+	Name string `PluginAttribute:"name"`
+}
+
+func (c *SlogAppender) GetName() string { return c.Name }
+func (c *SlogAppender) Start() error    { return nil }
+func (c *SlogAppender) Stop()           {}
+
+// Append converts the event to a slog record and handles it.
+func (c *SlogAppender) Append(e *log.Event) {
+	h := slog.Default().Handler()
+	ctx := context.Background()
+	level := ToSlogLevel(e.Level)
+	if !h.Enabled(ctx, level) {
+		return
+	}
+
+	var msg string
+	attrs := make([]slog.Attr, 0, len(e.Fields)+len(e.CtxFields)+3)
+	attrs = append(attrs,
+		slog.String("tag", e.Tag),
+		slog.String("caller", fmt.Sprintf("%s:%d", e.File, e.Line)),
+	)
+	if e.CtxString != "" {
+		attrs = append(attrs, slog.String("ctx", e.CtxString))
+	}
+	for _, f := range e.CtxFields {
+		attrs = appendAttrs(attrs, f)
+	}
+	for _, f := range e.Fields {
+		if f.Key == log.MsgKey && f.Type == log.ValueTypeString {
+			msg = fieldString(f)
+			continue
+		}
+		attrs = appendAttrs(attrs, f)
+	}
+
+	r := slog.NewRecord(e.Time, level, msg, 0)
+	r.AddAttrs(attrs...)
+	_ = h.Handle(ctx, r)
+}
+
+// Write writes the bytes as the message of an INFO record.
+func (c *SlogAppender) Write(b []byte) {
+	msg := string(bytes.TrimRight(b, "\n"))
+	_ = slog.Default().Handler().Handle(context.Background(),
+		slog.NewRecord(time.Now(), slog.LevelInfo, msg, 0))
+}
+
+// fieldString returns a copy of the value of a string field, since the
+// field points to memory that may be reused once the event is released.
+func fieldString(f log.Field) string {
+	if f.Num == 0 {
+		return ""
+	}
+	return strings.Clone(unsafe.String(f.Any.(*byte), f.Num))
+}
+
+// appendAttrs converts a log field to slog attributes and appends them.
+func appendAttrs(attrs []slog.Attr, f log.Field) []slog.Attr {
+	switch f.Type {
+	case log.ValueTypeBool:
+		return append(attrs, slog.Bool(f.Key, f.Num != 0))
+	case log.ValueTypeInt64:
+		return append(attrs, slog.Int64(f.Key, int64(f.Num)))
+	case log.ValueTypeUint64:
+		return append(attrs, slog.Uint64(f.Key, f.Num))
+	case log.ValueTypeFloat64:
+		return append(attrs, slog.Float64(f.Key, math.Float64frombits(f.Num)))
+	case log.ValueTypeString:
+		return append(attrs, slog.String(f.Key, fieldString(f)))
+	case log.ValueTypeObject:
+		var group []slog.Attr
+		for _, v := range f.Any.([]log.Field) {
+			group = appendAttrs(group, v)
+		}
+		return append(attrs, slog.Attr{Key: f.Key, Value: slog.GroupValue(group...)})
+	case log.ValueTypeFromMap:
+		m := f.Any.(map[string]any)
+		for _, k := range util.OrderedMapKeys(m) {
+			attrs = appendAttrs(attrs, log.Any(k, m[k]))
+		}
+		return attrs
+	case log.ValueTypeArray:
+		var buf bytes.Buffer
+		enc := log.NewJSONEncoder(&buf)
+		enc.AppendArrayBegin()
+		f.Any.(log.ArrayValue).EncodeArray(enc)
+		enc.AppendArrayEnd()
+		return append(attrs, slog.String(f.Key, buf.String()))
+	default:
+		return append(attrs, slog.Any(f.Key, f.Any))
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/internal/gs_log"
+)
+
+func TestToSlogLevel(t *testing.T) {
+	assert.That(t, gs_log.ToSlogLevel(log.TraceLevel)).Equal(slog.LevelDebug - 4)
+	assert.That(t, gs_log.ToSlogLevel(log.DebugLevel)).Equal(slog.LevelDebug)
+	assert.That(t, gs_log.ToSlogLevel(log.InfoLevel)).Equal(slog.LevelInfo)
+	assert.That(t, gs_log.ToSlogLevel(log.WarnLevel)).Equal(slog.LevelWarn)
+	assert.That(t, gs_log.ToSlogLevel(log.ErrorLevel)).Equal(slog.LevelError)
+	assert.That(t, gs_log.ToSlogLevel(log.PanicLevel)).Equal(slog.LevelError + 4)
+	assert.That(t, gs_log.ToSlogLevel(log.FatalLevel)).Equal(slog.LevelError + 8)
+}
+
+func TestNewHandler(t *testing.T) {
+
+	t.Run("invalid level", func(t *testing.T) {
+		_, _, err := gs_log.NewHandler(gs_log.SlogConfig{Handler: "text", Level: "verbose", Output: "stdout"})
+		assert.Error(t, err).Matches(`invalid log level: "verbose"`)
+	})
+
+	t.Run("invalid handler", func(t *testing.T) {
+		_, _, err := gs_log.NewHandler(gs_log.SlogConfig{Handler: "xml", Level: "info", Output: "stderr"})
+		assert.Error(t, err).Matches(`invalid logging handler: "xml"`)
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "no-such-dir", "app.log")
+		_, _, err := gs_log.NewHandler(gs_log.SlogConfig{Handler: "text", Level: "info", Output: output})
+		assert.Error(t, err).Matches("no such file or directory")
+	})
+
+	t.Run("text", func(t *testing.T) {
+		h, closer, err := gs_log.NewHandler(gs_log.SlogConfig{Handler: "TEXT", Level: "warn", Output: "stdout"})
+		assert.That(t, err).Nil()
+		assert.That(t, closer.Close()).Nil()
+		assert.That(t, h.Enabled(context.Background(), slog.LevelInfo)).False()
+		assert.That(t, h.Enabled(context.Background(), slog.LevelWarn)).True()
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	prev := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(prev)
+		log.Destroy()
+	})
+
+	output := filepath.Join(t.TempDir(), "app.log")
+	closer, err := gs_log.Refresh(gs_log.SlogConfig{
+		Handler: "json",
+		Level:   "info",
+		Output:  output,
+	})
+	assert.That(t, err).Nil()
+
+	ctx := context.Background()
+	log.Debugf(ctx, log.TagAppDef, "not logged")
+	log.Infof(ctx, log.TagAppDef, "hello %s", "world")
+	log.Warn(ctx, log.TagBizDef, log.Msg("fields"),
+		log.Bool("ok", true),
+		log.Int("n", -1),
+		log.Float("f", 1.5),
+		log.Object("obj", log.String("k", "v")),
+		log.Ints("arr", []int{1, 2}),
+		log.FieldsFromMap(map[string]any{"m": "x"}),
+	)
+	slog.Error("from slog", "code", 7)
+	(&gs_log.SlogAppender{}).Write([]byte("raw line\n"))
+	assert.That(t, closer.Close()).Nil()
+
+	b, err := os.ReadFile(output)
+	assert.That(t, err).Nil()
+	lines := bytes.Split(bytes.TrimSpace(b), []byte("\n"))
+	assert.That(t, len(lines)).Equal(4)
+
+	var records []map[string]any
+	for _, line := range lines {
+		var m map[string]any
+		assert.That(t, json.Unmarshal(line, &m)).Nil()
+		_, err = time.Parse(time.RFC3339Nano, m["time"].(string))
+		assert.That(t, err).Nil()
+		delete(m, "time")
+		records = append(records, m)
+	}
+
+	for i := range 2 {
+		assert.String(t, records[i]["caller"].(string)).Contains("slog_test.go:")
+		delete(records[i], "caller")
+	}
+	assert.That(t, records[0]).Equal(map[string]any{
+		"level": "INFO",
+		"msg":   "hello world",
+		"tag":   "_app_def",
+	})
+
+	assert.That(t, records[1]).Equal(map[string]any{
+		"level": "WARN",
+		"msg":   "fields",
+		"tag":   "_biz_def",
+		"ok":    true,
+		"n":     float64(-1),
+		"f":     1.5,
+		"obj":   map[string]any{"k": "v"},
+		"arr":   "[1,2]",
+		"m":     "x",
+	})
+
+	assert.That(t, records[2]).Equal(map[string]any{
+		"level": "ERROR",
+		"msg":   "from slog",
+		"code":  float64(7),
+	})
+
+	assert.That(t, records[3]).Equal(map[string]any{
+		"level": "INFO",
+		"msg":   "raw line",
+	})
+}
+
+func TestReconfigure(t *testing.T) {
+	prev := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(prev)
+		log.Destroy()
+	})
+
+	dir := t.TempDir()
+	closer, err := gs_log.Refresh(gs_log.SlogConfig{
+		Handler: "text",
+		Level:   "info",
+		Output:  filepath.Join(dir, "a.log"),
+	})
+	assert.That(t, err).Nil()
+	l := gs_log.Logger("reconfigure")
+	slog.Info("before")
+
+	err = gs_log.Reconfigure(gs_log.SlogConfig{Handler: "yaml", Output: "stdout"})
+	assert.Error(t, err).Matches(`invalid logging handler: "yaml"`)
+
+	err = gs_log.Reconfigure(gs_log.SlogConfig{
+		Handler: "json",
+		Output:  filepath.Join(dir, "b.log"),
+	})
+	assert.That(t, err).Nil()
+	slog.Info("after")
+	l.Info("named")
+	assert.That(t, closer.Close()).Nil()
+
+	b, err := os.ReadFile(filepath.Join(dir, "a.log"))
+	assert.That(t, err).Nil()
+	assert.String(t, string(b)).Matches(`level=INFO msg=before\n$`)
+
+	b, err = os.ReadFile(filepath.Join(dir, "b.log"))
+	assert.That(t, err).Nil()
+	assert.String(t, string(b)).Matches(`"msg":"after"}\n.*"msg":"named","logger":"reconfigure"}\n$`)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gs_otel records OpenTelemetry metrics about the Go-Spring runtime.
+//
+// The instruments are created from a [metric.MeterProvider] supplied by the
+// application, so nothing is recorded unless such a provider is registered
+// as a bean.
+//
+// It also records the spans of the application, see [Tracer], and of the
+// goroutines launched by goutil, see [GoroutineSpans], so that neither
+// the application nor goutil depend on OpenTelemetry themselves.
+package gs_otel
+
+import (
+	"context"
+
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrumentationName is the name of the meter used by Go-Spring.
+const instrumentationName = "github.com/go-spring/spring-core"
+
+// RefreshMetrics is an event listener that records metrics for every
+// [gs.PropertiesRefreshed] event:
+//
+//   - spring.config.refresh.duration: histogram of refresh durations,
+//     with an "outcome" attribute of "success" or "failure".
+//   - spring.config.refresh.count: number of refreshes by "outcome".
+//   - spring.config.refresh.changed_keys: number of changed keys by
+//     "source", e.g. a config file path, "Environment" or "Args".
+type RefreshMetrics struct {
+	duration metric.Float64Histogram
+	count    metric.Int64Counter
+	changes  metric.Int64Counter
+}
+
+// NewRefreshMetrics creates the refresh instruments from mp.
+func NewRefreshMetrics(mp metric.MeterProvider) (*RefreshMetrics, error) {
+	meter := mp.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram("spring.config.refresh.duration",
+		metric.WithDescription("Duration of configuration refreshes."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := meter.Int64Counter("spring.config.refresh.count",
+		metric.WithDescription("Number of configuration refreshes."),
+		metric.WithUnit("{refresh}"))
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := meter.Int64Counter("spring.config.refresh.changed_keys",
+		metric.WithDescription("Number of property keys changed by configuration refreshes."),
+		metric.WithUnit("{key}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshMetrics{
+		duration: duration,
+		count:    count,
+		changes:  changes,
+	}, nil
+}
+
+// OnEvent records the metrics of a [gs.PropertiesRefreshed] event and
+// ignores any other event.
+func (m *RefreshMetrics) OnEvent(ctx context.Context, event any) {
+	e, ok := event.(gs.PropertiesRefreshed)
+	if !ok {
+		return
+	}
+
+	outcome := "success"
+	if e.Err != nil {
+		outcome = "failure"
+	}
+	attrs := metric.WithAttributes(attribute.String("outcome", outcome))
+	m.duration.Record(ctx, e.Duration.Seconds(), attrs)
+	m.count.Add(ctx, 1, attrs)
+
+	sources := make(map[string]int64)
+	for _, c := range e.Changes {
+		sources[c.Source]++
+	}
+	for source, n := range sources {
+		m.changes.Add(ctx, n, metric.WithAttributes(attribute.String("source", source)))
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_otel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// collect returns the collected metrics of reader by name.
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	assert.That(t, reader.Collect(context.Background(), &rm)).Nil()
+	ret := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			ret[m.Name] = m.Data
+		}
+	}
+	return ret
+}
+
+// sums returns the data points of a counter keyed by the value of attr.
+func sums(data metricdata.Aggregation, attr attribute.Key) map[string]int64 {
+	ret := make(map[string]int64)
+	for _, p := range data.(metricdata.Sum[int64]).DataPoints {
+		v, _ := p.Attributes.Value(attr)
+		ret[v.AsString()] = p.Value
+	}
+	return ret
+}
+
+func TestRefreshMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m, err := gs_otel.NewRefreshMetrics(mp)
+	assert.That(t, err).Nil()
+
+	ctx := context.Background()
+	m.OnEvent(ctx, "ignored")
+	m.OnEvent(ctx, gs.PropertiesRefreshed{
+		Duration: 20 * time.Millisecond,
+		Changes: []gs.PropertyChange{
+			{Key: "a", Source: "app.yaml"},
+			{Key: "b", Source: "app.yaml"},
+			{Key: "c", Source: "Environment"},
+		},
+	})
+	m.OnEvent(ctx, gs.PropertiesRefreshed{
+		Duration: 10 * time.Millisecond,
+		Err:      errors.New("bad config"),
+	})
+
+	data := collect(t, reader)
+	assert.That(t, len(data)).Equal(3)
+	assert.That(t, sums(data["spring.config.refresh.count"], "outcome")).Equal(map[string]int64{
+		"success": 1,
+		"failure": 1,
+	})
+	assert.That(t, sums(data["spring.config.refresh.changed_keys"], "source")).Equal(map[string]int64{
+		"app.yaml":    2,
+		"Environment": 1,
+	})
+
+	durations := make(map[string]float64)
+	for _, p := range data["spring.config.refresh.duration"].(metricdata.Histogram[float64]).DataPoints {
+		v, _ := p.Attributes.Value("outcome")
+		durations[v.AsString()] = p.Sum
+	}
+	assert.That(t, durations).Equal(map[string]float64{
+		"success": 0.02,
+		"failure": 0.01,
+	})
+}
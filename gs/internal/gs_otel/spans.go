@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-spring/spring-core/util/goutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GoroutineSpans is the [goutil.SpanStarter] running the goroutines of
+// goutil in OpenTelemetry spans started by Tracer. Each span is a child of
+// the span carried by the context of the goroutine, or the root of a new
+// trace linked to it if Link is set, which suits background work outliving
+// the request starting it. No span is started if Tracer is nil.
+type GoroutineSpans struct {
+	Tracer trace.Tracer
+	Link   bool
+}
+
+// Start implements [goutil.SpanStarter].
+func (s *GoroutineSpans) Start(ctx context.Context, name string) (context.Context, goutil.Span) {
+	sc := trace.SpanContextFromContext(ctx)
+	if s.Tracer == nil || !sc.IsValid() {
+		return ctx, nil
+	}
+	var span trace.Span
+	if s.Link {
+		ctx, span = s.Tracer.Start(ctx, name, trace.WithNewRoot(), trace.WithLinks(trace.Link{SpanContext: sc}))
+	} else {
+		ctx, span = s.Tracer.Start(ctx, name)
+	}
+	return ctx, goroutineSpan{span}
+}
+
+// TraceID implements [goutil.SpanStarter].
+func (s *GoroutineSpans) TraceID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+// goroutineSpan adapts a [trace.Span] to [goutil.Span].
+type goroutineSpan struct {
+	span trace.Span
+}
+
+// SetError implements [goutil.Span].
+func (s goroutineSpan) SetError(err error) {
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// RecordPanic implements [goutil.Span].
+func (s goroutineSpan) RecordPanic(r any, stack []byte) {
+	s.span.RecordError(fmt.Errorf("panic recovered: %v", r),
+		trace.WithAttributes(attribute.String("exception.stacktrace", string(stack))))
+	s.span.SetStatus(codes.Error, "panic recovered")
+}
+
+// End implements [goutil.Span].
+func (s goroutineSpan) End() {
+	s.span.End()
+}
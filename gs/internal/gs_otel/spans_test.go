@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_otel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/internal/gs_otel"
+	"github.com/go-spring/spring-core/util/goutil"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestGoroutineSpans(t *testing.T) {
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	spans := &gs_otel.GoroutineSpans{Tracer: tp.Tracer("goutil")}
+	goutil.Spans = spans
+	defer func() { goutil.Spans = nil }()
+
+	onPanic := goutil.OnPanic
+	defer func() { goutil.OnPanic = onPanic }()
+	var panicTraceID string
+	goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {
+		panicTraceID = goutil.TraceID(ctx)
+	}
+
+	t.Run("no span", func(t *testing.T) {
+		var traceID string
+		goutil.Go(t.Context(), func(ctx context.Context) {
+			traceID = goutil.TraceID(ctx)
+		}).Wait()
+		assert.That(t, traceID).Equal("")
+		assert.That(t, len(rec.Ended())).Equal(0)
+	})
+
+	ctx, parent := tp.Tracer("test").Start(t.Context(), "request")
+	defer parent.End()
+	parentTraceID := parent.SpanContext().TraceID().String()
+
+	t.Run("go", func(t *testing.T) {
+		var traceID string
+		goutil.Go(ctx, func(ctx context.Context) {
+			traceID = goutil.TraceID(ctx)
+		}).Wait()
+		assert.That(t, traceID).Equal(parentTraceID)
+
+		ended := rec.Ended()
+		assert.That(t, len(ended)).Equal(1)
+		assert.String(t, ended[0].Name()).HasPrefix("github.com/go-spring/spring-core/gs/internal/gs_otel_test.TestGoroutineSpans.")
+		assert.That(t, ended[0].Parent().SpanID()).Equal(parent.SpanContext().SpanID())
+		assert.That(t, ended[0].Status().Code).Equal(codes.Unset)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		goutil.Go(ctx, func(ctx context.Context) {
+			panic("something is wrong")
+		}).Wait()
+		assert.That(t, panicTraceID).Equal(parentTraceID)
+
+		ended := rec.Ended()
+		assert.That(t, len(ended)).Equal(2)
+		assert.That(t, ended[1].Status().Code).Equal(codes.Error)
+		assert.String(t, ended[1].Status().Description).Equal("panic recovered")
+		assert.That(t, len(ended[1].Events())).Equal(1)
+	})
+
+	t.Run("go value error", func(t *testing.T) {
+		_, err := goutil.GoValue(ctx, func(ctx context.Context) (int, error) {
+			return 0, errors.New("expected error")
+		}).Wait()
+		assert.Error(t, err).Matches("expected error")
+
+		ended := rec.Ended()
+		assert.That(t, len(ended)).Equal(3)
+		assert.That(t, ended[2].Status().Code).Equal(codes.Error)
+		assert.String(t, ended[2].Status().Description).Equal("expected error")
+	})
+
+	t.Run("link spans", func(t *testing.T) {
+		spans.Link = true
+		defer func() { spans.Link = false }()
+
+		var traceID string
+		goutil.Go(ctx, func(ctx context.Context) {
+			traceID = goutil.TraceID(ctx)
+		}).Wait()
+		assert.That(t, traceID != "" && traceID != parentTraceID).True()
+
+		ended := rec.Ended()
+		assert.That(t, len(ended)).Equal(4)
+		assert.That(t, ended[3].Parent().IsValid()).False()
+		assert.That(t, len(ended[3].Links())).Equal(1)
+		assert.That(t, ended[3].Links()[0].SpanContext.SpanID()).Equal(parent.SpanContext().SpanID())
+	})
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_otel
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+	"github.com/go-spring/spring-core/gs/internal/gs_core"
+	"github.com/go-spring/spring-core/util/goutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is the [gs_app.Tracer] recording the spans of the application
+// with OpenTelemetry.
+type Tracer struct {
+	// Provider provides the tracer of the spans, the global OpenTelemetry
+	// provider if nil.
+	Provider trace.TracerProvider
+}
+
+// tracer returns the tracer of the spans.
+func (t *Tracer) tracer() trace.Tracer {
+	tp := t.Provider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(instrumentationName)
+}
+
+// TraceStartup records the startup as a "spring.app.start" span, with a
+// child span per startup phase. The span of the container phase has a
+// child span per refresh phase, and that of the inject phase a child
+// span per bean wired during the refresh.
+func (t *Tracer) TraceStartup(ctx context.Context, c *gs_core.Container, start time.Time, phases []gs_app.StartupPhase) {
+	tracer := t.tracer()
+	ctx, root := tracer.Start(ctx, "spring.app.start", trace.WithTimestamp(start))
+	for _, p := range phases {
+		end := start.Add(p.Duration)
+		phaseCtx, span := tracer.Start(ctx, "spring.startup."+p.Name, trace.WithTimestamp(start))
+		if p.Name == "container" {
+			traceContainer(phaseCtx, tracer, c)
+		}
+		span.End(trace.WithTimestamp(end))
+		start = end
+	}
+	root.End(trace.WithTimestamp(start))
+}
+
+// traceContainer records the phases of the container refresh as spans.
+func traceContainer(ctx context.Context, tracer trace.Tracer, c *gs_core.Container) {
+	for _, p := range c.Phases() {
+		phaseCtx, span := tracer.Start(ctx, "spring.container."+p.Name, trace.WithTimestamp(p.Start))
+		if p.Name == "inject" && c.Injecting != nil {
+			for _, b := range c.Timings() {
+				_, s := tracer.Start(phaseCtx, "spring.bean.init",
+					trace.WithTimestamp(b.Start),
+					trace.WithAttributes(
+						attribute.String("bean.name", b.Bean.Name()),
+						attribute.String("bean.type", b.Bean.Type().String()),
+					))
+				s.End(trace.WithTimestamp(b.Start.Add(b.Elapsed)))
+			}
+		}
+		span.End(trace.WithTimestamp(p.End))
+	}
+}
+
+// StartRefresh starts the "spring.config.refresh" span of a properties
+// refresh, ended with the error of the refresh, if any.
+func (t *Tracer) StartRefresh(ctx context.Context, start time.Time) func(err error) {
+	_, span := t.tracer().Start(ctx, "spring.config.refresh", trace.WithTimestamp(start))
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// GoroutineSpans returns the [GoroutineSpans] started by the tracer.
+func (t *Tracer) GoroutineSpans(link bool) goutil.SpanStarter {
+	return &GoroutineSpans{Tracer: t.tracer(), Link: link}
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_otel_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_otel"
+	"github.com/go-spring/spring-core/util/goutil"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type traceBean struct{}
+
+// reset clears the config sources of the application.
+func reset() {
+	os.Args = nil
+	os.Clearenv()
+	gs_conf.SysConf = conf.New()
+}
+
+func TestTracer(t *testing.T) {
+
+	t.Run("startup", func(t *testing.T) {
+		reset()
+		t.Cleanup(reset)
+
+		rec := tracetest.NewSpanRecorder()
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		app := gs_app.NewApp()
+		app.Tracer = &gs_otel.Tracer{Provider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))}
+		app.C.Root(app.C.Object(&traceBean{}).Name("traceBean"))
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		app.ShutDown()
+		app.WaitForShutdown()
+
+		var beans []string
+		spans := make(map[string]sdktrace.ReadOnlySpan)
+		parents := make(map[string]string)
+		for _, s := range rec.Ended() {
+			for _, kv := range s.Attributes() {
+				if kv.Key == "bean.name" {
+					beans = append(beans, kv.Value.AsString())
+				}
+			}
+			spans[s.Name()] = s
+		}
+		assert.That(t, slices.Contains(beans, "traceBean")).True()
+		for name, s := range spans {
+			for pName, p := range spans {
+				if s.Parent().SpanID() == p.SpanContext().SpanID() {
+					parents[name] = pName
+				}
+			}
+		}
+		assert.That(t, parents).Equal(map[string]string{
+			"spring.startup.config":    "spring.app.start",
+			"spring.startup.container": "spring.app.start",
+			"spring.startup.runners":   "spring.app.start",
+			"spring.container.resolve": "spring.startup.container",
+			"spring.container.inject":  "spring.startup.container",
+			"spring.bean.init":         "spring.container.inject",
+		})
+		root := spans["spring.app.start"]
+		assert.That(t, root.EndTime().After(root.StartTime())).True()
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		reset()
+		t.Cleanup(reset)
+
+		rec := tracetest.NewSpanRecorder()
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.enable.otel-tracing", "false", fileID)
+		app := gs_app.NewApp()
+		app.Tracer = &gs_otel.Tracer{Provider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))}
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		err = app.RefreshProperties()
+		assert.That(t, err).Nil()
+		app.ShutDown()
+		app.WaitForShutdown()
+		assert.That(t, len(rec.Ended())).Equal(0)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		reset()
+		t.Cleanup(reset)
+
+		rec := tracetest.NewSpanRecorder()
+		dir := t.TempDir()
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.config-local.dir", dir, fileID)
+		app := gs_app.NewApp()
+		app.Tracer = &gs_otel.Tracer{Provider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))}
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+
+		err = app.RefreshProperties()
+		assert.That(t, err).Nil()
+		err = os.WriteFile(dir+"/app.yaml", []byte("a: [1"), os.ModePerm)
+		assert.That(t, err).Nil()
+		err = app.RefreshProperties()
+		assert.Error(t, err).Matches("refresh error in source local")
+
+		var spans []sdktrace.ReadOnlySpan
+		for _, s := range rec.Ended() {
+			if s.Name() == "spring.config.refresh" {
+				spans = append(spans, s)
+			}
+		}
+		assert.That(t, len(spans)).Equal(2)
+		assert.That(t, spans[0].Status().Code).Equal(codes.Unset)
+		assert.That(t, spans[1].Status().Code).Equal(codes.Error)
+		assert.That(t, len(spans[1].Events())).Equal(1)
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("goroutine spans", func(t *testing.T) {
+		reset()
+		t.Cleanup(reset)
+		t.Cleanup(func() {
+			goutil.Spans = nil
+		})
+
+		rec := tracetest.NewSpanRecorder()
+		fileID := gs_conf.SysConf.AddFile("trace_test.go")
+		_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+		_ = gs_conf.SysConf.Set("spring.app.goroutine-spans", "link", fileID)
+		app := gs_app.NewApp()
+		app.Tracer = &gs_otel.Tracer{Provider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))}
+
+		err := app.Start()
+		assert.That(t, err).Nil()
+		spans, ok := goutil.Spans.(*gs_otel.GoroutineSpans)
+		assert.That(t, ok).True()
+		assert.That(t, spans.Tracer).NotNil()
+		assert.That(t, spans.Link).True()
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gs_prom exposes the statistics of a Go-Spring application as
+// Prometheus metrics.
+package gs_prom
+
+import (
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+	"github.com/go-spring/spring-core/util/goutil"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// phases lists all lifecycle phases reported by spring_app_phase.
+var phases = []gs_app.Phase{
+	gs_app.PhaseCreated,
+	gs_app.PhaseStarting,
+	gs_app.PhaseRunning,
+	gs_app.PhaseStopping,
+	gs_app.PhaseStopped,
+}
+
+// Collector is a [prometheus.Collector] that exposes the container, the
+// lifecycle and the goroutine statistics of an application:
+//
+//   - spring_beans: number of beans wired by the container.
+//   - spring_bean_init_seconds: time spent creating each bean, labelled
+//     by "bean", excluding the time spent creating its dependencies.
+//   - spring_app_phase: 1 for the current lifecycle "phase", 0 otherwise.
+//   - spring_jobs_started_total, spring_jobs_running, spring_jobs_failed_total:
+//     statistics of the application jobs.
+//   - spring_goroutines_started_total, spring_goroutines_running,
+//     spring_goroutine_panics_total: statistics of the goroutines launched
+//     by the goutil package.
+type Collector struct {
+	app *gs_app.App
+	reg prometheus.Registerer
+
+	beans             *prometheus.Desc
+	beanInit          *prometheus.Desc
+	phase             *prometheus.Desc
+	jobsStarted       *prometheus.Desc
+	jobsRunning       *prometheus.Desc
+	jobsFailed        *prometheus.Desc
+	goroutinesStarted *prometheus.Desc
+	goroutinesRunning *prometheus.Desc
+	goroutinePanics   *prometheus.Desc
+}
+
+// NewCollector creates a Collector for app.
+func NewCollector(app *gs_app.App) *Collector {
+	return &Collector{
+		app: app,
+		beans: prometheus.NewDesc("spring_beans",
+			"Number of beans wired by the container.", nil, nil),
+		beanInit: prometheus.NewDesc("spring_bean_init_seconds",
+			"Time spent creating the bean, excluding its dependencies.", []string{"bean"}, nil),
+		phase: prometheus.NewDesc("spring_app_phase",
+			"Current lifecycle phase of the application.", []string{"phase"}, nil),
+		jobsStarted: prometheus.NewDesc("spring_jobs_started_total",
+			"Number of jobs started.", nil, nil),
+		jobsRunning: prometheus.NewDesc("spring_jobs_running",
+			"Number of jobs currently running.", nil, nil),
+		jobsFailed: prometheus.NewDesc("spring_jobs_failed_total",
+			"Number of jobs that returned an error or panicked.", nil, nil),
+		goroutinesStarted: prometheus.NewDesc("spring_goroutines_started_total",
+			"Number of goroutines started by goutil.", nil, nil),
+		goroutinesRunning: prometheus.NewDesc("spring_goroutines_running",
+			"Number of goroutines started by goutil and not yet finished.", nil, nil),
+		goroutinePanics: prometheus.NewDesc("spring_goroutine_panics_total",
+			"Number of panics recovered in goroutines started by goutil.", nil, nil),
+	}
+}
+
+// Register creates a Collector for app and registers it into reg.
+func Register(app *gs_app.App, reg prometheus.Registerer) (*Collector, error) {
+	c := NewCollector(app)
+	if err := reg.Register(c); err != nil {
+		return nil, err
+	}
+	c.reg = reg
+	return c, nil
+}
+
+// Unregister removes the collector from the registerer it was registered
+// into by [Register].
+func (c *Collector) Unregister() {
+	if c.reg != nil {
+		c.reg.Unregister(c)
+		c.reg = nil
+	}
+}
+
+// Describe implements [prometheus.Collector].
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.beans
+	ch <- c.beanInit
+	ch <- c.phase
+	ch <- c.jobsStarted
+	ch <- c.jobsRunning
+	ch <- c.jobsFailed
+	ch <- c.goroutinesStarted
+	ch <- c.goroutinesRunning
+	ch <- c.goroutinePanics
+}
+
+// Collect implements [prometheus.Collector].
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	if c.app.C.Injecting != nil {
+		timings := c.app.C.Timings()
+		ch <- prometheus.MustNewConstMetric(c.beans, prometheus.GaugeValue, float64(len(timings)))
+		for _, t := range timings {
+			ch <- prometheus.MustNewConstMetric(c.beanInit, prometheus.GaugeValue, t.Duration.Seconds(), t.Bean.Name())
+		}
+	}
+
+	current := c.app.Phase()
+	for _, p := range phases {
+		v := 0.0
+		if p == current {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.phase, prometheus.GaugeValue, v, p.String())
+	}
+
+	jobs := c.app.JobStats()
+	ch <- prometheus.MustNewConstMetric(c.jobsStarted, prometheus.CounterValue, float64(jobs.Started))
+	ch <- prometheus.MustNewConstMetric(c.jobsRunning, prometheus.GaugeValue, float64(jobs.Running))
+	ch <- prometheus.MustNewConstMetric(c.jobsFailed, prometheus.CounterValue, float64(jobs.Failed))
+
+	stats := goutil.ReadStats()
+	ch <- prometheus.MustNewConstMetric(c.goroutinesStarted, prometheus.CounterValue, float64(stats.Started))
+	ch <- prometheus.MustNewConstMetric(c.goroutinesRunning, prometheus.GaugeValue, float64(stats.Running))
+	ch <- prometheus.MustNewConstMetric(c.goroutinePanics, prometheus.CounterValue, float64(stats.Panics))
+}
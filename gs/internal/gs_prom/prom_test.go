@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_prom_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_prom"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// value returns the value of the metric named name whose labels contain
+// the given name/value pairs, or -1 if there is no such metric.
+func value(t *testing.T, reg *prometheus.Registry, name string, labels ...string) float64 {
+	mfs, err := reg.Gather()
+	assert.That(t, err).Nil()
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if !hasLabels(m, labels) {
+				continue
+			}
+			if m.Gauge != nil {
+				return m.GetGauge().GetValue()
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return -1
+}
+
+// hasLabels reports whether m has all the name/value label pairs.
+func hasLabels(m *dto.Metric, labels []string) bool {
+	for i := 0; i < len(labels); i += 2 {
+		found := false
+		for _, l := range m.GetLabel() {
+			if l.GetName() == labels[i] && l.GetValue() == labels[i+1] {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type Service struct{}
+
+func TestCollector(t *testing.T) {
+	args := os.Args
+	os.Args = nil
+	t.Cleanup(func() {
+		os.Args = args
+		gs_conf.SysConf = conf.New()
+	})
+
+	gs_conf.SysConf = conf.New()
+	fileID := gs_conf.SysConf.AddFile("prom_test.go")
+	_ = gs_conf.SysConf.Set("spring.app.enable-servers", "false", fileID)
+
+	app := gs_app.NewApp()
+	app.C.Root(app.C.Object(&Service{}))
+	app.C.Object(gs.FuncJob(func(ctx context.Context) error {
+		return errors.New("job failed")
+	})).AsJob()
+
+	reg := prometheus.NewRegistry()
+	c, err := gs_prom.Register(app, reg)
+	assert.That(t, err).Nil()
+	assert.That(t, value(t, reg, "spring_app_phase", "phase", "created")).Equal(1.0)
+	assert.That(t, value(t, reg, "spring_beans")).Equal(-1.0)
+
+	err = app.Start()
+	assert.That(t, err).Nil()
+	time.Sleep(50 * time.Millisecond)
+
+	assert.That(t, value(t, reg, "spring_beans") >= 2).True()
+	assert.That(t, value(t, reg, "spring_bean_init_seconds", "bean", "Service") >= 0).True()
+	assert.That(t, value(t, reg, "spring_app_phase", "phase", "stopping")).Equal(1.0)
+	assert.That(t, value(t, reg, "spring_app_phase", "phase", "running")).Equal(0.0)
+	assert.That(t, value(t, reg, "spring_jobs_started_total")).Equal(1.0)
+	assert.That(t, value(t, reg, "spring_jobs_running")).Equal(0.0)
+	assert.That(t, value(t, reg, "spring_jobs_failed_total")).Equal(1.0)
+	assert.That(t, value(t, reg, "spring_goroutines_started_total") >= 1).True()
+	assert.That(t, value(t, reg, "spring_goroutine_panics_total") >= 0).True()
+
+	app.WaitForShutdown()
+	assert.That(t, value(t, reg, "spring_app_phase", "phase", "stopped")).Equal(1.0)
+
+	c.Unregister()
+	assert.That(t, value(t, reg, "spring_app_phase", "phase", "stopped")).Equal(-1.0)
+}
@@ -18,14 +18,26 @@ package gs
 
 import (
 	"context"
+	stdlog "log"
+	"log/slog"
 	"path/filepath"
 	"strings"
 
 	"github.com/go-spring/log"
 	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
 	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_log"
 )
 
+func init() {
+	// Rebuilds the log output when its properties change on refresh.
+	Object(FuncEventListener(onLoggingRefreshed)).Name("loggingListener").Export(
+		As[EventListener](),
+	)
+}
+
 // initLog initializes the application's logging system.
 func initLog() error {
 
@@ -79,12 +91,103 @@ func initLog() error {
 
 	// Step 5: Apply logging configuration or fall back to defaults.
 	switch n := len(logFiles); {
-	case n == 0:
-		log.Infof(context.Background(), log.TagAppDef, "no log configuration file found, using default logger")
-		return nil
 	case n > 1:
 		return util.FormatError(nil, "multiple log files found: %s", logFiles)
-	default:
-		return log.RefreshFile(logFiles[0])
+	case n == 1:
+		if err = gs_log.RefreshFile(logFiles[0]); err != nil {
+			return err
+		}
+	}
+
+	// Step 6: Apply the logging properties of the application config.
+	ap, err := gs_conf.NewAppConfig().Refresh()
+	if err != nil {
+		return util.FormatError(err, "refresh error in source app")
+	}
+	if len(logFiles) == 0 {
+		if err = initSlog(ap); err != nil {
+			return err
+		}
+	}
+	applyLogLevels(context.Background(), ap, logLevelNames(ap))
+	return nil
+}
+
+// onLoggingRefreshed rebuilds the handler and the output of the logs
+// bridged to slog when a "logging.*" property other than the levels is
+// changed by a properties refresh.
+func onLoggingRefreshed(ctx context.Context, event any) {
+	e, ok := event.(gs.PropertiesRefreshed)
+	if !ok {
+		return
+	}
+	changed := false
+	for _, c := range e.Changes {
+		if strings.HasPrefix(c.Key, "logging.") && !strings.HasPrefix(c.Key, LogLevelPrefix+".") {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+	var c gs_log.SlogConfig
+	if err := app.C.Properties().Bind(&c); err != nil {
+		log.Warnf(ctx, log.TagAppDef, "bind logging config error: %v", err)
+		return
+	}
+	if err := gs_log.Reconfigure(c); err != nil {
+		log.Warnf(ctx, log.TagAppDef, "reconfigure logging error: %v", err)
+	}
+}
+
+// logLevelNames returns the names of the loggers whose levels are set in
+// p, which may be dotted like "gs.conf" for "logging.level.gs.conf".
+func logLevelNames(p conf.Properties) []string {
+	var names []string
+	for _, key := range p.Keys() {
+		if name, ok := strings.CutPrefix(key, LogLevelPrefix+"."); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Logger returns the slog logger of the given name, whose level is set by
+// the "logging.level.<name>" property or that of its closest dotted
+// ancestor, and follows the changes of these properties on refresh.
+func Logger(name string) *slog.Logger {
+	return gs_log.Logger(name)
+}
+
+// StdLogger returns a standard library logger writing to the slog logger
+// of the given name at the INFO level, for the libraries that need one.
+func StdLogger(name string) *stdlog.Logger {
+	return slog.NewLogLogger(Logger(name).Handler(), slog.LevelInfo)
+}
+
+// ProvideLogger registers the slog logger of the given name as a bean of
+// the same name, to be injected into *slog.Logger fields tagged like
+// `autowire:"<name>"`.
+func ProvideLogger(name string) *gs.RegisteredBean {
+	return app.C.Provide(gs_log.Logger, ValueArg(name)).Name(name).Caller(1)
+}
+
+// initSlog routes the framework logs through log/slog when the handler
+// or the output of "logging.*" is set in the application config,
+// otherwise it keeps the default logger.
+func initSlog(p conf.Properties) error {
+	if !p.Has("logging.handler") && !p.Has("logging.output") {
+		log.Infof(context.Background(), log.TagAppDef, "no log configuration file found, using default logger")
+		return nil
+	}
+	var c gs_log.SlogConfig
+	if err := p.Bind(&c); err != nil {
+		return util.FormatError(err, "bind error in logging config")
+	}
+	// The output stays open for the lifetime of the process.
+	if _, err := gs_log.Refresh(c); err != nil {
+		return util.FormatError(err, "refresh error in logging config")
 	}
+	return nil
 }
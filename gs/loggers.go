@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_log"
+)
+
+// LogLevelPrefix is the prefix of the properties that set the levels of
+// the loggers, e.g. "logging.level.root=debug" or, for the named loggers
+// of [Logger], "logging.level.gs.conf=debug".
+const LogLevelPrefix = "logging.level"
+
+func init() {
+	// Mounts the loggers endpoints on the admin server when enabled.
+	for name, pattern := range map[string]string{
+		"loggersAdminHandler": "GET /loggers",
+		"loggerAdminHandler":  "/loggers/{name}",
+	} {
+		Object(NewLoggersAdminHandler(pattern, persistLogLevel)).Name(name).Condition(
+			OnEnableServers(),
+			OnProperty(EnableAdminServerProp).HavingValue("true"),
+			OnProperty(EnableAdminLoggersProp).HavingValue("true").MatchIfMissing(),
+		).Export(
+			As[AdminHandler](),
+		)
+	}
+
+	// Applies the levels of the loggers changed by a properties refresh.
+	Object(FuncEventListener(onLogLevelsRefreshed)).Name("logLevelsListener").Export(
+		As[EventListener](),
+	)
+}
+
+// LoggerDescriptor describes the level of a logger.
+type LoggerDescriptor struct {
+	ConfiguredLevel string `json:"configuredLevel"`
+}
+
+// LoggersDescriptor describes the levels of all the loggers.
+type LoggersDescriptor struct {
+	Levels  []string                    `json:"levels"`
+	Loggers map[string]LoggerDescriptor `json:"loggers"`
+}
+
+// LoggersAdminHandler serves the loggers endpoints on the admin server:
+//
+//	GET  /loggers         lists the levels of the configured loggers
+//	GET  /loggers/{name}  returns the level of a logger
+//	POST /loggers/{name}  changes the level of a logger, the request body
+//	                      is like {"configuredLevel":"DEBUG"}
+//
+// The root logger is named "root".
+type LoggersAdminHandler struct {
+	*http.ServeMux
+	pattern string
+	persist func(name, level string) error
+}
+
+// NewLoggersAdminHandler creates a new LoggersAdminHandler mounted on the
+// pattern. After a level has been changed, persist is called to record it
+// so that it survives the next properties refresh.
+func NewLoggersAdminHandler(pattern string, persist func(name, level string) error) *LoggersAdminHandler {
+	h := &LoggersAdminHandler{
+		ServeMux: http.NewServeMux(),
+		pattern:  pattern,
+		persist:  persist,
+	}
+	h.HandleFunc("GET /loggers", h.listLoggers)
+	h.HandleFunc("GET /loggers/{name}", h.getLogger)
+	h.HandleFunc("POST /loggers/{name}", h.setLogger)
+	return h
+}
+
+// Pattern returns the pattern the handler is mounted on.
+func (h *LoggersAdminHandler) Pattern() string {
+	return h.pattern
+}
+
+func (h *LoggersAdminHandler) listLoggers(w http.ResponseWriter, r *http.Request) {
+	ret := LoggersDescriptor{Loggers: make(map[string]LoggerDescriptor)}
+	for _, l := range []log.Level{
+		log.TraceLevel, log.DebugLevel, log.InfoLevel, log.WarnLevel,
+		log.ErrorLevel, log.PanicLevel, log.FatalLevel,
+	} {
+		ret.Levels = append(ret.Levels, l.String())
+	}
+	for _, l := range gs_log.Loggers() {
+		ret.Loggers[l.Name] = LoggerDescriptor{ConfiguredLevel: l.Level}
+	}
+	writeJSON(w, ret)
+}
+
+func (h *LoggersAdminHandler) getLogger(w http.ResponseWriter, r *http.Request) {
+	level, err := gs_log.GetLevel(r.PathValue("name"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, LoggerDescriptor{ConfiguredLevel: level})
+}
+
+func (h *LoggersAdminHandler) setLogger(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, err := gs_log.GetLevel(name); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	var req LoggerDescriptor
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	level, err := log.ParseLevel(req.ConfiguredLevel)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = gs_log.SetLevel(name, level.String()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.persist != nil {
+		if err = h.persist(name, level.String()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes v as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// persistLogLevel records the level of a logger in the runtime properties
+// of the application, then refreshes the properties to publish it.
+func persistLogLevel(name, level string) error {
+	if err := app.P.SetProperty(LogLevelPrefix+"."+name, level); err != nil {
+		return err
+	}
+	return app.RefreshProperties()
+}
+
+// onLogLevelsRefreshed applies the levels of the loggers changed by a
+// properties refresh.
+func onLogLevelsRefreshed(ctx context.Context, event any) {
+	e, ok := event.(gs.PropertiesRefreshed)
+	if !ok {
+		return
+	}
+	var names []string
+	for _, c := range e.Changes {
+		if name, ok := strings.CutPrefix(c.Key, LogLevelPrefix+"."); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		applyLogLevels(ctx, app.C.Properties(), names)
+	}
+}
+
+// applyLogLevels applies the levels of the named loggers set in p. Since
+// a bad level must not stop the application, errors are only logged.
+func applyLogLevels(ctx context.Context, p conf.Properties, names []string) {
+	for _, name := range names {
+		key := LogLevelPrefix + "." + name
+		if !p.Has(key) {
+			continue
+		}
+		if err := gs_log.ConfigureLevel(name, p.Get(key)); err != nil {
+			log.Warnf(ctx, log.TagAppDef, "set level of logger %s error: %v", name, err)
+		}
+	}
+}
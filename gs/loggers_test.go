@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+)
+
+func TestLoggersAdminHandler(t *testing.T) {
+
+	var persisted []string
+	h := gs.NewLoggersAdminHandler("GET /loggers", func(name, level string) error {
+		persisted = append(persisted, name+"="+level)
+		return nil
+	})
+	assert.String(t, h.Pattern()).Equal("GET /loggers")
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("list", func(t *testing.T) {
+		w := serve(http.MethodGet, "/loggers", "")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		assert.String(t, w.Header().Get("Content-Type")).Equal("application/json")
+		assert.String(t, w.Body.String()).Contains(`"levels":["TRACE","DEBUG","INFO","WARN","ERROR","PANIC","FATAL"]`)
+		assert.String(t, w.Body.String()).Contains(`"root":{"configuredLevel":"INFO"}`)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		w := serve(http.MethodGet, "/loggers/root", "")
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		assert.String(t, w.Body.String()).Equal(`{"configuredLevel":"INFO"}` + "\n")
+		w = serve(http.MethodGet, "/loggers/unknown", "")
+		assert.That(t, w.Code).Equal(http.StatusNotFound)
+		assert.String(t, w.Body.String()).Contains("logger unknown not found")
+	})
+
+	t.Run("set", func(t *testing.T) {
+		assert.That(t, serve(http.MethodPost, "/loggers/unknown", `{"configuredLevel":"DEBUG"}`).Code).Equal(http.StatusNotFound)
+		assert.That(t, serve(http.MethodPost, "/loggers/root", `{`).Code).Equal(http.StatusBadRequest)
+		assert.That(t, serve(http.MethodPost, "/loggers/root", `{"configuredLevel":"LOUD"}`).Code).Equal(http.StatusBadRequest)
+		assert.That(t, persisted).Nil()
+
+		w := serve(http.MethodPost, "/loggers/root", `{"configuredLevel":"debug"}`)
+		assert.That(t, w.Code).Equal(http.StatusNoContent)
+		assert.That(t, persisted).Equal([]string{"root=DEBUG"})
+		assert.String(t, serve(http.MethodGet, "/loggers/root", "").Body.String()).Equal(`{"configuredLevel":"DEBUG"}` + "\n")
+
+		w = serve(http.MethodPost, "/loggers/root", `{"configuredLevel":"INFO"}`)
+		assert.That(t, w.Code).Equal(http.StatusNoContent)
+		assert.String(t, serve(http.MethodGet, "/loggers/root", "").Body.String()).Equal(`{"configuredLevel":"INFO"}` + "\n")
+	})
+
+	t.Run("named", func(t *testing.T) {
+		l := gs.Logger("loggers.test")
+		assert.String(t, serve(http.MethodGet, "/loggers", "").Body.String()).Contains(`"loggers.test":{"configuredLevel":"INFO"}`)
+
+		w := serve(http.MethodPost, "/loggers/loggers.test", `{"configuredLevel":"debug"}`)
+		assert.That(t, w.Code).Equal(http.StatusNoContent)
+		assert.That(t, l.Enabled(t.Context(), slog.LevelDebug)).True()
+		assert.String(t, serve(http.MethodGet, "/loggers/loggers.test", "").Body.String()).Equal(`{"configuredLevel":"DEBUG"}` + "\n")
+	})
+}
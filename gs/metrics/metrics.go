@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package metrics is a facade to create the counters, gauges and
+// histograms of an application, backed by a Prometheus registry, and to
+// expose them in the Prometheus text format.
+//
+// Importing it makes the application provide a *Registry bean, which also
+// collects the Go runtime metrics (GC, goroutines, memory), and records
+// the metrics of the application into it, see the "spring.enable.metrics"
+// property. The registry is created by NewRegistry, or wraps the default
+// Prometheus registry when "spring.metrics.global-registry" is true. The
+// application doesn't depend on Prometheus otherwise. Metrics can be
+// declared as beans:
+//
+//	gs.Provide(metrics.CounterOf("orders_total", "Number of orders.")).Name("ordersTotal")
+//
+//	type OrderService struct {
+//		Orders metrics.Counter `autowire:"ordersTotal"`
+//	}
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Counter is a metric that only goes up, e.g. the number of requests.
+type Counter interface {
+	Inc()
+	Add(v float64)
+}
+
+// Gauge is a metric that goes up and down, e.g. the size of a queue.
+type Gauge interface {
+	Set(v float64)
+	Inc()
+	Dec()
+	Add(v float64)
+	Sub(v float64)
+}
+
+// Histogram samples observations, e.g. request durations, into buckets.
+type Histogram interface {
+	Observe(v float64)
+}
+
+// Registry creates the metrics and gathers them for exposition.
+type Registry struct {
+	reg      prometheus.Registerer
+	gatherer prometheus.Gatherer
+}
+
+// NewRegistry creates a Registry backed by a new Prometheus registry,
+// which collects the Go runtime and process metrics.
+func NewRegistry() *Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return &Registry{reg: reg, gatherer: reg}
+}
+
+// Wrap creates a Registry backed by an existing Prometheus registry, e.g.
+// prometheus.DefaultRegisterer and prometheus.DefaultGatherer.
+func Wrap(reg prometheus.Registerer, gatherer prometheus.Gatherer) *Registry {
+	return &Registry{reg: reg, gatherer: gatherer}
+}
+
+// Registerer returns the registerer the metrics are registered into.
+func (r *Registry) Registerer() prometheus.Registerer {
+	return r.reg
+}
+
+// Gatherer returns the gatherer the metrics are gathered from.
+func (r *Registry) Gatherer() prometheus.Gatherer {
+	return r.gatherer
+}
+
+// Handler returns the HTTP handler exposing the metrics in the Prometheus
+// text format.
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.gatherer, promhttp.HandlerOpts{})
+}
+
+// register registers c, or returns the collector already registered with
+// the same descriptor, so that a metric can be declared more than once.
+func register[T prometheus.Collector](r *Registry, c T) (T, error) {
+	if err := r.reg.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		var zero T
+		return zero, util.WrapError(err, "register metric error")
+	}
+	return c, nil
+}
+
+// Counter returns the counter of the name, creating it if needed.
+func (r *Registry) Counter(name, help string) (Counter, error) {
+	return register(r, prometheus.NewCounter(prometheus.CounterOpts{Name: name, Help: help}))
+}
+
+// CounterVec returns the counters of the name partitioned by the labels,
+// creating them if needed.
+func (r *Registry) CounterVec(name, help string, labels ...string) (*prometheus.CounterVec, error) {
+	return register(r, prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels))
+}
+
+// Gauge returns the gauge of the name, creating it if needed.
+func (r *Registry) Gauge(name, help string) (Gauge, error) {
+	return register(r, prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: help}))
+}
+
+// GaugeVec returns the gauges of the name partitioned by the labels,
+// creating them if needed.
+func (r *Registry) GaugeVec(name, help string, labels ...string) (*prometheus.GaugeVec, error) {
+	return register(r, prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels))
+}
+
+// Histogram returns the histogram of the name, creating it if needed,
+// with the buckets, or prometheus.DefBuckets if none.
+func (r *Registry) Histogram(name, help string, buckets ...float64) (Histogram, error) {
+	return register(r, prometheus.NewHistogram(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}))
+}
+
+// HistogramVec returns the histograms of the name partitioned by the
+// labels, creating them if needed, with prometheus.DefBuckets.
+func (r *Registry) HistogramVec(name, help string, labels ...string) (*prometheus.HistogramVec, error) {
+	return register(r, prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help}, labels))
+}
+
+// CounterOf returns a constructor of the counter of the name, to declare
+// it as a bean.
+func CounterOf(name, help string) func(r *Registry) (Counter, error) {
+	return func(r *Registry) (Counter, error) {
+		return r.Counter(name, help)
+	}
+}
+
+// GaugeOf returns a constructor of the gauge of the name, to declare it
+// as a bean.
+func GaugeOf(name, help string) func(r *Registry) (Gauge, error) {
+	return func(r *Registry) (Gauge, error) {
+		return r.Gauge(name, help)
+	}
+}
+
+// HistogramOf returns a constructor of the histogram of the name, to
+// declare it as a bean.
+func HistogramOf(name, help string, buckets ...float64) func(r *Registry) (Histogram, error) {
+	return func(r *Registry) (Histogram, error) {
+		return r.Histogram(name, help, buckets...)
+	}
+}
+
+// Since observes the seconds elapsed since start into h.
+func Since(h Histogram, start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRegistry(t *testing.T) {
+
+	scrape := func(r *metrics.Registry) string {
+		w := httptest.NewRecorder()
+		r.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.That(t, w.Code).Equal(http.StatusOK)
+		return w.Body.String()
+	}
+
+	t.Run("runtime metrics", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		s := scrape(r)
+		assert.String(t, s).Contains("go_goroutines")
+		assert.String(t, s).Contains("go_memstats_heap_alloc_bytes")
+		assert.String(t, s).Contains("go_gc_duration_seconds")
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		r := metrics.NewRegistry()
+
+		c, err := metrics.CounterOf("orders_total", "Number of orders.")(r)
+		assert.That(t, err).Nil()
+		c.Add(2)
+
+		// the same metric is returned when declared again
+		c2, err := r.Counter("orders_total", "Number of orders.")
+		assert.That(t, err).Nil()
+		c2.Inc()
+
+		g, err := metrics.GaugeOf("queue_size", "Size of the queue.")(r)
+		assert.That(t, err).Nil()
+		g.Set(5)
+		g.Dec()
+
+		h, err := metrics.HistogramOf("latency_seconds", "Latency.", 0.1, 1)(r)
+		assert.That(t, err).Nil()
+		h.Observe(0.5)
+
+		v, err := r.CounterVec("requests_total", "Number of requests.", "code")
+		assert.That(t, err).Nil()
+		v.WithLabelValues("200").Inc()
+
+		s := scrape(r)
+		assert.String(t, s).Contains("orders_total 3")
+		assert.String(t, s).Contains("queue_size 4")
+		assert.String(t, s).Contains(`latency_seconds_bucket{le="1"} 1`)
+		assert.String(t, s).Contains(`requests_total{code="200"} 1`)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		_, err := r.Counter("jobs", "Jobs.")
+		assert.That(t, err).Nil()
+		_, err = r.Gauge("jobs", "Jobs.")
+		assert.Error(t, err).Matches("register metric error")
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		r := metrics.Wrap(reg, reg)
+		assert.That(t, r.Registerer()).Equal(prometheus.Registerer(reg))
+		assert.That(t, r.Gatherer()).Equal(prometheus.Gatherer(reg))
+		_, err := r.Counter("hits_total", "Hits.")
+		assert.That(t, err).Nil()
+		mfs, err := reg.Gather()
+		assert.That(t, err).Nil()
+		assert.That(t, len(mfs)).Equal(1)
+	})
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+	"github.com/go-spring/spring-core/gs/internal/gs_prom"
+	"github.com/go-spring/spring-core/gs/scheduler"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GlobalRegistryProp makes the metrics registry bean wrap the default
+// Prometheus registry instead of a registry of its own.
+const GlobalRegistryProp = "spring.metrics.global-registry"
+
+func init() {
+	// Provides the metrics registry and records the metrics of the
+	// application into it.
+	gs.Module([]gs.ConditionOnProperty{
+		gs.OnProperty(gs.EnableMetricsProp).HavingValue("true").MatchIfMissing(),
+	}, func(p conf.Properties) error {
+
+		gs.Provide(
+			newRegistry,
+			gs.TagArg("${"+GlobalRegistryProp+":=false}"),
+		).Name("metricsRegistry")
+
+		gs.Provide(newTimingMetrics).Name("timingMetrics").Export(
+			gs.As[gs.EventListener](),
+		).Destroy(func(m *timingMetrics) {
+			m.unregister()
+		})
+
+		// Exposes the container, lifecycle and goroutine statistics.
+		gs.Root(gs.Provide(newAppMetrics).Name("appMetrics").Destroy(func(c *gs_prom.Collector) {
+			c.Unregister()
+		}))
+
+		// Exposes the run statistics of the tasks.
+		gs.Root(gs.Provide(newTaskMetrics).Name("taskMetrics").Condition(
+			gs.OnBean[*scheduler.Scheduler](),
+		).Destroy(func(m *taskMetrics) {
+			m.reg.Unregister(m)
+		}))
+
+		// Mounts the Prometheus exposition endpoint on the admin server.
+		gs.Provide(NewPrometheusAdminHandler).Name("prometheusAdminHandler").Condition(
+			gs.OnEnableServers(),
+			gs.OnProperty(gs.EnableAdminServerProp).HavingValue("true"),
+			gs.OnProperty(gs.EnableAdminPrometheusProp).HavingValue("true").MatchIfMissing(),
+		).Export(
+			gs.As[gs.AdminHandler](),
+		)
+
+		return nil
+	})
+}
+
+// newRegistry creates the metrics registry of the application. It is a
+// registry of its own, so that the metrics of an application started
+// again in the same process don't collide with the previous ones, unless
+// global is set to share the default Prometheus registry.
+func newRegistry(global bool) *Registry {
+	if global {
+		return Wrap(prometheus.DefaultRegisterer, prometheus.DefaultGatherer)
+	}
+	return NewRegistry()
+}
+
+// NewPrometheusAdminHandler creates the "GET /prometheus" endpoint, which
+// exposes the metrics of the registry in the Prometheus text format.
+func NewPrometheusAdminHandler(r *Registry) *gs.AdminEndpoint {
+	return gs.NewAdminEndpoint("GET /prometheus", r.Handler().ServeHTTP)
+}
+
+// newAppMetrics registers the collector of the container, lifecycle and
+// goroutine statistics of the application into the registry.
+func newAppMetrics(r *Registry) (*gs_prom.Collector, error) {
+	return gs_prom.Register(gs_app.Default(), r.Registerer())
+}
+
+// timingMetrics records the time spent starting the application and
+// refreshing its properties:
+//
+//   - spring_startup_seconds: total time spent starting the application.
+//   - spring_startup_phase_seconds: time spent in each startup "phase".
+//   - spring_properties_refresh_seconds: time spent refreshing the
+//     properties, labelled by "result", "success" or "failure".
+type timingMetrics struct {
+	reg     prometheus.Registerer
+	refresh *prometheus.HistogramVec
+	startup *prometheus.Desc
+	phase   *prometheus.Desc
+}
+
+// newTimingMetrics registers the timing metrics into the registry.
+func newTimingMetrics(r *Registry) (*timingMetrics, error) {
+	refresh, err := r.HistogramVec("spring_properties_refresh_seconds",
+		"Time spent refreshing the properties.", "result")
+	if err != nil {
+		return nil, err
+	}
+	m := &timingMetrics{
+		reg:     r.Registerer(),
+		refresh: refresh,
+		startup: prometheus.NewDesc("spring_startup_seconds",
+			"Time spent starting the application.", nil, nil),
+		phase: prometheus.NewDesc("spring_startup_phase_seconds",
+			"Time spent in the startup phase.", []string{"phase"}, nil),
+	}
+	if err = m.reg.Register(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// unregister removes the metrics from the registry.
+func (m *timingMetrics) unregister() {
+	m.reg.Unregister(m)
+	m.reg.Unregister(m.refresh)
+}
+
+// Describe implements [prometheus.Collector].
+func (m *timingMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.startup
+	ch <- m.phase
+}
+
+// Collect implements [prometheus.Collector].
+func (m *timingMetrics) Collect(ch chan<- prometheus.Metric) {
+	s := gs_app.Default().StartupSummary()
+	if s == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(m.startup, prometheus.GaugeValue, s.Duration.Seconds())
+	for _, p := range s.Phases {
+		ch <- prometheus.MustNewConstMetric(m.phase, prometheus.GaugeValue, p.Duration.Seconds(), p.Name)
+	}
+}
+
+// OnEvent observes the time spent by each properties refresh.
+func (m *timingMetrics) OnEvent(ctx context.Context, event any) {
+	e, ok := event.(gs.PropertiesRefreshed)
+	if !ok {
+		return
+	}
+	result := "success"
+	if e.Err != nil {
+		result = "failure"
+	}
+	m.refresh.WithLabelValues(result).Observe(e.Duration.Seconds())
+}
+
+// taskMetrics exposes the run statistics of the scheduled tasks, all
+// labelled by "task":
+//
+//   - spring_task_runs_total: number of completed runs.
+//   - spring_task_failures_total: number of failed runs.
+//   - spring_task_skipped_total: number of runs skipped by the overlap
+//     policy of the task.
+//   - spring_task_running: number of runs in progress.
+//   - spring_task_last_duration_seconds: duration of the last run.
+type taskMetrics struct {
+	reg          prometheus.Registerer
+	s            *scheduler.Scheduler
+	runs         *prometheus.Desc
+	failures     *prometheus.Desc
+	skipped      *prometheus.Desc
+	running      *prometheus.Desc
+	lastDuration *prometheus.Desc
+}
+
+// newTaskMetrics registers the task metrics into the registry.
+func newTaskMetrics(r *Registry, s *scheduler.Scheduler) (*taskMetrics, error) {
+	label := []string{"task"}
+	m := &taskMetrics{
+		reg: r.Registerer(),
+		s:   s,
+		runs: prometheus.NewDesc("spring_task_runs_total",
+			"Number of completed runs of the task.", label, nil),
+		failures: prometheus.NewDesc("spring_task_failures_total",
+			"Number of failed runs of the task.", label, nil),
+		skipped: prometheus.NewDesc("spring_task_skipped_total",
+			"Number of runs of the task skipped by its overlap policy.", label, nil),
+		running: prometheus.NewDesc("spring_task_running",
+			"Number of runs of the task in progress.", label, nil),
+		lastDuration: prometheus.NewDesc("spring_task_last_duration_seconds",
+			"Duration of the last run of the task.", label, nil),
+	}
+	if err := m.reg.Register(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Describe implements [prometheus.Collector].
+func (m *taskMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.runs
+	ch <- m.failures
+	ch <- m.skipped
+	ch <- m.running
+	ch <- m.lastDuration
+}
+
+// Collect implements [prometheus.Collector].
+func (m *taskMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range m.s.Stats() {
+		ch <- prometheus.MustNewConstMetric(m.runs, prometheus.CounterValue, float64(s.Runs), s.Name)
+		ch <- prometheus.MustNewConstMetric(m.failures, prometheus.CounterValue, float64(s.Failures), s.Name)
+		ch <- prometheus.MustNewConstMetric(m.skipped, prometheus.CounterValue, float64(s.Skipped), s.Name)
+		ch <- prometheus.MustNewConstMetric(m.running, prometheus.GaugeValue, float64(s.Running), s.Name)
+		ch <- prometheus.MustNewConstMetric(m.lastDuration, prometheus.GaugeValue, s.LastDuration.Seconds(), s.Name)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	gs.AddTester(&MetricsTester{})
+}
+
+// MetricsTester checks the metrics recorded into the registry bean.
+type MetricsTester struct {
+	Registry *metrics.Registry `autowire:""`
+}
+
+func (m *MetricsTester) TestPrometheusAdminHandler(t *testing.T) {
+	s := gs.NewAdminServer(gs.AdminServerConfig{}, []gs.AdminHandler{
+		metrics.NewPrometheusAdminHandler(m.Registry),
+	})
+
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prometheus", nil))
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	assert.String(t, w.Body.String()).Contains("go_goroutines")
+	assert.String(t, w.Body.String()).Contains("spring_startup_seconds")
+	assert.String(t, w.Body.String()).Contains(`spring_startup_phase_seconds{phase="container"}`)
+	assert.String(t, w.Body.String()).Contains("spring_beans")
+
+	assert.That(t, gs.RefreshProperties()).Nil()
+	w = httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prometheus", nil))
+	assert.String(t, w.Body.String()).Contains(`spring_properties_refresh_seconds_count{result="success"}`)
+}
+
+func (m *MetricsTester) TestPrivateRegistry(t *testing.T) {
+	w := httptest.NewRecorder()
+	metrics.Wrap(prometheus.DefaultRegisterer, prometheus.DefaultGatherer).
+		Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.String(t, w.Body.String()).Contains("go_goroutines")
+	assert.That(t, strings.Contains(w.Body.String(), "spring_startup_seconds")).False()
+}
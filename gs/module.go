@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/resolving"
+)
+
+// Registrar registers the beans of a module, i.e. the application
+// container or a test harness.
+type Registrar interface {
+	Object(i any) *gs.RegisteredBean
+	Provide(ctor any, args ...Arg) *gs.RegisteredBean
+}
+
+// AppModule is a reusable set of beans, property defaults and lifecycle
+// hooks, which libraries export and applications import with [Import]:
+//
+//	var Module = gs.AppModule{
+//		Name:       "redis",
+//		Properties: map[string]string{"redis.addr": "127.0.0.1:6379"},
+//		Conditions: []gs.ConditionOnProperty{
+//			gs.OnProperty("redis.enabled").HavingValue("true").MatchIfMissing(),
+//		},
+//		Register: func(r gs.Registrar, p conf.Properties) error {
+//			r.Provide(NewClient)
+//			return nil
+//		},
+//	}
+type AppModule struct {
+	Name       string                                     // Name of the module, imported once
+	Properties map[string]string                          // Defaults of the properties
+	Conditions []ConditionOnProperty                      // Conditions activating the module
+	Register   func(r Registrar, p conf.Properties) error // Registers the beans
+	OnStart    func() error                               // Called with the runners
+	OnStop     func() error                               // Called when the beans are destroyed
+}
+
+// Install registers the beans and the lifecycle hooks of the module to
+// r. It is called when the conditions of the module match.
+func (m AppModule) Install(r Registrar, p conf.Properties) error {
+	if m.Register != nil {
+		if err := m.Register(r, p); err != nil {
+			return util.FormatError(err, "install module %s error", m.Name)
+		}
+	}
+	if m.OnStart != nil || m.OnStop != nil {
+		r.Object(&moduleHooks{m}).Name(m.Name + ".hooks").AsRunner()
+	}
+	return nil
+}
+
+// moduleHooks runs the lifecycle hooks of a module as a runner bean.
+type moduleHooks struct {
+	m AppModule
+}
+
+// Run calls the OnStart hook.
+func (h *moduleHooks) Run() error {
+	if h.m.OnStart == nil {
+		return nil
+	}
+	return h.m.OnStart()
+}
+
+// PreDestroy calls the OnStop hook.
+func (h *moduleHooks) PreDestroy() error {
+	if h.m.OnStop == nil {
+		return nil
+	}
+	return h.m.OnStop()
+}
+
+var imported = struct {
+	sync.Mutex
+	names map[string]bool
+}{names: make(map[string]bool)}
+
+// Import imports the modules into the application. A module already
+// imported by name is skipped. The property defaults are set as system
+// properties, unless the properties are set already.
+func Import(modules ...AppModule) {
+	_, file, _, _ := runtime.Caller(1)
+	fileID := gs_conf.SysConf.AddFile(file)
+	for _, m := range modules {
+		if !addModule(m, fileID) {
+			continue
+		}
+		app.C.Module(m.Conditions, func(p conf.Properties) error {
+			return m.Install(app.C, p)
+		})
+	}
+}
+
+// AutoConfigure registers the module as an auto-configuration, usually
+// in the init function of a starter package. It is installed only if
+// the conditions of the module, and the conditions given, e.g.
+// [OnMissingBean], all match. They are evaluated after the imported
+// modules are installed, so the beans of the application take precedence.
+// The outcomes are reported by [AutoConfigReport].
+func AutoConfigure(m AppModule, conditions ...Condition) {
+	_, file, _, _ := runtime.Caller(1)
+	fileID := gs_conf.SysConf.AddFile(file)
+	if !addModule(m, fileID) {
+		return
+	}
+	var arr []Condition
+	for _, c := range m.Conditions {
+		arr = append(arr, c)
+	}
+	arr = append(arr, conditions...)
+	app.C.AutoConfig(m.Name, arr, func(p conf.Properties) error {
+		return m.Install(app.C, p)
+	})
+}
+
+// AutoConfigOutcome tells whether an auto-configuration matched, or the
+// condition rejecting it.
+type AutoConfigOutcome = resolving.AutoConfigOutcome
+
+// AutoConfigReport returns the auto-configurations evaluated when the
+// application started, and whether they matched.
+func AutoConfigReport() []AutoConfigOutcome {
+	return app.AutoConfigReport()
+}
+
+// addModule records the name of the module and sets its property
+// defaults, and returns false if the module is added already.
+func addModule(m AppModule, fileID int8) bool {
+	if m.Name == "" {
+		panic("module must have a name")
+	}
+	imported.Lock()
+	defer imported.Unlock()
+	if imported.names[m.Name] {
+		return false
+	}
+	imported.names[m.Name] = true
+	for key, val := range m.Properties {
+		if gs_conf.SysConf.Has(key) {
+			continue
+		}
+		if err := gs_conf.SysConf.Set(key, val, fileID); err != nil {
+			log.Errorf(context.Background(), log.TagAppDef, "failed to set property key=%s err=%v", key, err)
+		}
+	}
+	return true
+}
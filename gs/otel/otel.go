@@ -0,0 +1,43 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package otel instruments a Go-Spring application with OpenTelemetry.
+// Importing it records the startup, refresh and goroutine spans with the
+// global tracer provider, see the "spring.enable.otel-tracing" and
+// "spring.app.goroutine-spans" properties, and the refresh metrics when
+// a [metric.MeterProvider] bean exists, see "spring.enable.otel-metrics":
+//
+//	import _ "github.com/go-spring/spring-core/gs/otel"
+//
+// The application doesn't depend on OpenTelemetry otherwise.
+package otel
+
+import (
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_app"
+	"github.com/go-spring/spring-core/gs/internal/gs_otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func init() {
+	gs_app.Default().Tracer = &gs_otel.Tracer{}
+
+	// Records configuration refresh metrics when a MeterProvider bean exists.
+	gs.Provide(gs_otel.NewRefreshMetrics).Condition(
+		gs.OnBean[metric.MeterProvider](),
+		gs.OnProperty(gs.EnableOtelMetricsProp).HavingValue("true").MatchIfMissing(),
+	).Export(gs.As[gs.EventListener]())
+}
@@ -30,27 +30,61 @@ func init() {
 		OnEnableServers(),
 		OnProperty(EnableSimplePProfServerProp).HavingValue("true").MatchIfMissing(),
 	).AsServer()
-}
 
-// SimplePProfServer is a simple HTTP server that exposes pprof endpoints.
-type SimplePProfServer struct {
-	*SimpleHttpServer
+	// Mounts the pprof endpoints on the admin server when enabled.
+	Provide(
+		NewPProfAdminHandler,
+	).Condition(
+		OnEnableServers(),
+		OnProperty(EnableAdminServerProp).HavingValue("true"),
+		OnProperty(EnableAdminPProfProp).HavingValue("true"),
+	).Export(
+		As[AdminHandler](),
+	)
 }
 
-// NewSimplePProfServer creates a new SimplePProfServer at the given address.
-// It registers the standard pprof handlers for runtime profiling and debugging.
-func NewSimplePProfServer(addr string) *SimplePProfServer {
+// newPProfMux returns a multiplexer serving the standard pprof handlers.
+func newPProfMux() *http.ServeMux {
 	mux := http.NewServeMux()
-
-	// Register pprof handlers
 	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
 	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
 	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
 	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+	return mux
+}
 
+// SimplePProfServer is a simple HTTP server that exposes pprof endpoints.
+type SimplePProfServer struct {
+	*SimpleHttpServer
+}
+
+// NewSimplePProfServer creates a new SimplePProfServer at the given address.
+// It registers the standard pprof handlers for runtime profiling and debugging.
+func NewSimplePProfServer(addr string) *SimplePProfServer {
+	mux := newPProfMux()
 	cfg := SimpleHttpServerConfig{Address: addr}
 	return &SimplePProfServer{
 		SimpleHttpServer: NewSimpleHttpServer(mux, cfg),
 	}
 }
+
+// PProfAdminHandler serves the pprof endpoints on the admin server under
+// "/debug/pprof/". Since profiles expose the internals of the process, it
+// can only be enabled together with the admin server authentication.
+type PProfAdminHandler struct {
+	*http.ServeMux
+}
+
+// NewPProfAdminHandler creates a new PProfAdminHandler.
+func NewPProfAdminHandler(cfg AdminServerConfig) (*PProfAdminHandler, error) {
+	if err := requireAuth(cfg, "pprof"); err != nil {
+		return nil, err
+	}
+	return &PProfAdminHandler{ServeMux: newPProfMux()}, nil
+}
+
+// Pattern returns the pattern the pprof endpoints are mounted on.
+func (h *PProfAdminHandler) Pattern() string {
+	return "/debug/pprof/"
+}
@@ -29,6 +29,15 @@ const (
 	// to be treated as nullable (i.e. allowed to be nil).
 	ForceAutowireIsNullableProp = "spring.force-autowire-is-nullable"
 
+	// ParallelInitProp enables or disables wiring the independent beans
+	// concurrently during the refresh. Beans can't be looked up, e.g. by
+	// a provider, while they're wired concurrently.
+	ParallelInitProp = "spring.parallel-init"
+
+	// ParallelInitWorkersProp limits the number of beans wired at once
+	// when ParallelInitProp is enabled, GOMAXPROCS by default.
+	ParallelInitWorkersProp = "spring.parallel-init-workers"
+
 	// ActiveProfilesProp defines the active application profiles
 	// (e.g. "dev", "test", "prod").
 	ActiveProfilesProp = "spring.profiles.active"
@@ -39,6 +48,33 @@ const (
 	// EnableServersProp enables or disables all server components.
 	EnableServersProp = "spring.app.enable-servers"
 
+	// WatchLocalConfigProp enables or disables watching the local
+	// configuration files and refreshing the properties on changes.
+	WatchLocalConfigProp = "spring.app.config-local.watch"
+
+	// ConfigSchemaCheckProp sets how the properties are checked against
+	// the declared schemas: "none", "warn" or "fail".
+	ConfigSchemaCheckProp = "spring.config.schema-check"
+
+	// ConfigImportProp lists the files, directories or urls that a
+	// configuration file imports.
+	ConfigImportProp = "spring.config.import"
+
+	// ConfigIncludeProp lists the files, directories or urls of the shared
+	// fragments that a config file includes. The file overrides them, and
+	// a properties file may use the short "include" key instead.
+	ConfigIncludeProp = "spring.config.include"
+
+	// ConfigKubeDirsProp lists the directories of the Kubernetes ConfigMap
+	// and Secret volumes, in the "[prefix:]dir" form, whose files are
+	// loaded as properties keyed by the file names.
+	ConfigKubeDirsProp = "spring.app.config-kube.dirs"
+
+	// ConfigConflictProp is the prefix of the properties that set how a
+	// layer resolves its conflicts with the layers before it, e.g.
+	// "spring.config.conflict.remote=last-wins", see conf.ConflictStrategy.
+	ConfigConflictProp = "spring.config.conflict"
+
 	// EnableSimpleHttpServerProp enables or disables the built-in
 	// lightweight HTTP server.
 	EnableSimpleHttpServerProp = "spring.enable.simple-http-server"
@@ -46,6 +82,60 @@ const (
 	// EnableSimplePProfServerProp enables or disables the built-in
 	// lightweight pprof server.
 	EnableSimplePProfServerProp = "spring.enable.simple-pprof-server"
+
+	// EnableAdminServerProp enables or disables the admin server that
+	// serves management endpoints.
+	EnableAdminServerProp = "spring.enable.admin-server"
+
+	// EnableAdminPProfProp enables or disables the pprof endpoints on
+	// the admin server.
+	EnableAdminPProfProp = "spring.enable.admin-pprof"
+
+	// EnableAdminLoggersProp enables or disables the loggers endpoints
+	// on the admin server.
+	EnableAdminLoggersProp = "spring.enable.admin-loggers"
+
+	// EnableAdminHealthProp enables or disables the health endpoints on
+	// the admin server.
+	EnableAdminHealthProp = "spring.enable.admin-health"
+
+	// EnableAdminInfoProp enables or disables the info endpoint on the
+	// admin server.
+	EnableAdminInfoProp = "spring.enable.admin-info"
+
+	// EnableAdminEnvProp enables or disables the env endpoint on the
+	// admin server, which is disabled by default.
+	EnableAdminEnvProp = "spring.enable.admin-env"
+
+	// EnableAdminBeansProp enables or disables the beans endpoint on the
+	// admin server.
+	EnableAdminBeansProp = "spring.enable.admin-beans"
+
+	// EnableAdminMetricsProp enables or disables the metrics endpoint on
+	// the admin server.
+	EnableAdminMetricsProp = "spring.enable.admin-metrics"
+
+	// EnableAdminRefreshProp enables or disables the refresh endpoint on
+	// the admin server, which is disabled by default.
+	EnableAdminRefreshProp = "spring.enable.admin-refresh"
+
+	// EnableOtelMetricsProp enables or disables the OpenTelemetry metrics
+	// recorded when a MeterProvider bean is present, when the gs/otel
+	// package is imported.
+	EnableOtelMetricsProp = "spring.enable.otel-metrics"
+
+	// EnableMetricsProp enables or disables the metrics registry bean and
+	// the metrics of the application recorded into it, when the gs/metrics
+	// package is imported.
+	EnableMetricsProp = "spring.enable.metrics"
+
+	// EnableAdminPrometheusProp enables or disables the Prometheus
+	// exposition endpoint of the metrics on the admin server.
+	EnableAdminPrometheusProp = "spring.enable.admin-prometheus"
+
+	// EnableSchedulerProp enables or disables the scheduler running the
+	// task beans.
+	EnableSchedulerProp = "spring.enable.scheduler"
 )
 
 // AllowCircularReferences sets whether circular references between beans
@@ -61,6 +151,16 @@ func ForceAutowireIsNullable(enable bool) {
 	Property(ForceAutowireIsNullableProp, strconv.FormatBool(enable))
 }
 
+// ParallelInit enables or disables wiring the independent beans
+// concurrently, level by level in the topological order of their
+// dependencies, by at most workers goroutines at once. A workers of 0
+// means GOMAXPROCS. The constructors, init methods and post processors
+// of the beans must then be safe to run concurrently.
+func ParallelInit(enable bool, workers int) {
+	Property(ParallelInitProp, strconv.FormatBool(enable))
+	Property(ParallelInitWorkersProp, strconv.Itoa(workers))
+}
+
 // SetActiveProfiles sets the active application profiles (e.g. "dev", "prod").
 // This influences which configuration files and conditional beans are loaded.
 func SetActiveProfiles(profiles string) {
@@ -78,6 +178,13 @@ func EnableServers(enable bool) {
 	Property(EnableServersProp, strconv.FormatBool(enable))
 }
 
+// WatchLocalConfig enables or disables watching the local configuration
+// files, so that changes are applied to the properties and the dynamic
+// bean fields without restarting the application.
+func WatchLocalConfig(enable bool) {
+	Property(WatchLocalConfigProp, strconv.FormatBool(enable))
+}
+
 // EnableSimpleHttpServer enables or disables the built-in lightweight
 // HTTP server provided by the framework.
 func EnableSimpleHttpServer(enable bool) {
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"context"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/ratelimit"
+)
+
+// RateLimitPrefix is the prefix of the properties declaring the rate
+// limiters, e.g. "spring.ratelimit.api.qps=100".
+const RateLimitPrefix = "spring.ratelimit"
+
+func init() {
+	DeclareConfig[map[string]ratelimit.Config](RateLimitPrefix)
+	DeclareConfig[struct {
+		HttpServer string `value:"${http.server.rate-limit:=}" desc:"rate limiter of the built-in HTTP server"`
+	}]("").Claim()
+
+	// Registers a rate limiter bean for each "spring.ratelimit.<name>"
+	// entry, named after it and reconfigured when its properties change.
+	// The limiter named by "http.server.rate-limit" also limits the
+	// requests of the built-in HTTP server.
+	Module([]ConditionOnProperty{
+		OnProperty(RateLimitPrefix),
+	}, func(p conf.Properties) error {
+		var m map[string]ratelimit.Config
+		if err := p.Bind(&m, "${"+RateLimitPrefix+"}"); err != nil {
+			return err
+		}
+		var c struct {
+			HttpServer string `value:"${http.server.rate-limit:=}"`
+		}
+		if err := p.Bind(&c); err != nil {
+			return err
+		}
+		limiters := make(map[string]*ratelimit.Limiter)
+		for name, cfg := range m {
+			l, err := ratelimit.New(name, cfg)
+			if err != nil {
+				return err
+			}
+			limiters[name] = l
+			b := Object(l).Name(name)
+			if name == c.HttpServer {
+				b.Export(As[HttpMiddleware]())
+			}
+		}
+		if c.HttpServer != "" && limiters[c.HttpServer] == nil {
+			return util.FormatError(nil, "rate limiter %s not found", c.HttpServer)
+		}
+		_, err := Listen(RateLimitPrefix, func(_, m map[string]ratelimit.Config) {
+			for name, cfg := range m {
+				if l, ok := limiters[name]; ok && cfg != l.Config() {
+					if err := l.Update(cfg); err != nil {
+						log.Errorf(context.Background(), log.TagAppDef, "%s", err)
+					}
+				}
+			}
+		})
+		return err
+	})
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package ratelimit implements rate limiters that can be tuned at runtime.
+//
+// Two algorithms are available: a token bucket, which allows bursts up to
+// its capacity while refilling at a steady rate, and a sliding window,
+// which bounds the number of requests over the last window.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+// Algorithms of a limiter.
+const (
+	TokenBucket   = "token-bucket"
+	SlidingWindow = "sliding-window"
+)
+
+// Config configures a limiter.
+type Config struct {
+	Algorithm string        `value:"${algorithm:=token-bucket}" desc:"token-bucket or sliding-window"`
+	QPS       float64       `value:"${qps}" desc:"requests allowed per second"`
+	Burst     int           `value:"${burst:=0}" desc:"capacity of a token bucket, QPS rounded up if not positive"`
+	Window    time.Duration `value:"${window:=1s}" desc:"length of a sliding window"`
+}
+
+// algorithm is the state of a limiting algorithm, guarded by the Limiter.
+type algorithm interface {
+	allow(now time.Time) (ok bool, retryAfter time.Duration)
+}
+
+// newAlgorithm creates the algorithm configured by c.
+func newAlgorithm(c Config, now time.Time) (algorithm, error) {
+	if c.QPS <= 0 {
+		return nil, util.FormatError(nil, "invalid qps %v", c.QPS)
+	}
+	switch c.Algorithm {
+	case TokenBucket, "":
+		burst := float64(c.Burst)
+		if burst <= 0 {
+			burst = math.Ceil(c.QPS)
+		}
+		return &tokenBucket{rate: c.QPS, burst: burst, tokens: burst, last: now}, nil
+	case SlidingWindow:
+		if c.Window <= 0 {
+			return nil, util.FormatError(nil, "invalid window %s", c.Window)
+		}
+		limit := c.QPS * c.Window.Seconds()
+		return &slidingWindow{limit: limit, window: c.Window, start: now}, nil
+	default:
+		return nil, util.FormatError(nil, "invalid algorithm %q", c.Algorithm)
+	}
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate tokens per second,
+// each request taking one.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) allow(now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// slidingWindow estimates the number of requests over the last window from
+// the counts of the current and previous fixed windows, the latter weighted
+// by its overlap with the sliding one.
+type slidingWindow struct {
+	limit  float64
+	window time.Duration
+	start  time.Time // start of the current fixed window
+	prev   float64
+	curr   float64
+}
+
+func (w *slidingWindow) allow(now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(w.start); elapsed >= w.window {
+		n := elapsed / w.window
+		w.start = w.start.Add(n * w.window)
+		if n == 1 {
+			w.prev = w.curr
+		} else {
+			w.prev = 0
+		}
+		w.curr = 0
+	}
+	overlap := 1 - float64(now.Sub(w.start))/float64(w.window)
+	if w.prev*overlap+w.curr+1 <= w.limit {
+		w.curr++
+		return true, 0
+	}
+	return false, w.start.Add(w.window).Sub(now)
+}
+
+// Limiter limits the rate of requests. It is safe for concurrent use, and
+// can be reconfigured while in use.
+type Limiter struct {
+	name string
+	mu   sync.Mutex
+	cfg  Config
+	algo algorithm
+}
+
+// New creates a limiter, returning an error if the config is invalid.
+func New(name string, cfg Config) (*Limiter, error) {
+	algo, err := newAlgorithm(cfg, time.Now())
+	if err != nil {
+		return nil, util.WrapError(err, "create rate limiter %s error", name)
+	}
+	return &Limiter{name: name, cfg: cfg, algo: algo}, nil
+}
+
+// Name returns the name of the limiter.
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+// Config returns the current config of the limiter.
+func (l *Limiter) Config() Config {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cfg
+}
+
+// Update reconfigures the limiter, starting over with a full token bucket
+// or an empty window. The config is left unchanged if the new one is
+// invalid.
+func (l *Limiter) Update(cfg Config) error {
+	algo, err := newAlgorithm(cfg, time.Now())
+	if err != nil {
+		return util.WrapError(err, "update rate limiter %s error", l.name)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg, l.algo = cfg, algo
+	return nil
+}
+
+// Allow reports whether a request may proceed now.
+func (l *Limiter) Allow() bool {
+	ok, _ := l.Reserve()
+	return ok
+}
+
+// Reserve reports whether a request may proceed now, and if not, how long
+// to wait before the next one may.
+func (l *Limiter) Reserve() (ok bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.algo.allow(time.Now())
+}
+
+// Wrap returns a handler rejecting the requests over the limit with 429
+// Too Many Requests and a Retry-After header, and passing the others to
+// next.
+func (l *Limiter) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, retryAfter := l.Reserve()
+		if !ok {
+			secs := int(math.Ceil(retryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(secs, 1)))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/ratelimit"
+)
+
+func TestConfig(t *testing.T) {
+	p := conf.Map(map[string]any{
+		"ratelimit.api.qps": 100,
+	})
+	var m map[string]ratelimit.Config
+	err := p.Bind(&m, "${ratelimit}")
+	assert.That(t, err).Nil()
+	assert.That(t, m).Equal(map[string]ratelimit.Config{
+		"api": {Algorithm: ratelimit.TokenBucket, QPS: 100, Window: time.Second},
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := ratelimit.New("api", ratelimit.Config{})
+	assert.Error(t, err).Matches("create rate limiter api error << invalid qps 0")
+	_, err = ratelimit.New("api", ratelimit.Config{QPS: 1, Algorithm: "leaky"})
+	assert.Error(t, err).Matches(`invalid algorithm "leaky"`)
+	_, err = ratelimit.New("api", ratelimit.Config{QPS: 1, Algorithm: ratelimit.SlidingWindow})
+	assert.Error(t, err).Matches("invalid window 0s")
+}
+
+func TestTokenBucket(t *testing.T) {
+	l, err := ratelimit.New("api", ratelimit.Config{QPS: 100, Burst: 3})
+	assert.That(t, err).Nil()
+	for range 3 {
+		assert.That(t, l.Allow()).True()
+	}
+	ok, retryAfter := l.Reserve()
+	assert.That(t, ok).False()
+	assert.That(t, retryAfter > 0 && retryAfter <= 10*time.Millisecond).True()
+	time.Sleep(25 * time.Millisecond)
+	assert.That(t, l.Allow()).True()
+	assert.That(t, l.Allow()).True()
+}
+
+func TestSlidingWindow(t *testing.T) {
+	l, err := ratelimit.New("api", ratelimit.Config{
+		Algorithm: ratelimit.SlidingWindow,
+		QPS:       100,
+		Window:    50 * time.Millisecond,
+	})
+	assert.That(t, err).Nil()
+	for range 5 {
+		assert.That(t, l.Allow()).True()
+	}
+	ok, retryAfter := l.Reserve()
+	assert.That(t, ok).False()
+	assert.That(t, retryAfter > 0 && retryAfter <= 50*time.Millisecond).True()
+	time.Sleep(120 * time.Millisecond)
+	assert.That(t, l.Allow()).True()
+}
+
+func TestUpdate(t *testing.T) {
+	l, err := ratelimit.New("api", ratelimit.Config{QPS: 1})
+	assert.That(t, err).Nil()
+	assert.That(t, l.Allow()).True()
+	assert.That(t, l.Allow()).False()
+
+	err = l.Update(ratelimit.Config{QPS: -1})
+	assert.Error(t, err).Matches("update rate limiter api error << invalid qps -1")
+	assert.That(t, l.Config().QPS).Equal(1.0)
+
+	err = l.Update(ratelimit.Config{QPS: 2})
+	assert.That(t, err).Nil()
+	assert.That(t, l.Allow()).True()
+	assert.That(t, l.Allow()).True()
+	assert.That(t, l.Allow()).False()
+}
+
+func TestWrap(t *testing.T) {
+	l, err := ratelimit.New("api", ratelimit.Config{QPS: 0.5})
+	assert.That(t, err).Nil()
+	h := l.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.That(t, w.Code).Equal(http.StatusNoContent)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.That(t, w.Code).Equal(http.StatusTooManyRequests)
+	assert.String(t, w.Header().Get("Retry-After")).Equal("2")
+}
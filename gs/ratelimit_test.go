@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/ratelimit"
+)
+
+func init() {
+	gs.Property("spring.ratelimit.api.qps", "100")
+	gs.Property("ratelimit.mode", "strict") // not a limiter, left to the application
+	gs.AddTester(&RateLimitTester{})
+}
+
+type RateLimitTester struct {
+	API *ratelimit.Limiter `autowire:"api"`
+}
+
+func (r *RateLimitTester) TestRateLimit(t *testing.T) {
+	assert.String(t, r.API.Name()).Equal("api")
+	assert.That(t, r.API.Config().QPS).Equal(100.0)
+	assert.That(t, r.API.Allow()).True()
+
+	gs.Property("spring.ratelimit.api.qps", "5")
+	defer func() {
+		gs.Property("spring.ratelimit.api.qps", "100")
+		assert.That(t, gs.RefreshProperties()).Nil()
+	}()
+	assert.That(t, gs.RefreshProperties()).Nil()
+	assert.That(t, r.API.Config().QPS).Equal(5.0)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"testing"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/util/retry"
+)
+
+func init() {
+	gs.Property("retry.max-attempts", "5")
+	gs.AddTester(&RetryTester{})
+}
+
+type RetryTester struct {
+	Policy *retry.Policy `autowire:"retryPolicy"`
+}
+
+func (r *RetryTester) TestRetryPolicy(t *testing.T) {
+	c := retry.DefaultConfig()
+	c.MaxAttempts = 5
+	assert.That(t, r.Policy.Config).Equal(c)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs
+
+import (
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/scheduler"
+)
+
+func init() {
+	// Runs the task beans as a job, with the triggers set by the
+	// "task.<name>.*" properties.
+	Module([]ConditionOnProperty{
+		OnProperty(EnableSchedulerProp).HavingValue("true").MatchIfMissing(),
+	}, func(p conf.Properties) error {
+
+		Provide(func(tasks []*scheduler.Task) (*scheduler.Scheduler, error) {
+			cfgs, err := scheduler.Configs(p, tasks)
+			if err != nil {
+				return nil, err
+			}
+			return scheduler.New(tasks, cfgs)
+		}, TagArg("?")).Name("scheduler").Condition(
+			OnBean[*scheduler.Task](),
+		).AsJob()
+
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs/scheduler"
+)
+
+func TestParseCron(t *testing.T) {
+
+	t.Run("invalid", func(t *testing.T) {
+		for expr, msg := range map[string]string{
+			"* * *":           `invalid cron expression "\* \* \*": expected 5 or 6 fields`,
+			"60 * * * * *":    `invalid value "60" in second field`,
+			"0 0 25 * * *":    `invalid value "25" in hour field`,
+			"0 0 0 0 * *":     `invalid value "0" in day of month field`,
+			"0 0 0 * FOO *":   `invalid value "FOO" in month field`,
+			"0 0 0 * * 8":     `invalid value "8" in day of week field`,
+			"0 */0 * * * *":   `invalid step "0" in minute field`,
+			"0 30-10 * * * *": `invalid range "30-10" in minute field`,
+		} {
+			_, err := scheduler.ParseCron(expr)
+			assert.Error(t, err).Matches(msg)
+		}
+	})
+
+	t.Run("next", func(t *testing.T) {
+		now := time.Date(2025, 3, 14, 10, 7, 30, 500, time.UTC)
+		for expr, next := range map[string]string{
+			"0 */5 * * * *":          "2025-03-14 10:10:00",
+			"*/20 * * * * *":         "2025-03-14 10:07:40",
+			"30 7 10 * * *":          "2025-03-15 10:07:30",
+			"0 0 9-17/4 * * MON-FRI": "2025-03-14 13:00:00",
+			"0 0 0 * * SAT,SUN":      "2025-03-15 00:00:00",
+			"0 0 0 1 JAN ?":          "2026-01-01 00:00:00",
+			"0 0 0 29 2 *":           "2028-02-29 00:00:00",
+			"0 0 0 13 * 5":           "2025-03-21 00:00:00",
+			"0 0 0 * * 7":            "2025-03-16 00:00:00",
+			"15 10 * * *":            "2025-03-14 10:15:00",
+			"@hourly":                "2025-03-14 11:00:00",
+			"@monthly":               "2025-04-01 00:00:00",
+		} {
+			c, err := scheduler.ParseCron(expr)
+			assert.That(t, err).Nil()
+			assert.String(t, c.Next(now).Format(time.DateTime)).Equal(next)
+		}
+	})
+
+	t.Run("never", func(t *testing.T) {
+		c, err := scheduler.ParseCron("0 0 0 31 2 *")
+		assert.That(t, err).Nil()
+		assert.That(t, c.Next(time.Now()).IsZero()).True()
+	})
+}
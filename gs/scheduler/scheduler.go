@@ -0,0 +1,375 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package scheduler runs tasks periodically, on cron expressions or at a
+// fixed rate or delay, as a job of the Go-Spring application.
+//
+// The trigger of a task is set by the "task.<name>.*" properties, which
+// override the default config of the task:
+//
+//	task.cleanup.cron=0 */5 * * * *
+//	task.report.fixed-rate=1m
+//	task.report.overlap=wait
+package scheduler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-spring/log"
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// OverlapPolicy tells what to do when a task is triggered while its
+// previous run hasn't completed yet.
+type OverlapPolicy string
+
+const (
+	// OverlapSkip skips the run, which is the default.
+	OverlapSkip OverlapPolicy = "skip"
+
+	// OverlapWait delays the run until the previous one completes, the
+	// runs missed meanwhile being merged into it.
+	OverlapWait OverlapPolicy = "wait"
+
+	// OverlapAllow starts the run concurrently with the previous one.
+	OverlapAllow OverlapPolicy = "allow"
+)
+
+// TaskConfig holds the trigger of a task, bound from the "task.<name>"
+// properties. Exactly one of Cron, FixedRate and FixedDelay must be set.
+type TaskConfig struct {
+	// Cron is the cron expression of the task, see [ParseCron].
+	Cron string `value:"${cron:=}"`
+
+	// FixedRate is the interval between the starts of the runs.
+	FixedRate time.Duration `value:"${fixed-rate:=0}"`
+
+	// FixedDelay is the interval between the end of a run and the start
+	// of the next one.
+	FixedDelay time.Duration `value:"${fixed-delay:=0}"`
+
+	// InitialDelay delays the first run of a fixed rate or delay task.
+	InitialDelay time.Duration `value:"${initial-delay:=0}"`
+
+	// Overlap is the overlap policy of a cron or fixed rate task.
+	Overlap OverlapPolicy `value:"${overlap:=skip}"`
+
+	// Enabled tells whether the task is scheduled.
+	Enabled bool `value:"${enabled:=true}"`
+}
+
+// Task is a function run by the scheduler.
+type Task struct {
+	// Name is the name of the task, which selects its properties.
+	Name string
+
+	// Fn is the function run, its error is logged.
+	Fn func(ctx context.Context) error
+
+	// Config is the default trigger of the task, whose fields are
+	// overridden by the "task.<name>.*" properties that are set.
+	Config TaskConfig
+}
+
+// NewTask creates a task with the default config, which is usually set
+// by the properties.
+func NewTask(name string, fn func(ctx context.Context) error) *Task {
+	return &Task{Name: name, Fn: fn, Config: TaskConfig{Overlap: OverlapSkip, Enabled: true}}
+}
+
+// CronTask creates a task run on the cron expression.
+func CronTask(name, expr string, fn func(ctx context.Context) error) *Task {
+	t := NewTask(name, fn)
+	t.Config.Cron = expr
+	return t
+}
+
+// FixedRateTask creates a task started at the interval.
+func FixedRateTask(name string, interval time.Duration, fn func(ctx context.Context) error) *Task {
+	t := NewTask(name, fn)
+	t.Config.FixedRate = interval
+	return t
+}
+
+// FixedDelayTask creates a task started at the interval after the end
+// of its previous run.
+func FixedDelayTask(name string, interval time.Duration, fn func(ctx context.Context) error) *Task {
+	t := NewTask(name, fn)
+	t.Config.FixedDelay = interval
+	return t
+}
+
+// Configs returns the configs of the tasks that have "task.<name>.*"
+// properties in p, each made of the default config of the task with the
+// fields set by the properties overridden, for New.
+func Configs(p conf.Properties, tasks []*Task) (map[string]TaskConfig, error) {
+	cfgs := make(map[string]TaskConfig)
+	for _, t := range tasks {
+		prefix := "task." + t.Name
+		if !p.Has(prefix) {
+			continue
+		}
+		d := conf.New()
+		fileID := d.AddFile("task " + t.Name)
+		defaults := map[string]string{
+			"fixed-rate":    t.Config.FixedRate.String(),
+			"fixed-delay":   t.Config.FixedDelay.String(),
+			"initial-delay": t.Config.InitialDelay.String(),
+			"enabled":       strconv.FormatBool(t.Config.Enabled),
+		}
+		if t.Config.Cron != "" {
+			defaults["cron"] = t.Config.Cron
+		}
+		if t.Config.Overlap != "" {
+			defaults["overlap"] = string(t.Config.Overlap)
+		}
+		for k, v := range defaults {
+			if err := d.Set(prefix+"."+k, v, fileID); err != nil {
+				return nil, err
+			}
+		}
+		for _, k := range p.Keys() {
+			if strings.HasPrefix(k, prefix+".") {
+				if err := d.Set(k, p.Get(k), fileID); err != nil {
+					return nil, util.FormatError(err, "task %s", t.Name)
+				}
+			}
+		}
+		var cfg TaskConfig
+		if err := d.Bind(&cfg, "${"+prefix+"}"); err != nil {
+			return nil, util.FormatError(err, "task %s", t.Name)
+		}
+		cfgs[t.Name] = cfg
+	}
+	return cfgs, nil
+}
+
+// TaskStats holds the run statistics of a task.
+type TaskStats struct {
+	Name         string
+	Runs         int64         // number of completed runs
+	Failures     int64         // number of runs returning an error or panicking
+	Skipped      int64         // number of runs skipped by the overlap policy
+	Running      int64         // number of runs in progress
+	LastDuration time.Duration // duration of the last completed run
+}
+
+// scheduledTask is a task with its effective config and statistics.
+type scheduledTask struct {
+	*Task
+	cfg  TaskConfig
+	cron *Cron
+
+	runs, failures, skipped, running, lastDuration atomic.Int64
+}
+
+// Scheduler runs the enabled tasks until it is stopped.
+type Scheduler struct {
+	tasks []*scheduledTask
+	wg    sync.WaitGroup // runs in progress
+}
+
+// New creates a scheduler of the tasks, whose config is replaced by the
+// entry of the same name in cfgs if any, see Configs. It returns an error if a task
+// has an invalid trigger.
+func New(tasks []*Task, cfgs map[string]TaskConfig) (*Scheduler, error) {
+	s := &Scheduler{}
+	names := make(map[string]bool)
+	for _, t := range tasks {
+		if names[t.Name] {
+			return nil, util.FormatError(nil, "task %s defined twice", t.Name)
+		}
+		names[t.Name] = true
+		cfg := t.Config
+		if c, ok := cfgs[t.Name]; ok {
+			cfg = c
+		}
+		st := &scheduledTask{Task: t, cfg: cfg}
+		if err := st.validate(); err != nil {
+			return nil, util.FormatError(err, "task %s", t.Name)
+		}
+		s.tasks = append(s.tasks, st)
+	}
+	return s, nil
+}
+
+// validate checks the trigger and the overlap policy of the task.
+func (t *scheduledTask) validate() error {
+	n := 0
+	if t.cfg.Cron != "" {
+		c, err := ParseCron(t.cfg.Cron)
+		if err != nil {
+			return err
+		}
+		t.cron = c
+		n++
+	}
+	if t.cfg.FixedRate > 0 {
+		n++
+	}
+	if t.cfg.FixedDelay > 0 {
+		n++
+	}
+	if n != 1 && t.cfg.Enabled {
+		return util.FormatError(nil, "exactly one of cron, fixed-rate and fixed-delay must be set")
+	}
+	switch t.cfg.Overlap {
+	case "", OverlapSkip, OverlapWait, OverlapAllow:
+	default:
+		return util.FormatError(nil, "invalid overlap policy %q", t.cfg.Overlap)
+	}
+	return nil
+}
+
+// Stats returns the run statistics of the enabled tasks.
+func (s *Scheduler) Stats() []TaskStats {
+	var ret []TaskStats
+	for _, t := range s.tasks {
+		if !t.cfg.Enabled {
+			continue
+		}
+		ret = append(ret, TaskStats{
+			Name:         t.Name,
+			Runs:         t.runs.Load(),
+			Failures:     t.failures.Load(),
+			Skipped:      t.skipped.Load(),
+			Running:      t.running.Load(),
+			LastDuration: time.Duration(t.lastDuration.Load()),
+		})
+	}
+	return ret
+}
+
+// Run schedules the enabled tasks until ctx is done, then waits for the
+// runs in progress, whose context is done as well, to complete.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var loops []*goutil.Status
+	for _, t := range s.tasks {
+		if t.cfg.Enabled {
+			loops = append(loops, goutil.Go(ctx, func(ctx context.Context) {
+				s.schedule(ctx, t)
+			}))
+		}
+	}
+	for _, l := range loops {
+		l.Wait()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+// schedule runs the task on its trigger until ctx is done.
+func (s *Scheduler) schedule(ctx context.Context, t *scheduledTask) {
+	if t.cron == nil && !sleep(ctx, t.cfg.InitialDelay) {
+		return
+	}
+	if t.cfg.FixedDelay > 0 {
+		for {
+			s.run(ctx, t)
+			if !sleep(ctx, t.cfg.FixedDelay) {
+				return
+			}
+		}
+	}
+	next := time.Now()
+	for {
+		if t.cron != nil {
+			if next = t.cron.Next(time.Now()); next.IsZero() {
+				return
+			}
+		}
+		if !sleep(ctx, time.Until(next)) {
+			return
+		}
+		s.trigger(ctx, t)
+		if t.cron == nil {
+			// Fixed rate runs missed by a waiting run are merged
+			next = next.Add(t.cfg.FixedRate)
+			if now := time.Now(); next.Before(now) {
+				next = now
+			}
+		}
+	}
+}
+
+// trigger runs the task according to its overlap policy.
+func (s *Scheduler) trigger(ctx context.Context, t *scheduledTask) {
+	switch t.cfg.Overlap {
+	case OverlapWait:
+		s.run(ctx, t)
+	case OverlapAllow:
+		s.start(ctx, t)
+	default:
+		if t.running.Load() > 0 {
+			t.skipped.Add(1)
+			log.Warnf(ctx, log.TagAppDef, "task %s skipped, its previous run is still in progress", t.Name)
+			return
+		}
+		s.start(ctx, t)
+	}
+}
+
+// start runs the task in a new goroutine.
+func (s *Scheduler) start(ctx context.Context, t *scheduledTask) {
+	t.running.Add(1)
+	s.wg.Add(1)
+	goutil.Go(ctx, func(ctx context.Context) {
+		defer s.wg.Done()
+		s.exec(ctx, t)
+	})
+}
+
+// run runs the task and waits for it to complete.
+func (s *Scheduler) run(ctx context.Context, t *scheduledTask) {
+	t.running.Add(1)
+	s.exec(ctx, t)
+}
+
+// exec runs the task, recovering from its panic, and records the run.
+func (s *Scheduler) exec(ctx context.Context, t *scheduledTask) {
+	defer t.running.Add(-1)
+	start := time.Now()
+	_, err := goutil.GoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, t.Fn(ctx)
+	}).Wait()
+	t.lastDuration.Store(int64(time.Since(start)))
+	t.runs.Add(1)
+	if err != nil {
+		t.failures.Add(1)
+		log.Errorf(ctx, log.TagAppDef, "task %s run error: %v", t.Name, err)
+	}
+}
+
+// sleep waits for d, returning false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/scheduler"
+)
+
+// runFor runs the scheduler for d, then stops it and waits for it.
+func runFor(t *testing.T, s *scheduler.Scheduler, d time.Duration) {
+	ctx, cancel := context.WithTimeout(t.Context(), d)
+	defer cancel()
+	assert.That(t, s.Run(ctx)).Nil()
+}
+
+func TestScheduler(t *testing.T) {
+
+	t.Run("invalid config", func(t *testing.T) {
+		noop := func(ctx context.Context) error { return nil }
+
+		_, err := scheduler.New([]*scheduler.Task{scheduler.NewTask("a", noop)}, nil)
+		assert.Error(t, err).Matches("task a: exactly one of cron, fixed-rate and fixed-delay must be set")
+
+		_, err = scheduler.New([]*scheduler.Task{scheduler.CronTask("a", "* *", noop)}, nil)
+		assert.Error(t, err).Matches("task a: invalid cron expression")
+
+		_, err = scheduler.New([]*scheduler.Task{scheduler.FixedRateTask("a", time.Second, noop)},
+			map[string]scheduler.TaskConfig{"a": {FixedRate: time.Second, Overlap: "queue", Enabled: true}})
+		assert.Error(t, err).Matches(`task a: invalid overlap policy "queue"`)
+
+		_, err = scheduler.New([]*scheduler.Task{
+			scheduler.FixedRateTask("a", time.Second, noop),
+			scheduler.FixedDelayTask("a", time.Second, noop),
+		}, nil)
+		assert.Error(t, err).Matches("task a defined twice")
+
+		s, err := scheduler.New([]*scheduler.Task{scheduler.NewTask("a", noop)},
+			map[string]scheduler.TaskConfig{"a": {Enabled: false}})
+		assert.That(t, err).Nil()
+		assert.That(t, s.Stats()).Nil()
+	})
+
+	t.Run("fixed rate skip", func(t *testing.T) {
+		var calls atomic.Int32
+		s, err := scheduler.New([]*scheduler.Task{
+			scheduler.FixedRateTask("slow", 20*time.Millisecond, func(ctx context.Context) error {
+				calls.Add(1)
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		}, nil)
+		assert.That(t, err).Nil()
+		runFor(t, s, 110*time.Millisecond)
+
+		stats := s.Stats()
+		assert.That(t, calls.Load()).Equal(int32(1))
+		assert.That(t, stats[0].Runs).Equal(int64(1))
+		assert.That(t, stats[0].Failures).Equal(int64(1))
+		assert.That(t, stats[0].Skipped >= 3).True()
+		assert.That(t, stats[0].Running).Equal(int64(0))
+	})
+
+	t.Run("fixed rate wait", func(t *testing.T) {
+		var calls atomic.Int32
+		task := scheduler.FixedRateTask("wait", 10*time.Millisecond, func(ctx context.Context) error {
+			calls.Add(1)
+			time.Sleep(30 * time.Millisecond)
+			return nil
+		})
+		task.Config.Overlap = scheduler.OverlapWait
+		s, err := scheduler.New([]*scheduler.Task{task}, nil)
+		assert.That(t, err).Nil()
+		runFor(t, s, 100*time.Millisecond)
+
+		stats := s.Stats()
+		assert.That(t, calls.Load() >= 2 && calls.Load() <= 4).True()
+		assert.That(t, stats[0].Skipped).Equal(int64(0))
+		assert.That(t, stats[0].LastDuration >= 30*time.Millisecond).True()
+	})
+
+	t.Run("fixed delay from properties", func(t *testing.T) {
+		var starts []time.Time
+		s, err := scheduler.New([]*scheduler.Task{
+			scheduler.NewTask("delay", func(ctx context.Context) error {
+				starts = append(starts, time.Now())
+				time.Sleep(20 * time.Millisecond)
+				return errors.New("oops")
+			}),
+		}, map[string]scheduler.TaskConfig{
+			"delay": {FixedDelay: 20 * time.Millisecond, InitialDelay: 10 * time.Millisecond, Enabled: true},
+		})
+		assert.That(t, err).Nil()
+		start := time.Now()
+		runFor(t, s, 100*time.Millisecond)
+
+		assert.That(t, len(starts) >= 2).True()
+		assert.That(t, starts[0].Sub(start) >= 10*time.Millisecond).True()
+		assert.That(t, starts[1].Sub(starts[0]) >= 40*time.Millisecond).True()
+		assert.That(t, s.Stats()[0].Failures).Equal(int64(len(starts)))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		s, err := scheduler.New([]*scheduler.Task{
+			scheduler.FixedDelayTask("panic", 10*time.Millisecond, func(ctx context.Context) error {
+				panic("boom")
+			}),
+		}, nil)
+		assert.That(t, err).Nil()
+		runFor(t, s, 50*time.Millisecond)
+
+		stats := s.Stats()
+		assert.That(t, stats[0].Runs >= 2).True()
+		assert.That(t, stats[0].Failures).Equal(stats[0].Runs)
+	})
+
+	t.Run("cron", func(t *testing.T) {
+		var calls atomic.Int32
+		s, err := scheduler.New([]*scheduler.Task{
+			scheduler.CronTask("cron", "* * * * * *", func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			}),
+		}, nil)
+		assert.That(t, err).Nil()
+		runFor(t, s, 1100*time.Millisecond)
+		assert.That(t, calls.Load() >= 1).True()
+	})
+}
+
+func TestConfigs(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tasks := []*scheduler.Task{
+		scheduler.CronTask("clean", "0 * * * * *", noop),
+		scheduler.FixedRateTask("report", time.Minute, noop),
+	}
+
+	t.Run("partial override", func(t *testing.T) {
+		cfgs, err := scheduler.Configs(conf.Map(map[string]any{
+			"task.clean.overlap": "wait",
+		}), tasks)
+		assert.That(t, err).Nil()
+		assert.That(t, cfgs).Equal(map[string]scheduler.TaskConfig{
+			"clean": {Cron: "0 * * * * *", Overlap: scheduler.OverlapWait, Enabled: true},
+		})
+		_, err = scheduler.New(tasks, cfgs)
+		assert.That(t, err).Nil()
+	})
+
+	t.Run("trigger override", func(t *testing.T) {
+		cfgs, err := scheduler.Configs(conf.Map(map[string]any{
+			"task.report.fixed-rate": "5s",
+			"task.report.enabled":    "false",
+		}), tasks)
+		assert.That(t, err).Nil()
+		assert.That(t, cfgs["report"]).Equal(scheduler.TaskConfig{
+			FixedRate: 5 * time.Second, Overlap: scheduler.OverlapSkip,
+		})
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		_, err := scheduler.Configs(conf.Map(map[string]any{
+			"task.clean.fixed-rate": "soon",
+		}), tasks)
+		assert.Error(t, err).Matches("task clean")
+	})
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/spring-core/gs/scheduler"
+)
+
+var pingTaskCalls atomic.Int32
+
+func init() {
+	gs.Property("task.ping.fixed-rate", "10ms")
+	gs.Object(scheduler.NewTask("ping", func(ctx context.Context) error {
+		pingTaskCalls.Add(1)
+		return nil
+	}))
+}
+
+func (m *MetricsTester) TestTaskMetrics(t *testing.T) {
+	for pingTaskCalls.Load() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	w := httptest.NewRecorder()
+	m.Registry.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.String(t, w.Body.String()).Matches(`spring_task_runs_total\{task="ping"\} [1-9]`)
+	assert.String(t, w.Body.String()).Contains(`spring_task_skipped_total{task="ping"} 0`)
+}
@@ -24,69 +24,347 @@
 // A global `OnPanic` handler is triggered whenever a panic is recovered. It allows
 // developers to log the panic, report metrics, or perform other custom recovery
 // logic, making it easier to monitor and debug failures in concurrent code.
+//
+// When a global `Spans` hook is set, e.g. by the gs package for OpenTelemetry,
+// each goroutine runs in a span continuing the one carried by its context, so
+// that the background work is correlated with the originating request. The
+// package itself doesn't depend on any tracing library.
+//
+// A `Pool` runs tasks on a bounded number of workers with the same panic
+// recovery and tracing, for background work whose concurrency must be limited,
+// and a `Group` runs goroutines working on a common task, cancelling them all
+// on the first error.
 package goutil
 
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"runtime"
 	"runtime/debug"
-	"sync"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-spring/spring-base/util"
 )
 
+// PanicHandler handles a panic recovered inside a goroutine, given the
+// context of the goroutine, the recovered value and the stack trace.
+type PanicHandler func(ctx context.Context, r any, stack []byte)
+
 // OnPanic is a global callback function triggered whenever a panic is recovered
-// inside a goroutine launched by this package.
+// inside a goroutine launched by this package, before the handlers added by
+// AddPanicHandler.
 //
-// By default it prints the panic value and stack trace to stdout.
-// Applications may override it during initialization to provide custom logging,
-// metrics, or alerting.
+// By default it prints the panic value and stack trace to stdout, prefixed
+// with the trace ID if the context carries a span (see TraceID). Applications may override
+// it during initialization to provide custom logging, metrics, or alerting.
 //
 // Note: being global means it is shared across all usages. In testing
 // scenarios, remember to restore it after modification if necessary.
-var OnPanic = func(ctx context.Context, r any, stack []byte) {
+var OnPanic PanicHandler = func(ctx context.Context, r any, stack []byte) {
+	if id := TraceID(ctx); id != "" {
+		fmt.Printf("[PANIC] trace_id=%s %v\n%s\n", id, r, stack)
+		return
+	}
 	fmt.Printf("[PANIC] %v\n%s\n", r, stack)
 }
 
+// panicHandlers holds the chain of handlers added by AddPanicHandler.
+var panicHandlers atomic.Pointer[[]*PanicHandler]
+
+// AddPanicHandler appends h to the global chain of panic handlers, called in
+// order after OnPanic, so that e.g. metrics and logging can be plugged in
+// independently. The returned function removes h from the chain.
+func AddPanicHandler(h PanicHandler) (remove func()) {
+	p := &h
+	update := func(f func([]*PanicHandler) []*PanicHandler) {
+		for {
+			old := panicHandlers.Load()
+			var hs []*PanicHandler
+			if old != nil {
+				hs = *old
+			}
+			hs = f(slices.Clone(hs))
+			if panicHandlers.CompareAndSwap(old, &hs) {
+				return
+			}
+		}
+	}
+	update(func(hs []*PanicHandler) []*PanicHandler {
+		return append(hs, p)
+	})
+	return func() {
+		update(func(hs []*PanicHandler) []*PanicHandler {
+			return slices.DeleteFunc(hs, func(x *PanicHandler) bool { return x == p })
+		})
+	}
+}
+
+// PanicPolicy tells what to do once a recovered panic has been reported.
+type PanicPolicy int
+
+const (
+	PanicSuppress PanicPolicy = iota // the goroutine ends normally, the default
+	PanicRethrow                     // the panic is raised again, crashing the process
+)
+
+// Options customizes how a goroutine launched by Go or GoValue handles panics.
+type Options struct {
+	// OnPanic, if set, replaces OnPanic and the handlers added by
+	// AddPanicHandler for this goroutine.
+	OnPanic PanicHandler
+
+	// Policy tells whether the panic is suppressed or raised again after
+	// being reported, e.g. to fail fast on a broken invariant.
+	Policy PanicPolicy
+}
+
+// handlePanic counts and records the recovered panic, then reports it to the
+// handler of opts or else to the global ones, and raises it again if asked.
+func handlePanic(ctx context.Context, span Span, r any, stack []byte, opts Options) {
+	panics.Add(1)
+	recordPanic(span, r, stack)
+	if opts.OnPanic != nil {
+		opts.OnPanic(ctx, r, stack)
+	} else {
+		if OnPanic != nil {
+			OnPanic(ctx, r, stack)
+		}
+		if hs := panicHandlers.Load(); hs != nil {
+			for _, h := range *hs {
+				(*h)(ctx, r, stack)
+			}
+		}
+	}
+	if opts.Policy == PanicRethrow {
+		panic(r)
+	}
+}
+
+// options returns the first of opts, or the zero Options.
+func options(opts []Options) Options {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return Options{}
+}
+
+// PanicError is the error of a goroutine that panicked, carrying the value
+// recovered from the panic and the stack trace captured at that point, the
+// same ones passed to OnPanic.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic recovered: %v\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the recovered value if it is an error, e.g. a runtime
+// error, so that errors.Is and errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+/********************************* trace *************************************/
+
+// SpanStarter starts the spans of the goroutines launched by this package,
+// see Spans.
+type SpanStarter interface {
+	// Start starts the span of the goroutine function named name, returning
+	// the context carrying it, or ctx and a nil Span if ctx carries no span
+	// to continue.
+	Start(ctx context.Context, name string) (context.Context, Span)
+
+	// TraceID returns the trace ID of the span carried by ctx, or an empty
+	// string if there is none.
+	TraceID(ctx context.Context) string
+}
+
+// Span is the span a goroutine runs in.
+type Span interface {
+	// SetError marks the span as failed with the error of the goroutine.
+	SetError(err error)
+
+	// RecordPanic records the panic recovered from the goroutine and its
+	// stack trace, marking the span as failed.
+	RecordPanic(r any, stack []byte)
+
+	// End ends the span.
+	End()
+}
+
+// Spans, if set, starts a span for each goroutine launched by this package,
+// named after the goroutine function and recording its panic or error, if
+// any. It is set by the gs package from the "spring.app.goroutine-spans"
+// property.
+//
+// It is nil by default, i.e. no span is started, while the span of the
+// caller, as well as the baggage, is still visible through the context.
+var Spans SpanStarter
+
+// TraceID returns the trace ID of the span carried by ctx, or an empty
+// string if there is none or Spans is not set.
+func TraceID(ctx context.Context) string {
+	if spans := Spans; spans != nil {
+		return spans.TraceID(ctx)
+	}
+	return ""
+}
+
+// startSpan starts the span of the goroutine function f if Spans is set,
+// otherwise it returns a nil span.
+func startSpan(ctx context.Context, f any) (context.Context, Span) {
+	spans := Spans
+	if spans == nil {
+		return ctx, nil
+	}
+	name := "goroutine"
+	if fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer()); fn != nil {
+		name = fn.Name()
+	}
+	return spans.Start(ctx, name)
+}
+
+// recordPanic records the recovered panic on the span, if any.
+func recordPanic(span Span, r any, stack []byte) {
+	if span == nil {
+		return
+	}
+	span.RecordPanic(r, stack)
+}
+
+/********************************* stats *************************************/
+
+// Stats holds counters of the goroutines launched by this package.
+type Stats struct {
+	Started int64 // number of goroutines started
+	Running int64 // number of goroutines not yet finished
+	Panics  int64 // number of panics recovered
+}
+
+var (
+	started atomic.Int64
+	running atomic.Int64
+	panics  atomic.Int64
+)
+
+// ReadStats returns a snapshot of the goroutine counters.
+func ReadStats() Stats {
+	return Stats{
+		Started: started.Load(),
+		Running: running.Load(),
+		Panics:  panics.Load(),
+	}
+}
+
+// begin updates the counters when a goroutine starts.
+func begin() {
+	started.Add(1)
+	running.Add(1)
+}
+
+// end updates the counters when a goroutine finishes.
+func end() {
+	running.Add(-1)
+}
+
 /********************************** go ***************************************/
 
+// ErrTimeout is returned by the WaitTimeout methods when the goroutine is
+// still running after the given duration.
+var ErrTimeout = util.FormatError(nil, "wait timeout")
+
 // Status provides a handle to wait for a goroutine to finish.
 type Status struct {
-	wg sync.WaitGroup
+	ch chan struct{}
 }
 
 // newStatus creates and initializes a new Status.
 func newStatus() *Status {
-	s := &Status{}
-	s.wg.Add(1)
-	return s
+	return &Status{ch: make(chan struct{})}
 }
 
 // done marks the goroutine as finished.
 func (s *Status) done() {
-	s.wg.Done()
+	close(s.ch)
+}
+
+// finished reports whether the goroutine has completed.
+func (s *Status) finished() bool {
+	select {
+	case <-s.ch:
+		return true
+	default:
+		return false
+	}
 }
 
 // Wait blocks until the goroutine completes.
 func (s *Status) Wait() {
-	s.wg.Wait()
+	<-s.ch
+}
+
+// WaitContext blocks until the goroutine completes or ctx is done, in which
+// case it returns the error of ctx while the goroutine keeps running.
+func (s *Status) WaitContext(ctx context.Context) error {
+	if s.finished() {
+		return nil
+	}
+	select {
+	case <-s.ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitTimeout blocks until the goroutine completes or d elapses, in which
+// case it returns ErrTimeout while the goroutine keeps running.
+func (s *Status) WaitTimeout(d time.Duration) error {
+	if s.finished() {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-s.ch:
+		return nil
+	case <-t.C:
+		return ErrTimeout
+	}
 }
 
 // Go launches a goroutine that recovers from panics and invokes the global
 // OnPanic handler when a panic occurs.
 //
-// The provided context is passed to the goroutine function `f` and to OnPanic.
-// The goroutine does not stop automatically when the context is cancelled;
-// `f` should check `ctx.Done()` and return when appropriate.
-func Go(ctx context.Context, f func(ctx context.Context)) *Status {
+// The provided context is passed to the goroutine function `f` and to OnPanic,
+// in a span of its own if tracing is enabled (see Spans). The goroutine does not
+// stop automatically when the context is cancelled; `f` should check
+// `ctx.Done()` and return when appropriate.
+//
+// An optional Options overrides the handling of a panic for this goroutine.
+func Go(ctx context.Context, f func(ctx context.Context), opts ...Options) *Status {
+	o := options(opts)
 	s := newStatus()
+	ctx, span := startSpan(ctx, f)
+	begin()
 	go func() {
 		defer s.done()
+		defer end()
+		if span != nil {
+			defer span.End()
+		}
 		defer func() {
 			if r := recover(); r != nil {
-				if OnPanic != nil {
-					OnPanic(ctx, r, debug.Stack())
-				}
+				handlePanic(ctx, span, r, debug.Stack(), o)
 			}
 		}()
 		f(ctx)
@@ -99,48 +377,104 @@ func Go(ctx context.Context, f func(ctx context.Context)) *Status {
 // ValueStatus represents a goroutine that returns a value and an error.
 // It allows the caller to wait for the result.
 type ValueStatus[T any] struct {
-	wg  sync.WaitGroup
+	ch  chan struct{}
 	val T
 	err error
 }
 
 // newValueStatus creates and initializes a new ValueStatus.
 func newValueStatus[T any]() *ValueStatus[T] {
-	s := &ValueStatus[T]{}
-	s.wg.Add(1)
-	return s
+	return &ValueStatus[T]{ch: make(chan struct{})}
 }
 
 // done marks the goroutine as finished.
 func (s *ValueStatus[T]) done() {
-	s.wg.Done()
+	close(s.ch)
+}
+
+// finished reports whether the goroutine has completed.
+func (s *ValueStatus[T]) finished() bool {
+	select {
+	case <-s.ch:
+		return true
+	default:
+		return false
+	}
 }
 
 // Wait blocks until the goroutine completes and returns its value and error.
 func (s *ValueStatus[T]) Wait() (T, error) {
-	s.wg.Wait()
+	<-s.ch
 	return s.val, s.err
 }
 
+// WaitContext blocks until the goroutine completes and returns its value and
+// error, or until ctx is done, in which case it returns the zero value and
+// the error of ctx while the goroutine keeps running.
+func (s *ValueStatus[T]) WaitContext(ctx context.Context) (T, error) {
+	if s.finished() {
+		return s.val, s.err
+	}
+	select {
+	case <-s.ch:
+		return s.val, s.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// WaitTimeout blocks until the goroutine completes and returns its value and
+// error, or until d elapses, in which case it returns the zero value and
+// ErrTimeout while the goroutine keeps running.
+func (s *ValueStatus[T]) WaitTimeout(d time.Duration) (T, error) {
+	if s.finished() {
+		return s.val, s.err
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-s.ch:
+		return s.val, s.err
+	case <-t.C:
+		var zero T
+		return zero, ErrTimeout
+	}
+}
+
 // GoValue launches a goroutine that executes the provided function `f`,
 // recovers from any panic, and invokes the global OnPanic handler.
 //
-// The context is passed to both `f` and OnPanic. The caller must ensure
-// that `f` observes `ctx.Done()` if early cancellation is desired.
+// The context is passed to both `f` and OnPanic, in a span of its own if
+// tracing is enabled (see Spans). The caller must ensure that `f` observes
+// `ctx.Done()` if early cancellation is desired.
 //
 // If a panic occurs, the recovered panic and stack trace are also reported
-// via OnPanic and wrapped into the returned error.
-func GoValue[T any](ctx context.Context, f func(ctx context.Context) (T, error)) *ValueStatus[T] {
+// via OnPanic and returned as a *PanicError, unless overridden by an optional
+// Options.
+func GoValue[T any](ctx context.Context, f func(ctx context.Context) (T, error), opts ...Options) *ValueStatus[T] {
+	o := options(opts)
 	s := newValueStatus[T]()
+	ctx, span := startSpan(ctx, f)
+	begin()
 	go func() {
+		var recovered bool
 		defer s.done()
+		defer end()
+		if span != nil {
+			defer func() {
+				if s.err != nil && !recovered {
+					span.SetError(s.err)
+				}
+				span.End()
+			}()
+		}
 		defer func() {
 			if r := recover(); r != nil {
+				recovered = true
 				stack := debug.Stack()
-				if OnPanic != nil {
-					OnPanic(ctx, r, stack)
-				}
-				s.err = util.FormatError(nil, "panic recovered: %v\n%s", r, stack)
+				s.err = &PanicError{Value: r, Stack: stack}
+				handlePanic(ctx, span, r, stack, o)
 			}
 		}()
 		s.val, s.err = f(ctx)
@@ -19,6 +19,8 @@ package goutil_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"runtime"
 	"testing"
 	"time"
 
@@ -90,4 +92,226 @@ func TestGoValue(t *testing.T) {
 		}).Wait()
 		assert.That(t, err).Nil()
 	})
+
+	t.Run("panic error", func(t *testing.T) {
+		var reported any
+		onPanic := goutil.OnPanic
+		goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {
+			reported = r
+		}
+		defer func() { goutil.OnPanic = onPanic }()
+
+		value := struct{ Code int }{Code: 7}
+		_, err := goutil.GoValue(t.Context(), func(ctx context.Context) (int, error) {
+			panic(value)
+		}).Wait()
+		var pe *goutil.PanicError
+		assert.That(t, errors.As(err, &pe)).True()
+		assert.That(t, pe.Value).Equal(any(value))
+		assert.That(t, reported).Equal(any(value))
+		assert.String(t, string(pe.Stack)).Contains("goutil_test.TestGoValue")
+
+		_, err = goutil.GoValue(t.Context(), func(ctx context.Context) (int, error) {
+			var m map[string]int
+			m["a"] = 1
+			return 0, nil
+		}).Wait()
+		var re runtime.Error
+		assert.That(t, errors.As(err, &re)).True()
+	})
+}
+
+func TestPanicHandlers(t *testing.T) {
+	var calls []string
+	onPanic := goutil.OnPanic
+	goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {
+		calls = append(calls, fmt.Sprint("global:", r))
+	}
+	defer func() { goutil.OnPanic = onPanic }()
+
+	removeMetrics := goutil.AddPanicHandler(func(ctx context.Context, r any, stack []byte) {
+		calls = append(calls, fmt.Sprint("metrics:", r))
+	})
+	removeLogging := goutil.AddPanicHandler(func(ctx context.Context, r any, stack []byte) {
+		calls = append(calls, fmt.Sprint("logging:", r))
+	})
+
+	goutil.Go(t.Context(), func(ctx context.Context) {
+		panic("a")
+	}).Wait()
+	assert.That(t, calls).Equal([]string{"global:a", "metrics:a", "logging:a"})
+
+	calls = nil
+	_, err := goutil.GoValue(t.Context(), func(ctx context.Context) (int, error) {
+		panic("b")
+	}, goutil.Options{
+		OnPanic: func(ctx context.Context, r any, stack []byte) {
+			calls = append(calls, fmt.Sprint("local:", r))
+		},
+	}).Wait()
+	assert.Error(t, err).Matches("panic recovered: b")
+	assert.That(t, calls).Equal([]string{"local:b"})
+
+	calls = nil
+	removeMetrics()
+	goutil.Go(t.Context(), func(ctx context.Context) {
+		panic("c")
+	}).Wait()
+	assert.That(t, calls).Equal([]string{"global:c", "logging:c"})
+
+	calls = nil
+	removeLogging()
+	goutil.Go(t.Context(), func(ctx context.Context) {
+		panic("d")
+	}).Wait()
+	assert.That(t, calls).Equal([]string{"global:d"})
+}
+
+func TestWait(t *testing.T) {
+
+	t.Run("status", func(t *testing.T) {
+		release := make(chan struct{})
+		s := goutil.Go(t.Context(), func(ctx context.Context) {
+			<-release
+		})
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		assert.That(t, s.WaitContext(ctx)).Equal(context.DeadlineExceeded)
+		assert.That(t, s.WaitTimeout(10*time.Millisecond)).Equal(goutil.ErrTimeout)
+		close(release)
+		assert.That(t, s.WaitTimeout(time.Second)).Nil()
+		assert.That(t, s.WaitContext(ctx)).Nil()
+	})
+
+	t.Run("value status", func(t *testing.T) {
+		release := make(chan struct{})
+		s := goutil.GoValue(t.Context(), func(ctx context.Context) (int, error) {
+			<-release
+			return 42, nil
+		})
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		v, err := s.WaitContext(ctx)
+		assert.That(t, err).Equal(context.Canceled)
+		assert.That(t, v).Equal(0)
+		v, err = s.WaitTimeout(10 * time.Millisecond)
+		assert.That(t, err).Equal(goutil.ErrTimeout)
+		assert.That(t, v).Equal(0)
+		close(release)
+		v, err = s.WaitTimeout(time.Second)
+		assert.That(t, err).Nil()
+		assert.That(t, v).Equal(42)
+		v, err = s.Wait()
+		assert.That(t, err).Nil()
+		assert.That(t, v).Equal(42)
+	})
+}
+
+func TestReadStats(t *testing.T) {
+	before := goutil.ReadStats()
+
+	goutil.Go(t.Context(), func(ctx context.Context) {
+		panic("something is wrong")
+	}).Wait()
+
+	ch := make(chan struct{})
+	s := goutil.GoValue(t.Context(), func(ctx context.Context) (int, error) {
+		<-ch
+		return 0, nil
+	})
+
+	stats := goutil.ReadStats()
+	assert.That(t, stats.Started-before.Started).Equal(int64(2))
+	assert.That(t, stats.Running-before.Running).Equal(int64(1))
+	assert.That(t, stats.Panics-before.Panics).Equal(int64(1))
+
+	close(ch)
+	_, _ = s.Wait()
+	assert.That(t, goutil.ReadStats().Running).Equal(before.Running)
+}
+
+// testSpans records the spans started by goutil, see goutil.Spans.
+type testSpans struct {
+	names []string
+	spans []*testSpan
+}
+
+type traceKey struct{}
+
+func (s *testSpans) Start(ctx context.Context, name string) (context.Context, goutil.Span) {
+	if ctx.Value(traceKey{}) == nil {
+		return ctx, nil
+	}
+	span := &testSpan{}
+	s.names = append(s.names, name)
+	s.spans = append(s.spans, span)
+	return ctx, span
+}
+
+func (s *testSpans) TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceKey{}).(string)
+	return id
+}
+
+type testSpan struct {
+	err   error
+	panic any
+	ended bool
+}
+
+func (s *testSpan) SetError(err error)              { s.err = err }
+func (s *testSpan) RecordPanic(r any, stack []byte) { s.panic = r }
+func (s *testSpan) End()                            { s.ended = true }
+
+func TestSpans(t *testing.T) {
+
+	spans := &testSpans{}
+	goutil.Spans = spans
+	defer func() { goutil.Spans = nil }()
+
+	onPanic := goutil.OnPanic
+	defer func() { goutil.OnPanic = onPanic }()
+	var panicTraceID string
+	goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {
+		panicTraceID = goutil.TraceID(ctx)
+	}
+
+	t.Run("no span", func(t *testing.T) {
+		goutil.Go(t.Context(), func(ctx context.Context) {}).Wait()
+		assert.That(t, len(spans.spans)).Equal(0)
+	})
+
+	ctx := context.WithValue(t.Context(), traceKey{}, "trace-1")
+
+	t.Run("go", func(t *testing.T) {
+		var traceID string
+		goutil.Go(ctx, func(ctx context.Context) {
+			traceID = goutil.TraceID(ctx)
+		}).Wait()
+		assert.That(t, traceID).Equal("trace-1")
+		assert.That(t, len(spans.spans)).Equal(1)
+		assert.String(t, spans.names[0]).HasPrefix("github.com/go-spring/spring-core/util/goutil_test.TestSpans.")
+		assert.That(t, spans.spans[0].ended).True()
+		assert.That(t, spans.spans[0].err).Nil()
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		goutil.Go(ctx, func(ctx context.Context) {
+			panic("something is wrong")
+		}).Wait()
+		assert.That(t, panicTraceID).Equal("trace-1")
+		assert.That(t, len(spans.spans)).Equal(2)
+		assert.That(t, spans.spans[1].panic).Equal(any("something is wrong"))
+		assert.That(t, spans.spans[1].ended).True()
+	})
+
+	t.Run("go value error", func(t *testing.T) {
+		_, err := goutil.GoValue(ctx, func(ctx context.Context) (int, error) {
+			return 0, errors.New("expected error")
+		}).Wait()
+		assert.Error(t, err).Matches("expected error")
+		assert.That(t, len(spans.spans)).Equal(3)
+		assert.Error(t, spans.spans[2].err).Matches("expected error")
+		assert.That(t, spans.spans[2].ended).True()
+	})
 }
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package goutil
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+)
+
+// Group runs a set of goroutines working on a common task, in the manner of
+// errgroup, with the panic recovery and tracing of Go.
+//
+// The first goroutine returning an error, or panicking, cancels the context
+// shared by the group, while Wait collects the errors of all of them.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewGroup creates a Group whose goroutines run with a context derived from
+// ctx, cancelled by the first error or by Wait.
+func NewGroup(ctx context.Context) *Group {
+	g := &Group{}
+	g.ctx, g.cancel = context.WithCancelCause(ctx)
+	return g
+}
+
+// Context returns the context shared by the goroutines of the group.
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// SetLimit limits the number of goroutines running at once to n, so that
+// Go blocks until one of them returns. A negative n means no limit. It must
+// not be called while goroutines of the group are running.
+func (g *Group) SetLimit(n int) {
+	if len(g.sem) != 0 {
+		panic("goutil: modify limit while goroutines are running")
+	}
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go launches f in a goroutine of the group, waiting first for a free slot
+// if a limit is set. A panic of f is recovered, reported to OnPanic and
+// turned into a *PanicError as the error of the goroutine.
+func (g *Group) Go(f func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	ctx, span := startSpan(g.ctx, f)
+	begin()
+	go func() {
+		var (
+			err       error
+			recovered bool
+		)
+		defer g.wg.Done()
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+		}()
+		defer end()
+		defer func() {
+			if err != nil {
+				g.fail(err)
+			}
+		}()
+		if span != nil {
+			defer func() {
+				if err != nil && !recovered {
+					span.SetError(err)
+				}
+				span.End()
+			}()
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				recovered = true
+				stack := debug.Stack()
+				err = &PanicError{Value: r, Stack: stack}
+				handlePanic(ctx, span, r, stack, Options{})
+			}
+		}()
+		err = f(ctx)
+	}()
+}
+
+// fail records the error of a goroutine and cancels the shared context.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	g.errs = append(g.errs, err)
+	g.mu.Unlock()
+	g.cancel(err)
+}
+
+// Wait blocks until all goroutines of the group return, then cancels the
+// shared context and returns their errors joined, or nil if there is none.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
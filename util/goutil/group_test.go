@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package goutil_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+func TestGroup(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		g := goutil.NewGroup(t.Context())
+		var n atomic.Int64
+		for range 5 {
+			g.Go(func(ctx context.Context) error {
+				n.Add(1)
+				return nil
+			})
+		}
+		assert.That(t, g.Wait()).Nil()
+		assert.That(t, n.Load()).Equal(int64(5))
+		assert.That(t, g.Context().Err()).Equal(context.Canceled)
+	})
+
+	t.Run("first error cancels", func(t *testing.T) {
+		g := goutil.NewGroup(t.Context())
+		errA := errors.New("a")
+		errB := errors.New("b")
+		g.Go(func(ctx context.Context) error {
+			return errA
+		})
+		g.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			assert.That(t, context.Cause(ctx)).Equal(errA)
+			return errB
+		})
+		err := g.Wait()
+		assert.That(t, errors.Is(err, errA)).True()
+		assert.That(t, errors.Is(err, errB)).True()
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var reported atomic.Value
+		onPanic := goutil.OnPanic
+		goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {
+			reported.Store(r)
+		}
+		defer func() { goutil.OnPanic = onPanic }()
+
+		g := goutil.NewGroup(t.Context())
+		g.Go(func(ctx context.Context) error {
+			panic("boom")
+		})
+		g.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+		err := g.Wait()
+		assert.Error(t, err).Matches("panic recovered: boom")
+		var pe *goutil.PanicError
+		assert.That(t, errors.As(err, &pe)).True()
+		assert.That(t, pe.Value).Equal(any("boom"))
+		assert.That(t, reported.Load()).Equal("boom")
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		g := goutil.NewGroup(t.Context())
+		g.SetLimit(2)
+		var running, peak atomic.Int64
+		for range 8 {
+			g.Go(func(ctx context.Context) error {
+				n := running.Add(1)
+				for {
+					m := peak.Load()
+					if n <= m || peak.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+		}
+		assert.That(t, g.Wait()).Nil()
+		assert.That(t, peak.Load() <= 2).True()
+	})
+}
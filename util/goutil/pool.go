@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package goutil
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+)
+
+var (
+	ErrPoolFull   = util.FormatError(nil, "pool queue is full")
+	ErrPoolClosed = util.FormatError(nil, "pool is closed")
+)
+
+// PoolHooks are callbacks invoked on the events of a Pool, e.g. to report
+// metrics. Nil hooks are skipped. Hooks run on the submitting or the worker
+// goroutine, so they should return quickly.
+type PoolHooks struct {
+	Rejected func(err error)                            // a task is rejected
+	Started  func(wait time.Duration)                   // a task leaves the queue
+	Finished func(elapsed time.Duration, panicked bool) // a task returns
+}
+
+// PoolConfig configures a Pool.
+type PoolConfig struct {
+	Workers     int           // maximum number of tasks running at once, 1 if not positive
+	QueueSize   int           // maximum number of tasks waiting for a worker
+	TaskTimeout time.Duration // deadline of each task, none if not positive
+	Hooks       PoolHooks
+}
+
+// PoolStats holds the counters of a Pool.
+type PoolStats struct {
+	Workers   int   // number of workers
+	Queued    int64 // number of tasks waiting for a worker
+	Running   int64 // number of tasks running
+	Completed int64 // number of tasks returned, including panicked ones
+	Rejected  int64 // number of tasks rejected by Submit
+	Dropped   int64 // number of queued tasks discarded by a forced shutdown
+	Panics    int64 // number of panics recovered
+}
+
+// poolTask is a task waiting in the queue of a Pool.
+type poolTask struct {
+	ctx    context.Context
+	f      func(ctx context.Context)
+	queued time.Time
+}
+
+// Pool runs tasks on a fixed number of worker goroutines, so that the
+// concurrency of background work is bounded without hand-rolled semaphores.
+//
+// Tasks wait in a bounded queue until a worker is free. Each task runs with
+// its own context derived from the submitting one, which is cancelled when
+// the task times out or the pool is forcibly shut down. Panics are recovered
+// and reported to OnPanic, as in Go.
+type Pool struct {
+	cfg   PoolConfig
+	queue chan poolTask
+
+	mu        sync.RWMutex // guards closed against sends on the closed queue
+	closed    bool
+	closing   chan struct{} // closed when Shutdown is first called
+	closeOnce sync.Once
+
+	stop   context.Context // cancelled on forced shutdown
+	cancel context.CancelFunc
+	done   chan struct{} // closed once all workers exit
+
+	queued    atomic.Int64
+	running   atomic.Int64
+	completed atomic.Int64
+	rejected  atomic.Int64
+	dropped   atomic.Int64
+	panics    atomic.Int64
+}
+
+// NewPool creates a Pool and starts its workers.
+func NewPool(cfg PoolConfig) *Pool {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize < 0 {
+		cfg.QueueSize = 0
+	}
+	p := &Pool{
+		cfg:     cfg,
+		queue:   make(chan poolTask, cfg.QueueSize),
+		done:    make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+	p.stop, p.cancel = context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(cfg.Workers)
+	for range cfg.Workers {
+		go func() {
+			defer wg.Done()
+			p.work()
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.done)
+	}()
+	return p
+}
+
+// Submit queues f without blocking. It returns ErrPoolFull if the queue has
+// no room, i.e. with a zero QueueSize if no worker is idle, or ErrPoolClosed
+// if the pool is shutting down.
+func (p *Pool) Submit(ctx context.Context, f func(ctx context.Context)) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return p.reject(ErrPoolClosed)
+	}
+	p.queued.Add(1)
+	select {
+	case p.queue <- poolTask{ctx: ctx, f: f, queued: time.Now()}:
+		return nil
+	default:
+		p.queued.Add(-1)
+		return p.reject(ErrPoolFull)
+	}
+}
+
+// SubmitWait queues f, waiting for room in the queue until ctx is done, in
+// which case it returns the error of ctx. It returns ErrPoolClosed if the
+// pool is shutting down.
+func (p *Pool) SubmitWait(ctx context.Context, f func(ctx context.Context)) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return p.reject(ErrPoolClosed)
+	}
+	p.queued.Add(1)
+	select {
+	case p.queue <- poolTask{ctx: ctx, f: f, queued: time.Now()}:
+		return nil
+	case <-ctx.Done():
+		p.queued.Add(-1)
+		return p.reject(ctx.Err())
+	case <-p.closing:
+		p.queued.Add(-1)
+		return p.reject(ErrPoolClosed)
+	}
+}
+
+// reject counts a rejected task and reports it to the hook.
+func (p *Pool) reject(err error) error {
+	p.rejected.Add(1)
+	if h := p.cfg.Hooks.Rejected; h != nil {
+		h(err)
+	}
+	return err
+}
+
+// Shutdown stops accepting tasks and waits for the queued and running ones
+// to finish. If ctx is done first, the contexts of the running tasks are
+// cancelled, the queued ones are dropped, and the error of ctx is returned
+// without waiting any longer.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		close(p.closing) // wakes up the blocked SubmitWait calls holding mu
+		p.mu.Lock()
+		p.closed = true
+		close(p.queue)
+		p.mu.Unlock()
+	})
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the counters of the pool.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		Workers:   p.cfg.Workers,
+		Queued:    p.queued.Load(),
+		Running:   p.running.Load(),
+		Completed: p.completed.Load(),
+		Rejected:  p.rejected.Load(),
+		Dropped:   p.dropped.Load(),
+		Panics:    p.panics.Load(),
+	}
+}
+
+// work runs the queued tasks until the queue is closed and drained.
+func (p *Pool) work() {
+	for t := range p.queue {
+		p.queued.Add(-1)
+		if p.stop.Err() != nil {
+			p.dropped.Add(1)
+			continue
+		}
+		p.run(t)
+	}
+}
+
+// run executes a task with its own context, recovering from its panic.
+func (p *Pool) run(t poolTask) {
+	start := time.Now()
+	if h := p.cfg.Hooks.Started; h != nil {
+		h(start.Sub(t.queued))
+	}
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if p.cfg.TaskTimeout > 0 {
+		ctx, cancel = context.WithTimeout(t.ctx, p.cfg.TaskTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(t.ctx)
+	}
+	defer cancel()
+	stop := context.AfterFunc(p.stop, cancel)
+	defer stop()
+
+	ctx, span := startSpan(ctx, t.f)
+	p.running.Add(1)
+	panicked := false
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			p.panics.Add(1)
+			handlePanic(ctx, span, r, debug.Stack(), Options{})
+		}
+		if span != nil {
+			span.End()
+		}
+		p.running.Add(-1)
+		p.completed.Add(1)
+		if h := p.cfg.Hooks.Finished; h != nil {
+			h(time.Since(start), panicked)
+		}
+	}()
+	t.f(ctx)
+}
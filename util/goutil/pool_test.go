@@ -0,0 +1,228 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package goutil_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+func TestPool(t *testing.T) {
+
+	t.Run("bounded", func(t *testing.T) {
+		p := goutil.NewPool(goutil.PoolConfig{Workers: 2, QueueSize: 10})
+		var running, peak atomic.Int64
+		for range 10 {
+			err := p.Submit(t.Context(), func(ctx context.Context) {
+				n := running.Add(1)
+				for {
+					m := peak.Load()
+					if n <= m || peak.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+			})
+			assert.That(t, err).Nil()
+		}
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+		assert.That(t, peak.Load() <= 2).True()
+		s := p.Stats()
+		assert.That(t, s.Completed).Equal(int64(10))
+		assert.That(t, s.Queued).Equal(int64(0))
+		assert.That(t, s.Running).Equal(int64(0))
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		var rejected []error
+		p := goutil.NewPool(goutil.PoolConfig{
+			Workers:   1,
+			QueueSize: 1,
+			Hooks: goutil.PoolHooks{
+				Rejected: func(err error) { rejected = append(rejected, err) },
+			},
+		})
+		block := make(chan struct{})
+		started := make(chan struct{})
+		assert.That(t, p.Submit(t.Context(), func(ctx context.Context) {
+			close(started)
+			<-block
+		})).Nil()
+		<-started
+		assert.That(t, p.Submit(t.Context(), func(ctx context.Context) {})).Nil()
+		err := p.Submit(t.Context(), func(ctx context.Context) {})
+		assert.That(t, err).Equal(goutil.ErrPoolFull)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		err = p.SubmitWait(ctx, func(ctx context.Context) {})
+		assert.That(t, err).Equal(context.DeadlineExceeded)
+
+		close(block)
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+		err = p.Submit(t.Context(), func(ctx context.Context) {})
+		assert.That(t, err).Equal(goutil.ErrPoolClosed)
+		assert.That(t, rejected).Equal([]error{goutil.ErrPoolFull, context.DeadlineExceeded, goutil.ErrPoolClosed})
+		assert.That(t, p.Stats().Rejected).Equal(int64(3))
+		assert.That(t, p.Stats().Completed).Equal(int64(2))
+	})
+
+	t.Run("submit wait", func(t *testing.T) {
+		p := goutil.NewPool(goutil.PoolConfig{Workers: 1})
+		var n atomic.Int64
+		for range 5 {
+			err := p.SubmitWait(t.Context(), func(ctx context.Context) {
+				n.Add(1)
+			})
+			assert.That(t, err).Nil()
+		}
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+		assert.That(t, n.Load()).Equal(int64(5))
+	})
+
+	t.Run("shutdown wakes waiting", func(t *testing.T) {
+		p := goutil.NewPool(goutil.PoolConfig{Workers: 1})
+		block := make(chan struct{})
+		assert.That(t, p.SubmitWait(t.Context(), func(ctx context.Context) {
+			<-block
+		})).Nil()
+		errs := make(chan error)
+		go func() {
+			errs <- p.SubmitWait(t.Context(), func(ctx context.Context) {})
+		}()
+		time.Sleep(10 * time.Millisecond)
+		done := make(chan error)
+		go func() {
+			done <- p.Shutdown(t.Context())
+		}()
+		assert.That(t, <-errs).Equal(goutil.ErrPoolClosed)
+		close(block)
+		assert.That(t, <-done).Nil()
+	})
+
+	t.Run("task context", func(t *testing.T) {
+		type key struct{}
+		p := goutil.NewPool(goutil.PoolConfig{TaskTimeout: 10 * time.Millisecond})
+		var (
+			value any
+			err   error
+		)
+		ctx := context.WithValue(t.Context(), key{}, "v")
+		assert.That(t, p.SubmitWait(ctx, func(ctx context.Context) {
+			value = ctx.Value(key{})
+			<-ctx.Done()
+			err = ctx.Err()
+		})).Nil()
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+		assert.That(t, value).Equal("v")
+		assert.That(t, err).Equal(context.DeadlineExceeded)
+	})
+
+	t.Run("task timeout releases context", func(t *testing.T) {
+		// A parent of a foreign type makes each child context watch it in a
+		// goroutine, until the child is cancelled.
+		parent, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		ctx := opaqueContext{parent}
+
+		p := goutil.NewPool(goutil.PoolConfig{TaskTimeout: time.Minute})
+		before := runtime.NumGoroutine()
+		for range 50 {
+			assert.That(t, p.SubmitWait(ctx, func(ctx context.Context) {})).Nil()
+		}
+		assert.That(t, runtime.NumGoroutine()-before < 10).True()
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+	})
+
+	t.Run("forced shutdown", func(t *testing.T) {
+		p := goutil.NewPool(goutil.PoolConfig{Workers: 1, QueueSize: 5})
+		started := make(chan struct{})
+		cancelled := make(chan struct{})
+		assert.That(t, p.Submit(t.Context(), func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+		})).Nil()
+		var ran atomic.Bool
+		for range 3 {
+			assert.That(t, p.Submit(t.Context(), func(ctx context.Context) {
+				ran.Store(true)
+			})).Nil()
+		}
+		<-started
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		assert.That(t, p.Shutdown(ctx)).Equal(context.DeadlineExceeded)
+		<-cancelled
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+		assert.That(t, ran.Load()).False()
+		assert.That(t, p.Stats().Dropped).Equal(int64(3))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var (
+			mu       sync.Mutex
+			reported []any
+			finished []bool
+		)
+		onPanic := goutil.OnPanic
+		goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, r)
+		}
+		defer func() { goutil.OnPanic = onPanic }()
+
+		p := goutil.NewPool(goutil.PoolConfig{
+			Workers:   1,
+			QueueSize: 2,
+			Hooks: goutil.PoolHooks{
+				Finished: func(elapsed time.Duration, panicked bool) {
+					mu.Lock()
+					defer mu.Unlock()
+					finished = append(finished, panicked)
+				},
+			},
+		})
+		assert.That(t, p.Submit(t.Context(), func(ctx context.Context) {
+			panic("boom")
+		})).Nil()
+		assert.That(t, p.Submit(t.Context(), func(ctx context.Context) {})).Nil()
+		assert.That(t, p.Shutdown(t.Context())).Nil()
+		assert.That(t, reported).Equal([]any{"boom"})
+		assert.That(t, finished).Equal([]bool{true, false})
+		assert.That(t, p.Stats().Panics).Equal(int64(1))
+	})
+}
+
+// opaqueContext hides the cancelable parent it wraps from the context
+// package.
+type opaqueContext struct {
+	context.Context
+}
+
+func (c opaqueContext) Value(key any) any {
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package retry retries failing operations with an exponential backoff.
+//
+// The waits between attempts grow from InitialInterval by Multiplier up to
+// MaxInterval, each randomized by Jitter so that clients failing together
+// do not retry in lockstep. Retrying stops after MaxAttempts attempts, when
+// the error is not retryable, or when the context is done.
+//
+// A Config can be bound from properties, e.g. the "retry.*" ones of the
+// default policy bean of an application:
+//
+//	retry.max-attempts=5
+//	retry.initial-interval=200ms
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-spring/spring-base/util"
+	"github.com/go-spring/spring-core/util/goutil"
+)
+
+// Config holds the backoff settings of a Policy.
+type Config struct {
+	MaxAttempts     int           `value:"${max-attempts:=3}" desc:"attempts in total, unlimited if not positive"`
+	InitialInterval time.Duration `value:"${initial-interval:=100ms}" desc:"wait before the first retry"`
+	MaxInterval     time.Duration `value:"${max-interval:=10s}" desc:"upper bound of the waits"`
+	Multiplier      float64       `value:"${multiplier:=2}" desc:"growth factor of the waits"`
+	Jitter          float64       `value:"${jitter:=0.2}" desc:"randomization factor of the waits, in [0,1]"`
+	Safe            bool          `value:"${safe:=false}" desc:"whether attempts run via goutil, recovering panics"`
+}
+
+// DefaultConfig returns the Config with the default values of its tags.
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:     3,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+}
+
+// Policy retries operations with the backoff of its Config.
+//
+// Config is a named field rather than an embedded one, so that the fields of
+// a Policy bean are not bound again from the root properties.
+type Policy struct {
+	Config Config
+
+	// RetryIf tells whether an error is worth retrying. If nil, all errors
+	// but the ones of a done context are retried.
+	RetryIf func(err error) bool
+
+	// OnRetry, if set, is called before waiting for the next attempt, e.g.
+	// to log the failure.
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// New creates a Policy with the given Config, retrying all errors.
+func New(cfg Config) *Policy {
+	return &Policy{Config: cfg}
+}
+
+// Do calls f until it succeeds, see DoValue.
+func (p *Policy) Do(ctx context.Context, f func(ctx context.Context) error) error {
+	_, err := DoValue(ctx, p, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+	return err
+}
+
+// DoValue calls f until it succeeds and returns its value.
+//
+// An error not retryable by the policy is returned as is. When all attempts
+// fail, the last error is returned wrapped. When ctx is done while waiting,
+// both the error of ctx and the last error are returned wrapped. With Safe
+// set, a panic of f is recovered and retried as a *goutil.PanicError.
+func DoValue[T any](ctx context.Context, p *Policy, f func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	for attempt := 1; ; attempt++ {
+		v, err := call(ctx, p, f)
+		if err == nil {
+			return v, nil
+		}
+		if !p.retryable(err) {
+			return zero, err
+		}
+		if p.Config.MaxAttempts > 0 && attempt >= p.Config.MaxAttempts {
+			return zero, util.FormatError(err, "retry failed after %d attempts", attempt)
+		}
+		wait := p.backoff(attempt)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, wait)
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			err = errors.Join(ctx.Err(), err)
+			return zero, util.FormatError(err, "retry aborted after %d attempts", attempt)
+		}
+	}
+}
+
+// call runs one attempt, via goutil if the policy is safe.
+func call[T any](ctx context.Context, p *Policy, f func(ctx context.Context) (T, error)) (T, error) {
+	if p.Config.Safe {
+		return goutil.GoValue(ctx, f).Wait()
+	}
+	return f(ctx)
+}
+
+// retryable tells whether err is worth another attempt.
+func (p *Policy) retryable(err error) bool {
+	if p.RetryIf != nil {
+		return p.RetryIf(err)
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoff returns the randomized wait after the given attempt.
+func (p *Policy) backoff(attempt int) time.Duration {
+	d := float64(p.Config.InitialInterval) * math.Pow(max(p.Config.Multiplier, 1), float64(attempt-1))
+	if p.Config.MaxInterval > 0 {
+		d = min(d, float64(p.Config.MaxInterval))
+	}
+	if j := min(max(p.Config.Jitter, 0), 1); j > 0 {
+		d *= 1 + j*(2*rand.Float64()-1)
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-base/testing/assert"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/util/goutil"
+	"github.com/go-spring/spring-core/util/retry"
+)
+
+func fastPolicy(attempts int) *retry.Policy {
+	return retry.New(retry.Config{
+		MaxAttempts:     attempts,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     2 * time.Millisecond,
+		Multiplier:      2,
+	})
+}
+
+func TestConfig(t *testing.T) {
+
+	t.Run("default", func(t *testing.T) {
+		var c retry.Config
+		err := conf.New().Bind(&c, "${retry}")
+		assert.That(t, err).Nil()
+		assert.That(t, c).Equal(retry.DefaultConfig())
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		p := conf.Map(map[string]any{
+			"retry.max-attempts":     5,
+			"retry.initial-interval": "200ms",
+			"retry.jitter":           0.5,
+			"retry.safe":             true,
+		})
+		var c retry.Config
+		err := p.Bind(&c, "${retry}")
+		assert.That(t, err).Nil()
+		assert.That(t, c.MaxAttempts).Equal(5)
+		assert.That(t, c.InitialInterval).Equal(200 * time.Millisecond)
+		assert.That(t, c.Jitter).Equal(0.5)
+		assert.That(t, c.Safe).True()
+	})
+}
+
+func TestDo(t *testing.T) {
+
+	t.Run("success after failures", func(t *testing.T) {
+		p := fastPolicy(5)
+		var retries []int
+		p.OnRetry = func(attempt int, err error, wait time.Duration) {
+			retries = append(retries, attempt)
+		}
+		n := 0
+		v, err := retry.DoValue(t.Context(), p, func(ctx context.Context) (int, error) {
+			if n++; n < 3 {
+				return 0, errors.New("boom")
+			}
+			return n, nil
+		})
+		assert.That(t, err).Nil()
+		assert.That(t, v).Equal(3)
+		assert.That(t, retries).Equal([]int{1, 2})
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		n := 0
+		err := fastPolicy(3).Do(t.Context(), func(ctx context.Context) error {
+			n++
+			return errBoom
+		})
+		assert.Error(t, err).Matches("retry failed after 3 attempts: boom")
+		assert.That(t, errors.Is(err, errBoom)).True()
+		assert.That(t, n).Equal(3)
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		errFatal := errors.New("fatal")
+		p := fastPolicy(3)
+		p.RetryIf = func(err error) bool { return !errors.Is(err, errFatal) }
+		n := 0
+		err := p.Do(t.Context(), func(ctx context.Context) error {
+			n++
+			return errFatal
+		})
+		assert.That(t, err).Equal(errFatal)
+		assert.That(t, n).Equal(1)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		p := retry.New(retry.Config{InitialInterval: time.Hour})
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		err := p.Do(ctx, func(ctx context.Context) error {
+			return errors.New("boom")
+		})
+		assert.Error(t, err).Matches("retry aborted after 1 attempts")
+		assert.That(t, errors.Is(err, context.DeadlineExceeded)).True()
+	})
+
+	t.Run("safe", func(t *testing.T) {
+		onPanic := goutil.OnPanic
+		goutil.OnPanic = func(ctx context.Context, r any, stack []byte) {}
+		defer func() { goutil.OnPanic = onPanic }()
+
+		p := fastPolicy(3)
+		p.Config.Safe = true
+		n := 0
+		err := p.Do(t.Context(), func(ctx context.Context) error {
+			if n++; n < 2 {
+				panic("boom")
+			}
+			return nil
+		})
+		assert.That(t, err).Nil()
+		assert.That(t, n).Equal(2)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	p := retry.New(retry.Config{
+		MaxAttempts:     5,
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     25 * time.Millisecond,
+		Multiplier:      2,
+		Jitter:          0.5,
+	})
+	var waits []time.Duration
+	p.OnRetry = func(attempt int, err error, wait time.Duration) {
+		waits = append(waits, wait)
+	}
+	_ = p.Do(t.Context(), func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	assert.That(t, len(waits)).Equal(4)
+	for i, base := range []time.Duration{10, 20, 25, 25} {
+		base *= time.Millisecond
+		assert.That(t, waits[i] >= base/2 && waits[i] <= base*3/2).True()
+	}
+}